@@ -0,0 +1,448 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const (
+	// AnsibleExtraVarsAnnotation stores v1beta1 ansibleExtraVars values that
+	// can not be represented as a v1 extraVars map (or that are set together
+	// with ansibleExtraVarsMap) so that they survive the conversion round trip. The value is a JSON object keyed by
+	// ansibleExtraVarsSpecKey or by the name of the workflow step.
+	AnsibleExtraVarsAnnotation = "test.openstack.org/v1beta1-ansible-extra-vars"
+
+	ansibleExtraVarsSpecKey = ""
+)
+
+var _ conversion.Convertible = &AnsibleTest{}
+
+// ConvertTo converts this AnsibleTest to the Hub version (v1beta1)
+func (src *AnsibleTest) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.AnsibleTest)
+
+	rawExtraVars := map[string]string{}
+	if value, ok := src.Annotations[AnsibleExtraVarsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &rawExtraVars); err != nil {
+			return err
+		}
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	delete(dst.Annotations, AnsibleExtraVarsAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	dst.Status = convertCommonTestStatusTo(src.Status)
+
+	convertCommonOptionsTo(&src.Spec.CommonOptions, &dst.Spec.CommonOptions)
+	dst.Spec.CommonOpenstackConfig = v1beta1.CommonOpenstackConfig(src.Spec.CommonOpenstackConfig)
+	dst.Spec.Resources = src.Spec.Resources
+	dst.Spec.ComputesSSHKeySecretName = src.Spec.ComputeSSHKeySecretName
+	dst.Spec.WorkloadSSHKeySecretName = src.Spec.WorkloadSSHKeySecretName
+	dst.Spec.AnsibleGitRepo = src.Spec.GitRepo
+	dst.Spec.AnsibleGitAuthSecretName = src.Spec.GitAuthSecretName
+	dst.Spec.AnsibleVaultPasswordSecretName = src.Spec.VaultPasswordSecretName
+	dst.Spec.AnsibleGitRef = src.Spec.GitRef
+	dst.Spec.AnsibleGitCache = src.Spec.GitCache
+	dst.Spec.AnsiblePlaybookPath = src.Spec.PlaybookPath
+	dst.Spec.AnsiblePlaybooks = src.Spec.Playbooks
+	dst.Spec.AnsiblePlaybookFailurePolicy = v1beta1.PlaybookFailurePolicy(src.Spec.PlaybookFailurePolicy)
+	dst.Spec.AnsibleCollections = src.Spec.Collections
+	dst.Spec.AnsibleVarFiles = src.Spec.VarFiles
+	dst.Spec.AnsibleVarFilesFrom = convertVarFilesFromTo(src.Spec.VarFilesFrom)
+	dst.Spec.AnsibleExtraVars, dst.Spec.AnsibleExtraVarsMap = convertExtraVarsTo(
+		src.Spec.ExtraVars, rawExtraVars, ansibleExtraVarsSpecKey)
+	dst.Spec.AnsibleInventory = src.Spec.Inventory
+	dst.Spec.AnsibleInventoryConfigMap = (*v1beta1.InventoryConfigMap)(src.Spec.InventoryConfigMap.DeepCopy())
+	dst.Spec.AnsibleInventoryFromNodeSets = src.Spec.InventoryFromNodeSets
+	dst.Spec.AnsibleStdoutCallback = src.Spec.StdoutCallback
+	dst.Spec.AnsibleCallbacks = src.Spec.Callbacks
+	dst.Spec.AnsibleTags = src.Spec.Tags
+	dst.Spec.AnsibleSkipTags = src.Spec.SkipTags
+	dst.Spec.AnsibleLimit = src.Spec.Limit
+	dst.Spec.AnsibleExtraArgs = src.Spec.ExtraArgs
+	dst.Spec.Debug = src.Spec.Debug
+	dst.Spec.AnsibleCheckMode = src.Spec.CheckMode
+	dst.Spec.AnsibleDiff = src.Spec.Diff
+	dst.Spec.AnsibleVerbosity = src.Spec.Verbosity
+	dst.Spec.AnsibleForks = src.Spec.Forks
+	dst.Spec.OfflineMode = src.Spec.OfflineMode
+	dst.Spec.OfflineBundle = (*v1beta1.OfflineBundle)(src.Spec.OfflineBundle.DeepCopy())
+	dst.Spec.AnsibleGalaxyRequirements = (*v1beta1.GalaxyRequirements)(src.Spec.GalaxyRequirements.DeepCopy())
+	dst.Spec.AnsibleAra = (*v1beta1.AraConfig)(src.Spec.Ara.DeepCopy())
+	dst.Spec.ExtraMounts = convertExtraMountsTo(src.Spec.ExtraMounts)
+	dst.Spec.NetworkAttachments = src.Spec.NetworkAttachments
+
+	dst.Spec.Workflow = nil
+	if src.Spec.Workflow != nil {
+		dst.Spec.Workflow = make([]v1beta1.AnsibleTestWorkflowSpec, len(src.Spec.Workflow))
+	}
+
+	for i := range src.Spec.Workflow {
+		srcStep := &src.Spec.Workflow[i]
+		dstStep := &dst.Spec.Workflow[i]
+
+		convertWorkflowCommonParametersTo(&srcStep.WorkflowCommonParameters, &dstStep.WorkflowCommonParameters)
+
+		specOverride, err := convertSpecOverride(srcStep.SpecOverride, ansibleTestOverrideFields, convertOverrideExtraVarsTo)
+		if err != nil {
+			return err
+		}
+		dstStep.SpecOverride = specOverride
+
+		dstStep.CommonOpenstackConfig = v1beta1.CommonOpenstackConfig(srcStep.CommonOpenstackConfig)
+		dstStep.StepName = srcStep.StepName
+		dstStep.Resources = srcStep.Resources
+		dstStep.ComputesSSHKeySecretName = srcStep.ComputeSSHKeySecretName
+		dstStep.WorkloadSSHKeySecretName = srcStep.WorkloadSSHKeySecretName
+		dstStep.AnsibleGitRepo = srcStep.GitRepo
+		dstStep.AnsibleGitAuthSecretName = srcStep.GitAuthSecretName
+		dstStep.AnsibleVaultPasswordSecretName = srcStep.VaultPasswordSecretName
+		dstStep.AnsibleGitRef = srcStep.GitRef
+		dstStep.AnsiblePlaybookPath = srcStep.PlaybookPath
+		dstStep.AnsiblePlaybooks = srcStep.Playbooks
+		dstStep.AnsiblePlaybookFailurePolicy = v1beta1.PlaybookFailurePolicy(srcStep.PlaybookFailurePolicy)
+		dstStep.AnsibleCollections = srcStep.Collections
+		dstStep.AnsibleVarFiles = srcStep.VarFiles
+		dstStep.AnsibleVarFilesFrom = nil
+		if srcStep.VarFilesFrom != nil {
+			varFilesFrom := convertVarFilesFromTo(*srcStep.VarFilesFrom)
+			dstStep.AnsibleVarFilesFrom = &varFilesFrom
+		}
+		dstStep.AnsibleExtraVars, dstStep.AnsibleExtraVarsMap = convertExtraVarsTo(
+			srcStep.ExtraVars, rawExtraVars, srcStep.StepName)
+		dstStep.AnsibleInventory = srcStep.Inventory
+		dstStep.AnsibleInventoryConfigMap = (*v1beta1.InventoryConfigMap)(srcStep.InventoryConfigMap.DeepCopy())
+		dstStep.AnsibleInventoryFromNodeSets = srcStep.InventoryFromNodeSets
+		dstStep.AnsibleStdoutCallback = srcStep.StdoutCallback
+		dstStep.AnsibleCallbacks = srcStep.Callbacks
+		dstStep.AnsibleTags = srcStep.Tags
+		dstStep.AnsibleSkipTags = srcStep.SkipTags
+		dstStep.AnsibleLimit = srcStep.Limit
+		dstStep.AnsibleExtraArgs = srcStep.ExtraArgs
+		dstStep.Debug = srcStep.Debug
+		dstStep.AnsibleCheckMode = srcStep.CheckMode
+		dstStep.AnsibleDiff = srcStep.Diff
+		dstStep.AnsibleVerbosity = srcStep.Verbosity
+		dstStep.AnsibleForks = srcStep.Forks
+
+		dstStep.ExtraMounts = nil
+		if srcStep.ExtraMounts != nil {
+			extraMounts := convertExtraMountsTo(*srcStep.ExtraMounts)
+			dstStep.ExtraMounts = &extraMounts
+		}
+
+		dstStep.NetworkAttachments = srcStep.NetworkAttachments
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *AnsibleTest) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.AnsibleTest)
+
+	rawExtraVars := map[string]string{}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Status = convertCommonTestStatusFrom(src.Status)
+
+	convertCommonOptionsFrom(&src.Spec.CommonOptions, &dst.Spec.CommonOptions)
+	dst.Spec.CommonOpenstackConfig = CommonOpenstackConfig(src.Spec.CommonOpenstackConfig)
+	dst.Spec.Resources = src.Spec.Resources
+	dst.Spec.ComputeSSHKeySecretName = src.Spec.ComputesSSHKeySecretName
+	dst.Spec.WorkloadSSHKeySecretName = src.Spec.WorkloadSSHKeySecretName
+	dst.Spec.GitRepo = src.Spec.AnsibleGitRepo
+	dst.Spec.GitAuthSecretName = src.Spec.AnsibleGitAuthSecretName
+	dst.Spec.VaultPasswordSecretName = src.Spec.AnsibleVaultPasswordSecretName
+	dst.Spec.GitRef = src.Spec.AnsibleGitRef
+	dst.Spec.GitCache = src.Spec.AnsibleGitCache
+	dst.Spec.PlaybookPath = src.Spec.AnsiblePlaybookPath
+	dst.Spec.Playbooks = src.Spec.AnsiblePlaybooks
+	dst.Spec.PlaybookFailurePolicy = PlaybookFailurePolicy(src.Spec.AnsiblePlaybookFailurePolicy)
+	dst.Spec.Collections = src.Spec.AnsibleCollections
+	dst.Spec.VarFiles = src.Spec.AnsibleVarFiles
+	dst.Spec.VarFilesFrom = convertVarFilesFromFrom(src.Spec.AnsibleVarFilesFrom)
+	dst.Spec.ExtraVars = convertExtraVarsFrom(
+		src.Spec.AnsibleExtraVars, src.Spec.AnsibleExtraVarsMap, rawExtraVars, ansibleExtraVarsSpecKey)
+	dst.Spec.Inventory = src.Spec.AnsibleInventory
+	dst.Spec.InventoryConfigMap = (*InventoryConfigMap)(src.Spec.AnsibleInventoryConfigMap.DeepCopy())
+	dst.Spec.InventoryFromNodeSets = src.Spec.AnsibleInventoryFromNodeSets
+	dst.Spec.StdoutCallback = src.Spec.AnsibleStdoutCallback
+	dst.Spec.Callbacks = src.Spec.AnsibleCallbacks
+	dst.Spec.Tags = src.Spec.AnsibleTags
+	dst.Spec.SkipTags = src.Spec.AnsibleSkipTags
+	dst.Spec.Limit = src.Spec.AnsibleLimit
+	dst.Spec.ExtraArgs = src.Spec.AnsibleExtraArgs
+	dst.Spec.Debug = src.Spec.Debug
+	dst.Spec.CheckMode = src.Spec.AnsibleCheckMode
+	dst.Spec.Diff = src.Spec.AnsibleDiff
+	dst.Spec.Verbosity = src.Spec.AnsibleVerbosity
+	dst.Spec.Forks = src.Spec.AnsibleForks
+	dst.Spec.OfflineMode = src.Spec.OfflineMode
+	dst.Spec.OfflineBundle = (*OfflineBundle)(src.Spec.OfflineBundle.DeepCopy())
+	dst.Spec.GalaxyRequirements = (*GalaxyRequirements)(src.Spec.AnsibleGalaxyRequirements.DeepCopy())
+	dst.Spec.Ara = (*AraConfig)(src.Spec.AnsibleAra.DeepCopy())
+	dst.Spec.ExtraMounts = convertExtraMountsFrom(src.Spec.ExtraMounts)
+	dst.Spec.NetworkAttachments = src.Spec.NetworkAttachments
+
+	dst.Spec.Workflow = nil
+	if src.Spec.Workflow != nil {
+		dst.Spec.Workflow = make([]AnsibleTestWorkflowSpec, len(src.Spec.Workflow))
+	}
+
+	for i := range src.Spec.Workflow {
+		srcStep := &src.Spec.Workflow[i]
+		dstStep := &dst.Spec.Workflow[i]
+
+		convertWorkflowCommonParametersFrom(&srcStep.WorkflowCommonParameters, &dstStep.WorkflowCommonParameters)
+
+		specOverride, err := convertSpecOverride(srcStep.SpecOverride, ansibleTestHubOverrideFields, convertOverrideExtraVarsFrom)
+		if err != nil {
+			return err
+		}
+		dstStep.SpecOverride = specOverride
+
+		dstStep.CommonOpenstackConfig = CommonOpenstackConfig(srcStep.CommonOpenstackConfig)
+		dstStep.StepName = srcStep.StepName
+		dstStep.Resources = srcStep.Resources
+		dstStep.ComputeSSHKeySecretName = srcStep.ComputesSSHKeySecretName
+		dstStep.WorkloadSSHKeySecretName = srcStep.WorkloadSSHKeySecretName
+		dstStep.GitRepo = srcStep.AnsibleGitRepo
+		dstStep.GitAuthSecretName = srcStep.AnsibleGitAuthSecretName
+		dstStep.VaultPasswordSecretName = srcStep.AnsibleVaultPasswordSecretName
+		dstStep.GitRef = srcStep.AnsibleGitRef
+		dstStep.PlaybookPath = srcStep.AnsiblePlaybookPath
+		dstStep.Playbooks = srcStep.AnsiblePlaybooks
+		dstStep.PlaybookFailurePolicy = PlaybookFailurePolicy(srcStep.AnsiblePlaybookFailurePolicy)
+		dstStep.Collections = srcStep.AnsibleCollections
+		dstStep.VarFiles = srcStep.AnsibleVarFiles
+		dstStep.VarFilesFrom = nil
+		if srcStep.AnsibleVarFilesFrom != nil {
+			varFilesFrom := convertVarFilesFromFrom(*srcStep.AnsibleVarFilesFrom)
+			dstStep.VarFilesFrom = &varFilesFrom
+		}
+		dstStep.ExtraVars = convertExtraVarsFrom(
+			srcStep.AnsibleExtraVars, srcStep.AnsibleExtraVarsMap, rawExtraVars, srcStep.StepName)
+		dstStep.Inventory = srcStep.AnsibleInventory
+		dstStep.InventoryConfigMap = (*InventoryConfigMap)(srcStep.AnsibleInventoryConfigMap.DeepCopy())
+		dstStep.InventoryFromNodeSets = srcStep.AnsibleInventoryFromNodeSets
+		dstStep.StdoutCallback = srcStep.AnsibleStdoutCallback
+		dstStep.Callbacks = srcStep.AnsibleCallbacks
+		dstStep.Tags = srcStep.AnsibleTags
+		dstStep.SkipTags = srcStep.AnsibleSkipTags
+		dstStep.Limit = srcStep.AnsibleLimit
+		dstStep.ExtraArgs = srcStep.AnsibleExtraArgs
+		dstStep.CheckMode = srcStep.AnsibleCheckMode
+		dstStep.Diff = srcStep.AnsibleDiff
+		dstStep.Verbosity = srcStep.AnsibleVerbosity
+		dstStep.Forks = srcStep.AnsibleForks
+
+		dstStep.Debug = srcStep.Debug
+
+		dstStep.ExtraMounts = nil
+		if srcStep.ExtraMounts != nil {
+			extraMounts := convertExtraMountsFrom(*srcStep.ExtraMounts)
+			dstStep.ExtraMounts = &extraMounts
+		}
+
+		dstStep.NetworkAttachments = srcStep.NetworkAttachments
+	}
+
+	if len(rawExtraVars) > 0 {
+		value, err := json.Marshal(rawExtraVars)
+		if err != nil {
+			return err
+		}
+
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[AnsibleExtraVarsAnnotation] = string(value)
+	}
+
+	return nil
+}
+
+// convertExtraVarsTo returns the v1beta1 ansibleExtraVars and
+// ansibleExtraVarsMap fields. The extra vars are converted to the map, the
+// string is the value preserved in rawExtraVars under the given key (if any).
+func convertExtraVarsTo(
+	extraVars map[string]string,
+	rawExtraVars map[string]string,
+	key string,
+) (string, map[string]string) {
+	if len(extraVars) == 0 {
+		return rawExtraVars[key], nil
+	}
+
+	extraVarsMap := make(map[string]string, len(extraVars))
+	for name, value := range extraVars {
+		extraVarsMap[name] = value
+	}
+
+	return rawExtraVars[key], extraVarsMap
+}
+
+// convertExtraVarsFrom converts the v1beta1 ansibleExtraVarsMap field. When
+// the map is empty the ansibleExtraVars field is parsed instead. Values of
+// ansibleExtraVars that can not be converted to a map without losing
+// information are stored in rawExtraVars under the given key.
+func convertExtraVarsFrom(
+	value string,
+	valueMap map[string]string,
+	rawExtraVars map[string]string,
+	key string,
+) map[string]string {
+	if len(valueMap) > 0 {
+		if value != "" {
+			rawExtraVars[key] = value
+		}
+
+		extraVars := make(map[string]string, len(valueMap))
+		for name, mapValue := range valueMap {
+			extraVars[name] = mapValue
+		}
+
+		return extraVars
+	}
+
+	extraVars, ok := parseExtraVars(value)
+	if !ok {
+		rawExtraVars[key] = value
+		return nil
+	}
+
+	return extraVars
+}
+
+// renderExtraVars returns the extra vars as a list of "-e key=value"
+// arguments sorted by the key.
+func renderExtraVars(extraVars map[string]string) string {
+	keys := make([]string, 0, len(extraVars))
+	for key := range extraVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := make([]string, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, "-e "+key+"="+extraVars[key])
+	}
+
+	return strings.Join(args, " ")
+}
+
+// parseExtraVars parses a string consisting of "-e key=value" arguments. The
+// second return value is false when the string contains anything else or when
+// rendering the parsed map would not yield the same string.
+func parseExtraVars(value string) (map[string]string, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	fields := strings.Fields(value)
+	if len(fields)%2 != 0 {
+		return nil, false
+	}
+
+	extraVars := map[string]string{}
+	for i := 0; i < len(fields); i += 2 {
+		key, val, found := strings.Cut(fields[i+1], "=")
+		if fields[i] != "-e" || !found || key == "" {
+			return nil, false
+		}
+
+		extraVars[key] = val
+	}
+
+	if renderExtraVars(extraVars) != value {
+		return nil, false
+	}
+
+	return extraVars, true
+}
+
+// convertOverrideExtraVarsTo converts the values of the extraVars map of a
+// spec override to the strings of the ansibleExtraVarsMap of the hub version
+func convertOverrideExtraVarsTo(override map[string]interface{}) {
+	extraVars, ok := override["ansibleExtraVarsMap"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	stringExtraVars := make(map[string]string, len(extraVars))
+	for key, value := range extraVars {
+		stringExtraVars[key] = fmt.Sprint(value)
+	}
+
+	override["ansibleExtraVarsMap"] = stringExtraVars
+}
+
+// convertOverrideExtraVarsFrom parses the ansibleExtraVars string of a spec
+// override into the v1 extraVars map unless the override sets
+// ansibleExtraVarsMap. Values that can not be parsed are kept as they are.
+func convertOverrideExtraVarsFrom(override map[string]interface{}) {
+	if _, ok := override["extraVars"]; ok {
+		return
+	}
+
+	value, ok := override["ansibleExtraVars"].(string)
+	if !ok {
+		return
+	}
+
+	if extraVars, ok := parseExtraVars(value); ok && extraVars != nil {
+		override["extraVars"] = extraVars
+		delete(override, "ansibleExtraVars")
+	}
+}
+
+func convertVarFilesFromTo(src []AnsibleVarFileSource) []v1beta1.AnsibleVarFileSource {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.AnsibleVarFileSource, len(src))
+	for i := range src {
+		dst[i] = v1beta1.AnsibleVarFileSource(*src[i].DeepCopy())
+	}
+
+	return dst
+}
+
+func convertVarFilesFromFrom(src []v1beta1.AnsibleVarFileSource) []AnsibleVarFileSource {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]AnsibleVarFileSource, len(src))
+	for i := range src {
+		dst[i] = AnsibleVarFileSource(*src[i].DeepCopy())
+	}
+
+	return dst
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAnsibleTestConversionRoundTrip(t *testing.T) {
+	trueVar := true
+	falseVar := false
+
+	src := &AnsibleTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ansibletest",
+			Namespace: "openstack",
+		},
+		Spec: AnsibleTestSpec{
+			PlaybookPath: "playbook.yaml",
+			Debug:        true,
+			Workflow: []AnsibleTestWorkflowSpec{
+				{StepName: "inherit"},
+				{StepName: "disable-debug", Debug: &falseVar},
+				{StepName: "enable-debug", Debug: &trueVar},
+			},
+		},
+	}
+
+	hub := &v1beta1.AnsibleTest{}
+	if err := src.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() failed: %v", err)
+	}
+
+	dst := &AnsibleTest{}
+	if err := dst.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() failed: %v", err)
+	}
+
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("AnsibleTest changed after the conversion round trip (-want +got):\n%s", diff)
+	}
+}
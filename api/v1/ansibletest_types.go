@@ -0,0 +1,610 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnsibleTestSpec defines the desired state of AnsibleTest
+type AnsibleTestSpec struct {
+	CommonOptions         `json:",inline"`
+	CommonOpenstackConfig `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "4000m", memory: "4Gi"}, requests: {cpu: "2000m", memory: "2Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the AnsibleTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="dataplane-ansible-ssh-private-key-secret"
+	// ComputeSSHKeySecretName is the name of the k8s secret that contains an ssh key for computes.
+	// The key is mounted to ~/.ssh/id_ecdsa in the ansible pod
+	ComputeSSHKeySecretName string `json:"computeSSHKeySecretName"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=""
+	// WorkloadSSHKeySecretName is the name of the k8s secret that contains an ssh key for the ansible workload.
+	// The key is mounted to ~/test_keypair.key in the ansible pod
+	WorkloadSSHKeySecretName string `json:"workloadSSHKeySecretName"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default:=""
+	// GitRepo - git repo to clone into container
+	GitRepo string `json:"gitRepo"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// GitAuthSecretName - name of the secret with the credentials used to clone
+	// the git repo. The ssh-privatekey key (and optionally known_hosts) is
+	// used for SSH URLs, the password key (a password or a token, and
+	// optionally username) for HTTPS URLs.
+	GitAuthSecretName string `json:"gitAuthSecretName,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// VaultPasswordSecretName - name of the secret with the ansible vault password
+	// under the password key. It is used to decrypt the encrypted var files
+	// and group_vars.
+	VaultPasswordSecretName string `json:"vaultPasswordSecretName,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// GitRef - branch, tag or commit SHA of the git repo to check out.
+	// When it is not set the default branch of the repo is used. The commit
+	// that was checked out is recorded in status.steps[].gitCommit.
+	GitRef string `json:"gitRef,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// GitCache - clone the git repo to the logs PVC once and reuse the
+	// checkout in the following workflow steps that use the same git repo and
+	// ref instead of cloning it in each test pod
+	GitCache bool `json:"gitCache,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default:=""
+	// PlaybookPath - path to ansible playbook
+	PlaybookPath string `json:"playbookPath"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Playbooks - paths to ansible playbooks that are executed one after another
+	// in a single test pod. Mutually exclusive with playbookPath.
+	Playbooks []string `json:"playbooks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=FailFast
+	// +kubebuilder:validation:Enum:=FailFast;Continue
+	// PlaybookFailurePolicy - FailFast stops the workflow step at the first playbook of
+	// playbooks that fails. Continue executes the remaining playbooks as well,
+	// the workflow step fails when any of the playbooks failed.
+	PlaybookFailurePolicy PlaybookFailurePolicy `json:"playbookFailurePolicy,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// +kubebuilder:default:=""
+	// Collections - extra ansible collections to install in addition to the ones listed in the requirements.yaml
+	Collections string `json:"collections,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// +kubebuilder:default:=""
+	// VarFiles - interface to create ansible var files Those get added to the
+	// service config dir in /etc/test_operator/<file> and passed to the ansible command using -e @/etc/test_operator/<file>
+	VarFiles string `json:"varFiles,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// VarFilesFrom - ansible var files stored in config maps or secrets. They are
+	// mounted to the test pod and passed to the ansible command using -e @<file>
+	VarFilesFrom []AnsibleVarFileSource `json:"varFilesFrom,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// ExtraVars - variables passed to ansible. The values do not have to be
+	// escaped as they are rendered to the var file of varFiles.
+	ExtraVars map[string]string `json:"extraVars,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// +kubebuilder:default:=""
+	// Inventory - string that contains the inventory file content
+	Inventory string `json:"inventory,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// InventoryConfigMap - config map that contains the inventory file content. Mutually
+	// exclusive with inventory and inventoryFromNodeSets.
+	InventoryConfigMap *InventoryConfigMap `json:"inventoryConfigMap,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// InventoryFromNodeSets - names of the OpenStackDataPlaneNodeSets the inventory is
+	// generated from. The inventories of the node sets are merged into a
+	// single inventory. Mutually exclusive with inventory and inventoryConfigMap.
+	InventoryFromNodeSets []string `json:"inventoryFromNodeSets,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// StdoutCallback - name of the ansible stdout callback plugin (e.g. yaml or
+	// junit) passed in ANSIBLE_STDOUT_CALLBACK
+	StdoutCallback string `json:"stdoutCallback,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Callbacks - names of the additional ansible callback plugins that are
+	// enabled through ANSIBLE_CALLBACKS_ENABLED (e.g. junit or profile_tasks).
+	// The junit callback writes its results to the artifacts directory.
+	Callbacks []string `json:"callbacks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Tags - run only the plays and tasks tagged with these tags (--tags)
+	Tags []string `json:"tags,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// SkipTags - skip the plays and tasks tagged with these tags (--skip-tags)
+	SkipTags []string `json:"skipTags,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Limit - limit the playbook run to a subset of the hosts of the
+	// inventory (--limit), e.g. a host group
+	Limit string `json:"limit,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - additional arguments of ansible playbook for the options that
+	// are not exposed by other fields (e.g. --start-at-task=<task>). Each item
+	// is passed to ansible playbook as a separate argument so the values may
+	// contain spaces. Options that require user input or replace the
+	// inventory are rejected.
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Run ansible playbook with -vvvv
+	Debug bool `json:"debug"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Run ansible playbook in the check mode (--check) that reports the
+	// changes without making them, e.g. to validate a workflow step before
+	// the real run
+	CheckMode bool `json:"checkMode,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Run ansible playbook with --diff to show the changes made to files
+	// and templates
+	Diff bool `json:"diff,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4
+	// Verbosity of ansible playbook, the number of -v options (0-4). debug
+	// always runs ansible playbook with the highest verbosity.
+	Verbosity int64 `json:"verbosity,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Number of hosts ansible playbook runs the tasks on in parallel
+	// (--forks). When it is not set the ansible default is used.
+	Forks int64 `json:"forks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// OfflineMode disables all network fetches in the test pods. The ansible
+	// collections and the pip requirements are installed from the
+	// offlineBundle and the git repo has to be a local path. A collection
+	// that is missing in the bundle fails the test pod.
+	OfflineMode bool `json:"offlineMode"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// OfflineBundle - the bundle of ansible collections and pip requirements
+	// that is used when offlineMode is enabled
+	OfflineBundle *OfflineBundle `json:"offlineBundle,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// GalaxyRequirements - a requirements.yml with the ansible collections and
+	// roles that are installed with ansible-galaxy before the playbook runs
+	GalaxyRequirements *GalaxyRequirements `json:"galaxyRequirements,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Ara - record the playbook results in ARA (ARA Records Ansible) so
+	// that they can be browsed per task
+	Ara *AraConfig `json:"ara,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey:=name
+	// Extra secrets, config maps and persistent volume claims that should be
+	// mounted into the test pods (e.g. known_hosts, certificates or var files)
+	ExtraMounts []ExtraMount `json:"extraMounts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A parameter that contains a workflow definition.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Workflow []AnsibleTestWorkflowSpec `json:"workflow,omitempty"`
+}
+
+// AnsibleTestWorkflowSpec - configuration of a single workflow step. Values
+// that are not set fall back to the values specified in the AnsibleTestSpec.
+// OfflineBundle describes where the test pod finds the ansible collections
+// and the pip requirements when offlineMode is enabled. <path>/collections
+// contains the collection tarballs (ansible-galaxy collection download) and
+// <path>/pip the python packages (pip download).
+type OfflineBundle struct {
+	// +kubebuilder:validation:Optional
+	// Name of the persistent volume claim that contains the bundle. It is
+	// mounted read-only to the path. When empty, the bundle has to be a part
+	// of the container image.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="/var/lib/ansible-offline-bundle"
+	// +kubebuilder:validation:Pattern:=`^/`
+	// Absolute path to the bundle in the test pod
+	Path string `json:"path,omitempty"`
+}
+
+// PlaybookFailurePolicy describes what happens when one of the playbooks of
+// a workflow step fails
+type PlaybookFailurePolicy string
+
+const (
+	// PlaybookFailurePolicyFailFast - do not execute the remaining playbooks
+	PlaybookFailurePolicyFailFast PlaybookFailurePolicy = "FailFast"
+
+	// PlaybookFailurePolicyContinue - execute the remaining playbooks and
+	// report the failure once all of them finished
+	PlaybookFailurePolicyContinue PlaybookFailurePolicy = "Continue"
+)
+
+// GalaxyRequirements is a requirements.yml stored in a config map. The
+// collections and roles listed in it are installed by an init container of
+// the test pod.
+type GalaxyRequirements struct {
+	// +kubebuilder:validation:Required
+	// Name of the config map that contains the requirements.yml
+	ConfigMap string `json:"configMap"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="requirements.yml"
+	// Key of the config map with the content of the requirements.yml
+	Key string `json:"key,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// URL of the Galaxy server (e.g. a mirror) the collections and roles are
+	// installed from. When offlineMode is enabled the requirements have to
+	// refer to local files instead.
+	Server string `json:"server,omitempty"`
+}
+
+// AraConfig describes the ARA API server the playbook results are recorded
+// in
+type AraConfig struct {
+	// +kubebuilder:validation:Optional
+	// Name of the secret with the URL (url key) and optionally the
+	// credentials (username and password keys) of an external ARA API
+	// server. When empty, an ARA API server runs as a sidecar of the test
+	// pod and stores the records in the artifacts directory of the step.
+	ServerSecretName string `json:"serverSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="quay.io/recordsansible/ara-api:latest"
+	// Container image of the ARA API server sidecar
+	Image string `json:"image,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="/usr/lib/python3.9/site-packages/ara/plugins/callback"
+	// Path to the ARA callback plugins in the test container image (the
+	// output of python3 -m ara.setup.callback_plugins)
+	CallbackPluginsPath string `json:"callbackPluginsPath,omitempty"`
+}
+
+// InventoryConfigMap is an ansible inventory stored under a key of a config
+// map
+type InventoryConfigMap struct {
+	// +kubebuilder:validation:Required
+	// Name of the config map that contains the inventory
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="inventory"
+	// Key of the config map with the content of the inventory
+	Key string `json:"key,omitempty"`
+}
+
+// AnsibleVarFileSource is an ansible var file stored under a key of a config
+// map or of a secret
+// +kubebuilder:validation:XValidation:rule="has(self.configMapRef) != has(self.secretRef)",message="exactly one of configMapRef and secretRef must be set"
+type AnsibleVarFileSource struct {
+	// +kubebuilder:validation:Optional
+	// Config map that contains the var file
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Secret that contains the var file
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Key of the config map or of the secret with the content of the var file
+	Key string `json:"key"`
+}
+
+type AnsibleTestWorkflowSpec struct {
+	WorkflowCommonParameters `json:",inline"`
+	CommonOpenstackConfig    `json:",inline"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=100
+	// Name of a workflow step. The step name will be used for example to create
+	// a logs directory.
+	StepName string `json:"stepName"`
+
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the AnsibleTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	// +kubebuilder:default:={limits: {cpu: "2000m", memory: "2Gi"}, requests: {cpu: "1000m", memory: "2Gi"}}
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// ComputeSSHKeySecretName is the name of the k8s secret that contains an ssh key for computes.
+	// The key is mounted to ~/.ssh/id_ecdsa in the ansible pod
+	ComputeSSHKeySecretName string `json:"computeSSHKeySecretName"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// WorkloadSSHKeySecretName is the name of the k8s secret that contains an ssh key for the ansible workload.
+	// The key is mounted to ~/test_keypair.key in the ansible pod
+	WorkloadSSHKeySecretName string `json:"workloadSSHKeySecretName"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// GitRepo - git repo to clone into container
+	GitRepo string `json:"gitRepo,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// GitAuthSecretName - name of the secret with the credentials used to clone
+	// the git repo. The ssh-privatekey key (and optionally known_hosts) is
+	// used for SSH URLs, the password key (a password or a token, and
+	// optionally username) for HTTPS URLs.
+	GitAuthSecretName string `json:"gitAuthSecretName,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// VaultPasswordSecretName - name of the secret with the ansible vault password
+	// under the password key. It is used to decrypt the encrypted var files
+	// and group_vars.
+	VaultPasswordSecretName string `json:"vaultPasswordSecretName,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// GitRef - branch, tag or commit SHA of the git repo to check out.
+	// When it is not set the default branch of the repo is used. The commit
+	// that was checked out is recorded in status.steps[].gitCommit.
+	GitRef string `json:"gitRef,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// PlaybookPath - path to ansible playbook
+	PlaybookPath string `json:"playbookPath,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Playbooks - paths to ansible playbooks that are executed one after another
+	// in a single test pod. Mutually exclusive with playbookPath.
+	Playbooks *[]string `json:"playbooks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:=FailFast;Continue
+	// PlaybookFailurePolicy - FailFast stops the workflow step at the first playbook of
+	// playbooks that fails. Continue executes the remaining playbooks as well,
+	// the workflow step fails when any of the playbooks failed.
+	PlaybookFailurePolicy PlaybookFailurePolicy `json:"playbookFailurePolicy,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// Collections - extra ansible collections to install in addition to the ones listed in the requirements.yaml
+	Collections string `json:"collections,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// VarFiles - interface to create ansible var files Those get added to the
+	// service config dir in /etc/test_operator/<file> and passed to the ansible command using -e @/etc/test_operator/<file>
+	VarFiles string `json:"varFiles,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// VarFilesFrom - ansible var files stored in config maps or secrets. When
+	// set, it replaces spec.varFilesFrom for the step.
+	VarFilesFrom *[]AnsibleVarFileSource `json:"varFilesFrom,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// ExtraVars - variables passed to ansible. The values do not have to be
+	// escaped as they are rendered to the var file of varFiles.
+	ExtraVars map[string]string `json:"extraVars,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// Inventory - string that contains the inventory file content
+	Inventory string `json:"inventory,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// InventoryConfigMap - config map that contains the inventory file content. Mutually
+	// exclusive with inventory and inventoryFromNodeSets.
+	InventoryConfigMap *InventoryConfigMap `json:"inventoryConfigMap,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// InventoryFromNodeSets - names of the OpenStackDataPlaneNodeSets the inventory is
+	// generated from. The inventories of the node sets are merged into a
+	// single inventory. Mutually exclusive with inventory and inventoryConfigMap.
+	InventoryFromNodeSets *[]string `json:"inventoryFromNodeSets,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// StdoutCallback - name of the ansible stdout callback plugin (e.g. yaml or
+	// junit) passed in ANSIBLE_STDOUT_CALLBACK
+	StdoutCallback string `json:"stdoutCallback,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Callbacks - names of the additional ansible callback plugins that are
+	// enabled through ANSIBLE_CALLBACKS_ENABLED (e.g. junit or profile_tasks).
+	// When set, it replaces spec.callbacks for the step.
+	Callbacks *[]string `json:"callbacks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Tags - run only the plays and tasks tagged with these tags (--tags).
+	// When set, it replaces spec.tags for the step.
+	Tags *[]string `json:"tags,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// SkipTags - skip the plays and tasks tagged with these tags (--skip-tags).
+	// When set, it replaces spec.skipTags for the step.
+	SkipTags *[]string `json:"skipTags,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Limit - limit the playbook run to a subset of the hosts of the
+	// inventory (--limit), e.g. a host group
+	Limit string `json:"limit,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// ExtraArgs - additional arguments of ansible playbook for the options that
+	// are not exposed by other fields (e.g. --start-at-task=<task>). Each item
+	// is passed to ansible playbook as a separate argument so the values may
+	// contain spaces. Options that require user input or replace the
+	// inventory are rejected.
+	ExtraArgs *[]string `json:"extraArgs,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Run ansible playbook with -vvvv
+	Debug *bool `json:"debug,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Run ansible playbook in the check mode (--check) that reports the
+	// changes without making them, e.g. to validate a workflow step before
+	// the real run
+	CheckMode *bool `json:"checkMode,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Run ansible playbook with --diff to show the changes made to files
+	// and templates
+	Diff *bool `json:"diff,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4
+	// Verbosity of ansible playbook, the number of -v options (0-4). debug
+	// always runs ansible playbook with the highest verbosity.
+	Verbosity *int64 `json:"verbosity,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Number of hosts ansible playbook runs the tasks on in parallel
+	// (--forks). When it is not set the ansible default is used.
+	Forks *int64 `json:"forks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey:=name
+	// Extra secrets, config maps and persistent volume claims that should be
+	// mounted into the test pod of the workflow step. When set, it replaces
+	// spec.extraMounts for the step. An empty list mounts nothing.
+	ExtraMounts *[]ExtraMount `json:"extraMounts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names the test
+	// pod of the workflow step is attached to. When set, it replaces
+	// spec.networkAttachments for the step. An empty list attaches the test
+	// pod to no additional network.
+	NetworkAttachments *[]string `json:"networkAttachments,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// AnsibleTest is the Schema for the ansibletests API
+type AnsibleTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AnsibleTestSpec  `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AnsibleTestList contains a list of AnsibleTest
+type AnsibleTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AnsibleTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AnsibleTest{}, &AnsibleTestList{})
+}
@@ -0,0 +1,1477 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type ExtraConfigmapsMounts struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=253
+	// The name of an existing config map for mounting.
+	Name string `json:"name"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Required
+	// Path within the container at which the volume should be mounted.
+	MountPath string `json:"mountPath"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// +kubebuilder:default:=""
+	// Config map subpath for mounting, defaults to configmap root.
+	SubPath string `json:"subPath"`
+}
+
+// +kubebuilder:validation:XValidation:rule="[has(self.secret), has(self.configMap), has(self.persistentVolumeClaim)].filter(x, x).size() == 1",message="exactly one of secret, configMap and persistentVolumeClaim must be set"
+// ExtraMount describes a volume that is mounted into the test pods
+type ExtraMount struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=50
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Name of the volume. The volume is added to the test pods as
+	// extra-mount-<name>.
+	Name string `json:"name"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// A secret that should be mounted
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// A config map that should be mounted
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// A persistent volume claim that should be mounted
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Required
+	// Path within the container at which the volume should be mounted.
+	MountPath string `json:"mountPath"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Path within the volume from which the container's volume should be
+	// mounted. Defaults to the root of the volume.
+	SubPath string `json:"subPath,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	// Mount the volume read-only
+	ReadOnly bool `json:"readOnly"`
+}
+
+// CleanupAssertion describes OpenStack resources that must not remain in the
+// cloud once the test run finished.
+type CleanupAssertion struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum:=server;port;network;subnet;router;volume;securitygroup
+	// Type of the OpenStack resource that is checked.
+	Resource string `json:"resource"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Only resources with a name starting with this prefix are considered
+	// leftovers. When empty, any resource of the given type is a leftover.
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Only resources owned by this project are considered leftovers. When
+	// empty, resources visible with the credentials used by the test pods are
+	// checked.
+	Project string `json:"project,omitempty"`
+}
+
+// EndpointCheck contains the result of a reachability check of a single
+// OpenStack endpoint performed from within the cluster.
+type EndpointCheck struct {
+	// Name of the service the endpoint belongs to (e.g. neutron)
+	Service string `json:"service"`
+
+	// Interface of the endpoint (e.g. public)
+	Interface string `json:"interface"`
+
+	// URL of the endpoint
+	URL string `json:"url,omitempty"`
+
+	// Reachable is true when the endpoint responded to an HTTP request
+	Reachable bool `json:"reachable"`
+
+	// Time it took the endpoint to respond in milliseconds
+	LatencyMilliseconds int64 `json:"latencyMilliseconds,omitempty"`
+
+	// Error describes why the endpoint is not reachable
+	Error string `json:"error,omitempty"`
+}
+
+// TestList refers to the list of the tests selected by a workflow step that
+// was executed in the list-only mode
+type TestList struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod that listed the tests
+	PodName string `json:"podName"`
+
+	// Number of the listed tests
+	Count int `json:"count"`
+
+	// Name of the ConfigMap that contains the list of the tests
+	ConfigMapName string `json:"configMapName"`
+}
+
+// RallySLAResult contains the result of the SLA check of the rally task
+// executed by a workflow step
+type RallySLAResult struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod that executed the rally task
+	PodName string `json:"podName"`
+
+	// Passed is true when all workloads of the rally task met the SLA
+	Passed bool `json:"passed"`
+
+	// Failures describes the SLA criteria that were not met
+	Failures []string `json:"failures,omitempty"`
+}
+
+// FioResult contains the performance numbers measured by the fio profiles
+// executed by a workflow step
+type FioResult struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod that executed the fio profiles
+	PodName string `json:"podName"`
+
+	// Profiles contains the numbers measured by each fio profile
+	Profiles []FioProfileResult `json:"profiles,omitempty"`
+}
+
+// FioProfileResult contains the performance numbers measured by a single
+// fio profile
+type FioProfileResult struct {
+	// Name of the fio profile
+	Name string `json:"name"`
+
+	// ReadIOPS is the average number of read operations per second
+	ReadIOPS int64 `json:"readIOPS"`
+
+	// WriteIOPS is the average number of write operations per second
+	WriteIOPS int64 `json:"writeIOPS"`
+
+	// ReadBandwidthKiB is the average read bandwidth in KiB/s
+	ReadBandwidthKiB int64 `json:"readBandwidthKiB"`
+
+	// WriteBandwidthKiB is the average write bandwidth in KiB/s
+	WriteBandwidthKiB int64 `json:"writeBandwidthKiB"`
+
+	// ReadLatencyMean is the mean completion latency of the read operations
+	ReadLatencyMean *metav1.Duration `json:"readLatencyMean,omitempty"`
+
+	// ReadLatencyP99 is the 99th percentile of the completion latency of the
+	// read operations
+	ReadLatencyP99 *metav1.Duration `json:"readLatencyP99,omitempty"`
+
+	// WriteLatencyMean is the mean completion latency of the write operations
+	WriteLatencyMean *metav1.Duration `json:"writeLatencyMean,omitempty"`
+
+	// WriteLatencyP99 is the 99th percentile of the completion latency of the
+	// write operations
+	WriteLatencyP99 *metav1.Duration `json:"writeLatencyP99,omitempty"`
+}
+
+// K6ThresholdResult contains the result of the thresholds of the k6 script
+// executed by a workflow step
+type K6ThresholdResult struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod that executed the k6 script
+	PodName string `json:"podName"`
+
+	// Passed is true when none of the thresholds was crossed
+	Passed bool `json:"passed"`
+
+	// FailedThresholds describes the thresholds that were crossed
+	FailedThresholds []string `json:"failedThresholds,omitempty"`
+}
+
+// RobotResult contains the statistics of the Robot Framework suites
+// executed by a workflow step
+type RobotResult struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod that executed the suites
+	PodName string `json:"podName"`
+
+	// Total number of the executed tests
+	Total int `json:"total"`
+
+	// Number of the passed tests
+	Passed int `json:"passed"`
+
+	// Number of the failed tests
+	Failed int `json:"failed"`
+
+	// Number of the skipped tests
+	Skipped int `json:"skipped"`
+}
+
+// CustomTestResult contains the numbers reported by the test container of a
+// workflow step of a CustomTest
+type CustomTestResult struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod that executed the tests
+	PodName string `json:"podName"`
+
+	// Total number of the executed tests
+	Total int `json:"total"`
+
+	// Number of the passed tests
+	Passed int `json:"passed"`
+
+	// Number of the failed tests
+	Failed int `json:"failed"`
+
+	// Number of the skipped tests
+	Skipped int `json:"skipped"`
+}
+
+// StestrComparison compares the tempest run of a workflow step with the
+// previous run stored in the stestr history
+type StestrComparison struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod that executed the tempest run
+	PodName string `json:"podName"`
+
+	// ID of the tempest run in the stestr repository
+	RunID string `json:"runID,omitempty"`
+
+	// ID of the previous tempest run in the stestr repository. It is empty
+	// when there is no previous run to compare with.
+	PreviousRunID string `json:"previousRunID,omitempty"`
+
+	// Tests that failed in the tempest run and passed in the previous run
+	NewFailures []string `json:"newFailures,omitempty"`
+
+	// Tests that passed in the tempest run and failed in the previous run
+	FixedTests []string `json:"fixedTests,omitempty"`
+
+	// Tests whose duration grew by more than the timing regression threshold
+	TimingRegressions []StestrTimingRegression `json:"timingRegressions,omitempty"`
+}
+
+// StestrTimingRegression describes a test that took longer than in the
+// previous tempest run
+type StestrTimingRegression struct {
+	// Name of the test
+	Test string `json:"test"`
+
+	// Duration of the test in the tempest run in milliseconds
+	DurationMilliseconds int64 `json:"durationMilliseconds"`
+
+	// Duration of the test in the previous tempest run in milliseconds
+	PreviousDurationMilliseconds int64 `json:"previousDurationMilliseconds"`
+}
+
+// FailureSnapshot contains an excerpt of the state of the cluster captured
+// when a test pod failed. It helps to tell failures caused by the
+// infrastructure (e.g. a NotReady node or storage errors) from test failures.
+type FailureSnapshot struct {
+	// Name of the failed test pod
+	PodName string `json:"podName"`
+
+	// Name of the node the failed test pod was scheduled on
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Time when the snapshot was captured
+	CaptureTime metav1.Time `json:"captureTime"`
+
+	// Conditions of the node that indicate a problem (e.g. Ready=False)
+	NodeConditions []string `json:"nodeConditions,omitempty"`
+
+	// Most recent warning events from the namespace of the instance and the
+	// node of the failed test pod
+	Events []string `json:"events,omitempty"`
+
+	// Name of the ConfigMap that contains the full snapshot
+	ConfigMapName string `json:"configMapName"`
+}
+
+// TimelineEntryType is the type of an entry of the timeline of an instance
+type TimelineEntryType string
+
+const (
+	// TimelineEntryTypeQueueWait - the instance waited for the
+	// test-operator-lock
+	TimelineEntryTypeQueueWait TimelineEntryType = "QueueWait"
+
+	// TimelineEntryTypeStep - the test pod of a workflow step ran
+	TimelineEntryTypeStep TimelineEntryType = "Step"
+)
+
+// TimelineEntry is a single bar of the timeline of an instance. The timeline
+// is meant to be exported and rendered as a Gantt chart of the test pipeline.
+type TimelineEntry struct {
+	// Type of the entry
+	Type TimelineEntryType `json:"type"`
+
+	// Name of the test pod (Step) or of the lock (QueueWait)
+	Name string `json:"name"`
+
+	// Number of the workflow step the entry belongs to
+	WorkflowStep *int `json:"workflowStep,omitempty"`
+
+	// Time when the entry started
+	StartTime metav1.Time `json:"startTime"`
+
+	// Time when the entry finished. It is not set while the entry is in
+	// progress.
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+}
+
+// IOLimits throttles the disk and the network I/O of a test pod so that
+// aggressive performance tests do not starve etcd or the production storage
+// on converged clusters.
+type IOLimits struct {
+	// +kubebuilder:validation:Optional
+	// Ingress bandwidth of the test pod (e.g. 100M). The limit is enforced
+	// by the CNI bandwidth plugin.
+	IngressBandwidth *resource.Quantity `json:"ingressBandwidth,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Egress bandwidth of the test pod (e.g. 100M). The limit is enforced by
+	// the CNI bandwidth plugin.
+	EgressBandwidth *resource.Quantity `json:"egressBandwidth,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Name of the block I/O class of the test pod. The classes and their
+	// throttling limits are defined in the configuration of the container
+	// runtime.
+	BlockIOClass string `json:"blockIOClass,omitempty"`
+}
+
+// CABundleSource is a key of a secret or of a config map that contains PEM
+// encoded CA certificates
+// +kubebuilder:validation:XValidation:rule="has(self.secret) != has(self.configMap)",message="exactly one of secret and configMap must be set"
+type CABundleSource struct {
+	// +kubebuilder:validation:Optional
+	// Key of a secret that contains the CA certificates
+	Secret *corev1.SecretKeySelector `json:"secret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Key of a config map that contains the CA certificates
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+}
+
+// LogsServer configures the HTTP server that serves the logs PVCs of an
+// instance. The server is protected by basic authentication.
+type LogsServer struct {
+	// +kubebuilder:validation:Required
+	// Name of a secret with the htpasswd key that contains the users allowed
+	// to access the logs in the htpasswd format
+	HtpasswdSecretName string `json:"htpasswdSecretName"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Expose the server using an OpenShift Route with the edge TLS
+	// termination. The server is reachable only from the cluster otherwise.
+	Route bool `json:"route,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image of the HTTP server (nginx). The default image of the
+	// test-operator is used when it is not set.
+	Image string `json:"image,omitempty"`
+}
+
+// Report configures the container that generates an HTML report of the test
+// results once all test pods finish
+type Report struct {
+	// +kubebuilder:validation:Optional
+	// Image of the container. The default report image of the test-operator
+	// is used when it is not set.
+	Image string `json:"image,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Command that generates the report. It replaces the built-in script that
+	// converts the subunit streams (stestr) and the JUnit XML files found in
+	// the logs PVCs to HTML and writes index.html to the root of each logs
+	// PVC. The directories where the logs PVCs are mounted are passed in the
+	// REPORT_DIRECTORIES environment variable.
+	Command []string `json:"command,omitempty"`
+}
+
+// ReportPortal configures the upload of the test results to ReportPortal.
+// The results of each test pod are imported as a separate launch.
+type ReportPortal struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^https?://[^/]+`
+	// URL of ReportPortal, e.g. https://reportportal.example.com
+	Endpoint string `json:"endpoint"`
+
+	// +kubebuilder:validation:Required
+	// Name of the ReportPortal project the launches are imported to
+	Project string `json:"project"`
+
+	// +kubebuilder:validation:Required
+	// Name of a secret with the token key that contains the API key used to
+	// authenticate to ReportPortal
+	TokenSecretName string `json:"tokenSecretName"`
+
+	// +kubebuilder:validation:Optional
+	// Name of the launches. The name of the instance is used when it is not
+	// set.
+	LaunchName string `json:"launchName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Attributes added to each launch. The name of the instance, the run ID,
+	// the workflow step and the name of the test pod are always added.
+	LaunchAttributes map[string]string `json:"launchAttributes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Do not verify the certificate of ReportPortal
+	TLSInsecureSkipVerify bool `json:"tlsInsecureSkipVerify,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image of the upload pod. The default report image of the test-operator
+	// is used when it is not set.
+	Image string `json:"image,omitempty"`
+}
+
+// ReportPortalLaunch describes the upload of the results of a test pod to
+// ReportPortal
+type ReportPortalLaunch struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod whose results were uploaded
+	PodName string `json:"podName"`
+
+	// URL of the launch in ReportPortal. It is empty when the upload failed.
+	URL string `json:"url,omitempty"`
+
+	// Error reported by the upload pod when the upload failed
+	Error string `json:"error,omitempty"`
+}
+
+// Polarion configures the export of the test results to the Polarion xUnit
+// importer
+type Polarion struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^https?://[^/]+`
+	// URL of the xUnit importer, e.g.
+	// https://polarion.example.com/polarion/import/xunit
+	URL string `json:"url"`
+
+	// +kubebuilder:validation:Required
+	// Name of a secret with the username and password keys that are used to
+	// authenticate to the importer
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// +kubebuilder:validation:Required
+	// ID of the Polarion project (polarion-project-id)
+	ProjectID string `json:"projectID"`
+
+	// +kubebuilder:validation:Optional
+	// ID of the test run the results are imported to (polarion-testrun-id).
+	// <name of the instance>-<run ID> is used when it is not set.
+	TestRunID string `json:"testRunID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="polarion-testcase-id"
+	// Name of the testcase property that holds the ID of the test case
+	TestCaseIDProperty string `json:"testCaseIDProperty,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Regular expression matched against <classname>.<name> of each
+	// testcase. The first capturing group (or the whole match when there is
+	// no group) is used as the ID of the test case, e.g. \[id-([0-9a-f-]+)\]
+	// for the idempotent IDs of the tempest tests. The whole name is used
+	// when it is not set. Testcases that do not match are not exported.
+	TestCaseIDPattern string `json:"testCaseIDPattern,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Additional properties of the test run (e.g. polarion-lookup-method or
+	// polarion-custom-<field>)
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Do not verify the certificate of the importer
+	TLSInsecureSkipVerify bool `json:"tlsInsecureSkipVerify,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image of the export pod. The default report image of the test-operator
+	// is used when it is not set.
+	Image string `json:"image,omitempty"`
+}
+
+// DebugHold keeps a test pod alive before or after the test container runs so
+// that the environment of the test container can be inspected
+type DebugHold struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Keep the test pod running once its test container fails. A debug-hold
+	// container that shares the image, the environment and the volumes of
+	// the test container keeps the pod alive.
+	KeepPodOnFailure bool `json:"keepPodOnFailure,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// How long the failed test pod is kept (e.g. 2h). The pod is kept until
+	// it is released using the test.openstack.org/debug-hold-release
+	// annotation when it is not set.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Pause the test pod before the test container starts. A debug-pause
+	// init container that shares the image, the environment and the volumes
+	// of the test container waits until the test.openstack.org/debug-pause
+	// annotation of the test pod is removed or until the
+	// /var/lib/debug-pause/continue file is created in it.
+	PauseBeforeRun bool `json:"pauseBeforeRun,omitempty"`
+}
+
+// IPFamily - IP family of the addresses the test pods get on a network
+// +kubebuilder:validation:Enum:=IPv4;IPv6;DualStack
+type IPFamily string
+
+const (
+	// IPFamilyIPv4 - the test pods use IPv4 addresses
+	IPFamilyIPv4 IPFamily = "IPv4"
+
+	// IPFamilyIPv6 - the test pods use IPv6 addresses
+	IPFamilyIPv6 IPFamily = "IPv6"
+
+	// IPFamilyDualStack - the test pods use both IPv4 and IPv6 addresses
+	IPFamilyDualStack IPFamily = "DualStack"
+)
+
+// LogsPersistence - how the logs of the test pods are stored
+// +kubebuilder:validation:Enum:=none;pvc;ephemeralVolume
+type LogsPersistence string
+
+const (
+	// LogsPersistenceNone - the logs are stored in an emptyDir volume of the
+	// test pod
+	LogsPersistenceNone LogsPersistence = "none"
+
+	// LogsPersistencePVC - the logs are stored in the logs PVC
+	LogsPersistencePVC LogsPersistence = "pvc"
+
+	// LogsPersistenceEphemeralVolume - the logs are stored in a generic
+	// ephemeral volume of the test pod
+	LogsPersistenceEphemeralVolume LogsPersistence = "ephemeralVolume"
+)
+
+// LogRetention describes which logs of the finished test pods are kept in the
+// logs PVC. The logs are pruned by an init container of each test pod before
+// the tests start. A directory is pruned when any of the limits is exceeded.
+type LogRetention struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=1
+	// Number of the most recent finished test pods whose logs are kept
+	KeepLastRuns *int32 `json:"keepLastRuns,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum age of the logs (e.g. 168h). The age is measured from the
+	// finish of the test pod that stored the logs.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum size of the logs stored in the logs PVC (e.g. 10Gi). When the
+	// size is exceeded, the logs of the oldest test pods are pruned until
+	// the logs fit.
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+}
+
+// PrunedLogs contains the directories of the logs PVC that were pruned by the
+// test pod according to spec.logRetention
+type PrunedLogs struct {
+	// Name of the test pod that pruned the directories
+	PodName string `json:"podName"`
+
+	// Pruned directories of the logs PVC
+	Directories []string `json:"directories,omitempty"`
+}
+
+// LogsRotation limits the space occupied by the logs of the test pods in the
+// logs PVC. The rotation is executed by an init container of each test pod
+// before the tests start.
+type LogsRotation struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=0
+	// Number of the most recent finished test pods whose logs are kept
+	// uncompressed. The directories of the older test pods are replaced by
+	// gzipped tarballs (<directory>.tar.gz).
+	KeepUncompressed int32 `json:"keepUncompressed"`
+
+	// +kubebuilder:validation:Optional
+	// Maximum size of the logs stored in the logs PVC (e.g. 5Gi). When the
+	// size is exceeded, the oldest tarballs are deleted until the logs fit.
+	// The uncompressed logs are never deleted.
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+}
+
+// LogForwardingOutput - type of the endpoint the logs of the test pods are
+// shipped to
+// +kubebuilder:validation:Enum:=loki;elasticsearch;http
+type LogForwardingOutput string
+
+const (
+	// LogForwardingOutputLoki - the logs are pushed to the push API of loki
+	LogForwardingOutputLoki LogForwardingOutput = "loki"
+
+	// LogForwardingOutputElasticsearch - the logs are indexed by
+	// elasticsearch (or opensearch) using the bulk API
+	LogForwardingOutputElasticsearch LogForwardingOutput = "elasticsearch"
+
+	// LogForwardingOutputHTTP - the logs are posted as JSON to a generic HTTP
+	// endpoint
+	LogForwardingOutputHTTP LogForwardingOutput = "http"
+)
+
+// LogForwarding configures the sidecar that ships the logs of the test pods to
+// an external endpoint
+type LogForwarding struct {
+	// +kubebuilder:validation:Required
+	// Type of the endpoint the logs are shipped to
+	Output LogForwardingOutput `json:"output"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^https?://[^/]+`
+	// URL of the endpoint, e.g. https://loki.example.com/loki/api/v1/push.
+	// For loki the push API path is used when the URL does not contain a
+	// path.
+	URL string `json:"url"`
+
+	// +kubebuilder:validation:Optional
+	// Name of a secret with the username and password keys that are used to
+	// authenticate to the endpoint
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Do not verify the certificate of the endpoint
+	TLSInsecureSkipVerify bool `json:"tlsInsecureSkipVerify,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="test-operator"
+	// Name of the elasticsearch index the logs are stored in
+	Index string `json:"index,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Labels added to each shipped record. The namespace, the name of the
+	// instance and the name of the test pod are always added.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Image of the fluent-bit sidecar. The default image of the test-operator
+	// is used when it is not set.
+	Image string `json:"image,omitempty"`
+}
+
+// ProxyConfig contains the proxy settings of the test pods
+type ProxyConfig struct {
+	// +kubebuilder:validation:Optional
+	// URL of the proxy for HTTP requests
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// URL of the proxy for HTTPS requests
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Comma-separated list of hostnames, domains and CIDRs for which the
+	// proxy is not used
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// TestSecurityContext contains the security settings of the test pods that
+// replace the defaults set by the test-operator. It allows to tune the test
+// pods so that they pass the restricted pod security standard.
+type TestSecurityContext struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// UID used to run the containers of the test pod
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// GID used to run the containers of the test pod
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// Supplemental group that owns the volumes of the test pod
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Seccomp profile of the containers of the test pod (defaults to
+	// RuntimeDefault)
+	SeccompProfile *corev1.SeccompProfile `json:"seccompProfile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Capabilities added to and dropped from the containers of the test pod.
+	// They replace the capabilities set by the test-operator (all
+	// capabilities are dropped unless the test pod is privileged).
+	Capabilities *corev1.Capabilities `json:"capabilities,omitempty"`
+}
+
+// TestPhase is the phase of a test instance
+type TestPhase string
+
+const (
+	// TestPhasePending - no test pod has been created yet
+	TestPhasePending TestPhase = "Pending"
+
+	// TestPhaseQueued - the instance is waiting for the test-operator-lock
+	TestPhaseQueued TestPhase = "Queued"
+
+	// TestPhaseRunning - not all test pods have finished yet
+	TestPhaseRunning TestPhase = "Running"
+
+	// TestPhaseSucceeded - all test pods finished successfully
+	TestPhaseSucceeded TestPhase = "Succeeded"
+
+	// TestPhaseFailed - all test pods finished and at least one of them
+	// failed
+	TestPhaseFailed TestPhase = "Failed"
+)
+
+// TestStepStatus is the state of the test pod of a single workflow step
+type TestStepStatus struct {
+	// Name of the workflow step. It is empty when the instance does not
+	// have a workflow.
+	StepName string `json:"stepName,omitempty"`
+
+	// Number of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the test pod
+	PodName string `json:"podName"`
+
+	// Phase of the test pod
+	Phase corev1.PodPhase `json:"phase"`
+
+	// Time when the test pod started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Time when the test pod finished
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+
+	// Exit code of the container of the finished test pod
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Reason of the termination of the container of the finished test pod
+	// (e.g. Completed, Error or OOMKilled)
+	Reason string `json:"reason,omitempty"`
+
+	// Location of the logs of the test pod in the form of
+	// pvc://<namespace>/<persistent volume claim name>
+	ArtifactURL string `json:"artifactURL,omitempty"`
+
+	// Directory of the logs PVC that contains the logs of the test pod. Each
+	// workflow step uses a distinct directory.
+	ArtifactDirectory string `json:"artifactDirectory,omitempty"`
+
+	// Commit of the git repo that was checked out by the git-checkout init
+	// container of the test pod. It is set only for AnsibleTest steps.
+	GitCommit string `json:"gitCommit,omitempty"`
+
+	// Location of the ARA records of the playbook run of the test pod. It is
+	// set only for AnsibleTest steps with ARA enabled.
+	AraURL string `json:"araURL,omitempty"`
+
+	// Indicate whether the test pod executed the failed tests of the
+	// workflow step once more (see tempestRun.rerunFailed)
+	Rerun bool `json:"rerun,omitempty"`
+
+	// Index of the shard of the tests executed by the test pod. It is set
+	// only for the shards of Tempest steps with shards greater than 1.
+	Shard *int `json:"shard,omitempty"`
+
+	// Packages installed into the test pod together with the tempest plugins
+	// in the pip freeze format (e.g. whitebox-tempest-plugin @
+	// git+https://...@<commit>). It is set only for Tempest steps with
+	// plugins.
+	Plugins []string `json:"plugins,omitempty"`
+
+	// Files with the screenshots and the page sources captured when the
+	// tests failed, relative to the artifact directory. It is set only for
+	// HorizonTest steps with failureScreenshots.
+	FailureCaptures []string `json:"failureCaptures,omitempty"`
+}
+
+// PodRetentionPolicy describes what happens with the test pods once they
+// finish
+type PodRetentionPolicy string
+
+const (
+	// PodRetentionPolicyRetain - keep all test pods until the instance is
+	// deleted
+	PodRetentionPolicyRetain PodRetentionPolicy = "Retain"
+
+	// PodRetentionPolicyDeleteFinished - delete the finished test pods once
+	// their exit code and timestamps are recorded in status.steps. The logs
+	// of the tests are kept in the logs PVC. The test pod of the most recent
+	// workflow step is always kept.
+	PodRetentionPolicyDeleteFinished PodRetentionPolicy = "DeleteFinished"
+)
+
+type CommonOptions struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// +kubebuilder:default=false
+	// +optional
+	// Use with caution! This parameter specifies whether test-operator should spawn
+	// test pods with allowedPrivilegedEscalation: true, readOnlyRootFilesystem: false,
+	// runAsNonRoot: false, automountServiceAccountToken: true, and the default
+	// capabilities on top of capabilities that are usually needed by the test
+	// pods (NET_ADMIN, NET_RAW). This parameter is deemed insecure but it is
+	// needed for certain test-operator functionalities to work properly (e.g.:
+	// extraRPMs in Tempest CR, or certain set of tobiko tests).
+	Privileged bool `json:"privileged"`
+
+	// +kubebuilder:validation:XValidation:rule="size(self) > 0",message="storageClass must not be empty, it is used to create the PVC for the test logs"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="local-storage"
+	// StorageClass used to create any test-operator related PVCs.
+	StorageClass string `json:"storageClass"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=""
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A SELinuxLevel that should be used for test pods spawned by the test
+	// operator.
+	SELinuxLevel string `json:"seLinuxLevel"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=""
+	// A URL of a container image that should be used by the test-operator for tests execution.
+	ContainerImage string `json:"containerImage"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Names of the secrets that are used to pull the container images of
+	// the pods spawned by the test operator (e.g. from a private registry)
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:=Always;IfNotPresent;Never
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Image pull policy of the containers of the pods spawned by the test
+	// operator. When empty, the default policy of the cluster applies.
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// PinImageDigest - resolve the tag of the container image to a digest
+	// before the first test pod that uses the image is created. The test pods
+	// use the digest, which is recorded in status.imageDigests, so that all
+	// workflow steps run the same image.
+	PinImageDigest bool `json:"pinImageDigest,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// CosignPublicKey - key of a ConfigMap that contains a PEM encoded cosign
+	// public key. When set, the container image is pinned to a digest and
+	// the test pods are created only when the image has a cosign signature
+	// made by the key.
+	CosignPublicKey *corev1.ConfigMapKeySelector `json:"cosignPublicKey,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=Retain
+	// +kubebuilder:validation:Enum:=Retain;DeleteFinished
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// PodRetentionPolicy - Retain keeps all test pods. DeleteFinished deletes
+	// the finished test pods (except for the test pod of the most recent
+	// workflow step) once their exit code and timestamps are recorded in
+	// status.steps. It keeps the namespace tidy during long workflows.
+	PodRetentionPolicy PodRetentionPolicy `json:"podRetentionPolicy,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// BackoffLimit allows to define the maximum number of retried executions (defaults to 0).
+	// +kubebuilder:default:=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Extra configmaps for mounting inside the pod
+	ExtraConfigmapsMounts []ExtraConfigmapsMounts `json:"extraConfigmapsMounts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Names of secrets whose keys are exposed as environment variables in
+	// the test pods
+	EnvFromSecrets []string `json:"envFromSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Names of config maps whose keys are exposed as environment variables in
+	// the test pods
+	EnvFromConfigMaps []string `json:"envFromConfigMaps,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// This value contains a nodeSelector value that is applied to test pods
+	// spawned by the test operator.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Run the test pods in the host network namespace. It requires
+	// spec.privileged to be set to true.
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// DNS policy of the test pods. When hostNetwork is enabled it defaults
+	// to ClusterFirstWithHostNet.
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// DNS parameters (e.g. custom resolvers) of the test pods that are merged
+	// with the ones generated from the dnsPolicy
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the RuntimeClass that is used to run the test pods (e.g. kata
+	// to run the test pods in a sandboxed runtime)
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Security settings of the test pods (e.g. runAsUser, seccompProfile or
+	// capabilities) that replace the defaults set by the test-operator.
+	SecurityContext *TestSecurityContext `json:"securityContext,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:=restricted-v2;nonroot-v2;nonroot;anyuid;privileged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the OpenShift security context constraint (SCC) the test pods
+	// are required to use. The service account of the test pods is allowed
+	// to use this SCC only. When empty, the privileged SCC is used for
+	// privileged test pods and the nonroot-v2 SCC for the others.
+	SecurityContextConstraint string `json:"securityContextConstraint,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Proxy settings that are exposed to the test pods using the HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables (e.g. to install ansible
+	// collections or tempest plugins from behind a corporate proxy). When
+	// empty, the settings of the cluster-wide Proxy of OpenShift are used.
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="combined-ca-bundle"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the secret with the CA bundle (the tls-ca-bundle.pem key) that
+	// is mounted to the test pods when the secret exists
+	CABundleSecretName string `json:"caBundleSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Whether the CA bundle secret is mounted to the test pods
+	MountCABundle *bool `json:"mountCABundle,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Additional CA certificates (e.g. of IPA, of the ingress or of external
+	// services) stored in secrets or config maps. An init container merges
+	// them with the CA bundle secret into a single trust bundle that is
+	// mounted to the test pods.
+	CABundleSources []CABundleSource `json:"caBundleSources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// This value contains the affinity (node affinity, pod affinity and pod
+	// anti-affinity) that is applied to test pods spawned by the test
+	// operator.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// This value contains topology spread constraints that are applied to
+	// test pods spawned by the test operator.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Disk I/O and network bandwidth limits that are applied to test pods
+	// spawned by the test operator. The applied limits are recorded in
+	// status.ioLimits.
+	IOLimits *IOLimits `json:"ioLimits,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// This value contains a toleration that is applied to pods spawned by the
+	// test pods that are spawned by the test-operator.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// CleanupAssertions is a list of checks that are evaluated by a verification
+	// pod once all test pods finished. Each check fails when a matching OpenStack
+	// resource is still present in the cloud. Failures are reported using the
+	// CleanupVerified condition.
+	CleanupAssertions []CleanupAssertion `json:"cleanupAssertions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// When enabled, test-operator spawns a short-lived pod before the first
+	// test pod that checks the reachability and latency of all public
+	// endpoints listed in the OpenStack service catalog. The results are
+	// stored in status.endpointChecks and reported using the
+	// EndpointsReachable condition.
+	EndpointPreflight bool `json:"endpointPreflight"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Forward the output of the running test pods to the log of the
+	// test-operator while they run. Each line is logged together with the
+	// name of the test pod, so the output can be followed without access to
+	// the test pods and is kept in the operator log when a test pod is
+	// evicted.
+	StreamLogs bool `json:"streamLogs"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Ship the files written by the test pods to the logs PVC to an external
+	// endpoint (e.g. loki, elasticsearch) using a fluent-bit sidecar. It is
+	// useful in environments where the logs PVC can not be mounted after the
+	// test pods finish.
+	LogForwarding *LogForwarding `json:"logForwarding,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Compress the logs of the older test pods and limit the size of the logs
+	// stored in the logs PVC so that the PVC does not fill up and fail the
+	// subsequent test pods.
+	LogsRotation *LogsRotation `json:"logsRotation,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Prune the logs of old test pods from the logs PVC. The pruned
+	// directories are recorded in status.prunedLogs and reported using
+	// Events of the instance.
+	LogRetention *LogRetention `json:"logRetention,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Store the logs of each workflow step in its own logs PVC (named with
+	// the index of the workflow step) instead of sharing a single logs PVC.
+	// The PVCs of the workflow steps are always separate when the test pods
+	// run in parallel.
+	LogsPVCPerStep bool `json:"logsPVCPerStep"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=pvc
+	// Where the logs of the test pods are stored. With pvc the logs are
+	// stored in the logs PVC that is kept until the instance is deleted.
+	// With ephemeralVolume each test pod gets its own volume provisioned
+	// using storageClass that is deleted together with the test pod. With
+	// none the logs are stored in an emptyDir volume and no storage class is
+	// needed. Use none or ephemeralVolume when the logs are not needed after
+	// the test pods are deleted or when they are shipped elsewhere (e.g.
+	// using logForwarding).
+	Persistence LogsPersistence `json:"persistence"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Serve the logs PVCs of the instance read-only over HTTP once all test
+	// pods finish. The URL of the server is stored in status.logsServerURL.
+	LogsServer *LogsServer `json:"logsServer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Generate an HTML report of the test results in the logs PVCs once all
+	// test pods finish. The result is reported using the ReportGenerated
+	// condition.
+	Report *Report `json:"report,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Upload the results (JUnit XML and subunit) of each test pod to
+	// ReportPortal once the test pod finishes. The URLs of the launches are
+	// stored in status.reportPortalLaunches.
+	ReportPortal *ReportPortal `json:"reportPortal,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Export the results of all test pods to the Polarion xUnit importer once
+	// all test pods finish. The result is reported using the PolarionExported
+	// condition.
+	Polarion *Polarion `json:"polarion,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Number of the TestRun records of the finished runs of the instance that
+	// are kept. The oldest records are deleted once the limit is exceeded.
+	// Zero disables the records. Defaults to 10.
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Execute the whole workflow once more when the spec of a completed
+	// instance changes. The generation of the spec executed by the current
+	// run is stored in status.observedGeneration.
+	RerunOnSpecChange bool `json:"rerunOnSpecChange,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Use with caution! Keep the test pods alive for debugging before their
+	// test container starts or once it fails. A held test pod keeps the
+	// test-operator-lock.
+	DebugHold *DebugHold `json:"debugHold,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// IP family (IPv4, IPv6 or DualStack) of the addresses the test pods use
+	// on the networks of NetworkAttachments indexed by the name of the
+	// NetworkAttachment. A test pod is spawned only when the
+	// NetworkAttachmentDefinition assigns addresses of the family and only
+	// the addresses of the family are reported in status.networkAttachments.
+	NetworkAttachmentIPFamilies map[string]IPFamily `json:"networkAttachmentIPFamilies,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Networks the test pods are attached to, each described by a complete
+	// NetworkSelectionElement of the k8s.v1.cni.cncf.io/networks annotation
+	// (e.g. {"name": "sriov-net", "interface": "net1", "ips":
+	// ["192.168.10.5/24"]}). An element replaces the element generated from
+	// NetworkAttachments for the same network. The test container requests
+	// one device of the k8s.v1.cni.cncf.io/resourceName resource (e.g. a
+	// SR-IOV virtual function) of each of the networks.
+	NetworkSelections []runtime.RawExtension `json:"networkSelections,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Use with caution! PodTemplateOverrides contains patches that are applied
+	// to the generated definition of each test pod as the last step before
+	// the pod is created. They allow to set fields of the pod that are not
+	// exposed by the API (e.g. schedulerName, hostAliases).
+	PodTemplateOverrides *PodTemplateOverrides `json:"podTemplateOverrides,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Labels that are added to the test pods (e.g. cost-center labels). The
+	// labels set by the test-operator take precedence.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Annotations that are added to the test pods (e.g. sidecar injection or
+	// log routing hints). The annotations set by the test-operator take
+	// precedence.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+}
+
+// PodTemplateOverrides contains patches of the generated test pods
+type PodTemplateOverrides struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// A strategic merge patch of the pod (e.g. {"spec": {"schedulerName":
+	// "custom-scheduler"}})
+	StrategicMergePatch *runtime.RawExtension `json:"strategicMergePatch,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// A JSON patch (RFC 6902) of the pod in the JSON format. It is applied
+	// after the strategic merge patch.
+	JSONPatch string `json:"jsonPatch,omitempty"`
+}
+
+type CommonOpenstackConfig struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=openstack-config
+	// +kubebuilder:validation:Optional
+	// OpenStackConfigMap is the name of the ConfigMap containing the clouds.yaml
+	OpenStackConfigMap string `json:"openStackConfigMap"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=openstack-config-secret
+	// +kubebuilder:validation:Optional
+	// OpenStackConfigSecret is the name of the Secret containing the secure.yaml
+	OpenStackConfigSecret string `json:"openStackConfigSecret"`
+}
+
+// CommonTestStatus defines the observed state of the controller
+type CommonTestStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Phase of the instance
+	Phase TestPhase `json:"phase,omitempty"`
+
+	// Steps contains the state of the test pod of each workflow step that
+	// has been created so far
+	Steps []TestStepStatus `json:"steps,omitempty"`
+
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// NetworkAttachments status of the deployment pods
+	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
+
+	// LockWaitStartTime is the time when the instance started waiting for the
+	// test-operator-lock. It is cleared once the lock is acquired.
+	LockWaitStartTime *metav1.Time `json:"lockWaitStartTime,omitempty"`
+
+	// EndpointChecks contains the results of the endpoint preflight checks
+	EndpointChecks []EndpointCheck `json:"endpointChecks,omitempty"`
+
+	// FailureSnapshots contains an excerpt of the state of the cluster for
+	// each failed test pod
+	FailureSnapshots []FailureSnapshot `json:"failureSnapshots,omitempty"`
+
+	// FeatureGates contains the state of the feature gates that applied to
+	// the last reconciliation of the instance
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// Timeline contains the start and the finish times of the test pods and
+	// of the waits for the test-operator-lock ordered by the start time
+	Timeline []TimelineEntry `json:"timeline,omitempty"`
+	// IOLimits contains the disk I/O and network bandwidth limits applied to
+	// the test pods indexed by the name of the pod
+	IOLimits map[string]IOLimits `json:"ioLimits,omitempty"`
+	// ImageDigests contains the container images of the test pods pinned to
+	// a digest indexed by the container image they were resolved from
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+	// StestrComparisons contains the comparison of the tempest run of each
+	// workflow step with the previous run stored in the stestr history
+	StestrComparisons []StestrComparison `json:"stestrComparisons,omitempty"`
+	// TestLists refers to the lists of the tests of the workflow steps
+	// executed in the list-only mode
+	TestLists []TestList `json:"testLists,omitempty"`
+	// RallySLAResults contains the result of the SLA check of the rally task
+	// of each workflow step of a RallyTest
+	RallySLAResults []RallySLAResult `json:"rallySLAResults,omitempty"`
+	// FioResults contains the performance numbers measured by the fio
+	// profiles of each workflow step of a FioTest
+	FioResults []FioResult `json:"fioResults,omitempty"`
+	// K6ThresholdResults contains the result of the thresholds of the k6
+	// script of each workflow step of a K6Test
+	K6ThresholdResults []K6ThresholdResult `json:"k6ThresholdResults,omitempty"`
+	// RobotResults contains the statistics of the Robot Framework suites of
+	// each workflow step of a RobotTest
+	RobotResults []RobotResult `json:"robotResults,omitempty"`
+	// CustomTestResults contains the numbers reported by the test container
+	// of each workflow step of a CustomTest
+	CustomTestResults []CustomTestResult `json:"customTestResults,omitempty"`
+	// PrunedLogs contains the directories of the logs PVC that were pruned
+	// according to spec.logRetention
+	PrunedLogs []PrunedLogs `json:"prunedLogs,omitempty"`
+	// LogsServerURL is the URL of the HTTP server that serves the logs PVCs
+	// (see spec.logsServer)
+	LogsServerURL string `json:"logsServerURL,omitempty"`
+	// ResultsConfigMap is the name of the ConfigMap that contains the
+	// results of the run encoded as JSON (see TestResults)
+	ResultsConfigMap string `json:"resultsConfigMap,omitempty"`
+	// ReportPortalLaunches contains the launches the results of the test pods
+	// were imported to (see spec.reportPortal)
+	ReportPortalLaunches []ReportPortalLaunch `json:"reportPortalLaunches,omitempty"`
+	// ObservedRerun is the value of the test.openstack.org/rerun annotation
+	// that triggered the current run of the workflow
+	ObservedRerun string `json:"observedRerun,omitempty"`
+	// ObservedGeneration is the generation of the spec executed by the
+	// current run of the workflow
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Reruns is the number of the runs of the workflow that were triggered
+	// after the first one (see test.openstack.org/rerun and
+	// spec.rerunOnSpecChange)
+	Reruns int `json:"reruns,omitempty"`
+}
+
+type WorkflowCommonParameters struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:optional
+	// +optional
+	// Use with caution! This parameter specifies whether test-operator should spawn test
+	// pods with allowedPrivilegedEscalation: true and the default capabilities on
+	// top of capabilities that are usually needed by the test pods (NET_ADMIN, NET_RAW).
+	// This parameter is deemed insecure but it is needed for certain test-operator
+	// functionalities to work properly (e.g.: extraRPMs in Tempest CR, or certain set
+	// of tobiko tests).
+	Privileged *bool `json:"privileged,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="size(self) > 0",message="storageClass must not be empty, it is used to create the PVC for the test logs"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="local-storage"
+	// StorageClass used to create any test-operator related PVCs.
+	StorageClass *string `json:"storageClass"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +optional
+	// A SELinuxLevel that should be used for test pods spawned by the test
+	// operator.
+	SELinuxLevel *string `json:"seLinuxLevel,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=""
+	// A URL of a container image that should be used by the test-operator for tests execution.
+	ContainerImage string `json:"containerImage"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// BackoffLimit allows to define the maximum number of retried executions (defaults to 0).
+	// +kubebuilder:default:=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Extra configmaps for mounting inside the pod
+	ExtraConfigmapsMounts []ExtraConfigmapsMounts `json:"extraConfigmapsMounts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Names of secrets whose keys are exposed as environment variables in
+	// the test pod of the workflow step. When set, it replaces
+	// spec.envFromSecrets for the step.
+	EnvFromSecrets []string `json:"envFromSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Names of config maps whose keys are exposed as environment variables in
+	// the test pod of the workflow step. When set, it replaces
+	// spec.envFromConfigMaps for the step.
+	EnvFromConfigMaps []string `json:"envFromConfigMaps,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// This value contains a nodeSelector value that is applied to test pods
+	// spawned by the test operator.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// This value contains the affinity (node affinity, pod affinity and pod
+	// anti-affinity) that is applied to the test pod of the workflow step.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// This value contains topology spread constraints that are applied to
+	// the test pod of the workflow step.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Disk I/O and network bandwidth limits that are applied to the test pod
+	// of the workflow step. When set, it replaces spec.ioLimits for the step.
+	IOLimits *IOLimits `json:"ioLimits,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Networks the test pod of the workflow step is attached to, each
+	// described by a complete NetworkSelectionElement. When set, it replaces
+	// spec.networkSelections for the step.
+	NetworkSelections []runtime.RawExtension `json:"networkSelections,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:=restricted-v2;nonroot-v2;nonroot;anyuid;privileged
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the OpenShift security context constraint (SCC) the test pod of
+	// the workflow step is required to use
+	SecurityContextConstraint *string `json:"securityContextConstraint,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// This value contains a toleration that is applied to pods spawned by the
+	// test pods that are spawned by the test-operator.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// SpecOverride is a sparse copy of the spec of the instance that is
+	// strategically merged over the spec for this workflow step. It allows to
+	// override any field of the spec, including the fields that can not be set
+	// directly in the workflow step. The fields set directly in the workflow
+	// step take precedence over the values from SpecOverride. The workflow
+	// itself can not be overridden.
+	SpecOverride *runtime.RawExtension `json:"specOverride,omitempty"`
+}
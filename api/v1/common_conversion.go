@@ -0,0 +1,684 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+// convertCommonOptionsTo converts the v1 CommonOptions to the hub version
+func convertCommonOptionsTo(src *CommonOptions, dst *v1beta1.CommonOptions) {
+	dst.Privileged = src.Privileged
+	dst.StorageClass = src.StorageClass
+	dst.SELinuxLevel = src.SELinuxLevel
+	dst.ContainerImage = src.ContainerImage
+	dst.ImagePullSecrets = src.ImagePullSecrets
+	dst.ImagePullPolicy = src.ImagePullPolicy
+	dst.PinImageDigest = src.PinImageDigest
+	dst.CosignPublicKey = src.CosignPublicKey
+	dst.PodRetentionPolicy = v1beta1.PodRetentionPolicy(src.PodRetentionPolicy)
+	dst.BackoffLimit = src.BackoffLimit
+	dst.ExtraConfigmapsMounts = convertExtraConfigmapsMountsTo(src.ExtraConfigmapsMounts)
+	dst.EnvFromSecrets = src.EnvFromSecrets
+	dst.EnvFromConfigMaps = src.EnvFromConfigMaps
+	dst.NodeSelector = src.NodeSelector
+	dst.Tolerations = src.Tolerations
+	dst.Affinity = src.Affinity
+	dst.IOLimits = (*v1beta1.IOLimits)(src.IOLimits.DeepCopy())
+	dst.TopologySpreadConstraints = src.TopologySpreadConstraints
+	dst.RuntimeClassName = src.RuntimeClassName
+	dst.SecurityContext = (*v1beta1.TestSecurityContext)(src.SecurityContext.DeepCopy())
+	dst.SecurityContextConstraint = src.SecurityContextConstraint
+	dst.Proxy = (*v1beta1.ProxyConfig)(src.Proxy.DeepCopy())
+	dst.CABundleSecretName = src.CABundleSecretName
+	dst.MountCABundle = src.MountCABundle
+	dst.CABundleSources = convertCABundleSourcesTo(src.CABundleSources)
+	dst.HostNetwork = src.HostNetwork
+	dst.DNSPolicy = src.DNSPolicy
+	dst.DNSConfig = src.DNSConfig
+	dst.CleanupAssertions = convertCleanupAssertionsTo(src.CleanupAssertions)
+	dst.EndpointPreflight = src.EndpointPreflight
+	dst.StreamLogs = src.StreamLogs
+	dst.LogForwarding = convertLogForwardingTo(src.LogForwarding)
+	dst.LogsRotation = (*v1beta1.LogsRotation)(src.LogsRotation.DeepCopy())
+	dst.LogRetention = (*v1beta1.LogRetention)(src.LogRetention.DeepCopy())
+	dst.LogsPVCPerStep = src.LogsPVCPerStep
+	dst.Persistence = v1beta1.LogsPersistence(src.Persistence)
+	dst.LogsServer = (*v1beta1.LogsServer)(src.LogsServer.DeepCopy())
+	dst.Report = (*v1beta1.Report)(src.Report.DeepCopy())
+	dst.ReportPortal = (*v1beta1.ReportPortal)(src.ReportPortal.DeepCopy())
+	dst.Polarion = (*v1beta1.Polarion)(src.Polarion.DeepCopy())
+	dst.HistoryLimit = src.HistoryLimit
+	dst.RerunOnSpecChange = src.RerunOnSpecChange
+	dst.DebugHold = (*v1beta1.DebugHold)(src.DebugHold.DeepCopy())
+	dst.NetworkAttachmentIPFamilies = convertIPFamiliesTo(src.NetworkAttachmentIPFamilies)
+	dst.NetworkSelections = src.NetworkSelections
+	dst.PodTemplateOverrides = (*v1beta1.PodTemplateOverrides)(src.PodTemplateOverrides.DeepCopy())
+	dst.PodLabels = src.PodLabels
+	dst.PodAnnotations = src.PodAnnotations
+}
+
+// convertCommonOptionsFrom converts the hub version of CommonOptions to v1
+func convertCommonOptionsFrom(src *v1beta1.CommonOptions, dst *CommonOptions) {
+	dst.Privileged = src.Privileged
+	dst.StorageClass = src.StorageClass
+	dst.SELinuxLevel = src.SELinuxLevel
+	dst.ContainerImage = src.ContainerImage
+	dst.ImagePullSecrets = src.ImagePullSecrets
+	dst.ImagePullPolicy = src.ImagePullPolicy
+	dst.PinImageDigest = src.PinImageDigest
+	dst.CosignPublicKey = src.CosignPublicKey
+	dst.PodRetentionPolicy = PodRetentionPolicy(src.PodRetentionPolicy)
+	dst.BackoffLimit = src.BackoffLimit
+	dst.ExtraConfigmapsMounts = convertExtraConfigmapsMountsFrom(src.ExtraConfigmapsMounts)
+	dst.EnvFromSecrets = src.EnvFromSecrets
+	dst.EnvFromConfigMaps = src.EnvFromConfigMaps
+	dst.NodeSelector = src.NodeSelector
+	dst.Tolerations = src.Tolerations
+	dst.Affinity = src.Affinity
+	dst.IOLimits = (*IOLimits)(src.IOLimits.DeepCopy())
+	dst.TopologySpreadConstraints = src.TopologySpreadConstraints
+	dst.RuntimeClassName = src.RuntimeClassName
+	dst.SecurityContext = (*TestSecurityContext)(src.SecurityContext.DeepCopy())
+	dst.SecurityContextConstraint = src.SecurityContextConstraint
+	dst.Proxy = (*ProxyConfig)(src.Proxy.DeepCopy())
+	dst.CABundleSecretName = src.CABundleSecretName
+	dst.MountCABundle = src.MountCABundle
+	dst.CABundleSources = convertCABundleSourcesFrom(src.CABundleSources)
+	dst.HostNetwork = src.HostNetwork
+	dst.DNSPolicy = src.DNSPolicy
+	dst.DNSConfig = src.DNSConfig
+	dst.CleanupAssertions = convertCleanupAssertionsFrom(src.CleanupAssertions)
+	dst.EndpointPreflight = src.EndpointPreflight
+	dst.StreamLogs = src.StreamLogs
+	dst.LogForwarding = convertLogForwardingFrom(src.LogForwarding)
+	dst.LogsRotation = (*LogsRotation)(src.LogsRotation.DeepCopy())
+	dst.LogRetention = (*LogRetention)(src.LogRetention.DeepCopy())
+	dst.LogsPVCPerStep = src.LogsPVCPerStep
+	dst.Persistence = LogsPersistence(src.Persistence)
+	dst.LogsServer = (*LogsServer)(src.LogsServer.DeepCopy())
+	dst.Report = (*Report)(src.Report.DeepCopy())
+	dst.ReportPortal = (*ReportPortal)(src.ReportPortal.DeepCopy())
+	dst.Polarion = (*Polarion)(src.Polarion.DeepCopy())
+	dst.HistoryLimit = src.HistoryLimit
+	dst.RerunOnSpecChange = src.RerunOnSpecChange
+	dst.DebugHold = (*DebugHold)(src.DebugHold.DeepCopy())
+	dst.NetworkAttachmentIPFamilies = convertIPFamiliesFrom(src.NetworkAttachmentIPFamilies)
+	dst.NetworkSelections = src.NetworkSelections
+	dst.PodTemplateOverrides = (*PodTemplateOverrides)(src.PodTemplateOverrides.DeepCopy())
+	dst.PodLabels = src.PodLabels
+	dst.PodAnnotations = src.PodAnnotations
+}
+
+// convertCommonTestStatusTo converts the v1 CommonTestStatus to the hub version
+func convertCommonTestStatusTo(src CommonTestStatus) v1beta1.CommonTestStatus {
+	dst := v1beta1.CommonTestStatus{
+		Phase:              v1beta1.TestPhase(src.Phase),
+		Hash:               src.Hash,
+		Conditions:         src.Conditions,
+		NetworkAttachments: src.NetworkAttachments,
+		LockWaitStartTime:  src.LockWaitStartTime,
+		FeatureGates:       src.FeatureGates,
+		ImageDigests:       src.ImageDigests,
+		LogsServerURL:      src.LogsServerURL,
+		ResultsConfigMap:   src.ResultsConfigMap,
+		ObservedRerun:      src.ObservedRerun,
+		ObservedGeneration: src.ObservedGeneration,
+		Reruns:             src.Reruns,
+	}
+
+	if src.Steps != nil {
+		dst.Steps = make([]v1beta1.TestStepStatus, len(src.Steps))
+		for i := range src.Steps {
+			dst.Steps[i] = v1beta1.TestStepStatus(*src.Steps[i].DeepCopy())
+		}
+	}
+
+	if src.EndpointChecks != nil {
+		dst.EndpointChecks = make([]v1beta1.EndpointCheck, len(src.EndpointChecks))
+		for i := range src.EndpointChecks {
+			dst.EndpointChecks[i] = v1beta1.EndpointCheck(src.EndpointChecks[i])
+		}
+	}
+
+	if src.FailureSnapshots != nil {
+		dst.FailureSnapshots = make([]v1beta1.FailureSnapshot, len(src.FailureSnapshots))
+		for i := range src.FailureSnapshots {
+			dst.FailureSnapshots[i] = v1beta1.FailureSnapshot(src.FailureSnapshots[i])
+		}
+	}
+
+	if src.IOLimits != nil {
+		dst.IOLimits = make(map[string]v1beta1.IOLimits, len(src.IOLimits))
+		for podName, ioLimits := range src.IOLimits {
+			dst.IOLimits[podName] = v1beta1.IOLimits(*ioLimits.DeepCopy())
+		}
+	}
+
+	if src.TestLists != nil {
+		dst.TestLists = make([]v1beta1.TestList, len(src.TestLists))
+		for i := range src.TestLists {
+			dst.TestLists[i] = v1beta1.TestList(src.TestLists[i])
+		}
+	}
+
+	if src.RallySLAResults != nil {
+		dst.RallySLAResults = make([]v1beta1.RallySLAResult, len(src.RallySLAResults))
+		for i := range src.RallySLAResults {
+			dst.RallySLAResults[i] = v1beta1.RallySLAResult(*src.RallySLAResults[i].DeepCopy())
+		}
+	}
+
+	if src.FioResults != nil {
+		dst.FioResults = make([]v1beta1.FioResult, len(src.FioResults))
+		for i := range src.FioResults {
+			profiles := make([]v1beta1.FioProfileResult, len(src.FioResults[i].Profiles))
+			for j := range src.FioResults[i].Profiles {
+				profiles[j] = v1beta1.FioProfileResult(*src.FioResults[i].Profiles[j].DeepCopy())
+			}
+
+			dst.FioResults[i] = v1beta1.FioResult{
+				WorkflowStep: src.FioResults[i].WorkflowStep,
+				StepName:     src.FioResults[i].StepName,
+				PodName:      src.FioResults[i].PodName,
+				Profiles:     profiles,
+			}
+		}
+	}
+
+	if src.K6ThresholdResults != nil {
+		dst.K6ThresholdResults = make([]v1beta1.K6ThresholdResult, len(src.K6ThresholdResults))
+		for i := range src.K6ThresholdResults {
+			dst.K6ThresholdResults[i] = v1beta1.K6ThresholdResult(*src.K6ThresholdResults[i].DeepCopy())
+		}
+	}
+
+	if src.RobotResults != nil {
+		dst.RobotResults = make([]v1beta1.RobotResult, len(src.RobotResults))
+		for i := range src.RobotResults {
+			dst.RobotResults[i] = v1beta1.RobotResult(*src.RobotResults[i].DeepCopy())
+		}
+	}
+
+	if src.CustomTestResults != nil {
+		dst.CustomTestResults = make([]v1beta1.CustomTestResult, len(src.CustomTestResults))
+		for i := range src.CustomTestResults {
+			dst.CustomTestResults[i] = v1beta1.CustomTestResult(*src.CustomTestResults[i].DeepCopy())
+		}
+	}
+
+	if src.PrunedLogs != nil {
+		dst.PrunedLogs = make([]v1beta1.PrunedLogs, len(src.PrunedLogs))
+		for i := range src.PrunedLogs {
+			dst.PrunedLogs[i] = v1beta1.PrunedLogs(*src.PrunedLogs[i].DeepCopy())
+		}
+	}
+
+	if src.ReportPortalLaunches != nil {
+		dst.ReportPortalLaunches = make([]v1beta1.ReportPortalLaunch, len(src.ReportPortalLaunches))
+		for i := range src.ReportPortalLaunches {
+			dst.ReportPortalLaunches[i] = v1beta1.ReportPortalLaunch(src.ReportPortalLaunches[i])
+		}
+	}
+
+	if src.StestrComparisons != nil {
+		dst.StestrComparisons = make([]v1beta1.StestrComparison, len(src.StestrComparisons))
+		for i := range src.StestrComparisons {
+			dst.StestrComparisons[i] = convertStestrComparisonTo(src.StestrComparisons[i])
+		}
+	}
+
+	if src.Timeline != nil {
+		dst.Timeline = make([]v1beta1.TimelineEntry, len(src.Timeline))
+		for i := range src.Timeline {
+			dst.Timeline[i] = v1beta1.TimelineEntry{
+				Type:         v1beta1.TimelineEntryType(src.Timeline[i].Type),
+				Name:         src.Timeline[i].Name,
+				WorkflowStep: src.Timeline[i].WorkflowStep,
+				StartTime:    src.Timeline[i].StartTime,
+				FinishTime:   src.Timeline[i].FinishTime,
+			}
+		}
+	}
+
+	return dst
+}
+
+// convertCommonTestStatusFrom converts the hub version of CommonTestStatus to v1
+func convertCommonTestStatusFrom(src v1beta1.CommonTestStatus) CommonTestStatus {
+	dst := CommonTestStatus{
+		Phase:              TestPhase(src.Phase),
+		Hash:               src.Hash,
+		Conditions:         src.Conditions,
+		NetworkAttachments: src.NetworkAttachments,
+		LockWaitStartTime:  src.LockWaitStartTime,
+		FeatureGates:       src.FeatureGates,
+		ImageDigests:       src.ImageDigests,
+		LogsServerURL:      src.LogsServerURL,
+		ResultsConfigMap:   src.ResultsConfigMap,
+		ObservedRerun:      src.ObservedRerun,
+		ObservedGeneration: src.ObservedGeneration,
+		Reruns:             src.Reruns,
+	}
+
+	if src.Steps != nil {
+		dst.Steps = make([]TestStepStatus, len(src.Steps))
+		for i := range src.Steps {
+			dst.Steps[i] = TestStepStatus(*src.Steps[i].DeepCopy())
+		}
+	}
+
+	if src.EndpointChecks != nil {
+		dst.EndpointChecks = make([]EndpointCheck, len(src.EndpointChecks))
+		for i := range src.EndpointChecks {
+			dst.EndpointChecks[i] = EndpointCheck(src.EndpointChecks[i])
+		}
+	}
+
+	if src.FailureSnapshots != nil {
+		dst.FailureSnapshots = make([]FailureSnapshot, len(src.FailureSnapshots))
+		for i := range src.FailureSnapshots {
+			dst.FailureSnapshots[i] = FailureSnapshot(src.FailureSnapshots[i])
+		}
+	}
+
+	if src.IOLimits != nil {
+		dst.IOLimits = make(map[string]IOLimits, len(src.IOLimits))
+		for podName, ioLimits := range src.IOLimits {
+			dst.IOLimits[podName] = IOLimits(*ioLimits.DeepCopy())
+		}
+	}
+
+	if src.TestLists != nil {
+		dst.TestLists = make([]TestList, len(src.TestLists))
+		for i := range src.TestLists {
+			dst.TestLists[i] = TestList(src.TestLists[i])
+		}
+	}
+
+	if src.RallySLAResults != nil {
+		dst.RallySLAResults = make([]RallySLAResult, len(src.RallySLAResults))
+		for i := range src.RallySLAResults {
+			dst.RallySLAResults[i] = RallySLAResult(*src.RallySLAResults[i].DeepCopy())
+		}
+	}
+
+	if src.FioResults != nil {
+		dst.FioResults = make([]FioResult, len(src.FioResults))
+		for i := range src.FioResults {
+			profiles := make([]FioProfileResult, len(src.FioResults[i].Profiles))
+			for j := range src.FioResults[i].Profiles {
+				profiles[j] = FioProfileResult(*src.FioResults[i].Profiles[j].DeepCopy())
+			}
+
+			dst.FioResults[i] = FioResult{
+				WorkflowStep: src.FioResults[i].WorkflowStep,
+				StepName:     src.FioResults[i].StepName,
+				PodName:      src.FioResults[i].PodName,
+				Profiles:     profiles,
+			}
+		}
+	}
+
+	if src.K6ThresholdResults != nil {
+		dst.K6ThresholdResults = make([]K6ThresholdResult, len(src.K6ThresholdResults))
+		for i := range src.K6ThresholdResults {
+			dst.K6ThresholdResults[i] = K6ThresholdResult(*src.K6ThresholdResults[i].DeepCopy())
+		}
+	}
+
+	if src.RobotResults != nil {
+		dst.RobotResults = make([]RobotResult, len(src.RobotResults))
+		for i := range src.RobotResults {
+			dst.RobotResults[i] = RobotResult(*src.RobotResults[i].DeepCopy())
+		}
+	}
+
+	if src.CustomTestResults != nil {
+		dst.CustomTestResults = make([]CustomTestResult, len(src.CustomTestResults))
+		for i := range src.CustomTestResults {
+			dst.CustomTestResults[i] = CustomTestResult(*src.CustomTestResults[i].DeepCopy())
+		}
+	}
+
+	if src.PrunedLogs != nil {
+		dst.PrunedLogs = make([]PrunedLogs, len(src.PrunedLogs))
+		for i := range src.PrunedLogs {
+			dst.PrunedLogs[i] = PrunedLogs(*src.PrunedLogs[i].DeepCopy())
+		}
+	}
+
+	if src.ReportPortalLaunches != nil {
+		dst.ReportPortalLaunches = make([]ReportPortalLaunch, len(src.ReportPortalLaunches))
+		for i := range src.ReportPortalLaunches {
+			dst.ReportPortalLaunches[i] = ReportPortalLaunch(src.ReportPortalLaunches[i])
+		}
+	}
+
+	if src.StestrComparisons != nil {
+		dst.StestrComparisons = make([]StestrComparison, len(src.StestrComparisons))
+		for i := range src.StestrComparisons {
+			dst.StestrComparisons[i] = convertStestrComparisonFrom(src.StestrComparisons[i])
+		}
+	}
+
+	if src.Timeline != nil {
+		dst.Timeline = make([]TimelineEntry, len(src.Timeline))
+		for i := range src.Timeline {
+			dst.Timeline[i] = TimelineEntry{
+				Type:         TimelineEntryType(src.Timeline[i].Type),
+				Name:         src.Timeline[i].Name,
+				WorkflowStep: src.Timeline[i].WorkflowStep,
+				StartTime:    src.Timeline[i].StartTime,
+				FinishTime:   src.Timeline[i].FinishTime,
+			}
+		}
+	}
+
+	return dst
+}
+
+// convertStestrComparisonTo converts the v1 StestrComparison to the hub version
+func convertStestrComparisonTo(src StestrComparison) v1beta1.StestrComparison {
+	dst := v1beta1.StestrComparison{
+		WorkflowStep:  src.WorkflowStep,
+		StepName:      src.StepName,
+		PodName:       src.PodName,
+		RunID:         src.RunID,
+		PreviousRunID: src.PreviousRunID,
+		NewFailures:   src.NewFailures,
+		FixedTests:    src.FixedTests,
+	}
+
+	if src.TimingRegressions != nil {
+		dst.TimingRegressions = make([]v1beta1.StestrTimingRegression, len(src.TimingRegressions))
+		for i := range src.TimingRegressions {
+			dst.TimingRegressions[i] = v1beta1.StestrTimingRegression(src.TimingRegressions[i])
+		}
+	}
+
+	return dst
+}
+
+// convertStestrComparisonFrom converts the hub version of StestrComparison to v1
+func convertStestrComparisonFrom(src v1beta1.StestrComparison) StestrComparison {
+	dst := StestrComparison{
+		WorkflowStep:  src.WorkflowStep,
+		StepName:      src.StepName,
+		PodName:       src.PodName,
+		RunID:         src.RunID,
+		PreviousRunID: src.PreviousRunID,
+		NewFailures:   src.NewFailures,
+		FixedTests:    src.FixedTests,
+	}
+
+	if src.TimingRegressions != nil {
+		dst.TimingRegressions = make([]StestrTimingRegression, len(src.TimingRegressions))
+		for i := range src.TimingRegressions {
+			dst.TimingRegressions[i] = StestrTimingRegression(src.TimingRegressions[i])
+		}
+	}
+
+	return dst
+}
+
+// convertWorkflowCommonParametersTo converts the v1 WorkflowCommonParameters
+// to the hub version
+func convertWorkflowCommonParametersTo(src *WorkflowCommonParameters, dst *v1beta1.WorkflowCommonParameters) {
+	dst.Privileged = src.Privileged
+	dst.StorageClass = src.StorageClass
+	dst.SELinuxLevel = src.SELinuxLevel
+	dst.ContainerImage = src.ContainerImage
+	dst.BackoffLimit = src.BackoffLimit
+	dst.NodeSelector = mapToPtr(src.NodeSelector)
+	dst.Tolerations = sliceToPtr(src.Tolerations)
+	dst.Affinity = src.Affinity
+	dst.IOLimits = (*v1beta1.IOLimits)(src.IOLimits.DeepCopy())
+	dst.NetworkSelections = sliceToPtr(src.NetworkSelections)
+	dst.SecurityContextConstraint = src.SecurityContextConstraint
+	dst.TopologySpreadConstraints = sliceToPtr(src.TopologySpreadConstraints)
+	dst.EnvFromSecrets = sliceToPtr(src.EnvFromSecrets)
+	dst.EnvFromConfigMaps = sliceToPtr(src.EnvFromConfigMaps)
+
+	if src.ExtraConfigmapsMounts != nil {
+		extraConfigmapsMounts := convertExtraConfigmapsMountsTo(src.ExtraConfigmapsMounts)
+		dst.ExtraConfigmapsMounts = &extraConfigmapsMounts
+	}
+}
+
+// convertWorkflowCommonParametersFrom converts the hub version of
+// WorkflowCommonParameters to v1
+func convertWorkflowCommonParametersFrom(src *v1beta1.WorkflowCommonParameters, dst *WorkflowCommonParameters) {
+	dst.Privileged = src.Privileged
+	dst.StorageClass = src.StorageClass
+	dst.SELinuxLevel = src.SELinuxLevel
+	dst.ContainerImage = src.ContainerImage
+	dst.BackoffLimit = src.BackoffLimit
+	dst.NodeSelector = ptrToMap(src.NodeSelector)
+	dst.Tolerations = ptrToSlice(src.Tolerations)
+	dst.Affinity = src.Affinity
+	dst.IOLimits = (*IOLimits)(src.IOLimits.DeepCopy())
+	dst.NetworkSelections = ptrToSlice(src.NetworkSelections)
+	dst.SecurityContextConstraint = src.SecurityContextConstraint
+	dst.TopologySpreadConstraints = ptrToSlice(src.TopologySpreadConstraints)
+	dst.EnvFromSecrets = ptrToSlice(src.EnvFromSecrets)
+	dst.EnvFromConfigMaps = ptrToSlice(src.EnvFromConfigMaps)
+
+	if src.ExtraConfigmapsMounts != nil {
+		dst.ExtraConfigmapsMounts = convertExtraConfigmapsMountsFrom(*src.ExtraConfigmapsMounts)
+	}
+}
+
+func convertExtraConfigmapsMountsTo(src []ExtraConfigmapsMounts) []v1beta1.ExtraConfigmapsMounts {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.ExtraConfigmapsMounts, len(src))
+	for i := range src {
+		dst[i] = v1beta1.ExtraConfigmapsMounts(src[i])
+	}
+
+	return dst
+}
+
+func convertExtraConfigmapsMountsFrom(src []v1beta1.ExtraConfigmapsMounts) []ExtraConfigmapsMounts {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]ExtraConfigmapsMounts, len(src))
+	for i := range src {
+		dst[i] = ExtraConfigmapsMounts(src[i])
+	}
+
+	return dst
+}
+
+func convertExtraMountsTo(src []ExtraMount) []v1beta1.ExtraMount {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.ExtraMount, len(src))
+	for i := range src {
+		dst[i] = v1beta1.ExtraMount(src[i])
+	}
+
+	return dst
+}
+
+func convertExtraMountsFrom(src []v1beta1.ExtraMount) []ExtraMount {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]ExtraMount, len(src))
+	for i := range src {
+		dst[i] = ExtraMount(src[i])
+	}
+
+	return dst
+}
+
+func convertCleanupAssertionsTo(src []CleanupAssertion) []v1beta1.CleanupAssertion {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.CleanupAssertion, len(src))
+	for i := range src {
+		dst[i] = v1beta1.CleanupAssertion(src[i])
+	}
+
+	return dst
+}
+
+func convertCleanupAssertionsFrom(src []v1beta1.CleanupAssertion) []CleanupAssertion {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]CleanupAssertion, len(src))
+	for i := range src {
+		dst[i] = CleanupAssertion(src[i])
+	}
+
+	return dst
+}
+
+func convertCABundleSourcesTo(src []CABundleSource) []v1beta1.CABundleSource {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.CABundleSource, len(src))
+	for i := range src {
+		dst[i] = v1beta1.CABundleSource(*src[i].DeepCopy())
+	}
+
+	return dst
+}
+
+func convertCABundleSourcesFrom(src []v1beta1.CABundleSource) []CABundleSource {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]CABundleSource, len(src))
+	for i := range src {
+		dst[i] = CABundleSource(*src[i].DeepCopy())
+	}
+
+	return dst
+}
+
+func convertLogForwardingTo(src *LogForwarding) *v1beta1.LogForwarding {
+	if src == nil {
+		return nil
+	}
+
+	dst := src.DeepCopy()
+	return &v1beta1.LogForwarding{
+		Output:                v1beta1.LogForwardingOutput(dst.Output),
+		URL:                   dst.URL,
+		CredentialsSecretName: dst.CredentialsSecretName,
+		TLSInsecureSkipVerify: dst.TLSInsecureSkipVerify,
+		Index:                 dst.Index,
+		Labels:                dst.Labels,
+		Image:                 dst.Image,
+	}
+}
+
+func convertLogForwardingFrom(src *v1beta1.LogForwarding) *LogForwarding {
+	if src == nil {
+		return nil
+	}
+
+	dst := src.DeepCopy()
+	return &LogForwarding{
+		Output:                LogForwardingOutput(dst.Output),
+		URL:                   dst.URL,
+		CredentialsSecretName: dst.CredentialsSecretName,
+		TLSInsecureSkipVerify: dst.TLSInsecureSkipVerify,
+		Index:                 dst.Index,
+		Labels:                dst.Labels,
+		Image:                 dst.Image,
+	}
+}
+
+func convertIPFamiliesTo(src map[string]IPFamily) map[string]v1beta1.IPFamily {
+	if src == nil {
+		return nil
+	}
+
+	dst := make(map[string]v1beta1.IPFamily, len(src))
+	for name, ipFamily := range src {
+		dst[name] = v1beta1.IPFamily(ipFamily)
+	}
+
+	return dst
+}
+
+func convertIPFamiliesFrom(src map[string]v1beta1.IPFamily) map[string]IPFamily {
+	if src == nil {
+		return nil
+	}
+
+	dst := make(map[string]IPFamily, len(src))
+	for name, ipFamily := range src {
+		dst[name] = IPFamily(ipFamily)
+	}
+
+	return dst
+}
+
+// sliceToPtr returns a pointer to the slice or nil when the slice is not set
+func sliceToPtr[T any](s []T) *[]T {
+	if s == nil {
+		return nil
+	}
+
+	return &s
+}
+
+// ptrToSlice returns the slice the pointer points to or nil
+func ptrToSlice[T any](p *[]T) []T {
+	if p == nil {
+		return nil
+	}
+
+	return *p
+}
+
+// mapToPtr returns a pointer to the map or nil when the map is not set
+func mapToPtr[K comparable, V any](m map[K]V) *map[K]V {
+	if m == nil {
+		return nil
+	}
+
+	return &m
+}
+
+// ptrToMap returns the map the pointer points to or nil
+func ptrToMap[K comparable, V any](p *map[K]V) map[K]V {
+	if p == nil {
+		return nil
+	}
+
+	return *p
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &HorizonTest{}
+
+// ConvertTo converts this HorizonTest to the Hub version (v1beta1)
+func (src *HorizonTest) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.HorizonTest)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = convertCommonTestStatusTo(src.Status)
+
+	convertCommonOptionsTo(&src.Spec.CommonOptions, &dst.Spec.CommonOptions)
+	dst.Spec.Resources = src.Spec.Resources
+	dst.Spec.Debug = src.Spec.Debug
+	dst.Spec.ExtraFlag = src.Spec.ExtraFlag
+	dst.Spec.ProjectNameXpath = src.Spec.ProjectNameXPath
+	dst.Spec.AdminUsername = src.Spec.AdminUsername
+	dst.Spec.AdminPassword = src.Spec.AdminPassword
+	dst.Spec.DashboardUrl = src.Spec.DashboardURL
+	dst.Spec.AuthUrl = src.Spec.AuthURL
+	dst.Spec.RepoUrl = src.Spec.RepoURL
+	dst.Spec.HorizonRepoBranch = src.Spec.HorizonRepoBranch
+	dst.Spec.ImageUrl = src.Spec.ImageURL
+	dst.Spec.ProjectName = src.Spec.ProjectName
+	dst.Spec.User = src.Spec.User
+	dst.Spec.Password = src.Spec.Password
+	dst.Spec.FlavorName = src.Spec.FlavorName
+	dst.Spec.LogsDirectoryName = src.Spec.LogsDirectoryName
+	dst.Spec.HorizonTestDir = src.Spec.HorizonTestDir
+	dst.Spec.Parallel = src.Spec.Parallel
+	dst.Spec.VideoRecording = src.Spec.VideoRecording
+	dst.Spec.FailureScreenshots = src.Spec.FailureScreenshots
+	dst.Spec.Browsers = convertHorizonTestBrowsersTo(src.Spec.Browsers)
+	dst.Spec.ParallelBrowsers = src.Spec.ParallelBrowsers
+	dst.Spec.SeleniumGridURL = src.Spec.SeleniumGridURL
+	dst.Spec.SeleniumGridCredentialsSecretName = src.Spec.SeleniumGridCredentialsSecretName
+	dst.Spec.KubeconfigSecretName = src.Spec.KubeconfigSecretName
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *HorizonTest) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.HorizonTest)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = convertCommonTestStatusFrom(src.Status)
+
+	convertCommonOptionsFrom(&src.Spec.CommonOptions, &dst.Spec.CommonOptions)
+	dst.Spec.Resources = src.Spec.Resources
+	dst.Spec.Debug = src.Spec.Debug
+	dst.Spec.ExtraFlag = src.Spec.ExtraFlag
+	dst.Spec.ProjectNameXPath = src.Spec.ProjectNameXpath
+	dst.Spec.AdminUsername = src.Spec.AdminUsername
+	dst.Spec.AdminPassword = src.Spec.AdminPassword
+	dst.Spec.DashboardURL = src.Spec.DashboardUrl
+	dst.Spec.AuthURL = src.Spec.AuthUrl
+	dst.Spec.RepoURL = src.Spec.RepoUrl
+	dst.Spec.HorizonRepoBranch = src.Spec.HorizonRepoBranch
+	dst.Spec.ImageURL = src.Spec.ImageUrl
+	dst.Spec.ProjectName = src.Spec.ProjectName
+	dst.Spec.User = src.Spec.User
+	dst.Spec.Password = src.Spec.Password
+	dst.Spec.FlavorName = src.Spec.FlavorName
+	dst.Spec.LogsDirectoryName = src.Spec.LogsDirectoryName
+	dst.Spec.HorizonTestDir = src.Spec.HorizonTestDir
+	dst.Spec.Parallel = src.Spec.Parallel
+	dst.Spec.VideoRecording = src.Spec.VideoRecording
+	dst.Spec.FailureScreenshots = src.Spec.FailureScreenshots
+	dst.Spec.Browsers = convertHorizonTestBrowsersFrom(src.Spec.Browsers)
+	dst.Spec.ParallelBrowsers = src.Spec.ParallelBrowsers
+	dst.Spec.SeleniumGridURL = src.Spec.SeleniumGridURL
+	dst.Spec.SeleniumGridCredentialsSecretName = src.Spec.SeleniumGridCredentialsSecretName
+	dst.Spec.KubeconfigSecretName = src.Spec.KubeconfigSecretName
+
+	return nil
+}
+
+func convertHorizonTestBrowsersTo(src []HorizonTestBrowser) []v1beta1.HorizonTestBrowser {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.HorizonTestBrowser, len(src))
+	for i := range src {
+		dst[i] = v1beta1.HorizonTestBrowser(src[i])
+	}
+
+	return dst
+}
+
+func convertHorizonTestBrowsersFrom(src []v1beta1.HorizonTestBrowser) []HorizonTestBrowser {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]HorizonTestBrowser, len(src))
+	for i := range src {
+		dst[i] = HorizonTestBrowser(src[i])
+	}
+
+	return dst
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHorizonTestConversionRoundTrip(t *testing.T) {
+	src := &HorizonTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "horizontest",
+			Namespace: "openstack",
+		},
+		Spec: HorizonTestSpec{
+			CommonOptions: CommonOptions{
+				ContainerImage: "quay.io/podified/horizontest:latest",
+				StorageClass:   "local-storage",
+			},
+			Debug:                             true,
+			ExtraFlag:                         "not pagination",
+			ProjectNameXPath:                  "//span[@class='rcueicon rcueicon-folder-open']/ancestor::li",
+			AdminUsername:                     "admin",
+			AdminPassword:                     "password",
+			DashboardURL:                      "https://horizon-openstack.apps.ocp.openstack.lab/",
+			AuthURL:                           "https://keystone-public-openstack.apps.ocp.openstack.lab",
+			RepoURL:                           "https://review.opendev.org/openstack/horizon",
+			HorizonRepoBranch:                 "master",
+			ImageURL:                          "http://download.cirros-cloud.net/0.6.2/cirros-0.6.2-x86_64-disk.img",
+			ProjectName:                       "horizontest",
+			User:                              "horizontest",
+			Password:                          "horizontest",
+			FlavorName:                        "m1.tiny",
+			LogsDirectoryName:                 "horizon",
+			HorizonTestDir:                    "/var/lib/horizontest",
+			VideoRecording:                    true,
+			FailureScreenshots:                true,
+			Browsers:                          []HorizonTestBrowser{HorizonTestBrowserChrome, HorizonTestBrowserFirefox},
+			ParallelBrowsers:                  true,
+			SeleniumGridURL:                   "http://selenium-hub:4444/wd/hub",
+			SeleniumGridCredentialsSecretName: "selenium-grid",
+			KubeconfigSecretName:              "kubeconfig",
+		},
+	}
+
+	hub := &v1beta1.HorizonTest{}
+	if err := src.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() failed: %v", err)
+	}
+
+	dst := &HorizonTest{}
+	if err := dst.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() failed: %v", err)
+	}
+
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("HorizonTest changed after the conversion round trip (-want +got):\n%s", diff)
+	}
+}
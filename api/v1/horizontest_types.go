@@ -0,0 +1,232 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HorizonTestBrowser - browser used to execute the Horizon tests
+type HorizonTestBrowser string
+
+const (
+	// HorizonTestBrowserChrome - the tests are executed in Chrome
+	HorizonTestBrowserChrome HorizonTestBrowser = "chrome"
+
+	// HorizonTestBrowserFirefox - the tests are executed in Firefox
+	HorizonTestBrowserFirefox HorizonTestBrowser = "firefox"
+)
+
+// HorizonTestSpec defines the desired state of HorizonTest
+type HorizonTestSpec struct {
+	CommonOptions `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "2000m", memory: "4Gi"}, requests: {cpu: "1000m", memory: "2Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the HorizonTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Activate debug mode. When debug mode is activated any error encountered
+	// inside the test-pod causes that the pod will be kept alive indefinitely
+	// (stuck in "Running" phase) or until the corresponding HorizonTest CR is deleted.
+	// This allows the user to debug any potential troubles with `oc rsh`.
+	Debug bool `json:"debug"`
+
+	// ExtraFlag is an extra flag that can be set to modify pytest command to
+	// exclude or include particular test(s)
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ExtraFlag string `json:"extraFlag"`
+
+	// ProjectNameXPath is the xpath to select project name
+	// on the horizon dashboard based on the u/s or d/s theme
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ProjectNameXPath string `json:"projectNameXPath"`
+
+	// AdminUsername is the username for the OpenStack admin user.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="admin"
+	AdminUsername string `json:"adminUsername"`
+
+	// AdminPassword is the password for the OpenStack admin user.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="admin"
+	AdminPassword string `json:"adminPassword"`
+
+	// DashboardURL is the URL of the Horizon dashboard.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DashboardURL string `json:"dashboardURL"`
+
+	// AuthURL is the authentication URL for OpenStack.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AuthURL string `json:"authURL"`
+
+	// RepoURL is the URL of the Horizon repository.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="https://review.opendev.org/openstack/horizon"
+	RepoURL string `json:"repoURL"`
+
+	// HorizonRepoBranch is the branch of the Horizon repository to checkout.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="master"
+	HorizonRepoBranch string `json:"horizonRepoBranch"`
+
+	// ImageURL is the URL to download the Cirros image.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="http://download.cirros-cloud.net/0.6.2/cirros-0.6.2-x86_64-disk.img"
+	ImageURL string `json:"imageURL"`
+
+	// ProjectName is the name of the OpenStack project for Horizon tests.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="horizontest"
+	ProjectName string `json:"projectName"`
+
+	// User is the username under which the Horizon tests will run.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="horizontest"
+	User string `json:"user"`
+
+	// Password is the password for the user running the Horizon tests.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="horizontest"
+	Password string `json:"password"`
+
+	// FlavorName is the name of the OpenStack flavor to create for Horizon tests.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="m1.tiny"
+	FlavorName string `json:"flavorName"`
+
+	// LogsDirectoryName is the name of the directory to store test logs.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="horizon"
+	LogsDirectoryName string `json:"logsDirectoryName"`
+
+	// HorizonTestDir is the directory path for Horizon tests.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="/var/lib/horizontest"
+	HorizonTestDir string `json:"horizonTestDir"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Parallel
+	Parallel bool `json:"parallel"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// VideoRecording - when set to true, the browser sessions of the tests
+	// are recorded. The recordings are stored in the videos directory of the
+	// logs directory of the test pod so that failed UI tests can be reviewed
+	// without executing them once more.
+	VideoRecording bool `json:"videoRecording,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// FailureScreenshots - when set to true, a screenshot and the page source
+	// are captured when a test fails. The files are stored in the screenshots
+	// directory of the logs directory of the test pod and listed in
+	// status.steps[].failureCaptures.
+	FailureScreenshots bool `json:"failureScreenshots,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MaxItems:=2
+	// +kubebuilder:validation:items:Enum:=chrome;firefox
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y == x))",message="browsers must be unique"
+	// Browsers - the tests are executed once for each browser by a separate
+	// test pod. The result of each browser is reported in status.steps under
+	// the name of the browser. When empty, a single test pod uses the
+	// default browser of the container image.
+	Browsers []HorizonTestBrowser `json:"browsers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// ParallelBrowsers - when set to true, the test pods of all the browsers
+	// run at the same time, each one with its own logs PVC. Otherwise the
+	// browsers are tested one after another.
+	ParallelBrowsers bool `json:"parallelBrowsers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern:=`^https?://[^\s]+$`
+	// SeleniumGridURL - URL of an existing Selenium Grid (or Moon)
+	// deployment, e.g. http://selenium-hub:4444/wd/hub. When set, the tests
+	// drive the browsers of the grid instead of a browser started in the test
+	// pod.
+	SeleniumGridURL string `json:"seleniumGridUrl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// SeleniumGridCredentialsSecretName - name of the k8s secret with the
+	// credentials (username and password keys) used to access the Selenium
+	// Grid
+	SeleniumGridCredentialsSecretName string `json:"seleniumGridCredentialsSecretName,omitempty"`
+
+	// Name of a secret that contains a kubeconfig. The kubeconfig is mounted under /var/lib/horizontest/.kube/config
+	// in the test pod.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// HorizonTest is the Schema for the horizontests API
+type HorizonTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HorizonTestSpec  `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HorizonTestList contains a list of HorizonTest
+type HorizonTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HorizonTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HorizonTest{}, &HorizonTestList{})
+}
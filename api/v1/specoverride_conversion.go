@@ -0,0 +1,177 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// overrideField describes a field of a spec override whose name differs
+// between v1 and the hub version. Nested contains the fields of an object (or
+// of the objects in a list) that need to be renamed as well.
+type overrideField struct {
+	Hub    string
+	Nested map[string]overrideField
+}
+
+// overrideValueConverter converts the values of a spec override that have a
+// different type in v1 and in the hub version. It is called with the fields
+// of the override already renamed.
+type overrideValueConverter func(override map[string]interface{})
+
+var commonOverrideFields = map[string]overrideField{
+	"seLinuxLevel": {Hub: "SELinuxLevel"},
+}
+
+var tempestOverrideFields = mergeOverrideFields(commonOverrideFields, map[string]overrideField{
+	"sshKeySecretName": {Hub: "SSHKeySecretName"},
+	"tempestRun": {Hub: "tempestRun", Nested: map[string]overrideField{
+		"extraImages": {Hub: "extraImages", Nested: map[string]overrideField{
+			"url": {Hub: "URL"},
+			"id":  {Hub: "ID"},
+			"flavor": {Hub: "flavor", Nested: map[string]overrideField{
+				"ram": {Hub: "RAM"},
+				"id":  {Hub: "ID"},
+			}},
+		}},
+	}},
+})
+
+var tobikoOverrideFields = commonOverrideFields
+
+var ansibleTestOverrideFields = mergeOverrideFields(commonOverrideFields, map[string]overrideField{
+	"gitRepo":                 {Hub: "ansibleGitRepo"},
+	"gitAuthSecretName":       {Hub: "ansibleGitAuthSecretName"},
+	"vaultPasswordSecretName": {Hub: "ansibleVaultPasswordSecretName"},
+	"gitRef":                  {Hub: "ansibleGitRef"},
+	"gitCache":                {Hub: "ansibleGitCache"},
+	"playbookPath":            {Hub: "ansiblePlaybookPath"},
+	"playbooks":               {Hub: "ansiblePlaybooks"},
+	"playbookFailurePolicy":   {Hub: "ansiblePlaybookFailurePolicy"},
+	"collections":             {Hub: "ansibleCollections"},
+	"varFiles":                {Hub: "ansibleVarFiles"},
+	"varFilesFrom":            {Hub: "ansibleVarFilesFrom"},
+	"extraVars":               {Hub: "ansibleExtraVarsMap"},
+	"inventory":               {Hub: "ansibleInventory"},
+	"inventoryConfigMap":      {Hub: "ansibleInventoryConfigMap"},
+	"inventoryFromNodeSets":   {Hub: "ansibleInventoryFromNodeSets"},
+	"galaxyRequirements":      {Hub: "ansibleGalaxyRequirements"},
+	"ara":                     {Hub: "ansibleAra"},
+	"stdoutCallback":          {Hub: "ansibleStdoutCallback"},
+	"callbacks":               {Hub: "ansibleCallbacks"},
+	"tags":                    {Hub: "ansibleTags"},
+	"skipTags":                {Hub: "ansibleSkipTags"},
+	"limit":                   {Hub: "ansibleLimit"},
+	"extraArgs":               {Hub: "ansibleExtraArgs"},
+	"checkMode":               {Hub: "ansibleCheckMode"},
+	"diff":                    {Hub: "ansibleDiff"},
+	"verbosity":               {Hub: "ansibleVerbosity"},
+	"forks":                   {Hub: "ansibleForks"},
+})
+
+// The renames from the hub version to v1
+var (
+	tempestHubOverrideFields     = invertOverrideFields(tempestOverrideFields)
+	tobikoHubOverrideFields      = invertOverrideFields(tobikoOverrideFields)
+	ansibleTestHubOverrideFields = invertOverrideFields(ansibleTestOverrideFields)
+)
+
+func mergeOverrideFields(fields ...map[string]overrideField) map[string]overrideField {
+	merged := map[string]overrideField{}
+	for _, f := range fields {
+		for name, field := range f {
+			merged[name] = field
+		}
+	}
+
+	return merged
+}
+
+// invertOverrideFields returns the renames from the hub version to v1
+func invertOverrideFields(fields map[string]overrideField) map[string]overrideField {
+	inverted := map[string]overrideField{}
+	for name, field := range fields {
+		inverted[field.Hub] = overrideField{
+			Hub:    name,
+			Nested: invertOverrideFields(field.Nested),
+		}
+	}
+
+	return inverted
+}
+
+// renameOverrideFields renames the fields of a decoded spec override
+func renameOverrideFields(value interface{}, fields map[string]overrideField) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(typedValue))
+		for name, nestedValue := range typedValue {
+			field, ok := fields[name]
+			if !ok {
+				renamed[name] = nestedValue
+				continue
+			}
+
+			renamed[field.Hub] = renameOverrideFields(nestedValue, field.Nested)
+		}
+
+		return renamed
+
+	case []interface{}:
+		renamed := make([]interface{}, len(typedValue))
+		for i := range typedValue {
+			renamed[i] = renameOverrideFields(typedValue[i], fields)
+		}
+
+		return renamed
+
+	default:
+		return value
+	}
+}
+
+// convertSpecOverride converts a spec override between v1 and the hub
+// version. Overrides that are not a JSON object are copied as they are and
+// rejected later by the validation webhook.
+func convertSpecOverride(
+	src *runtime.RawExtension,
+	fields map[string]overrideField,
+	convertValues overrideValueConverter,
+) (*runtime.RawExtension, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	override := map[string]interface{}{}
+	if err := json.Unmarshal(src.Raw, &override); err != nil {
+		return src.DeepCopy(), nil
+	}
+
+	override = renameOverrideFields(override, fields).(map[string]interface{})
+	if convertValues != nil {
+		convertValues(override)
+	}
+
+	raw, err := json.Marshal(override)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runtime.RawExtension{Raw: raw}, nil
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &Tempest{}
+
+// ConvertTo converts this Tempest to the Hub version (v1beta1)
+func (src *Tempest) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Tempest)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = convertCommonTestStatusTo(src.Status)
+
+	convertCommonOptionsTo(&src.Spec.CommonOptions, &dst.Spec.CommonOptions)
+	dst.Spec.CommonOpenstackConfig = v1beta1.CommonOpenstackConfig(src.Spec.CommonOpenstackConfig)
+	dst.Spec.Resources = src.Spec.Resources
+	dst.Spec.Parallel = src.Spec.Parallel
+	dst.Spec.Debug = src.Spec.Debug
+	dst.Spec.Cleanup = src.Spec.Cleanup
+	dst.Spec.NetworkAttachments = src.Spec.NetworkAttachments
+	dst.Spec.TempestRun = convertTempestRunSpecTo(src.Spec.TempestRun)
+	dst.Spec.TempestconfRun = v1beta1.TempestconfRunSpec(src.Spec.TempestconfRun)
+	dst.Spec.SSHKeySecretName = src.Spec.SSHKeySecretName
+	dst.Spec.TempestconfSecretName = src.Spec.TempestconfSecretName
+	dst.Spec.AccountsSecretName = src.Spec.AccountsSecretName
+	dst.Spec.ListTestsOnly = src.Spec.ListTestsOnly
+	dst.Spec.Shards = src.Spec.Shards
+	dst.Spec.Plugins = convertTempestPluginsTo(src.Spec.Plugins)
+	dst.Spec.Whitebox = convertWhiteboxTo(src.Spec.Whitebox)
+	dst.Spec.ConfigOverwrite = src.Spec.ConfigOverwrite
+	dst.Spec.StestrHistory = (*v1beta1.StestrHistorySpec)(src.Spec.StestrHistory)
+
+	dst.Spec.Workflow = nil
+	if src.Spec.Workflow != nil {
+		dst.Spec.Workflow = make([]v1beta1.WorkflowTempestSpec, len(src.Spec.Workflow))
+	}
+
+	for i := range src.Spec.Workflow {
+		srcStep := &src.Spec.Workflow[i]
+		dstStep := &dst.Spec.Workflow[i]
+
+		convertWorkflowCommonParametersTo(&srcStep.WorkflowCommonParameters, &dstStep.WorkflowCommonParameters)
+
+		specOverride, err := convertSpecOverride(srcStep.SpecOverride, tempestOverrideFields, nil)
+		if err != nil {
+			return err
+		}
+		dstStep.SpecOverride = specOverride
+
+		dstStep.CommonOpenstackConfig = v1beta1.CommonOpenstackConfig(srcStep.CommonOpenstackConfig)
+		dstStep.Resources = srcStep.Resources
+		dstStep.StepName = srcStep.StepName
+		dstStep.Parallel = srcStep.Parallel
+		dstStep.Cleanup = srcStep.Cleanup
+		dstStep.NetworkAttachments = sliceToPtr(srcStep.NetworkAttachments)
+		dstStep.TempestRun = convertWorkflowTempestRunSpecTo(srcStep.TempestRun)
+		dstStep.TempestconfRun = v1beta1.WorkflowTempestconfRunSpec(srcStep.TempestconfRun)
+		dstStep.SSHKeySecretName = srcStep.SSHKeySecretName
+		dstStep.ConfigOverwrite = mapToPtr(srcStep.ConfigOverwrite)
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *Tempest) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Tempest)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = convertCommonTestStatusFrom(src.Status)
+
+	convertCommonOptionsFrom(&src.Spec.CommonOptions, &dst.Spec.CommonOptions)
+	dst.Spec.CommonOpenstackConfig = CommonOpenstackConfig(src.Spec.CommonOpenstackConfig)
+	dst.Spec.Resources = src.Spec.Resources
+	dst.Spec.Parallel = src.Spec.Parallel
+	dst.Spec.Debug = src.Spec.Debug
+	dst.Spec.Cleanup = src.Spec.Cleanup
+	dst.Spec.NetworkAttachments = src.Spec.NetworkAttachments
+	dst.Spec.TempestRun = convertTempestRunSpecFrom(src.Spec.TempestRun)
+	dst.Spec.TempestconfRun = TempestconfRunSpec(src.Spec.TempestconfRun)
+	dst.Spec.SSHKeySecretName = src.Spec.SSHKeySecretName
+	dst.Spec.TempestconfSecretName = src.Spec.TempestconfSecretName
+	dst.Spec.AccountsSecretName = src.Spec.AccountsSecretName
+	dst.Spec.ListTestsOnly = src.Spec.ListTestsOnly
+	dst.Spec.Shards = src.Spec.Shards
+	dst.Spec.Plugins = convertTempestPluginsFrom(src.Spec.Plugins)
+	dst.Spec.Whitebox = convertWhiteboxFrom(src.Spec.Whitebox)
+	dst.Spec.ConfigOverwrite = src.Spec.ConfigOverwrite
+	dst.Spec.StestrHistory = (*StestrHistorySpec)(src.Spec.StestrHistory)
+
+	dst.Spec.Workflow = nil
+	if src.Spec.Workflow != nil {
+		dst.Spec.Workflow = make([]WorkflowTempestSpec, len(src.Spec.Workflow))
+	}
+
+	for i := range src.Spec.Workflow {
+		srcStep := &src.Spec.Workflow[i]
+		dstStep := &dst.Spec.Workflow[i]
+
+		convertWorkflowCommonParametersFrom(&srcStep.WorkflowCommonParameters, &dstStep.WorkflowCommonParameters)
+
+		specOverride, err := convertSpecOverride(srcStep.SpecOverride, tempestHubOverrideFields, nil)
+		if err != nil {
+			return err
+		}
+		dstStep.SpecOverride = specOverride
+
+		dstStep.CommonOpenstackConfig = CommonOpenstackConfig(srcStep.CommonOpenstackConfig)
+		dstStep.Resources = srcStep.Resources
+		dstStep.StepName = srcStep.StepName
+		dstStep.Parallel = srcStep.Parallel
+		dstStep.Cleanup = srcStep.Cleanup
+		dstStep.NetworkAttachments = ptrToSlice(srcStep.NetworkAttachments)
+		dstStep.TempestRun = convertWorkflowTempestRunSpecFrom(srcStep.TempestRun)
+		dstStep.TempestconfRun = WorkflowTempestconfRunSpec(srcStep.TempestconfRun)
+		dstStep.SSHKeySecretName = srcStep.SSHKeySecretName
+		dstStep.ConfigOverwrite = ptrToMap(srcStep.ConfigOverwrite)
+	}
+
+	return nil
+}
+
+func convertTempestRunSpecTo(src TempestRunSpec) v1beta1.TempestRunSpec {
+	return v1beta1.TempestRunSpec{
+		IncludeList:          src.IncludeList,
+		ExcludeList:          src.ExcludeList,
+		IncludeListConfigMap: src.IncludeListConfigMap.DeepCopy(),
+		ExcludeListConfigMap: src.ExcludeListConfigMap.DeepCopy(),
+		RerunFailed:          src.RerunFailed,
+		ExpectedFailuresList: src.ExpectedFailuresList,
+		Concurrency:          src.Concurrency,
+		Smoke:                src.Smoke,
+		Parallel:             src.Parallel,
+		Serial:               src.Serial,
+		WorkerFile:           src.WorkerFile,
+		ExternalPlugin:       convertExternalPluginsTo(src.ExternalPlugin),
+		ExtraRPMs:            src.ExtraRPMs,
+		ExtraImages:          convertExtraImagesTo(src.ExtraImages),
+	}
+}
+
+func convertTempestRunSpecFrom(src v1beta1.TempestRunSpec) TempestRunSpec {
+	return TempestRunSpec{
+		IncludeList:          src.IncludeList,
+		ExcludeList:          src.ExcludeList,
+		IncludeListConfigMap: src.IncludeListConfigMap.DeepCopy(),
+		ExcludeListConfigMap: src.ExcludeListConfigMap.DeepCopy(),
+		RerunFailed:          src.RerunFailed,
+		ExpectedFailuresList: src.ExpectedFailuresList,
+		Concurrency:          src.Concurrency,
+		Smoke:                src.Smoke,
+		Parallel:             src.Parallel,
+		Serial:               src.Serial,
+		WorkerFile:           src.WorkerFile,
+		ExternalPlugin:       convertExternalPluginsFrom(src.ExternalPlugin),
+		ExtraRPMs:            src.ExtraRPMs,
+		ExtraImages:          convertExtraImagesFrom(src.ExtraImages),
+	}
+}
+
+func convertWorkflowTempestRunSpecTo(src WorkflowTempestRunSpec) v1beta1.WorkflowTempestRunSpec {
+	dst := v1beta1.WorkflowTempestRunSpec{
+		IncludeList:          src.IncludeList,
+		ExcludeList:          src.ExcludeList,
+		IncludeListConfigMap: src.IncludeListConfigMap.DeepCopy(),
+		ExcludeListConfigMap: src.ExcludeListConfigMap.DeepCopy(),
+		RerunFailed:          src.RerunFailed,
+		ExpectedFailuresList: src.ExpectedFailuresList,
+		Concurrency:          src.Concurrency,
+		Smoke:                src.Smoke,
+		Parallel:             src.Parallel,
+		Serial:               src.Serial,
+		WorkerFile:           src.WorkerFile,
+		ExtraRPMs:            sliceToPtr(src.ExtraRPMs),
+	}
+
+	if src.ExternalPlugin != nil {
+		externalPlugin := convertExternalPluginsTo(src.ExternalPlugin)
+		dst.ExternalPlugin = &externalPlugin
+	}
+
+	if src.ExtraImages != nil {
+		extraImages := convertExtraImagesTo(src.ExtraImages)
+		dst.ExtraImages = &extraImages
+	}
+
+	return dst
+}
+
+func convertWorkflowTempestRunSpecFrom(src v1beta1.WorkflowTempestRunSpec) WorkflowTempestRunSpec {
+	dst := WorkflowTempestRunSpec{
+		IncludeList:          src.IncludeList,
+		ExcludeList:          src.ExcludeList,
+		IncludeListConfigMap: src.IncludeListConfigMap.DeepCopy(),
+		ExcludeListConfigMap: src.ExcludeListConfigMap.DeepCopy(),
+		RerunFailed:          src.RerunFailed,
+		ExpectedFailuresList: src.ExpectedFailuresList,
+		Concurrency:          src.Concurrency,
+		Smoke:                src.Smoke,
+		Parallel:             src.Parallel,
+		Serial:               src.Serial,
+		WorkerFile:           src.WorkerFile,
+		ExtraRPMs:            ptrToSlice(src.ExtraRPMs),
+	}
+
+	if src.ExternalPlugin != nil {
+		dst.ExternalPlugin = convertExternalPluginsFrom(*src.ExternalPlugin)
+	}
+
+	if src.ExtraImages != nil {
+		dst.ExtraImages = convertExtraImagesFrom(*src.ExtraImages)
+	}
+
+	return dst
+}
+
+func convertExternalPluginsTo(src []ExternalPluginType) []v1beta1.ExternalPluginType {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.ExternalPluginType, len(src))
+	for i := range src {
+		dst[i] = v1beta1.ExternalPluginType(src[i])
+	}
+
+	return dst
+}
+
+func convertExternalPluginsFrom(src []v1beta1.ExternalPluginType) []ExternalPluginType {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]ExternalPluginType, len(src))
+	for i := range src {
+		dst[i] = ExternalPluginType(src[i])
+	}
+
+	return dst
+}
+
+func convertTempestPluginsTo(src []TempestPlugin) []v1beta1.TempestPlugin {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.TempestPlugin, len(src))
+	for i := range src {
+		dst[i] = v1beta1.TempestPlugin(src[i])
+	}
+
+	return dst
+}
+
+func convertTempestPluginsFrom(src []v1beta1.TempestPlugin) []TempestPlugin {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]TempestPlugin, len(src))
+	for i := range src {
+		dst[i] = TempestPlugin(src[i])
+	}
+
+	return dst
+}
+
+func convertWhiteboxTo(src *WhiteboxSpec) *v1beta1.WhiteboxSpec {
+	if src == nil {
+		return nil
+	}
+
+	dst := &v1beta1.WhiteboxSpec{
+		SSHKeySecretName: src.SSHKeySecretName,
+		SSHUser:          src.SSHUser,
+		ContainerRuntime: v1beta1.WhiteboxContainerRuntime(src.ContainerRuntime),
+	}
+
+	if src.Nodes != nil {
+		dst.Nodes = make([]v1beta1.WhiteboxNode, len(src.Nodes))
+		for i := range src.Nodes {
+			dst.Nodes[i] = v1beta1.WhiteboxNode(src.Nodes[i])
+		}
+	}
+
+	return dst
+}
+
+func convertWhiteboxFrom(src *v1beta1.WhiteboxSpec) *WhiteboxSpec {
+	if src == nil {
+		return nil
+	}
+
+	dst := &WhiteboxSpec{
+		SSHKeySecretName: src.SSHKeySecretName,
+		SSHUser:          src.SSHUser,
+		ContainerRuntime: WhiteboxContainerRuntime(src.ContainerRuntime),
+	}
+
+	if src.Nodes != nil {
+		dst.Nodes = make([]WhiteboxNode, len(src.Nodes))
+		for i := range src.Nodes {
+			dst.Nodes[i] = WhiteboxNode(src.Nodes[i])
+		}
+	}
+
+	return dst
+}
+
+func convertExtraImagesTo(src []ExtraImagesType) []v1beta1.ExtraImagesType {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]v1beta1.ExtraImagesType, len(src))
+	for i := range src {
+		dst[i] = v1beta1.ExtraImagesType{
+			URL:                  src[i].URL,
+			Name:                 src[i].Name,
+			OsCloud:              src[i].OsCloud,
+			ContainerFormat:      src[i].ContainerFormat,
+			DiskFormat:           src[i].DiskFormat,
+			ID:                   src[i].ID,
+			ImageCreationTimeout: src[i].ImageCreationTimeout,
+			Flavor:               v1beta1.ExtraImagesFlavorType(src[i].Flavor),
+		}
+	}
+
+	return dst
+}
+
+func convertExtraImagesFrom(src []v1beta1.ExtraImagesType) []ExtraImagesType {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]ExtraImagesType, len(src))
+	for i := range src {
+		dst[i] = ExtraImagesType{
+			URL:                  src[i].URL,
+			Name:                 src[i].Name,
+			OsCloud:              src[i].OsCloud,
+			ContainerFormat:      src[i].ContainerFormat,
+			DiskFormat:           src[i].DiskFormat,
+			ID:                   src[i].ID,
+			ImageCreationTimeout: src[i].ImageCreationTimeout,
+			Flavor:               ExtraImagesFlavorType(src[i].Flavor),
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTempestConversionRoundTrip(t *testing.T) {
+	trueVar := true
+	falseVar := false
+	concurrency := int64(4)
+	includeList := "tempest.api.compute"
+	sshKeySecretName := "step-ssh-key"
+
+	src := &Tempest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tempest",
+			Namespace: "openstack",
+		},
+		Spec: TempestSpec{
+			CommonOptions: CommonOptions{
+				ContainerImage:   "quay.io/podified/tempest:latest",
+				StorageClass:     "local-storage",
+				Privileged:       true,
+				ImagePullSecrets: []string{"pull-secret"},
+				NodeSelector:     map[string]string{"kubernetes.io/os": "linux"},
+				Tolerations: []corev1.Toleration{
+					{Key: "test", Operator: corev1.TolerationOpExists},
+				},
+			},
+			CommonOpenstackConfig: CommonOpenstackConfig{
+				OpenStackConfigMap:    "openstack-config",
+				OpenStackConfigSecret: "openstack-config-secret",
+			},
+			Parallel:         true,
+			Debug:            true,
+			Cleanup:          true,
+			SSHKeySecretName: "ssh-key",
+			TempestRun: TempestRunSpec{
+				IncludeList: "tempest.api.identity",
+				Concurrency: 8,
+				ExternalPlugin: []ExternalPluginType{
+					{Repository: "https://opendev.org/openstack/barbican-tempest-plugin"},
+				},
+				ExtraImages: []ExtraImagesType{
+					{
+						URL:    "http://download.cirros-cloud.net/cirros.img",
+						Name:   "cirros",
+						Flavor: ExtraImagesFlavorType{Name: "tiny", RAM: 512, Disk: 1, Vcpus: 1},
+					},
+				},
+			},
+			TempestconfRun: TempestconfRunSpec{
+				Create:    true,
+				Overrides: "identity.v3_endpoint_type public",
+			},
+			Plugins: []TempestPlugin{
+				{Name: "neutron", GitURL: "https://opendev.org/openstack/neutron-tempest-plugin", Ref: "master"},
+			},
+			Whitebox: &WhiteboxSpec{
+				SSHKeySecretName: "whitebox-ssh-key",
+				Nodes:            []WhiteboxNode{{Name: "compute-0", Address: "192.168.122.100"}},
+				ContainerRuntime: WhiteboxContainerRuntimePodman,
+			},
+			ConfigOverwrite: map[string]string{"tempest.conf": "[compute]\nmin_compute_nodes = 2\n"},
+			Workflow: []WorkflowTempestSpec{
+				{StepName: "inherit"},
+				{
+					StepName:           "override",
+					Parallel:           &falseVar,
+					Cleanup:            &trueVar,
+					NetworkAttachments: []string{"ctlplane"},
+					SSHKeySecretName:   &sshKeySecretName,
+					ConfigOverwrite:    map[string]string{"tempest.conf": "[compute]\nmin_compute_nodes = 1\n"},
+					TempestRun: WorkflowTempestRunSpec{
+						IncludeList: &includeList,
+						Concurrency: &concurrency,
+						ExtraRPMs:   []string{"python3-requests"},
+						ExternalPlugin: []ExternalPluginType{
+							{Repository: "https://opendev.org/openstack/octavia-tempest-plugin"},
+						},
+					},
+					TempestconfRun: WorkflowTempestconfRunSpec{
+						Create: &falseVar,
+					},
+				},
+				{
+					WorkflowCommonParameters: WorkflowCommonParameters{
+						SpecOverride: &runtime.RawExtension{Raw: []byte(`{"debug":false}`)},
+					},
+					StepName: "spec-override",
+				},
+			},
+		},
+		Status: CommonTestStatus{
+			Phase: "Succeeded",
+			Steps: []TestStepStatus{
+				{StepName: "inherit", PodName: "tempest-s00-inherit", Phase: corev1.PodSucceeded},
+			},
+			NetworkAttachments: map[string][]string{"openstack/ctlplane": {"192.168.122.10"}},
+		},
+	}
+
+	hub := &v1beta1.Tempest{}
+	if err := src.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() failed: %v", err)
+	}
+
+	dst := &Tempest{}
+	if err := dst.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() failed: %v", err)
+	}
+
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("Tempest changed after the conversion round trip (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,663 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+This file contains an extension of the Tempest CR. Ultimataly it is a copy of
+tempest_types.go that removes all default values for each config options. This
+is necessary to be able to detect when the user explicitly set a value in the
+`workflow` setcion.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExtraImagesType - is used to specify extra images that should be downloaded
+// inside the test pod and uploaded to openstack
+type ExtraImagesType struct {
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// URL that points to a location where the image is located
+	URL string `json:"url"`
+
+	// +kubebuilder:validation:Required
+	// Name of the image
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="-"
+	// Cloud that should be used for authentication
+	OsCloud string `json:"osCloud"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="-"
+	// Image container format
+	ContainerFormat string `json:"containerFormat"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="-"
+	// Image disk format
+	DiskFormat string `json:"diskFormat"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="-"
+	// ID that should be assigned to the newly created image
+	ID string `json:"id"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=300
+	// Timeout duration for an image to reach the active state after its creation
+	ImageCreationTimeout int64 `json:"imageCreationTimeout"`
+
+	// +kubebuilder:validation:Optional
+	// Information about flavor that should be created together with the image
+	Flavor ExtraImagesFlavorType `json:"flavor"`
+}
+
+type ExtraImagesFlavorType struct {
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the flavor that should be created
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// How much RAM should be allocated when this flavor is used
+	RAM int64 `json:"ram"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// How much disk space should be allocated when this flavor is used
+	Disk int64 `json:"disk"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// How many vcpus should be be allocated when this flavor is used
+	Vcpus int64 `json:"vcpus"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="-"
+	// ID that should be assigned to the newly created flavor
+	ID string `json:"id"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="-"
+	// Cloud that should be used for authentication
+	OsCloud string `json:"osCloud"`
+}
+
+// ExternalPluginType - is used to specify a plugin that should be installed
+// from an external resource
+type ExternalPluginType struct {
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// URL that points to a git repository containing an external plugin.
+	Repository string `json:"repository"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// URL that points to a repository that contains a change that should be
+	// applied to the repository defined by Repository (ChangeRefspec must be
+	// defined as well).
+	ChangeRepository string `json:"changeRepository,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ChangeRefspec specifies which change the remote repository should be
+	// checked out to (ChangeRepository must be defined as well).
+	ChangeRefspec string `json:"changeRefspec,omitempty"`
+}
+
+// TempestRunSpec - is used to configure execution of tempest. Please refer to
+// Please refer to https://docs.openstack.org/tempest/latest/ for the further
+// explanation of the CLI parameters.
+type TempestRunSpec struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="tempest.api.identity.v3"
+	// A content of include.txt file that is passed to tempest via --include-list
+	IncludeList string `json:"includeList"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A key of a config map with the content of include.txt, e.g. a list
+	// shared by several Tempest CRs. When it is set includeList is ignored.
+	IncludeListConfigMap *corev1.ConfigMapKeySelector `json:"includeListConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A content of exclude.txt file that is passed to tempest via --exclude-list
+	ExcludeList string `json:"excludeList"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A key of a config map with the content of exclude.txt, e.g. a skip list
+	// shared by several Tempest CRs. When it is set excludeList is ignored.
+	ExcludeListConfigMap *corev1.ConfigMapKeySelector `json:"excludeListConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// The expectedFailuresList parameter contains tests that should not count
+	// as failures. When a test from this list fails, the test pod ends with
+	// Completed state rather than with Error state.
+	ExpectedFailuresList string `json:"expectedFailuresList"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=0
+	// Concurrency value that is passed to tempest via --concurrency
+	Concurrency int64 `json:"concurrency"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether tempest should be executed with --smoke
+	Smoke bool `json:"smoke"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=true
+	// Indicate whether tempest should be executed with --parallel
+	Parallel bool `json:"parallel"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether tempest should be executed with --serial. Serial
+	// takes precedence over parallel.
+	Serial bool `json:"serial"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// A content of worker_file.yaml that is passed to tempest via --worker-file
+	WorkerFile string `json:"workerFile"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ExternalPlugin contains information about plugin that should be installed
+	// within the tempest test pod. If this option is specified then only tests
+	// that are part of the external plugin can be executed.
+	ExternalPlugin []ExternalPluginType `json:"externalPlugin,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A list URLs that point to RPMs that should be downloaded and installed
+	// inside the tempest test pod.
+	ExtraRPMs []string `json:"extraRPMs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Extra images that should be downloaded inside the test pod and uploaded to
+	// openstack.
+	ExtraImages []ExtraImagesType `json:"extraImages,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether the failed tests should be executed once more when
+	// the tempest run fails. The failed tests are read from the stestr state
+	// in the logs PVC and executed by a second test pod. The result of the
+	// second test pod decides the result of the workflow step.
+	RerunFailed bool `json:"rerunFailed,omitempty"`
+}
+
+// TempestconfRunSpec - is used to configure execution of discover-tempest-config
+// Please refer to https://docs.opendev.org/openinfra/python-tempestconf for the
+// further explanation of the CLI parameters.
+type TempestconfRunSpec struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=true
+	// Indicate whether discover-tempest-config should be executed with --create
+	Create bool `json:"create"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether discover-tempest-config should be executed with
+	// --collect-timing
+	CollectTiming bool `json:"collectTiming"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether discover-tempest-config should be executed with --insecure
+	Insecure bool `json:"insecure"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether discover-tempest-config should be executed with
+	// --no-default-deployer
+	NoDefaultDeployer bool `json:"noDefaultDeployer"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether discover-tempest-config should be executed with --debug
+	Debug bool `json:"debug"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether discover-tempest-config should be executed with --verbose
+	Verbose bool `json:"verbose"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether discover-tempest-config should be executed with --non-admin
+	NonAdmin bool `json:"nonAdmin"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether discover-tempest-config should be executed with --retry-image
+	RetryImage bool `json:"retryImage"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether discover-tempest-config should be executed with
+	// --convert-to-raw
+	ConvertToRaw bool `json:"convertToRaw"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// The content of this variable will be passed to discover-tempest-config via
+	// the --out parameter
+	Out string `json:"out"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// A content of deployer_input.ini that is passed to tempest via --deployer-input
+	DeployerInput string `json:"deployerInput"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// A content of accounts.yaml that is passed to tempest via --test-acounts
+	TestAccounts string `json:"testAccounts"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// The content of this variable will be passed to discover-tempest-config via
+	// the --create-accounts-file
+	CreateAccountsFile string `json:"createAccountsFile"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// A content of profile.yaml that is passed to tempest via --profile
+	Profile string `json:"profile"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// The content of this variable will be passed to discover-tempest-config via
+	// --generate-profile
+	GenerateProfile string `json:"generateProfile"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// The content of this variable will be passed to discover-tempest-config via
+	// --image-disk-format
+	ImageDiskFormat string `json:"imageDiskFormat"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// The content of this variable will be passed to discover-tempest-config via
+	// --image
+	Image string `json:"image"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=0
+	// The content of this variable will be passed to discover-tempest-config via
+	// --flavor-min-mem
+	FlavorMinMem int64 `json:"flavorMinMem"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=0
+	// The content of this variable will be passed to discover-tempest-config via
+	// --flavor-min-disk
+	FlavorMinDisk int64 `json:"flavorMinDisk"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// The content of this variable will be passed to discover-tempest-config via
+	// --network-id
+	NetworkID string `json:"networkID"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// The content of this variable will be passed to discover-tempest-config via
+	// --append
+	Append string `json:"append"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// The content of this variable will be passed to discover-tempest-config via
+	// --remove
+	Remove string `json:"remove"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="identity.v3_endpoint_type public"
+	// The content of this variable will be appended at the end of the command
+	// that executes discover-tempest-config (override values).
+	Overrides string `json:"overrides"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=0
+	// The content of this variable will be passed to discover-tempest-config via
+	// --timeout
+	Timeout int64 `json:"timeout"`
+}
+
+// TempestPlugin - an out-of-tree tempest plugin that is installed into the
+// test pod before the tests are executed
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.gitUrl)",message="exactly one of name and gitUrl must be set"
+type TempestPlugin struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the python package of the plugin (e.g. whitebox-tempest-plugin)
+	// that is installed from the package index
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// URL of the git repository of the plugin
+	GitURL string `json:"gitUrl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Version of the package when name is set, or a branch, tag or commit of
+	// the git repository when gitUrl is set. The latest version or the
+	// default branch is installed when it is not set.
+	Ref string `json:"ref,omitempty"`
+}
+
+// WhiteboxContainerRuntime - container runtime that runs the OpenStack services
+// on the compute nodes
+type WhiteboxContainerRuntime string
+
+const (
+	// WhiteboxContainerRuntimePodman - the services run in podman containers
+	WhiteboxContainerRuntimePodman WhiteboxContainerRuntime = "podman"
+
+	// WhiteboxContainerRuntimeDocker - the services run in docker containers
+	WhiteboxContainerRuntimeDocker WhiteboxContainerRuntime = "docker"
+
+	// WhiteboxContainerRuntimeNone - the services are not containerized
+	WhiteboxContainerRuntimeNone WhiteboxContainerRuntime = "none"
+)
+
+// WhiteboxNode - a compute node that the whitebox tempest plugin accesses
+type WhiteboxNode struct {
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the hypervisor as reported by nova
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Address (IP or hostname) used to access the node over SSH
+	Address string `json:"address"`
+}
+
+// WhiteboxSpec - configuration of the access of the whitebox tempest plugin to
+// the compute nodes. It is used to generate the whitebox section of
+// tempest.conf.
+type WhiteboxSpec struct {
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the k8s secret that contains the private SSH key (under the
+	// ssh-privatekey key) used to access the compute nodes
+	SSHKeySecretName string `json:"sshKeySecretName"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="cloud-admin"
+	// User used to access the compute nodes over SSH
+	SSHUser string `json:"sshUser,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Compute nodes that are accessed by the whitebox tests
+	Nodes []WhiteboxNode `json:"nodes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum:=podman;docker;none
+	// +kubebuilder:default:=podman
+	// Container runtime that runs the OpenStack services on the compute
+	// nodes
+	ContainerRuntime WhiteboxContainerRuntime `json:"containerRuntime,omitempty"`
+}
+
+// StestrHistorySpec - configuration of the stestr history that is kept across
+// the executions of the Tempest CR
+type StestrHistorySpec struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the PVC that stores the stestr repositories. The PVC is created
+	// by the test-operator when it does not exist and it is not deleted
+	// together with the Tempest CR so that it can be reused by the next
+	// execution. Defaults to <name of the Tempest CR>-stestr-history.
+	ClaimName string `json:"claimName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=50
+	// +kubebuilder:validation:Minimum=0
+	// Percentage by which the duration of a test has to grow compared with
+	// the previous run to be reported as a timing regression
+	TimingRegressionThreshold int64 `json:"timingRegressionThreshold,omitempty"`
+}
+
+// TempestSpec - configuration of execution of tempest. For specific configuration
+// of tempest see TempestRunSpec and for discover-tempest-config see TempestconfRunSpec.
+type TempestSpec struct {
+	CommonOptions         `json:",inline"`
+	CommonOpenstackConfig `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "8000m", memory: "4Gi"}, requests: {cpu: "4000m", memory: "2Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the Tempest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// By default test-operator executes the test-pods sequentially if multiple
+	// instances of test-operator related CRs exist. If you want to turn off this
+	// behaviour then set this option to true.
+	Parallel bool `json:"parallel"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Activate debug mode. When debug mode is activated any error encountered
+	// inside the test-pod causes that the pod will be kept alive indefinitely
+	// (stuck in "Running" phase) or until the corresponding Tempest CR is deleted.
+	// This allows the user to debug any potential troubles with `oc rsh`.
+	Debug bool `json:"debug"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Activate tempest cleanup. When activated, the state of the cloud is
+	// recorded (tempest cleanup --init-saved-state) before the tests are
+	// executed and tempest cleanup is run after test execution is complete
+	// to delete any resources created by tempest that may have been left out.
+	// The resources recorded in the saved state are preserved.
+	Cleanup bool `json:"cleanup"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TempestRun TempestRunSpec `json:"tempestRun,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TempestconfRun TempestconfRunSpec `json:"tempestconfRun,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// SSHKeySecretName is the name of the k8s secret that contains an ssh key.
+	// The key is mounted to ~/.ssh/id_ecdsa in the tempest pod
+	SSHKeySecretName string `json:"sshKeySecretName"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TempestconfSecretName is the name of the k8s secret that contains a
+	// complete tempest.conf under the tempest.conf key. When it is set,
+	// discover-tempest-config is not executed and the provided tempest.conf
+	// is used verbatim. The tempestconfRun section is ignored in that case.
+	TempestconfSecretName string `json:"tempestconfSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// AccountsSecretName is the name of the k8s secret that contains
+	// pre-provisioned credentials for tempest under the accounts.yaml key.
+	// The file is mounted to /etc/tempest/accounts.yaml and tempest is
+	// configured to use it instead of creating the credentials dynamically so
+	// that the tests can run without admin credentials (see also
+	// tempestconfRun.nonAdmin). It takes precedence over
+	// tempestconfRun.testAccounts.
+	AccountsSecretName string `json:"accountsSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// ListTestsOnly - when set to true, the tests are not executed. Tempest
+	// only lists the tests selected by the configured regexes and include /
+	// exclude lists (tempest run --list-tests). The list of each workflow
+	// step is stored in a ConfigMap referred from status.testLists.
+	ListTestsOnly bool `json:"listTestsOnly,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	// Shards - number of test pods that execute the tests of a workflow step
+	// concurrently. The selected tests are split across the shards and each
+	// shard stores its logs in its own directory of the logs PVC. Once all
+	// the shards finish, a merge pod combines their subunit streams and its
+	// result decides the result of the workflow step. Sharding is disabled
+	// when listTestsOnly is set.
+	Shards int `json:"shards,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Plugins - out-of-tree tempest plugins that are installed by an init
+	// container into a virtual environment shared with the test container.
+	// The resolved versions of the installed packages are recorded in
+	// status.steps[].plugins so that the runs are reproducible.
+	Plugins []TempestPlugin `json:"plugins,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Whitebox - access of the whitebox tempest plugin to the compute nodes.
+	// The SSH key is mounted to the test pod and the whitebox section of
+	// tempest.conf is generated from these values. It is ignored when
+	// tempestconfSecretName is set.
+	Whitebox *WhiteboxSpec `json:"whitebox,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ConfigOverwrite - interface to overwrite default config files like e.g. logging.conf
+	// But can also be used to add additional files. Those get added to the
+	// service config dir in /etc/test_operator/<file>
+	ConfigOverwrite map[string]string `json:"configOverwrite,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// StestrHistory enables the persistence of the stestr repository of each
+	// workflow step across the executions of the Tempest CR. The latest run
+	// is compared with the previous one and the result is stored in
+	// status.stestrComparisons.
+	StestrHistory *StestrHistorySpec `json:"stestrHistory,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Workflow - can be used to specify a multiple executions of tempest with
+	// a different configuration in a single CR. Accepts a list of dictionaries
+	// where each member of the list accepts the same values as the Tempest CR
+	// does in the `spec`` section. Values specified using the workflow section have
+	// a higher precedence than the values specified higher in the Tempest CR
+	// hierarchy.
+	Workflow []WorkflowTempestSpec `json:"workflow,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// Tempest is the Schema for the tempests API
+type Tempest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TempestSpec      `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TempestList contains a list of Tempest
+type TempestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tempest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tempest{}, &TempestList{})
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &Tobiko{}
+
+// ConvertTo converts this Tobiko to the Hub version (v1beta1)
+func (src *Tobiko) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Tobiko)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = convertCommonTestStatusTo(src.Status)
+
+	convertCommonOptionsTo(&src.Spec.CommonOptions, &dst.Spec.CommonOptions)
+	dst.Spec.Resources = src.Spec.Resources
+	dst.Spec.Debug = src.Spec.Debug
+	dst.Spec.Testenv = src.Spec.Testenv
+	dst.Spec.PytestAddopts = src.Spec.PytestAddopts
+	dst.Spec.PytestMarkers = src.Spec.PytestMarkers
+	dst.Spec.TestPattern = src.Spec.TestPattern
+	dst.Spec.PreventCreate = src.Spec.PreventCreate
+	dst.Spec.NumProcesses = src.Spec.NumProcesses
+	dst.Spec.Version = src.Spec.Version
+	dst.Spec.Config = src.Spec.Config
+	dst.Spec.PrivateKey = src.Spec.PrivateKey
+	dst.Spec.PublicKey = src.Spec.PublicKey
+	dst.Spec.Parallel = src.Spec.Parallel
+	dst.Spec.KubeconfigSecretName = src.Spec.KubeconfigSecretName
+	dst.Spec.NetworkAttachments = src.Spec.NetworkAttachments
+	dst.Spec.DisruptionWindow = (*v1beta1.DisruptionWindow)(src.Spec.DisruptionWindow.DeepCopy())
+
+	dst.Spec.Workflow = nil
+	if src.Spec.Workflow != nil {
+		dst.Spec.Workflow = make([]v1beta1.TobikoWorkflowSpec, len(src.Spec.Workflow))
+	}
+
+	for i := range src.Spec.Workflow {
+		srcStep := &src.Spec.Workflow[i]
+		dstStep := &dst.Spec.Workflow[i]
+
+		convertWorkflowCommonParametersTo(&srcStep.WorkflowCommonParameters, &dstStep.WorkflowCommonParameters)
+
+		specOverride, err := convertSpecOverride(srcStep.SpecOverride, tobikoOverrideFields, nil)
+		if err != nil {
+			return err
+		}
+		dstStep.SpecOverride = specOverride
+
+		dstStep.Resources = srcStep.Resources
+		dstStep.Testenv = srcStep.Testenv
+		dstStep.PytestAddopts = srcStep.PytestAddopts
+		dstStep.PytestMarkers = srcStep.PytestMarkers
+		dstStep.TestPattern = srcStep.TestPattern
+		dstStep.PreventCreate = srcStep.PreventCreate
+		dstStep.NumProcesses = srcStep.NumProcesses
+		dstStep.NetworkAttachments = srcStep.NetworkAttachments
+		dstStep.Version = srcStep.Version
+		dstStep.Config = srcStep.Config
+		dstStep.PrivateKey = srcStep.PrivateKey
+		dstStep.PublicKey = srcStep.PublicKey
+		dstStep.KubeconfigSecretName = srcStep.KubeconfigSecretName
+		dstStep.StepName = srcStep.StepName
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *Tobiko) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Tobiko)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = convertCommonTestStatusFrom(src.Status)
+
+	convertCommonOptionsFrom(&src.Spec.CommonOptions, &dst.Spec.CommonOptions)
+	dst.Spec.Resources = src.Spec.Resources
+	dst.Spec.Debug = src.Spec.Debug
+	dst.Spec.Testenv = src.Spec.Testenv
+	dst.Spec.PytestAddopts = src.Spec.PytestAddopts
+	dst.Spec.PytestMarkers = src.Spec.PytestMarkers
+	dst.Spec.TestPattern = src.Spec.TestPattern
+	dst.Spec.PreventCreate = src.Spec.PreventCreate
+	dst.Spec.NumProcesses = src.Spec.NumProcesses
+	dst.Spec.Version = src.Spec.Version
+	dst.Spec.Config = src.Spec.Config
+	dst.Spec.PrivateKey = src.Spec.PrivateKey
+	dst.Spec.PublicKey = src.Spec.PublicKey
+	dst.Spec.Parallel = src.Spec.Parallel
+	dst.Spec.KubeconfigSecretName = src.Spec.KubeconfigSecretName
+	dst.Spec.NetworkAttachments = src.Spec.NetworkAttachments
+	dst.Spec.DisruptionWindow = (*DisruptionWindow)(src.Spec.DisruptionWindow.DeepCopy())
+
+	dst.Spec.Workflow = nil
+	if src.Spec.Workflow != nil {
+		dst.Spec.Workflow = make([]TobikoWorkflowSpec, len(src.Spec.Workflow))
+	}
+
+	for i := range src.Spec.Workflow {
+		srcStep := &src.Spec.Workflow[i]
+		dstStep := &dst.Spec.Workflow[i]
+
+		convertWorkflowCommonParametersFrom(&srcStep.WorkflowCommonParameters, &dstStep.WorkflowCommonParameters)
+
+		specOverride, err := convertSpecOverride(srcStep.SpecOverride, tobikoHubOverrideFields, nil)
+		if err != nil {
+			return err
+		}
+		dstStep.SpecOverride = specOverride
+
+		dstStep.Resources = srcStep.Resources
+		dstStep.Testenv = srcStep.Testenv
+		dstStep.PytestAddopts = srcStep.PytestAddopts
+		dstStep.PytestMarkers = srcStep.PytestMarkers
+		dstStep.TestPattern = srcStep.TestPattern
+		dstStep.PreventCreate = srcStep.PreventCreate
+		dstStep.NumProcesses = srcStep.NumProcesses
+		dstStep.NetworkAttachments = srcStep.NetworkAttachments
+		dstStep.Version = srcStep.Version
+		dstStep.Config = srcStep.Config
+		dstStep.PrivateKey = srcStep.PrivateKey
+		dstStep.PublicKey = srcStep.PublicKey
+		dstStep.KubeconfigSecretName = srcStep.KubeconfigSecretName
+		dstStep.StepName = srcStep.StepName
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTobikoConversionRoundTrip(t *testing.T) {
+	trueVar := true
+	numProcesses := uint8(2)
+
+	src := &Tobiko{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tobiko",
+			Namespace: "openstack",
+		},
+		Spec: TobikoSpec{
+			CommonOptions: CommonOptions{
+				ContainerImage: "quay.io/podified/tobiko:latest",
+				StorageClass:   "local-storage",
+				Privileged:     true,
+			},
+			Debug:                true,
+			Testenv:              "functional",
+			PytestAddopts:        "-m not flaky",
+			PytestMarkers:        "minimal",
+			TestPattern:          "tobiko/tests/scenario",
+			NumProcesses:         4,
+			Version:              "master",
+			Config:               "[DEFAULT]\nlog_file = tobiko.log\n",
+			PrivateKey:           "private-key",
+			PublicKey:            "public-key",
+			KubeconfigSecretName: "kubeconfig",
+			NetworkAttachments:   []string{"ctlplane"},
+			DisruptionWindow: &DisruptionWindow{
+				Start:    "0 2 * * *",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+			},
+			Workflow: []TobikoWorkflowSpec{
+				{StepName: "inherit"},
+				{
+					StepName:           "override",
+					Testenv:            "sanity",
+					PreventCreate:      &trueVar,
+					NumProcesses:       &numProcesses,
+					NetworkAttachments: []string{"internalapi"},
+				},
+				{
+					WorkflowCommonParameters: WorkflowCommonParameters{
+						SpecOverride: &runtime.RawExtension{Raw: []byte(`{"debug":false}`)},
+					},
+					StepName: "spec-override",
+				},
+			},
+		},
+	}
+
+	hub := &v1beta1.Tobiko{}
+	if err := src.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() failed: %v", err)
+	}
+
+	dst := &Tobiko{}
+	if err := dst.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() failed: %v", err)
+	}
+
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("Tobiko changed after the conversion round trip (-want +got):\n%s", diff)
+	}
+}
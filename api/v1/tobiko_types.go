@@ -0,0 +1,263 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DisruptionWindow - daily maintenance window in which the test pods can be
+// created
+type DisruptionWindow struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Time of the day (HH:MM, UTC) when the window opens
+	Start string `json:"start"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// How long the window stays open (e.g. 2h30m). It must be greater than
+	// zero and at most 24h.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// TobikoSpec defines the desired state of Tobiko
+type TobikoSpec struct {
+	CommonOptions `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "8000m", memory: "8Gi"}, requests: {cpu: "4000m", memory: "4Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the Tobiko CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Activate debug mode. When debug mode is activated any error encountered
+	// inside the test-pod causes that the pod will be kept alive indefinitely
+	// (stuck in "Running" phase) or until the corresponding Tobiko CR is deleted.
+	// This allows the user to debug any potential troubles with `oc rsh`.
+	Debug bool `json:"debug"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="py3"
+	// Test environment
+	Testenv string `json:"testenv"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// String including any options to pass to pytest when it runs tobiko tests
+	PytestAddopts string `json:"pytestAddopts"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Marker expression that selects the tobiko tests to run (passed to
+	// pytest as -m), e.g. "ha and not slow"
+	PytestMarkers string `json:"pytestMarkers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Expression matched against the names of the tobiko tests that selects
+	// the tests to run (passed to pytest as -k), e.g. "test_reboot or
+	// test_network"
+	TestPattern string `json:"testPattern,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Boolean specifying whether tobiko tests create new resources or re-use those previously created
+	PreventCreate bool `json:"preventCreate"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=4
+	// Number of processes/workers used to run tobiko tests - value 0 results in automatic decission
+	NumProcesses uint8 `json:"numProcesses"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// Tobiko version
+	Version string `json:"version"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// tobiko.conf
+	Config string `json:"config"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// Private Key
+	PrivateKey string `json:"privateKey"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// Public Key
+	PublicKey string `json:"publicKey"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// By default test-operator executes the test-pods sequentially if multiple
+	// instances of test-operator related CRs exist. To run test-pods in parallel
+	// set this option to true.
+	Parallel bool `json:"parallel"`
+
+	// Name of a secret that contains a kubeconfig. The kubeconfig is mounted under /var/lib/tobiko/.kube/config
+	// in the test pod.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// DisruptionWindow - restricts the execution of the disruptive (e.g.
+	// faults) tests to a daily maintenance window. The test pods are created
+	// only while the window is open, a test pod that is already running is
+	// not stopped when the window closes. Until the window opens the
+	// DeploymentReady condition has the WaitingForWindow reason.
+	DisruptionWindow *DisruptionWindow `json:"disruptionWindow,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
+	// A parameter  that contains a workflow definition.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Workflow []TobikoWorkflowSpec `json:"workflow,omitempty"`
+}
+
+type TobikoWorkflowSpec struct {
+	WorkflowCommonParameters `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "8000m", memory: "8Gi"}, requests: {cpu: "4000m", memory: "4Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the Tobiko CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Test environment
+	Testenv string `json:"testenv,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// String including any options to pass to pytest when it runs tobiko tests
+	PytestAddopts string `json:"pytestAddopts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Marker expression that selects the tobiko tests to run (passed to
+	// pytest as -m)
+	PytestMarkers string `json:"pytestMarkers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Expression matched against the names of the tobiko tests that selects
+	// the tests to run (passed to pytest as -k)
+	TestPattern string `json:"testPattern,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Boolean specifying whether tobiko tests create new resources or re-use those previously created
+	PreventCreate *bool `json:"preventCreate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Number of processes/workers used to run tobiko tests - value 0 results in automatic decission
+	NumProcesses *uint8 `json:"numProcesses,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Tobiko version
+	Version string `json:"version,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// tobiko.conf
+	Config string `json:"config,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Private Key
+	PrivateKey string `json:"privateKey,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Public Key
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// Name of a secret that contains a kubeconfig. The kubeconfig is mounted under /var/lib/tobiko/.kube/config
+	// in the test pod.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
+
+	// A parameter that contains a definition of a single workflow step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=""
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	StepName string `json:"stepName"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// Tobiko is the Schema for the tobikoes API
+type Tobiko struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TobikoSpec       `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TobikoList contains a list of Tobiko
+type TobikoList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tobiko `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tobiko{}, &TobikoList{})
+}
@@ -0,0 +1,2335 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleTest) DeepCopyInto(out *AnsibleTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleTest.
+func (in *AnsibleTest) DeepCopy() *AnsibleTest {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnsibleTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleTestList) DeepCopyInto(out *AnsibleTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AnsibleTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleTestList.
+func (in *AnsibleTestList) DeepCopy() *AnsibleTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AnsibleTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleTestSpec) DeepCopyInto(out *AnsibleTestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Playbooks != nil {
+		in, out := &in.Playbooks, &out.Playbooks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VarFilesFrom != nil {
+		in, out := &in.VarFilesFrom, &out.VarFilesFrom
+		*out = make([]AnsibleVarFileSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVars != nil {
+		in, out := &in.ExtraVars, &out.ExtraVars
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InventoryConfigMap != nil {
+		in, out := &in.InventoryConfigMap, &out.InventoryConfigMap
+		*out = new(InventoryConfigMap)
+		**out = **in
+	}
+	if in.InventoryFromNodeSets != nil {
+		in, out := &in.InventoryFromNodeSets, &out.InventoryFromNodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Callbacks != nil {
+		in, out := &in.Callbacks, &out.Callbacks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipTags != nil {
+		in, out := &in.SkipTags, &out.SkipTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OfflineBundle != nil {
+		in, out := &in.OfflineBundle, &out.OfflineBundle
+		*out = new(OfflineBundle)
+		**out = **in
+	}
+	if in.GalaxyRequirements != nil {
+		in, out := &in.GalaxyRequirements, &out.GalaxyRequirements
+		*out = new(GalaxyRequirements)
+		**out = **in
+	}
+	if in.Ara != nil {
+		in, out := &in.Ara, &out.Ara
+		*out = new(AraConfig)
+		**out = **in
+	}
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = make([]ExtraMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]AnsibleTestWorkflowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleTestSpec.
+func (in *AnsibleTestSpec) DeepCopy() *AnsibleTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleTestWorkflowSpec) DeepCopyInto(out *AnsibleTestWorkflowSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Playbooks != nil {
+		in, out := &in.Playbooks, &out.Playbooks
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.VarFilesFrom != nil {
+		in, out := &in.VarFilesFrom, &out.VarFilesFrom
+		*out = new([]AnsibleVarFileSource)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]AnsibleVarFileSource, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.ExtraVars != nil {
+		in, out := &in.ExtraVars, &out.ExtraVars
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InventoryConfigMap != nil {
+		in, out := &in.InventoryConfigMap, &out.InventoryConfigMap
+		*out = new(InventoryConfigMap)
+		**out = **in
+	}
+	if in.InventoryFromNodeSets != nil {
+		in, out := &in.InventoryFromNodeSets, &out.InventoryFromNodeSets
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Callbacks != nil {
+		in, out := &in.Callbacks, &out.Callbacks
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.SkipTags != nil {
+		in, out := &in.SkipTags, &out.SkipTags
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CheckMode != nil {
+		in, out := &in.CheckMode, &out.CheckMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Verbosity != nil {
+		in, out := &in.Verbosity, &out.Verbosity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Forks != nil {
+		in, out := &in.Forks, &out.Forks
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = new([]ExtraMount)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]ExtraMount, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleTestWorkflowSpec.
+func (in *AnsibleTestWorkflowSpec) DeepCopy() *AnsibleTestWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleTestWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleVarFileSource) DeepCopyInto(out *AnsibleVarFileSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleVarFileSource.
+func (in *AnsibleVarFileSource) DeepCopy() *AnsibleVarFileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleVarFileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AraConfig) DeepCopyInto(out *AraConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AraConfig.
+func (in *AraConfig) DeepCopy() *AraConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AraConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupAssertion) DeepCopyInto(out *CleanupAssertion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupAssertion.
+func (in *CleanupAssertion) DeepCopy() *CleanupAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonOpenstackConfig) DeepCopyInto(out *CommonOpenstackConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonOpenstackConfig.
+func (in *CommonOpenstackConfig) DeepCopy() *CommonOpenstackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonOpenstackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonOptions) DeepCopyInto(out *CommonOptions) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CosignPublicKey != nil {
+		in, out := &in.CosignPublicKey, &out.CosignPublicKey
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExtraConfigmapsMounts != nil {
+		in, out := &in.ExtraConfigmapsMounts, &out.ExtraConfigmapsMounts
+		*out = make([]ExtraConfigmapsMounts, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvFromSecrets != nil {
+		in, out := &in.EnvFromSecrets, &out.EnvFromSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvFromConfigMaps != nil {
+		in, out := &in.EnvFromConfigMaps, &out.EnvFromConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(TestSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
+	if in.MountCABundle != nil {
+		in, out := &in.MountCABundle, &out.MountCABundle
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CABundleSources != nil {
+		in, out := &in.CABundleSources, &out.CABundleSources
+		*out = make([]CABundleSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IOLimits != nil {
+		in, out := &in.IOLimits, &out.IOLimits
+		*out = new(IOLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CleanupAssertions != nil {
+		in, out := &in.CleanupAssertions, &out.CleanupAssertions
+		*out = make([]CleanupAssertion, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogForwarding != nil {
+		in, out := &in.LogForwarding, &out.LogForwarding
+		*out = new(LogForwarding)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogsRotation != nil {
+		in, out := &in.LogsRotation, &out.LogsRotation
+		*out = new(LogsRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogRetention != nil {
+		in, out := &in.LogRetention, &out.LogRetention
+		*out = new(LogRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogsServer != nil {
+		in, out := &in.LogsServer, &out.LogsServer
+		*out = new(LogsServer)
+		**out = **in
+	}
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(Report)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReportPortal != nil {
+		in, out := &in.ReportPortal, &out.ReportPortal
+		*out = new(ReportPortal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Polarion != nil {
+		in, out := &in.Polarion, &out.Polarion
+		*out = new(Polarion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DebugHold != nil {
+		in, out := &in.DebugHold, &out.DebugHold
+		*out = new(DebugHold)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkAttachmentIPFamilies != nil {
+		in, out := &in.NetworkAttachmentIPFamilies, &out.NetworkAttachmentIPFamilies
+		*out = make(map[string]IPFamily, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NetworkSelections != nil {
+		in, out := &in.NetworkSelections, &out.NetworkSelections
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTemplateOverrides != nil {
+		in, out := &in.PodTemplateOverrides, &out.PodTemplateOverrides
+		*out = new(PodTemplateOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonOptions.
+func (in *CommonOptions) DeepCopy() *CommonOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonTestStatus) DeepCopyInto(out *CommonTestStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TestStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.LockWaitStartTime != nil {
+		in, out := &in.LockWaitStartTime, &out.LockWaitStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndpointChecks != nil {
+		in, out := &in.EndpointChecks, &out.EndpointChecks
+		*out = make([]EndpointCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureSnapshots != nil {
+		in, out := &in.FailureSnapshots, &out.FailureSnapshots
+		*out = make([]FailureSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make([]TimelineEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IOLimits != nil {
+		in, out := &in.IOLimits, &out.IOLimits
+		*out = make(map[string]IOLimits, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ImageDigests != nil {
+		in, out := &in.ImageDigests, &out.ImageDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StestrComparisons != nil {
+		in, out := &in.StestrComparisons, &out.StestrComparisons
+		*out = make([]StestrComparison, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TestLists != nil {
+		in, out := &in.TestLists, &out.TestLists
+		*out = make([]TestList, len(*in))
+		copy(*out, *in)
+	}
+	if in.RallySLAResults != nil {
+		in, out := &in.RallySLAResults, &out.RallySLAResults
+		*out = make([]RallySLAResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FioResults != nil {
+		in, out := &in.FioResults, &out.FioResults
+		*out = make([]FioResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.K6ThresholdResults != nil {
+		in, out := &in.K6ThresholdResults, &out.K6ThresholdResults
+		*out = make([]K6ThresholdResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RobotResults != nil {
+		in, out := &in.RobotResults, &out.RobotResults
+		*out = make([]RobotResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomTestResults != nil {
+		in, out := &in.CustomTestResults, &out.CustomTestResults
+		*out = make([]CustomTestResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrunedLogs != nil {
+		in, out := &in.PrunedLogs, &out.PrunedLogs
+		*out = make([]PrunedLogs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReportPortalLaunches != nil {
+		in, out := &in.ReportPortalLaunches, &out.ReportPortalLaunches
+		*out = make([]ReportPortalLaunch, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonTestStatus.
+func (in *CommonTestStatus) DeepCopy() *CommonTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomTestResult) DeepCopyInto(out *CustomTestResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTestResult.
+func (in *CustomTestResult) DeepCopy() *CustomTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugHold) DeepCopyInto(out *DebugHold) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugHold.
+func (in *DebugHold) DeepCopy() *DebugHold {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugHold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionWindow) DeepCopyInto(out *DisruptionWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionWindow.
+func (in *DisruptionWindow) DeepCopy() *DisruptionWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointCheck) DeepCopyInto(out *EndpointCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointCheck.
+func (in *EndpointCheck) DeepCopy() *EndpointCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalPluginType) DeepCopyInto(out *ExternalPluginType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPluginType.
+func (in *ExternalPluginType) DeepCopy() *ExternalPluginType {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalPluginType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraConfigmapsMounts) DeepCopyInto(out *ExtraConfigmapsMounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraConfigmapsMounts.
+func (in *ExtraConfigmapsMounts) DeepCopy() *ExtraConfigmapsMounts {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraConfigmapsMounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraImagesFlavorType) DeepCopyInto(out *ExtraImagesFlavorType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraImagesFlavorType.
+func (in *ExtraImagesFlavorType) DeepCopy() *ExtraImagesFlavorType {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraImagesFlavorType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraImagesType) DeepCopyInto(out *ExtraImagesType) {
+	*out = *in
+	out.Flavor = in.Flavor
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraImagesType.
+func (in *ExtraImagesType) DeepCopy() *ExtraImagesType {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraImagesType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraMount) DeepCopyInto(out *ExtraMount) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(corev1.SecretVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraMount.
+func (in *ExtraMount) DeepCopy() *ExtraMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureSnapshot) DeepCopyInto(out *FailureSnapshot) {
+	*out = *in
+	in.CaptureTime.DeepCopyInto(&out.CaptureTime)
+	if in.NodeConditions != nil {
+		in, out := &in.NodeConditions, &out.NodeConditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureSnapshot.
+func (in *FailureSnapshot) DeepCopy() *FailureSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioProfileResult) DeepCopyInto(out *FioProfileResult) {
+	*out = *in
+	if in.ReadLatencyMean != nil {
+		in, out := &in.ReadLatencyMean, &out.ReadLatencyMean
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReadLatencyP99 != nil {
+		in, out := &in.ReadLatencyP99, &out.ReadLatencyP99
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WriteLatencyMean != nil {
+		in, out := &in.WriteLatencyMean, &out.WriteLatencyMean
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WriteLatencyP99 != nil {
+		in, out := &in.WriteLatencyP99, &out.WriteLatencyP99
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioProfileResult.
+func (in *FioProfileResult) DeepCopy() *FioProfileResult {
+	if in == nil {
+		return nil
+	}
+	out := new(FioProfileResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioResult) DeepCopyInto(out *FioResult) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]FioProfileResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioResult.
+func (in *FioResult) DeepCopy() *FioResult {
+	if in == nil {
+		return nil
+	}
+	out := new(FioResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GalaxyRequirements) DeepCopyInto(out *GalaxyRequirements) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GalaxyRequirements.
+func (in *GalaxyRequirements) DeepCopy() *GalaxyRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(GalaxyRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonTest) DeepCopyInto(out *HorizonTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTest.
+func (in *HorizonTest) DeepCopy() *HorizonTest {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HorizonTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonTestList) DeepCopyInto(out *HorizonTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HorizonTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTestList.
+func (in *HorizonTestList) DeepCopy() *HorizonTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HorizonTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonTestSpec) DeepCopyInto(out *HorizonTestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Browsers != nil {
+		in, out := &in.Browsers, &out.Browsers
+		*out = make([]HorizonTestBrowser, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTestSpec.
+func (in *HorizonTestSpec) DeepCopy() *HorizonTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOLimits) DeepCopyInto(out *IOLimits) {
+	*out = *in
+	if in.IngressBandwidth != nil {
+		in, out := &in.IngressBandwidth, &out.IngressBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EgressBandwidth != nil {
+		in, out := &in.EgressBandwidth, &out.EgressBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOLimits.
+func (in *IOLimits) DeepCopy() *IOLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(IOLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryConfigMap) DeepCopyInto(out *InventoryConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryConfigMap.
+func (in *InventoryConfigMap) DeepCopy() *InventoryConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6ThresholdResult) DeepCopyInto(out *K6ThresholdResult) {
+	*out = *in
+	if in.FailedThresholds != nil {
+		in, out := &in.FailedThresholds, &out.FailedThresholds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6ThresholdResult.
+func (in *K6ThresholdResult) DeepCopy() *K6ThresholdResult {
+	if in == nil {
+		return nil
+	}
+	out := new(K6ThresholdResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogForwarding) DeepCopyInto(out *LogForwarding) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogForwarding.
+func (in *LogForwarding) DeepCopy() *LogForwarding {
+	if in == nil {
+		return nil
+	}
+	out := new(LogForwarding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRetention) DeepCopyInto(out *LogRetention) {
+	*out = *in
+	if in.KeepLastRuns != nil {
+		in, out := &in.KeepLastRuns, &out.KeepLastRuns
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRetention.
+func (in *LogRetention) DeepCopy() *LogRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsRotation) DeepCopyInto(out *LogsRotation) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsRotation.
+func (in *LogsRotation) DeepCopy() *LogsRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsServer) DeepCopyInto(out *LogsServer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsServer.
+func (in *LogsServer) DeepCopy() *LogsServer {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineBundle) DeepCopyInto(out *OfflineBundle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineBundle.
+func (in *OfflineBundle) DeepCopy() *OfflineBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateOverrides) DeepCopyInto(out *PodTemplateOverrides) {
+	*out = *in
+	if in.StrategicMergePatch != nil {
+		in, out := &in.StrategicMergePatch, &out.StrategicMergePatch
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateOverrides.
+func (in *PodTemplateOverrides) DeepCopy() *PodTemplateOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Polarion) DeepCopyInto(out *Polarion) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Polarion.
+func (in *Polarion) DeepCopy() *Polarion {
+	if in == nil {
+		return nil
+	}
+	out := new(Polarion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrunedLogs) DeepCopyInto(out *PrunedLogs) {
+	*out = *in
+	if in.Directories != nil {
+		in, out := &in.Directories, &out.Directories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrunedLogs.
+func (in *PrunedLogs) DeepCopy() *PrunedLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(PrunedLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RallySLAResult) DeepCopyInto(out *RallySLAResult) {
+	*out = *in
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RallySLAResult.
+func (in *RallySLAResult) DeepCopy() *RallySLAResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RallySLAResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Report) DeepCopyInto(out *Report) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Report.
+func (in *Report) DeepCopy() *Report {
+	if in == nil {
+		return nil
+	}
+	out := new(Report)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportPortal) DeepCopyInto(out *ReportPortal) {
+	*out = *in
+	if in.LaunchAttributes != nil {
+		in, out := &in.LaunchAttributes, &out.LaunchAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportPortal.
+func (in *ReportPortal) DeepCopy() *ReportPortal {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportPortal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportPortalLaunch) DeepCopyInto(out *ReportPortalLaunch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportPortalLaunch.
+func (in *ReportPortalLaunch) DeepCopy() *ReportPortalLaunch {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportPortalLaunch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotResult) DeepCopyInto(out *RobotResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotResult.
+func (in *RobotResult) DeepCopy() *RobotResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StestrComparison) DeepCopyInto(out *StestrComparison) {
+	*out = *in
+	if in.NewFailures != nil {
+		in, out := &in.NewFailures, &out.NewFailures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FixedTests != nil {
+		in, out := &in.FixedTests, &out.FixedTests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimingRegressions != nil {
+		in, out := &in.TimingRegressions, &out.TimingRegressions
+		*out = make([]StestrTimingRegression, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StestrComparison.
+func (in *StestrComparison) DeepCopy() *StestrComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(StestrComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StestrHistorySpec) DeepCopyInto(out *StestrHistorySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StestrHistorySpec.
+func (in *StestrHistorySpec) DeepCopy() *StestrHistorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StestrHistorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StestrTimingRegression) DeepCopyInto(out *StestrTimingRegression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StestrTimingRegression.
+func (in *StestrTimingRegression) DeepCopy() *StestrTimingRegression {
+	if in == nil {
+		return nil
+	}
+	out := new(StestrTimingRegression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tempest) DeepCopyInto(out *Tempest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tempest.
+func (in *Tempest) DeepCopy() *Tempest {
+	if in == nil {
+		return nil
+	}
+	out := new(Tempest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Tempest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestList) DeepCopyInto(out *TempestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Tempest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestList.
+func (in *TempestList) DeepCopy() *TempestList {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TempestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestPlugin) DeepCopyInto(out *TempestPlugin) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestPlugin.
+func (in *TempestPlugin) DeepCopy() *TempestPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestRunSpec) DeepCopyInto(out *TempestRunSpec) {
+	*out = *in
+	if in.IncludeListConfigMap != nil {
+		in, out := &in.IncludeListConfigMap, &out.IncludeListConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeListConfigMap != nil {
+		in, out := &in.ExcludeListConfigMap, &out.ExcludeListConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalPlugin != nil {
+		in, out := &in.ExternalPlugin, &out.ExternalPlugin
+		*out = make([]ExternalPluginType, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraRPMs != nil {
+		in, out := &in.ExtraRPMs, &out.ExtraRPMs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraImages != nil {
+		in, out := &in.ExtraImages, &out.ExtraImages
+		*out = make([]ExtraImagesType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestRunSpec.
+func (in *TempestRunSpec) DeepCopy() *TempestRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestSpec) DeepCopyInto(out *TempestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.TempestRun.DeepCopyInto(&out.TempestRun)
+	out.TempestconfRun = in.TempestconfRun
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]TempestPlugin, len(*in))
+		copy(*out, *in)
+	}
+	if in.Whitebox != nil {
+		in, out := &in.Whitebox, &out.Whitebox
+		*out = new(WhiteboxSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigOverwrite != nil {
+		in, out := &in.ConfigOverwrite, &out.ConfigOverwrite
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StestrHistory != nil {
+		in, out := &in.StestrHistory, &out.StestrHistory
+		*out = new(StestrHistorySpec)
+		**out = **in
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]WorkflowTempestSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestSpec.
+func (in *TempestSpec) DeepCopy() *TempestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestconfRunSpec) DeepCopyInto(out *TempestconfRunSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestconfRunSpec.
+func (in *TempestconfRunSpec) DeepCopy() *TempestconfRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestconfRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestList) DeepCopyInto(out *TestList) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestList.
+func (in *TestList) DeepCopy() *TestList {
+	if in == nil {
+		return nil
+	}
+	out := new(TestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestSecurityContext) DeepCopyInto(out *TestSecurityContext) {
+	*out = *in
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SeccompProfile != nil {
+		in, out := &in.SeccompProfile, &out.SeccompProfile
+		*out = new(corev1.SeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(corev1.Capabilities)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestSecurityContext.
+func (in *TestSecurityContext) DeepCopy() *TestSecurityContext {
+	if in == nil {
+		return nil
+	}
+	out := new(TestSecurityContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestStepStatus) DeepCopyInto(out *TestStepStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Shard != nil {
+		in, out := &in.Shard, &out.Shard
+		*out = new(int)
+		**out = **in
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureCaptures != nil {
+		in, out := &in.FailureCaptures, &out.FailureCaptures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestStepStatus.
+func (in *TestStepStatus) DeepCopy() *TestStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TestStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimelineEntry) DeepCopyInto(out *TimelineEntry) {
+	*out = *in
+	if in.WorkflowStep != nil {
+		in, out := &in.WorkflowStep, &out.WorkflowStep
+		*out = new(int)
+		**out = **in
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimelineEntry.
+func (in *TimelineEntry) DeepCopy() *TimelineEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(TimelineEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tobiko) DeepCopyInto(out *Tobiko) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tobiko.
+func (in *Tobiko) DeepCopy() *Tobiko {
+	if in == nil {
+		return nil
+	}
+	out := new(Tobiko)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Tobiko) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TobikoList) DeepCopyInto(out *TobikoList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Tobiko, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TobikoList.
+func (in *TobikoList) DeepCopy() *TobikoList {
+	if in == nil {
+		return nil
+	}
+	out := new(TobikoList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TobikoList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TobikoSpec) DeepCopyInto(out *TobikoSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisruptionWindow != nil {
+		in, out := &in.DisruptionWindow, &out.DisruptionWindow
+		*out = new(DisruptionWindow)
+		**out = **in
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]TobikoWorkflowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TobikoSpec.
+func (in *TobikoSpec) DeepCopy() *TobikoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TobikoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TobikoWorkflowSpec) DeepCopyInto(out *TobikoWorkflowSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreventCreate != nil {
+		in, out := &in.PreventCreate, &out.PreventCreate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NumProcesses != nil {
+		in, out := &in.NumProcesses, &out.NumProcesses
+		*out = new(uint8)
+		**out = **in
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TobikoWorkflowSpec.
+func (in *TobikoWorkflowSpec) DeepCopy() *TobikoWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TobikoWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhiteboxNode) DeepCopyInto(out *WhiteboxNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhiteboxNode.
+func (in *WhiteboxNode) DeepCopy() *WhiteboxNode {
+	if in == nil {
+		return nil
+	}
+	out := new(WhiteboxNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhiteboxSpec) DeepCopyInto(out *WhiteboxSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]WhiteboxNode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhiteboxSpec.
+func (in *WhiteboxSpec) DeepCopy() *WhiteboxSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WhiteboxSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowCommonParameters) DeepCopyInto(out *WorkflowCommonParameters) {
+	*out = *in
+	if in.Privileged != nil {
+		in, out := &in.Privileged, &out.Privileged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.SELinuxLevel != nil {
+		in, out := &in.SELinuxLevel, &out.SELinuxLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExtraConfigmapsMounts != nil {
+		in, out := &in.ExtraConfigmapsMounts, &out.ExtraConfigmapsMounts
+		*out = make([]ExtraConfigmapsMounts, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvFromSecrets != nil {
+		in, out := &in.EnvFromSecrets, &out.EnvFromSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvFromConfigMaps != nil {
+		in, out := &in.EnvFromConfigMaps, &out.EnvFromConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IOLimits != nil {
+		in, out := &in.IOLimits, &out.IOLimits
+		*out = new(IOLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkSelections != nil {
+		in, out := &in.NetworkSelections, &out.NetworkSelections
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContextConstraint != nil {
+		in, out := &in.SecurityContextConstraint, &out.SecurityContextConstraint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SpecOverride != nil {
+		in, out := &in.SpecOverride, &out.SpecOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowCommonParameters.
+func (in *WorkflowCommonParameters) DeepCopy() *WorkflowCommonParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowCommonParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowTempestRunSpec) DeepCopyInto(out *WorkflowTempestRunSpec) {
+	*out = *in
+	if in.IncludeList != nil {
+		in, out := &in.IncludeList, &out.IncludeList
+		*out = new(string)
+		**out = **in
+	}
+	if in.IncludeListConfigMap != nil {
+		in, out := &in.IncludeListConfigMap, &out.IncludeListConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeList != nil {
+		in, out := &in.ExcludeList, &out.ExcludeList
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExcludeListConfigMap != nil {
+		in, out := &in.ExcludeListConfigMap, &out.ExcludeListConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpectedFailuresList != nil {
+		in, out := &in.ExpectedFailuresList, &out.ExpectedFailuresList
+		*out = new(string)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Smoke != nil {
+		in, out := &in.Smoke, &out.Smoke
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Parallel != nil {
+		in, out := &in.Parallel, &out.Parallel
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Serial != nil {
+		in, out := &in.Serial, &out.Serial
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WorkerFile != nil {
+		in, out := &in.WorkerFile, &out.WorkerFile
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExternalPlugin != nil {
+		in, out := &in.ExternalPlugin, &out.ExternalPlugin
+		*out = make([]ExternalPluginType, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraRPMs != nil {
+		in, out := &in.ExtraRPMs, &out.ExtraRPMs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraImages != nil {
+		in, out := &in.ExtraImages, &out.ExtraImages
+		*out = make([]ExtraImagesType, len(*in))
+		copy(*out, *in)
+	}
+	if in.RerunFailed != nil {
+		in, out := &in.RerunFailed, &out.RerunFailed
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowTempestRunSpec.
+func (in *WorkflowTempestRunSpec) DeepCopy() *WorkflowTempestRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowTempestRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowTempestSpec) DeepCopyInto(out *WorkflowTempestSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parallel != nil {
+		in, out := &in.Parallel, &out.Parallel
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.TempestRun.DeepCopyInto(&out.TempestRun)
+	in.TempestconfRun.DeepCopyInto(&out.TempestconfRun)
+	if in.SSHKeySecretName != nil {
+		in, out := &in.SSHKeySecretName, &out.SSHKeySecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigOverwrite != nil {
+		in, out := &in.ConfigOverwrite, &out.ConfigOverwrite
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowTempestSpec.
+func (in *WorkflowTempestSpec) DeepCopy() *WorkflowTempestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowTempestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowTempestconfRunSpec) DeepCopyInto(out *WorkflowTempestconfRunSpec) {
+	*out = *in
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CollectTiming != nil {
+		in, out := &in.CollectTiming, &out.CollectTiming
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NoDefaultDeployer != nil {
+		in, out := &in.NoDefaultDeployer, &out.NoDefaultDeployer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Verbose != nil {
+		in, out := &in.Verbose, &out.Verbose
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NonAdmin != nil {
+		in, out := &in.NonAdmin, &out.NonAdmin
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RetryImage != nil {
+		in, out := &in.RetryImage, &out.RetryImage
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConvertToRaw != nil {
+		in, out := &in.ConvertToRaw, &out.ConvertToRaw
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Out != nil {
+		in, out := &in.Out, &out.Out
+		*out = new(string)
+		**out = **in
+	}
+	if in.DeployerInput != nil {
+		in, out := &in.DeployerInput, &out.DeployerInput
+		*out = new(string)
+		**out = **in
+	}
+	if in.TestAccounts != nil {
+		in, out := &in.TestAccounts, &out.TestAccounts
+		*out = new(string)
+		**out = **in
+	}
+	if in.CreateAccountsFile != nil {
+		in, out := &in.CreateAccountsFile, &out.CreateAccountsFile
+		*out = new(string)
+		**out = **in
+	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(string)
+		**out = **in
+	}
+	if in.GenerateProfile != nil {
+		in, out := &in.GenerateProfile, &out.GenerateProfile
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageDiskFormat != nil {
+		in, out := &in.ImageDiskFormat, &out.ImageDiskFormat
+		*out = new(string)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.FlavorMinMem != nil {
+		in, out := &in.FlavorMinMem, &out.FlavorMinMem
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FlavorMinDisk != nil {
+		in, out := &in.FlavorMinDisk, &out.FlavorMinDisk
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NetworkID != nil {
+		in, out := &in.NetworkID, &out.NetworkID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Append != nil {
+		in, out := &in.Append, &out.Append
+		*out = new(string)
+		**out = **in
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = new(string)
+		**out = **in
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowTempestconfRunSpec.
+func (in *WorkflowTempestconfRunSpec) DeepCopy() *WorkflowTempestconfRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowTempestconfRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -55,12 +55,56 @@ type AnsibleTestSpec struct {
 	// AnsibleGitRepo - git repo to clone into container
 	AnsibleGitRepo string `json:"ansibleGitRepo"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleGitAuthSecretName - name of the secret with the credentials used to clone
+	// the git repo. The ssh-privatekey key (and optionally known_hosts) is
+	// used for SSH URLs, the password key (a password or a token, and
+	// optionally username) for HTTPS URLs.
+	AnsibleGitAuthSecretName string `json:"ansibleGitAuthSecretName,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleVaultPasswordSecretName - name of the secret with the ansible vault password
+	// under the password key. It is used to decrypt the encrypted var files
+	// and group_vars.
+	AnsibleVaultPasswordSecretName string `json:"ansibleVaultPasswordSecretName,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleGitRef - branch, tag or commit SHA of the git repo to check out.
+	// When it is not set the default branch of the repo is used. The commit
+	// that was checked out is recorded in status.steps[].gitCommit.
+	AnsibleGitRef string `json:"ansibleGitRef,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleGitCache - clone the git repo to the logs PVC once and reuse the
+	// checkout in the following workflow steps that use the same git repo and
+	// ref instead of cloning it in each test pod
+	AnsibleGitCache bool `json:"ansibleGitCache,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default:=""
 	// AnsiblePlaybookPath - path to ansible playbook
 	AnsiblePlaybookPath string `json:"ansiblePlaybookPath"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsiblePlaybooks - paths to ansible playbooks that are executed one after another
+	// in a single test pod. Mutually exclusive with ansiblePlaybookPath.
+	AnsiblePlaybooks []string `json:"ansiblePlaybooks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=FailFast
+	// +kubebuilder:validation:Enum:=FailFast;Continue
+	// AnsiblePlaybookFailurePolicy - FailFast stops the workflow step at the first playbook of
+	// ansiblePlaybooks that fails. Continue executes the remaining playbooks as well,
+	// the workflow step fails when any of the playbooks failed.
+	AnsiblePlaybookFailurePolicy PlaybookFailurePolicy `json:"ansiblePlaybookFailurePolicy,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:optional
 	// +kubebuilder:default:=""
@@ -73,24 +117,161 @@ type AnsibleTestSpec struct {
 	// AnsibleVarFiles - interface to create ansible var files Those get added to the
 	AnsibleVarFiles string `json:"ansibleVarFiles,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleVarFilesFrom - ansible var files stored in config maps or secrets. They are
+	// mounted to the test pod and passed to the ansible command using -e @<file>
+	AnsibleVarFilesFrom []AnsibleVarFileSource `json:"ansibleVarFilesFrom,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:optional
 	// +kubebuilder:default:=""
 	// AnsibleExtraVars - string to pass parameters to ansible using
 	AnsibleExtraVars string `json:"ansibleExtraVars,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleExtraVarsMap - variables passed to ansible. Unlike
+	// ansibleExtraVars the values do not have to be escaped as they are
+	// rendered to the var file of ansibleVarFiles.
+	AnsibleExtraVarsMap map[string]string `json:"ansibleExtraVarsMap,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:optional
 	// +kubebuilder:default:=""
 	// AnsibleInventory - string that contains the inventory file content
 	AnsibleInventory string `json:"ansibleInventory,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleInventoryConfigMap - config map that contains the inventory file content. Mutually
+	// exclusive with ansibleInventory and ansibleInventoryFromNodeSets.
+	AnsibleInventoryConfigMap *InventoryConfigMap `json:"ansibleInventoryConfigMap,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleInventoryFromNodeSets - names of the OpenStackDataPlaneNodeSets the inventory is
+	// generated from. The inventories of the node sets are merged into a
+	// single inventory. Mutually exclusive with ansibleInventory and ansibleInventoryConfigMap.
+	AnsibleInventoryFromNodeSets []string `json:"ansibleInventoryFromNodeSets,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleStdoutCallback - name of the ansible stdout callback plugin (e.g. yaml or
+	// junit) passed in ANSIBLE_STDOUT_CALLBACK
+	AnsibleStdoutCallback string `json:"ansibleStdoutCallback,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleCallbacks - names of the additional ansible callback plugins that are
+	// enabled through ANSIBLE_CALLBACKS_ENABLED (e.g. junit or profile_tasks).
+	// The junit callback writes its results to the artifacts directory.
+	AnsibleCallbacks []string `json:"ansibleCallbacks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleTags - run only the plays and tasks tagged with these tags (--tags)
+	AnsibleTags []string `json:"ansibleTags,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleSkipTags - skip the plays and tasks tagged with these tags (--skip-tags)
+	AnsibleSkipTags []string `json:"ansibleSkipTags,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleLimit - limit the playbook run to a subset of the hosts of the
+	// inventory (--limit), e.g. a host group
+	AnsibleLimit string `json:"ansibleLimit,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleExtraArgs - additional arguments of ansible playbook for the options that
+	// are not exposed by other fields (e.g. --start-at-task=<task>). Each item
+	// is passed to ansible playbook as a separate argument so the values may
+	// contain spaces. Options that require user input or replace the
+	// inventory are rejected.
+	AnsibleExtraArgs []string `json:"ansibleExtraArgs,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default:=false
 	// Run ansible playbook with -vvvv
 	Debug bool `json:"debug"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Run ansible playbook in the check mode (--check) that reports the
+	// changes without making them, e.g. to validate a workflow step before
+	// the real run
+	AnsibleCheckMode bool `json:"ansibleCheckMode,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Run ansible playbook with --diff to show the changes made to files
+	// and templates
+	AnsibleDiff bool `json:"ansibleDiff,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4
+	// Verbosity of ansible playbook, the number of -v options (0-4). debug
+	// always runs ansible playbook with the highest verbosity.
+	AnsibleVerbosity int64 `json:"ansibleVerbosity,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Number of hosts ansible playbook runs the tasks on in parallel
+	// (--forks). When it is not set the ansible default is used.
+	AnsibleForks int64 `json:"ansibleForks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// OfflineMode disables all network fetches in the test pods. The ansible
+	// collections and the pip requirements are installed from the
+	// offlineBundle and the git repo has to be a local path. A collection
+	// that is missing in the bundle fails the test pod.
+	OfflineMode bool `json:"offlineMode"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// OfflineBundle - the bundle of ansible collections and pip requirements
+	// that is used when offlineMode is enabled
+	OfflineBundle *OfflineBundle `json:"offlineBundle,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleGalaxyRequirements - a requirements.yml with the ansible collections and
+	// roles that are installed with ansible-galaxy before the playbook runs
+	AnsibleGalaxyRequirements *GalaxyRequirements `json:"ansibleGalaxyRequirements,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleAra - record the playbook results in ARA (ARA Records Ansible) so
+	// that they can be browsed per task
+	AnsibleAra *AraConfig `json:"ansibleAra,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey:=name
+	// Extra secrets, config maps and persistent volume claims that should be
+	// mounted into the test pods (e.g. known_hosts, certificates or var files)
+	ExtraMounts []ExtraMount `json:"extraMounts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// A parameter that contains a workflow definition.
 	// +kubebuilder:validation:Optional
@@ -98,6 +279,110 @@ type AnsibleTestSpec struct {
 	Workflow []AnsibleTestWorkflowSpec `json:"workflow,omitempty"`
 }
 
+// OfflineBundle describes where the test pod finds the ansible collections
+// and the pip requirements when offlineMode is enabled. <path>/collections
+// contains the collection tarballs (ansible-galaxy collection download) and
+// <path>/pip the python packages (pip download).
+type OfflineBundle struct {
+	// +kubebuilder:validation:Optional
+	// Name of the persistent volume claim that contains the bundle. It is
+	// mounted read-only to the path. When empty, the bundle has to be a part
+	// of the container image.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="/var/lib/ansible-offline-bundle"
+	// +kubebuilder:validation:Pattern:=`^/`
+	// Absolute path to the bundle in the test pod
+	Path string `json:"path,omitempty"`
+}
+
+// PlaybookFailurePolicy describes what happens when one of the playbooks of
+// a workflow step fails
+type PlaybookFailurePolicy string
+
+const (
+	// PlaybookFailurePolicyFailFast - do not execute the remaining playbooks
+	PlaybookFailurePolicyFailFast PlaybookFailurePolicy = "FailFast"
+
+	// PlaybookFailurePolicyContinue - execute the remaining playbooks and
+	// report the failure once all of them finished
+	PlaybookFailurePolicyContinue PlaybookFailurePolicy = "Continue"
+)
+
+// GalaxyRequirements is a requirements.yml stored in a config map. The
+// collections and roles listed in it are installed by an init container of
+// the test pod.
+type GalaxyRequirements struct {
+	// +kubebuilder:validation:Required
+	// Name of the config map that contains the requirements.yml
+	ConfigMap string `json:"configMap"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="requirements.yml"
+	// Key of the config map with the content of the requirements.yml
+	Key string `json:"key,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// URL of the Galaxy server (e.g. a mirror) the collections and roles are
+	// installed from. When offlineMode is enabled the requirements have to
+	// refer to local files instead.
+	Server string `json:"server,omitempty"`
+}
+
+// AraConfig describes the ARA API server the playbook results are recorded
+// in
+type AraConfig struct {
+	// +kubebuilder:validation:Optional
+	// Name of the secret with the URL (url key) and optionally the
+	// credentials (username and password keys) of an external ARA API
+	// server. When empty, an ARA API server runs as a sidecar of the test
+	// pod and stores the records in the artifacts directory of the step.
+	ServerSecretName string `json:"serverSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="quay.io/recordsansible/ara-api:latest"
+	// Container image of the ARA API server sidecar
+	Image string `json:"image,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="/usr/lib/python3.9/site-packages/ara/plugins/callback"
+	// Path to the ARA callback plugins in the test container image (the
+	// output of python3 -m ara.setup.callback_plugins)
+	CallbackPluginsPath string `json:"callbackPluginsPath,omitempty"`
+}
+
+// InventoryConfigMap is an ansible inventory stored under a key of a config
+// map
+type InventoryConfigMap struct {
+	// +kubebuilder:validation:Required
+	// Name of the config map that contains the inventory
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="inventory"
+	// Key of the config map with the content of the inventory
+	Key string `json:"key,omitempty"`
+}
+
+// AnsibleVarFileSource is an ansible var file stored under a key of a config
+// map or of a secret
+// +kubebuilder:validation:XValidation:rule="has(self.configMapRef) != has(self.secretRef)",message="exactly one of configMapRef and secretRef must be set"
+type AnsibleVarFileSource struct {
+	// +kubebuilder:validation:Optional
+	// Config map that contains the var file
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Secret that contains the var file
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Key of the config map or of the secret with the content of the var file
+	Key string `json:"key"`
+}
+
 type AnsibleTestWorkflowSpec struct {
 	WorkflowCommonParameters `json:",inline"`
 	CommonOpenstackConfig `json:",inline"`
@@ -131,11 +416,47 @@ type AnsibleTestWorkflowSpec struct {
 	// AnsibleGitRepo - git repo to clone into container
 	AnsibleGitRepo string `json:"ansibleGitRepo,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleGitAuthSecretName - name of the secret with the credentials used to clone
+	// the git repo. The ssh-privatekey key (and optionally known_hosts) is
+	// used for SSH URLs, the password key (a password or a token, and
+	// optionally username) for HTTPS URLs.
+	AnsibleGitAuthSecretName string `json:"ansibleGitAuthSecretName,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleVaultPasswordSecretName - name of the secret with the ansible vault password
+	// under the password key. It is used to decrypt the encrypted var files
+	// and group_vars.
+	AnsibleVaultPasswordSecretName string `json:"ansibleVaultPasswordSecretName,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleGitRef - branch, tag or commit SHA of the git repo to check out.
+	// When it is not set the default branch of the repo is used. The commit
+	// that was checked out is recorded in status.steps[].gitCommit.
+	AnsibleGitRef string `json:"ansibleGitRef,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:Optional
 	// AnsiblePlaybookPath - path to ansible playbook
 	AnsiblePlaybookPath string `json:"ansiblePlaybookPath,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsiblePlaybooks - paths to ansible playbooks that are executed one after another
+	// in a single test pod. Mutually exclusive with ansiblePlaybookPath.
+	AnsiblePlaybooks *[]string `json:"ansiblePlaybooks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:=FailFast;Continue
+	// AnsiblePlaybookFailurePolicy - FailFast stops the workflow step at the first playbook of
+	// ansiblePlaybooks that fails. Continue executes the remaining playbooks as well,
+	// the workflow step fails when any of the playbooks failed.
+	AnsiblePlaybookFailurePolicy PlaybookFailurePolicy `json:"ansiblePlaybookFailurePolicy,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:optional
 	// AnsibleCollections - extra ansible collections to instal in additionn to the ones exist in the requirements.yaml
@@ -147,25 +468,138 @@ type AnsibleTestWorkflowSpec struct {
 	// service config dir in /etc/test_operator/<file> and passed to the ansible command using -e @/etc/test_operator/<file>
 	AnsibleVarFiles string `json:"ansibleVarFiles,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleVarFilesFrom - ansible var files stored in config maps or secrets. When
+	// set, it replaces spec.ansibleVarFilesFrom for the step.
+	AnsibleVarFilesFrom *[]AnsibleVarFileSource `json:"ansibleVarFilesFrom,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:optional
 	// AnsibleExtraVars - interface to pass parameters to ansible using -e
 	AnsibleExtraVars string `json:"ansibleExtraVars,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleExtraVarsMap - variables passed to ansible. Unlike
+	// ansibleExtraVars the values do not have to be escaped as they are
+	// rendered to the var file of ansibleVarFiles.
+	AnsibleExtraVarsMap map[string]string `json:"ansibleExtraVarsMap,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:optional
 	// AnsibleInventory - string that contains the inventory file content
 	AnsibleInventory string `json:"ansibleInventory,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleInventoryConfigMap - config map that contains the inventory file content. Mutually
+	// exclusive with ansibleInventory and ansibleInventoryFromNodeSets.
+	AnsibleInventoryConfigMap *InventoryConfigMap `json:"ansibleInventoryConfigMap,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleInventoryFromNodeSets - names of the OpenStackDataPlaneNodeSets the inventory is
+	// generated from. The inventories of the node sets are merged into a
+	// single inventory. Mutually exclusive with ansibleInventory and ansibleInventoryConfigMap.
+	AnsibleInventoryFromNodeSets *[]string `json:"ansibleInventoryFromNodeSets,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleStdoutCallback - name of the ansible stdout callback plugin (e.g. yaml or
+	// junit) passed in ANSIBLE_STDOUT_CALLBACK
+	AnsibleStdoutCallback string `json:"ansibleStdoutCallback,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleCallbacks - names of the additional ansible callback plugins that are
+	// enabled through ANSIBLE_CALLBACKS_ENABLED (e.g. junit or profile_tasks).
+	// When set, it replaces spec.ansibleCallbacks for the step.
+	AnsibleCallbacks *[]string `json:"ansibleCallbacks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleTags - run only the plays and tasks tagged with these tags (--tags).
+	// When set, it replaces spec.ansibleTags for the step.
+	AnsibleTags *[]string `json:"ansibleTags,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleSkipTags - skip the plays and tasks tagged with these tags (--skip-tags).
+	// When set, it replaces spec.ansibleSkipTags for the step.
+	AnsibleSkipTags *[]string `json:"ansibleSkipTags,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleLimit - limit the playbook run to a subset of the hosts of the
+	// inventory (--limit), e.g. a host group
+	AnsibleLimit string `json:"ansibleLimit,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// AnsibleExtraArgs - additional arguments of ansible playbook for the options that
+	// are not exposed by other fields (e.g. --start-at-task=<task>). Each item
+	// is passed to ansible playbook as a separate argument so the values may
+	// contain spaces. Options that require user input or replace the
+	// inventory are rejected.
+	AnsibleExtraArgs *[]string `json:"ansibleExtraArgs,omitempty"`
+
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:Optional
 	// Run ansible playbook with -vvvv
-	Debug bool `json:"debug,omitempty"`
+	Debug *bool `json:"debug,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Run ansible playbook in the check mode (--check) that reports the
+	// changes without making them, e.g. to validate a workflow step before
+	// the real run
+	AnsibleCheckMode *bool `json:"ansibleCheckMode,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// Run ansible playbook with --diff to show the changes made to files
+	// and templates
+	AnsibleDiff *bool `json:"ansibleDiff,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4
+	// Verbosity of ansible playbook, the number of -v options (0-4). debug
+	// always runs ansible playbook with the highest verbosity.
+	AnsibleVerbosity *int64 `json:"ansibleVerbosity,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Number of hosts ansible playbook runs the tasks on in parallel
+	// (--forks). When it is not set the ansible default is used.
+	AnsibleForks *int64 `json:"ansibleForks,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey:=name
+	// Extra secrets, config maps and persistent volume claims that should be
+	// mounted into the test pod of the workflow step. When set, it replaces
+	// spec.extraMounts for the step. An empty list mounts nothing.
+	ExtraMounts *[]ExtraMount `json:"extraMounts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names the test
+	// pod of the workflow step is attached to. When set, it replaces
+	// spec.networkAttachments for the step. An empty list attaches the test
+	// pod to no additional network.
+	NetworkAttachments *[]string `json:"networkAttachments,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
 
 type AnsibleTest struct {
@@ -203,3 +637,18 @@ func (instance AnsibleTest) RbacNamespace() string {
 func (instance AnsibleTest) RbacResourceName() string {
 	return instance.Name
 }
+
+// GetCommonStatus - return the status of the instance
+func (instance *AnsibleTest) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps
+func (instance *AnsibleTest) GetStepNames() []string {
+	stepNames := []string{}
+	for _, step := range instance.Spec.Workflow {
+		stepNames = append(stepNames, step.StepName)
+	}
+
+	return stepNames
+}
@@ -23,44 +23,102 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+const (
+	// ErrInvalidAnsibleTag
+	ErrInvalidAnsibleTag = "ansible tags must not be empty or contain commas or whitespace"
+
+	// ErrInvalidAnsibleLimit
+	ErrInvalidAnsibleLimit = "the ansible limit must not contain whitespace, separate the patterns with commas or colons"
+
+	// ErrInvalidAnsibleExtraArg
+	ErrInvalidAnsibleExtraArg = "the arguments of ansible playbook must not be empty or contain line breaks"
+
+	// ErrForbiddenAnsibleExtraArg
+	ErrForbiddenAnsibleExtraArg = "the %s option of ansible playbook can not be passed in ansibleExtraArgs"
+
+	// ErrMultiplePlaybookSources
+	ErrMultiplePlaybookSources = "only one of ansiblePlaybookPath and ansiblePlaybooks can be set"
+
+	// ErrInvalidAnsiblePlaybook
+	ErrInvalidAnsiblePlaybook = "the paths of ansiblePlaybooks must not be empty or contain line breaks"
+
+	// ErrMultipleInventorySources
+	ErrMultipleInventorySources = "only one of ansibleInventory, ansibleInventoryConfigMap and " +
+		"ansibleInventoryFromNodeSets can be set"
+)
+
+// forbiddenAnsibleLongOptions are the options of ansible playbook that wait
+// for user input or replace the inventory of the test pod
+var forbiddenAnsibleLongOptions = []string{
+	"--ask-pass",
+	"--ask-become-pass",
+	"--ask-vault-pass", "--ask-vault-password",
+	"--step",
+	"--inventory", "--inventory-file",
+}
+
+// forbiddenAnsibleShortOptions are the short forms of the
+// forbiddenAnsibleLongOptions
+const forbiddenAnsibleShortOptions = "kKJi"
+
+// ansibleShortOptionsWithValue are the short options of ansible playbook that
+// take a value. The rest of a group of short options (e.g. -vvvi) that
+// follows one of them is the value of the option.
+const ansibleShortOptionsWithValue = "ceilMtTu"
+
 // log is for logging in this package.
 var ansibletestlog = logf.Log.WithName("ansibletest-resource")
 
 func (r *AnsibleTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-ansibletest,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=ansibletests,verbs=create;update,versions=v1beta1,name=mansibletest.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Defaulter = &AnsibleTest{}
+var _ testWebhookObject = &AnsibleTest{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type
-func (r *AnsibleTest) Default() {
+// setDefaults fills in the defaults of the AnsibleTest CR
+func (r *AnsibleTest) setDefaults(ctx context.Context, reader client.Reader) {
 	ansibletestlog.Info("default", "name", r.Name)
 
-	// TODO(user): fill in your defaulting logic.
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "AnsibleTest", &r.Spec.CommonOptions)
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-ansibletest,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=ansibletests,verbs=create;update,versions=v1beta1,name=vansibletest.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Validator = &AnsibleTest{}
-
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (r *AnsibleTest) ValidateCreate() (admission.Warnings, error) {
+// validateCreate checks the AnsibleTest CR on create
+func (r *AnsibleTest) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
 	ansibletestlog.Info("validate create", "name", r.Name)
 
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the AnsibleTest CR on both create and update. The old object is
+// nil on create.
+func (r *AnsibleTest) validate(ctx context.Context, reader client.Reader, old *AnsibleTest) (admission.Warnings, error) {
+	var allErrs field.ErrorList
 	var allWarnings admission.Warnings
 
 	if r.Spec.Privileged {
@@ -71,21 +129,385 @@ func (r *AnsibleTest) ValidateCreate() (admission.Warnings, error) {
 		allWarnings = append(allWarnings, fmt.Sprintf(WarnSELinuxLevel, r.Kind))
 	}
 
+	privileged := r.Spec.Privileged
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("computeSSHKeySecretName"), r.Spec.ComputesSSHKeySecretName},
+		{field.NewPath("spec").Child("workloadSSHKeySecretName"), r.Spec.WorkloadSSHKeySecretName},
+		{field.NewPath("spec").Child("openStackConfigSecret"), r.Spec.OpenStackConfigSecret},
+		{field.NewPath("spec").Child("ansibleGitAuthSecretName"), r.Spec.AnsibleGitAuthSecretName},
+		{field.NewPath("spec").Child("ansibleVaultPasswordSecretName"), r.Spec.AnsibleVaultPasswordSecretName},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+
+	secretRefs = append(secretRefs,
+		getVarFileSecretReferences(field.NewPath("spec").Child("ansibleVarFilesFrom"), r.Spec.AnsibleVarFilesFrom)...)
+
+	if r.Spec.AnsibleAra != nil {
+		secretRefs = append(secretRefs, secretReference{
+			field.NewPath("spec").Child("ansibleAra", "serverSecretName"),
+			r.Spec.AnsibleAra.ServerSecretName,
+		})
+	}
+
+	for idx, extraMount := range r.Spec.ExtraMounts {
+		if extraMount.Secret != nil {
+			secretRefs = append(secretRefs, secretReference{
+				field.NewPath("spec").Child("extraMounts").Index(idx).Child("secret", "secretName"),
+				extraMount.Secret.SecretName,
+			})
+		}
+	}
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+		secretRefs = append(secretRefs,
+			secretReference{stepPath.Child("computeSSHKeySecretName"), step.ComputesSSHKeySecretName},
+			secretReference{stepPath.Child("workloadSSHKeySecretName"), step.WorkloadSSHKeySecretName},
+			secretReference{stepPath.Child("openStackConfigSecret"), step.OpenStackConfigSecret},
+			secretReference{stepPath.Child("ansibleGitAuthSecretName"), step.AnsibleGitAuthSecretName},
+			secretReference{stepPath.Child("ansibleVaultPasswordSecretName"), step.AnsibleVaultPasswordSecretName},
+		)
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.AnsibleVarFilesFrom != nil {
+			secretRefs = append(secretRefs,
+				getVarFileSecretReferences(stepPath.Child("ansibleVarFilesFrom"), *step.AnsibleVarFilesFrom)...)
+		}
+
+		if step.ExtraMounts != nil {
+			for mountIdx, extraMount := range *step.ExtraMounts {
+				if extraMount.Secret != nil {
+					secretRefs = append(secretRefs, secretReference{
+						stepPath.Child("extraMounts").Index(mountIdx).Child("secret", "secretName"),
+						extraMount.Secret.SecretName,
+					})
+				}
+			}
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		if step.AnsibleTags != nil {
+			allErrs = append(allErrs, validateAnsibleTags(stepPath.Child("ansibleTags"), *step.AnsibleTags)...)
+		}
+
+		if step.AnsibleSkipTags != nil {
+			allErrs = append(allErrs, validateAnsibleTags(stepPath.Child("ansibleSkipTags"), *step.AnsibleSkipTags)...)
+		}
+
+		if err := validateAnsibleLimit(stepPath.Child("ansibleLimit"), step.AnsibleLimit); err != nil {
+			allErrs = append(allErrs, err)
+		}
+
+		if step.AnsibleExtraArgs != nil {
+			allErrs = append(allErrs, validateAnsibleExtraArgs(stepPath.Child("ansibleExtraArgs"), *step.AnsibleExtraArgs)...)
+		}
+
+		if step.AnsiblePlaybooks != nil {
+			allErrs = append(allErrs, validateAnsiblePlaybooks(stepPath, step.AnsiblePlaybookPath, *step.AnsiblePlaybooks)...)
+		}
+
+		stepNodeSets := []string{}
+		if step.AnsibleInventoryFromNodeSets != nil {
+			stepNodeSets = *step.AnsibleInventoryFromNodeSets
+		}
+
+		err := validateInventorySources(stepPath, step.AnsibleInventory, step.AnsibleInventoryConfigMap, stepNodeSets)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		}
+
+		allErrs = append(allErrs, ValidateOfflineMode(stepPath, AnsibleTestSpec{
+			OfflineMode:        r.Spec.OfflineMode,
+			OfflineBundle:      r.Spec.OfflineBundle,
+			AnsibleGitRepo:     step.AnsibleGitRepo,
+			AnsibleCollections: step.AnsibleCollections,
+		})...)
+
+		overrideSpec := AnsibleTestSpec{}
+		err = validateSpecOverride(stepPath.Child("specOverride"), "AnsibleTest", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else {
+			privileged = privileged || overrideSpec.Privileged
+			allErrs = append(allErrs, validateAnsibleArgs(stepPath.Child("specOverride"), overrideSpec)...)
+		}
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("AnsibleTest", stepNames)...)
+	allErrs = append(allErrs, ValidateOfflineMode(field.NewPath("spec"), r.Spec)...)
+	allErrs = append(allErrs, validateAnsibleArgs(field.NewPath("spec"), r.Spec)...)
+
+	allErrs = append(allErrs, validateAnsiblePlaybooks(field.NewPath("spec"), r.Spec.AnsiblePlaybookPath, r.Spec.AnsiblePlaybooks)...)
+
+	err := validateInventorySources(
+		field.NewPath("spec"), r.Spec.AnsibleInventory, r.Spec.AnsibleInventoryConfigMap, r.Spec.AnsibleInventoryFromNodeSets)
+	if err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "AnsibleTest", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "AnsibleTest", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "AnsibleTest"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "AnsibleTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "AnsibleTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "AnsibleTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return allWarnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: GroupVersion.WithKind("AnsibleTest").Group,
+				Kind:  GroupVersion.WithKind("AnsibleTest").Kind,
+			}, r.GetName(), allErrs)
+	}
+
 	return allWarnings, nil
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *AnsibleTest) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+// validateUpdate checks the AnsibleTest CR on update
+func (r *AnsibleTest) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
 	ansibletestlog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	oldAnsibleTest, ok := old.(*AnsibleTest)
+	if !ok || oldAnsibleTest == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldAnsibleTest)
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *AnsibleTest) ValidateDelete() (admission.Warnings, error) {
+// validateDelete checks the AnsibleTest CR on delete
+func (r *AnsibleTest) validateDelete() (admission.Warnings, error) {
 	ansibletestlog.Info("validate delete", "name", r.Name)
 
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil, nil
 }
+
+// simulatedSteps returns the test pods that would be spawned for the AnsibleTest CR
+func (r *AnsibleTest) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
+
+// getVarFileSecretReferences returns the references to the secrets that
+// contain ansible var files
+func getVarFileSecretReferences(fldPath *field.Path, varFiles []AnsibleVarFileSource) []secretReference {
+	secretRefs := []secretReference{}
+	for idx, varFile := range varFiles {
+		if varFile.SecretRef != nil {
+			secretRefs = append(secretRefs, secretReference{
+				fldPath.Index(idx).Child("secretRef", "name"),
+				varFile.SecretRef.Name,
+			})
+		}
+	}
+
+	return secretRefs
+}
+
+// validateAnsibleTags checks that the tags can be passed to the --tags and
+// --skip-tags options of ansible-playbook as a comma separated list
+func validateAnsibleTags(fldPath *field.Path, tags []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for idx, tag := range tags {
+		if tag == "" || strings.ContainsAny(tag, ", \t\n") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(idx), tag, ErrInvalidAnsibleTag))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAnsibleLimit checks that the limit can be passed to the --limit
+// option of ansible-playbook as a single argument
+func validateAnsibleLimit(fldPath *field.Path, limit string) *field.Error {
+	if strings.ContainsAny(limit, " \t\n") {
+		return field.Invalid(fldPath, limit, ErrInvalidAnsibleLimit)
+	}
+
+	return nil
+}
+
+// validateInventorySources checks that the inventory is read from a single
+// source
+func validateInventorySources(
+	fldPath *field.Path,
+	inventory string,
+	inventoryConfigMap *InventoryConfigMap,
+	nodeSets []string,
+) *field.Error {
+	sources := 0
+	if inventory != "" {
+		sources++
+	}
+
+	if inventoryConfigMap != nil {
+		sources++
+	}
+
+	if len(nodeSets) > 0 {
+		sources++
+	}
+
+	if sources > 1 {
+		return field.Forbidden(fldPath, ErrMultipleInventorySources)
+	}
+
+	return nil
+}
+
+// validateAnsiblePlaybooks checks that the playbooks are not set in both
+// ansiblePlaybookPath and ansiblePlaybooks and that they can be passed to the
+// test pod as a list separated by line breaks
+func validateAnsiblePlaybooks(fldPath *field.Path, playbookPath string, playbooks []string) field.ErrorList {
+	var allErrs field.ErrorList
+	if playbookPath != "" && len(playbooks) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ansiblePlaybooks"), ErrMultiplePlaybookSources))
+	}
+
+	for idx, playbook := range playbooks {
+		if playbook == "" || strings.ContainsAny(playbook, "\r\n") {
+			allErrs = append(allErrs, field.Invalid(
+				fldPath.Child("ansiblePlaybooks").Index(idx), playbook, ErrInvalidAnsiblePlaybook))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAnsibleArgs checks the fields of the spec that are passed to
+// ansible playbook as arguments
+func validateAnsibleArgs(fldPath *field.Path, spec AnsibleTestSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateAnsibleTags(fldPath.Child("ansibleTags"), spec.AnsibleTags)...)
+	allErrs = append(allErrs, validateAnsibleTags(fldPath.Child("ansibleSkipTags"), spec.AnsibleSkipTags)...)
+	if err := validateAnsibleLimit(fldPath.Child("ansibleLimit"), spec.AnsibleLimit); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validateAnsibleExtraArgs(fldPath.Child("ansibleExtraArgs"), spec.AnsibleExtraArgs)...)
+
+	return allErrs
+}
+
+// validateAnsibleExtraArgs checks that the arguments can be passed to ansible
+// playbook and that they do not contain forbiddenAnsibleLongOptions or
+// forbiddenAnsibleShortOptions. The options are matched the same way as
+// ansible playbook parses them, including groups of short options (-kK),
+// short options with an attached value (-i/tmp/inventory) and unambiguous
+// prefixes of the long options (--inv).
+func validateAnsibleExtraArgs(fldPath *field.Path, args []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for idx, arg := range args {
+		if arg == "" || arg == "--" || strings.ContainsAny(arg, "\n\r\x00") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(idx), arg, ErrInvalidAnsibleExtraArg))
+			continue
+		}
+
+		if option := getForbiddenAnsibleOption(arg); option != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(idx), fmt.Sprintf(ErrForbiddenAnsibleExtraArg, option)))
+		}
+	}
+
+	return allErrs
+}
+
+// getForbiddenAnsibleOption returns the forbidden option of ansible playbook
+// the argument is parsed as or an empty string when the argument is allowed.
+// The values of the options are not checked as ansible playbook does not
+// accept a separate value that starts with a dash.
+func getForbiddenAnsibleOption(arg string) string {
+	if strings.HasPrefix(arg, "--") {
+		name, _, _ := strings.Cut(arg, "=")
+		for _, option := range forbiddenAnsibleLongOptions {
+			// A prefix of a forbidden option is rejected even when it is
+			// ambiguous as ansible playbook does not accept it in that case
+			if strings.HasPrefix(option, name) {
+				return option
+			}
+		}
+
+		return ""
+	}
+
+	if !strings.HasPrefix(arg, "-") {
+		return ""
+	}
+
+	for _, short := range arg[1:] {
+		if strings.ContainsRune(forbiddenAnsibleShortOptions, short) {
+			return "-" + string(short)
+		}
+
+		if strings.ContainsRune(ansibleShortOptionsWithValue, short) {
+			break
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestGetForbiddenAnsibleOption(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		// allowed options
+		{arg: "--start-at-task=Install packages", want: ""},
+		{arg: "--start-at-task", want: ""},
+		{arg: "Install packages", want: ""},
+		{arg: "--flush-cache", want: ""},
+		{arg: "-vvv", want: ""},
+		{arg: "--check", want: ""},
+
+		// long options and their prefixes
+		{arg: "--inventory", want: "--inventory"},
+		{arg: "--inventory=/tmp/hosts", want: "--inventory"},
+		{arg: "--inventory=", want: "--inventory"},
+		{arg: "--inventory-file=/tmp/hosts", want: "--inventory-file"},
+		{arg: "--inv", want: "--inventory"},
+		{arg: "--ask-pass", want: "--ask-pass"},
+		{arg: "--ask-become-pass", want: "--ask-become-pass"},
+		{arg: "--ask-vault-password", want: "--ask-vault-password"},
+		{arg: "--ask-vault-pass", want: "--ask-vault-pass"},
+		{arg: "--ask", want: "--ask-pass"},
+		{arg: "--step", want: "--step"},
+
+		// short options and groups of short options
+		{arg: "-i", want: "-i"},
+		{arg: "-i/tmp/hosts", want: "-i"},
+		{arg: "-k", want: "-k"},
+		{arg: "-K", want: "-K"},
+		{arg: "-J", want: "-J"},
+		{arg: "-kK", want: "-k"},
+		{arg: "-Kk", want: "-K"},
+		{arg: "-vvvi", want: "-i"},
+		{arg: "-vK", want: "-K"},
+
+		// short options that take a value end the group, the rest of the
+		// argument is the value
+		{arg: "-ekey=value", want: ""},
+		{arg: "-eK=1", want: ""},
+		{arg: "-lcompute-0", want: ""},
+		{arg: "-ukolla", want: ""},
+		{arg: "-vtkeystone", want: ""},
+		{arg: "-vMi", want: ""},
+		{arg: "-kukolla", want: "-k"},
+	}
+
+	for _, tt := range tests {
+		if got := getForbiddenAnsibleOption(tt.arg); got != tt.want {
+			t.Errorf("getForbiddenAnsibleOption(%q) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAnsibleExtraArgs(t *testing.T) {
+	fldPath := field.NewPath("spec", "ansibleExtraArgs")
+
+	tests := []struct {
+		name string
+		args []string
+		want field.ErrorList
+	}{
+		{
+			name: "allowed arguments",
+			args: []string{"--start-at-task", "Install packages", "-vv", "--flush-cache"},
+		},
+		{
+			name: "value of an option in a separate argument",
+			args: []string{"-e", "key=value", "--limit", "compute-0"},
+		},
+		{
+			name: "empty argument",
+			args: []string{"-v", ""},
+			want: field.ErrorList{field.Invalid(fldPath.Index(1), "", ErrInvalidAnsibleExtraArg)},
+		},
+		{
+			name: "end of the options",
+			args: []string{"--", "-i"},
+			want: field.ErrorList{
+				field.Invalid(fldPath.Index(0), "--", ErrInvalidAnsibleExtraArg),
+				field.Forbidden(fldPath.Index(1), fmt.Sprintf(ErrForbiddenAnsibleExtraArg, "-i")),
+			},
+		},
+		{
+			name: "line break",
+			args: []string{"--start-at-task=a\nb"},
+			want: field.ErrorList{field.Invalid(fldPath.Index(0), "--start-at-task=a\nb", ErrInvalidAnsibleExtraArg)},
+		},
+		{
+			name: "forbidden options",
+			args: []string{"--inventory=", "-kK", "-v", "-ekey=value", "-vvvi/tmp/hosts"},
+			want: field.ErrorList{
+				field.Forbidden(fldPath.Index(0), fmt.Sprintf(ErrForbiddenAnsibleExtraArg, "--inventory")),
+				field.Forbidden(fldPath.Index(1), fmt.Sprintf(ErrForbiddenAnsibleExtraArg, "-k")),
+				field.Forbidden(fldPath.Index(4), fmt.Sprintf(ErrForbiddenAnsibleExtraArg, "-i")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateAnsibleExtraArgs(fldPath, tt.args)
+			if len(got) != len(tt.want) {
+				t.Fatalf("validateAnsibleExtraArgs(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+
+			for i := range got {
+				if got[i].Error() != tt.want[i].Error() {
+					t.Errorf("validateAnsibleExtraArgs(%q)[%d] = %v, want %v", tt.args, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -1,5 +1,24 @@
 package v1beta1
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
 const (
 	// ErrPrivilegedModeRequired
 	ErrPrivilegedModeRequired = "%s.Spec.Privileged is requied in order to successfully " +
@@ -7,6 +26,55 @@ const (
 
 	// ErrDebug
 	ErrDebug = "%s.Spec.Workflow parameter must be empty to run debug mode"
+
+	// ErrContainerImage
+	ErrContainerImage = "%s.Spec.ContainerImage is empty and there is no default " +
+		"container image that could be used instead. Specify the image in the CR, " +
+		"in the test-operator-config config map or in the operator defaults."
+
+	// ErrDuplicateStepName
+	ErrDuplicateStepName = "%s.Spec.Workflow contains more than one step named %s"
+
+	// ErrInvalidSecretName
+	ErrInvalidSecretName = "%s is not a valid secret name: %s"
+
+	// ErrPrivilegedNamespace
+	ErrPrivilegedNamespace = "%s.Spec.Privileged is set to true but the %s namespace " +
+		"enforces the %s pod security standard which does not allow privileged test pods."
+
+	// ErrSpecOverrideWorkflow
+	ErrSpecOverrideWorkflow = "%s.Spec.Workflow can not be overridden by the specOverride of a workflow step"
+
+	// ErrInvalidSpecOverride
+	ErrInvalidSpecOverride = "specOverride must be a sparse copy of %s.Spec: %s"
+
+	// ErrInvalidJSONPatch
+	ErrInvalidJSONPatch = "jsonPatch must be a valid JSON patch: %s"
+
+	// ErrHostNetworkPrivileged
+	ErrHostNetworkPrivileged = "%s.Spec.HostNetwork requires %s.Spec.Privileged to be set to true"
+
+	// ErrSecurityContextConstraintPrivileged
+	ErrSecurityContextConstraintPrivileged = "%s.Spec.Privileged is set to true which requires the " +
+		"%s security context constraint as privileged test pods add capabilities and allow privilege escalation"
+
+	// ErrRunAsRootPrivileged
+	ErrRunAsRootPrivileged = "%s.Spec.SecurityContext.RunAsUser can be set to 0 only when " +
+		"%s.Spec.Privileged is set to true as the test pods run with runAsNonRoot: true otherwise"
+
+	// ErrConvertOldObject
+	ErrConvertOldObject = "unable to convert existing object"
+
+	// ErrUnexpectedObject
+	ErrUnexpectedObject = "unexpected object %T, expected a test CR"
+
+	// ErrLogsPersistenceRequired
+	ErrLogsPersistenceRequired = "%s.Spec.Persistence must be set to pvc to use %s as the test pods " +
+		"read the logs of the other test pods of the workflow step"
+
+	// ErrNetworkOptionNotSupported
+	ErrNetworkOptionNotSupported = "%s.Spec.%s is not supported as the %s test pods are not " +
+		"attached to additional networks"
 )
 
 const (
@@ -27,4 +95,355 @@ const (
 		"set to true. Please, consider setting %[1]s.Spec.SELinuxLevel. This " +
 		"ensures that the copying of the logs to the PV is completed without any " +
 		"complications."
+
+	// WarnSecretNotFound
+	WarnSecretNotFound = "%s refers to the %s secret which does not exist in the %s " +
+		"namespace. Test pods will not start until the secret is created."
+
+	// WarnPodTemplateOverrides
+	WarnPodTemplateOverrides = "%s.Spec.PodTemplateOverrides is set. The patches are applied " +
+		"to the test pods as they are and may prevent the tests from running."
 )
+
+const (
+	// testOperatorConfigMapName is the name of the config map that can be used
+	// to override the default container images
+	testOperatorConfigMapName = "test-operator-config"
+
+	// podSecurityEnforceLabel is the namespace label that holds the pod
+	// security standard enforced by the pod security admission
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+
+	// DefaultStorageClass is the storage class used for the test-operator
+	// related PVCs when no storage class is specified in the CR
+	DefaultStorageClass = "local-storage"
+)
+
+// getDefaultContainerImage returns the container image that is used for the
+// test pods of the given kind when the image is not specified in the CR.
+func getDefaultContainerImage(ctx context.Context, reader client.Reader, namespace string, kind string) string {
+	if reader != nil {
+		cm := &corev1.ConfigMap{}
+		objectKey := client.ObjectKey{Namespace: namespace, Name: testOperatorConfigMapName}
+		err := reader.Get(ctx, objectKey, cm)
+		if err == nil && len(cm.Data[strings.ToLower(kind)+"-image"]) > 0 {
+			return cm.Data[strings.ToLower(kind)+"-image"]
+		}
+	}
+
+	return os.Getenv(fmt.Sprintf("RELATED_IMAGE_TEST_%s_IMAGE_URL_DEFAULT", strings.ToUpper(kind)))
+}
+
+// defaultCommonOptions fills in the container image and the storage class
+// when they are not specified in the CR so that the resolved values are
+// visible in the spec.
+func defaultCommonOptions(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	kind string,
+	options *CommonOptions,
+) {
+	if len(options.ContainerImage) == 0 {
+		options.ContainerImage = getDefaultContainerImage(ctx, reader, namespace, kind)
+	}
+
+	if len(options.StorageClass) == 0 {
+		options.StorageClass = DefaultStorageClass
+	}
+}
+
+// validateContainerImage returns an error when a test pod would be spawned
+// without a container image. The workflowImages contain the container images
+// specified for each workflow step.
+func validateContainerImage(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	kind string,
+	containerImage string,
+	workflowImages []string,
+) *field.Error {
+	if len(containerImage) > 0 {
+		return nil
+	}
+
+	imageRequired := len(workflowImages) == 0
+	for _, image := range workflowImages {
+		if len(image) == 0 {
+			imageRequired = true
+		}
+	}
+
+	if !imageRequired || len(getDefaultContainerImage(ctx, reader, namespace, kind)) > 0 {
+		return nil
+	}
+
+	return &field.Error{
+		Type:     field.ErrorTypeRequired,
+		Field:    field.NewPath("spec").Child("containerImage").String(),
+		BadValue: containerImage,
+		Detail:   fmt.Sprintf(ErrContainerImage, kind),
+	}
+}
+
+// validateSpecOverride returns an error when the spec override of a workflow
+// step is not a sparse copy of the spec or when it overrides the workflow. The
+// decoded override is stored in spec so that the caller can validate the
+// overridden values.
+func validateSpecOverride(
+	path *field.Path,
+	kind string,
+	specOverride *runtime.RawExtension,
+	spec interface{},
+) *field.Error {
+	if specOverride == nil || len(specOverride.Raw) == 0 {
+		return nil
+	}
+
+	override := map[string]interface{}{}
+	if err := json.Unmarshal(specOverride.Raw, &override); err != nil {
+		return field.Invalid(path, string(specOverride.Raw), fmt.Sprintf(ErrInvalidSpecOverride, kind, err))
+	}
+
+	if _, ok := override["workflow"]; ok {
+		return field.Forbidden(path.Child("workflow"), fmt.Sprintf(ErrSpecOverrideWorkflow, kind))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(specOverride.Raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(spec); err != nil {
+		return field.Invalid(path, string(specOverride.Raw), fmt.Sprintf(ErrInvalidSpecOverride, kind, err))
+	}
+
+	return nil
+}
+
+// validatePodTemplateOverrides returns an error when the JSON patch of the pod
+// template overrides can not be decoded. The patches are applied to the test
+// pods by the controller.
+func validatePodTemplateOverrides(path *field.Path, overrides *PodTemplateOverrides) *field.Error {
+	if overrides == nil || overrides.JSONPatch == "" {
+		return nil
+	}
+
+	if _, err := jsonpatch.DecodePatch([]byte(overrides.JSONPatch)); err != nil {
+		return field.Invalid(path.Child("jsonPatch"), overrides.JSONPatch, fmt.Sprintf(ErrInvalidJSONPatch, err))
+	}
+
+	return nil
+}
+
+// validateFeatureGatesAnnotation returns an error when the
+// FeatureGatesAnnotation of an instance can not be parsed
+func validateFeatureGatesAnnotation(annotations map[string]string) *field.Error {
+	value, ok := annotations[FeatureGatesAnnotation]
+	if !ok {
+		return nil
+	}
+
+	if _, err := ParseFeatureGates(value); err != nil {
+		path := field.NewPath("metadata").Child("annotations").Key(FeatureGatesAnnotation)
+		return field.Invalid(path, value, err.Error())
+	}
+
+	return nil
+}
+
+// validatePodMetadata returns an error for each invalid label or annotation
+// in podLabels and podAnnotations
+func validatePodMetadata(path *field.Path, options CommonOptions) field.ErrorList {
+	allErrs := metav1validation.ValidateLabels(options.PodLabels, path.Child("podLabels"))
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(options.PodAnnotations, path.Child("podAnnotations"))...)
+
+	return allErrs
+}
+
+// validateWorkflowStepNames returns an error for each workflow step that uses
+// a name of a previous workflow step.
+func validateWorkflowStepNames(kind string, stepNames []string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	seen := make(map[string]bool)
+	for idx, stepName := range stepNames {
+		if seen[stepName] {
+			allErrs = append(allErrs, &field.Error{
+				Type:     field.ErrorTypeDuplicate,
+				Field:    field.NewPath("spec").Child("workflow").Index(idx).Child("stepName").String(),
+				BadValue: stepName,
+				Detail:   fmt.Sprintf(ErrDuplicateStepName, kind, stepName),
+			})
+		}
+
+		seen[stepName] = true
+	}
+
+	return allErrs
+}
+
+// validateSecretName returns an error when the secretName can not be used as
+// a name of a secret and a warning when the secret does not exist yet.
+func validateSecretName(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	fldPath *field.Path,
+	secretName string,
+) (*field.Error, admission.Warnings) {
+	if len(secretName) == 0 {
+		return nil, nil
+	}
+
+	if errs := validation.IsDNS1123Subdomain(secretName); len(errs) > 0 {
+		return &field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    fldPath.String(),
+			BadValue: secretName,
+			Detail:   fmt.Sprintf(ErrInvalidSecretName, secretName, strings.Join(errs, ", ")),
+		}, nil
+	}
+
+	if reader == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	objectKey := client.ObjectKey{Namespace: namespace, Name: secretName}
+	if err := reader.Get(ctx, objectKey, secret); err != nil {
+		return nil, admission.Warnings{
+			fmt.Sprintf(WarnSecretNotFound, fldPath.String(), secretName, namespace),
+		}
+	}
+
+	return nil, nil
+}
+
+// secretReference holds a name of a secret together with the path of the
+// field that refers to it.
+type secretReference struct {
+	fldPath    *field.Path
+	secretName string
+}
+
+// getSecretListReferences returns the references to the secrets listed in a
+// field that contains secret names (e.g. envFromSecrets or imagePullSecrets)
+func getSecretListReferences(fldPath *field.Path, secretNames []string) []secretReference {
+	secretRefs := []secretReference{}
+	for idx, secretName := range secretNames {
+		secretRefs = append(secretRefs, secretReference{fldPath.Index(idx), secretName})
+	}
+
+	return secretRefs
+}
+
+// validateSecretReferences validates names of all secrets referred by a CR
+func validateSecretReferences(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	secretRefs []secretReference,
+) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var allWarnings admission.Warnings
+
+	for _, secretRef := range secretRefs {
+		err, warnings := validateSecretName(ctx, reader, namespace, secretRef.fldPath, secretRef.secretName)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		}
+		allWarnings = append(allWarnings, warnings...)
+	}
+
+	return allErrs, allWarnings
+}
+
+// validatePrivilegedNamespace returns an error when privileged test pods are
+// requested in a namespace that enforces a pod security standard which does
+// not allow privileged pods.
+func validatePrivilegedNamespace(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	kind string,
+	privileged bool,
+) *field.Error {
+	if !privileged || reader == nil {
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return nil
+	}
+
+	level := ns.Labels[podSecurityEnforceLabel]
+	if level != "baseline" && level != "restricted" {
+		return nil
+	}
+
+	return &field.Error{
+		Type:     field.ErrorTypeForbidden,
+		Field:    field.NewPath("spec").Child("privileged").String(),
+		BadValue: privileged,
+		Detail:   fmt.Sprintf(ErrPrivilegedNamespace, kind, namespace, level),
+	}
+}
+
+// validateHostNetwork checks that the test pods are not spawned in the host
+// network namespace unless the privileged mode is enabled
+func validateHostNetwork(path *field.Path, kind string, options CommonOptions) *field.Error {
+	if !options.HostNetwork || options.Privileged {
+		return nil
+	}
+
+	return field.Invalid(path.Child("hostNetwork"), options.HostNetwork,
+		fmt.Sprintf(ErrHostNetworkPrivileged, kind, kind))
+}
+
+// validateNoNetworkOptions returns errors for the network options of
+// CommonOptions that are set for a kind whose test pods are not attached to
+// additional networks (e.g. HorizonTest)
+func validateNoNetworkOptions(path *field.Path, kind string, options CommonOptions) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(options.NetworkAttachmentIPFamilies) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("networkAttachmentIPFamilies"),
+			fmt.Sprintf(ErrNetworkOptionNotSupported, kind, "NetworkAttachmentIPFamilies", kind)))
+	}
+
+	if len(options.NetworkSelections) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("networkSelections"),
+			fmt.Sprintf(ErrNetworkOptionNotSupported, kind, "NetworkSelections", kind)))
+	}
+
+	return allErrs
+}
+
+// validateSecurityContext returns an error when the test pods would run as
+// root without being privileged. Such pods are rejected by the kubelet
+// because of runAsNonRoot.
+func validateSecurityContext(path *field.Path, kind string, options CommonOptions) *field.Error {
+	securityContext := options.SecurityContext
+	if securityContext == nil || securityContext.RunAsUser == nil || options.Privileged {
+		return nil
+	}
+
+	if *securityContext.RunAsUser != 0 {
+		return nil
+	}
+
+	return field.Invalid(path.Child("securityContext").Child("runAsUser"), *securityContext.RunAsUser,
+		fmt.Sprintf(ErrRunAsRootPrivileged, kind, kind))
+}
+
+// validateSecurityContextConstraint returns an error when privileged test pods
+// are required to use an SCC that would not admit them
+func validateSecurityContextConstraint(path *field.Path, kind string, options CommonOptions) *field.Error {
+	scc := options.SecurityContextConstraint
+	if !options.Privileged || len(scc) == 0 || scc == SecurityContextConstraintPrivileged {
+		return nil
+	}
+
+	return field.Invalid(path.Child("securityContextConstraint"), scc,
+		fmt.Sprintf(ErrSecurityContextConstraintPrivileged, kind, SecurityContextConstraintPrivileged))
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+const (
+	// StarvedCondition Status=True condition which indicates that the instance
+	// has been waiting for the test-operator-lock longer than the lock
+	// starvation threshold allows.
+	StarvedCondition condition.Type = "Starved"
+
+	// CleanupVerifiedCondition Status=True condition which indicates that no
+	// resources described by the cleanup assertions remained after the tests
+	// finished.
+	CleanupVerifiedCondition condition.Type = "CleanupVerified"
+
+	// EndpointsReachableCondition Status=True condition which indicates that
+	// all OpenStack endpoints checked by the endpoint preflight are reachable.
+	EndpointsReachableCondition condition.Type = "EndpointsReachable"
+
+	// ReportGeneratedCondition Status=True condition which indicates that the
+	// HTML report of the test results was generated.
+	ReportGeneratedCondition condition.Type = "ReportGenerated"
+
+	// PolarionExportedCondition Status=True condition which indicates that the
+	// test results were imported to Polarion.
+	PolarionExportedCondition condition.Type = "PolarionExported"
+)
+
+const (
+	// CleanupFailedReason - leftover resources were found after the tests
+	// finished
+	CleanupFailedReason condition.Reason = "CleanupFailed"
+
+	// EndpointsUnreachableReason - at least one OpenStack endpoint is not
+	// reachable from the cluster
+	EndpointsUnreachableReason condition.Reason = "EndpointsUnreachable"
+
+	// WaitingForWindowReason - the test pod is not created until the
+	// disruption window opens
+	WaitingForWindowReason condition.Reason = "WaitingForWindow"
+
+	// ReportFailedReason - the report generation container failed
+	ReportFailedReason condition.Reason = "ReportFailed"
+
+	// PolarionExportFailedReason - the test results were not imported to
+	// Polarion
+	PolarionExportFailedReason condition.Reason = "PolarionExportFailed"
+)
+
+const (
+	// StarvedMessage
+	StarvedMessage = "Waiting for the %s lock for %s which exceeds the starvation threshold of %s"
+
+	// CleanupVerifiedInitMessage
+	CleanupVerifiedInitMessage = "Cleanup verification not started"
+
+	// CleanupVerifiedRunningMessage
+	CleanupVerifiedRunningMessage = "Cleanup verification in progress"
+
+	// CleanupVerifiedMessage
+	CleanupVerifiedMessage = "Cleanup verification passed, no leftover resources found"
+
+	// CleanupVerifiedErrorMessage
+	CleanupVerifiedErrorMessage = "Cleanup verification failed, leftover resources found. " +
+		"Check the logs of the %s pod for details"
+
+	// EndpointsReachableRunningMessage
+	EndpointsReachableRunningMessage = "Endpoint preflight checks in progress"
+
+	// EndpointsReachableMessage
+	EndpointsReachableMessage = "All OpenStack endpoints are reachable"
+
+	// EndpointsReachableErrorMessage
+	EndpointsReachableErrorMessage = "OpenStack endpoints not reachable: %s"
+
+	// EndpointsReachableUnknownMessage
+	EndpointsReachableUnknownMessage = "Reachability of the OpenStack endpoints is unknown. " +
+		"Check the logs of the %s pod for details: %s"
+
+	// EndpointsReachableFailedMessage
+	EndpointsReachableFailedMessage = "Endpoint preflight checks failed. " +
+		"Check the logs of the %s pod for details"
+
+	// ReportGeneratedRunningMessage
+	ReportGeneratedRunningMessage = "Report generation in progress"
+
+	// ReportGeneratedMessage
+	ReportGeneratedMessage = "Report generated"
+
+	// ReportGeneratedErrorMessage
+	ReportGeneratedErrorMessage = "Report generation failed. " +
+		"Check the logs of the %s pod for details"
+
+	// PolarionExportedRunningMessage
+	PolarionExportedRunningMessage = "Polarion export in progress"
+
+	// PolarionExportedMessage
+	PolarionExportedMessage = "Results imported to the Polarion test run %s (import jobs: %s)"
+
+	// PolarionExportedErrorMessage
+	PolarionExportedErrorMessage = "Polarion export failed: %s"
+
+	// DeploymentReadyWaitingForWindowMessage
+	DeploymentReadyWaitingForWindowMessage = "Waiting for the disruption window that opens at %s"
+)
@@ -0,0 +1,32 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// v1beta1 is the storage version of the test-operator CRDs. All other API
+// versions are converted to and from these types by the conversion webhook.
+
+// Hub marks this type as a conversion hub.
+func (*Tempest) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Tobiko) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*AnsibleTest) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*HorizonTest) Hub() {}
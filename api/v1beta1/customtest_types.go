@@ -0,0 +1,213 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CustomTestSpec defines the desired state of CustomTest
+type CustomTestSpec struct {
+	CommonOptions `json:",inline"`
+
+	CommonOpenstackConfig `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "2000m", memory: "2Gi"}, requests: {cpu: "1000m", memory: "1Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the CustomTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// By default test-operator executes the test-pods sequentially if multiple
+	// instances of test-operator related CRs exist. To run test-pods in parallel
+	// set this option to true.
+	Parallel bool `json:"parallel"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Entrypoint of the test container. The entrypoint of the container image
+	// is used when it is not set.
+	Command []string `json:"command,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Arguments of the entrypoint
+	Args []string `json:"args,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Working directory of the test container. The working directory of the
+	// container image is used when it is not set.
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Environment variables of the test container
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the cloud in the clouds.yaml the test container uses. It is
+	// passed to the test container as OS_CLOUD.
+	Cloud string `json:"cloud,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="/var/lib/test-operator/logs"
+	// +kubebuilder:validation:Pattern:=`^/`
+	// Path the logs PVC is mounted to. The test container is expected to
+	// store its logs in the directory passed to it as TEST_OPERATOR_LOGS_DIR.
+	LogsMountPath string `json:"logsMountPath"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern:=`^/`
+	// Path of the file the test container writes its result to, e.g.
+	// {"total": 10, "passed": 9, "failed": 1, "skipped": 0}. The file is used
+	// as the termination message of the test container and the reported
+	// numbers are stored in status.customTestResults. When it is not set,
+	// the result is read from /dev/termination-log.
+	ResultFile string `json:"resultFile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
+	// A parameter  that contains a workflow definition.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	Workflow []CustomTestWorkflowSpec `json:"workflow,omitempty"`
+}
+
+type CustomTestWorkflowSpec struct {
+	WorkflowCommonParameters `json:",inline"`
+
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the CustomTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Entrypoint of the test container
+	Command []string `json:"command,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Arguments of the entrypoint
+	Args []string `json:"args,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Working directory of the test container
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Environment variables of the test container. They are merged with the
+	// environment variables of the spec.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the cloud in the clouds.yaml the test container uses
+	Cloud string `json:"cloud,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern:=`^/`
+	// Path of the file the test container writes its result to
+	ResultFile string `json:"resultFile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// A parameter that contains a definition of a single workflow step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StepName string `json:"stepName"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// CustomTest is the Schema for the customtests API
+type CustomTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomTestSpec   `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CustomTestList contains a list of CustomTest
+type CustomTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CustomTest{}, &CustomTestList{})
+}
+
+// RbacConditionsSet - set the conditions for the rbac object
+func (instance CustomTest) RbacConditionsSet(c *condition.Condition) {
+	instance.Status.Conditions.Set(c)
+}
+
+// RbacNamespace - return the namespace
+func (instance CustomTest) RbacNamespace() string {
+	return instance.Namespace
+}
+
+// RbacResourceName - return the name to be used for rbac objects (serviceaccount, role, rolebinding)
+func (instance CustomTest) RbacResourceName() string {
+	return instance.Name
+}
+
+// GetCommonStatus - return the status of the instance
+func (instance *CustomTest) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps
+func (instance *CustomTest) GetStepNames() []string {
+	stepNames := []string{}
+	for _, step := range instance.Spec.Workflow {
+		stepNames = append(stepNames, step.StepName)
+	}
+
+	return stepNames
+}
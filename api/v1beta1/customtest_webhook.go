@@ -0,0 +1,229 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var customtestlog = logf.Log.WithName("customtest-resource")
+
+func (r *CustomTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-customtest,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=customtests,verbs=create;update,versions=v1beta1,name=mcustomtest.kb.io,admissionReviewVersions=v1
+
+var _ testWebhookObject = &CustomTest{}
+
+// setDefaults fills in the defaults of the CustomTest CR
+func (r *CustomTest) setDefaults(ctx context.Context, reader client.Reader) {
+	customtestlog.Info("default", "name", r.Name)
+
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "CustomTest", &r.Spec.CommonOptions)
+}
+
+//+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-customtest,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=customtests,verbs=create;update,versions=v1beta1,name=vcustomtest.kb.io,admissionReviewVersions=v1
+
+// validateCreate checks the CustomTest CR on create
+func (r *CustomTest) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
+	customtestlog.Info("validate create", "name", r.Name)
+
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the CustomTest CR on both create and update. The old object is
+// nil on create.
+func (r *CustomTest) validate(ctx context.Context, reader client.Reader, old *CustomTest) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var allWarnings admission.Warnings
+
+	if r.Spec.Privileged {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOn, "CustomTest"))
+	}
+
+	privileged := r.Spec.Privileged
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("openStackConfigSecret"), r.Spec.OpenStackConfigSecret},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+	secretRefs = append(secretRefs, getEnvSecretReferences(field.NewPath("spec").Child("env"), r.Spec.Env)...)
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+
+		secretRefs = append(secretRefs, getEnvSecretReferences(stepPath.Child("env"), step.Env)...)
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		overrideSpec := CustomTestSpec{}
+		err := validateSpecOverride(stepPath.Child("specOverride"), "CustomTest", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else if overrideSpec.Privileged {
+			privileged = true
+		}
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("CustomTest", stepNames)...)
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "CustomTest", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "CustomTest", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "CustomTest"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "CustomTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "CustomTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "CustomTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return allWarnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: GroupVersion.WithKind("CustomTest").Group,
+				Kind:  GroupVersion.WithKind("CustomTest").Kind,
+			}, r.GetName(), allErrs)
+	}
+
+	if r.Spec.Privileged && len(r.Spec.Workflow) > 0 && len(r.Spec.SELinuxLevel) == 0 {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnSELinuxLevel, r.Kind))
+	}
+
+	return allWarnings, nil
+}
+
+// validateUpdate checks the CustomTest CR on update
+func (r *CustomTest) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
+	customtestlog.Info("validate update", "name", r.Name)
+
+	oldCustomTest, ok := old.(*CustomTest)
+	if !ok || oldCustomTest == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldCustomTest)
+}
+
+// validateDelete checks the CustomTest CR on delete
+func (r *CustomTest) validateDelete() (admission.Warnings, error) {
+	customtestlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+// simulatedSteps returns the test pods that would be spawned for the CustomTest CR
+func (r *CustomTest) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
+
+// getEnvSecretReferences returns the references to the secrets the values of
+// the environment variables are read from
+func getEnvSecretReferences(path *field.Path, envVars []corev1.EnvVar) []secretReference {
+	secretRefs := []secretReference{}
+	for idx, envVar := range envVars {
+		if envVar.ValueFrom == nil || envVar.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+
+		secretRefs = append(secretRefs, secretReference{
+			path.Index(idx).Child("valueFrom", "secretKeyRef", "name"), envVar.ValueFrom.SecretKeyRef.Name})
+	}
+
+	return secretRefs
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FeatureGatesAnnotation allows a single test instance to override the
+	// feature gates configured for the operator. The value has the same
+	// format as the --feature-gates flag of the operator, e.g.
+	// "FailureSnapshots=false,WorkflowSpecOverride=true".
+	FeatureGatesAnnotation = "test.openstack.org/feature-gates"
+)
+
+const (
+	// FeatureGateFailureSnapshots - capture the events and the node
+	// conditions when a test pod fails (status.failureSnapshots)
+	FeatureGateFailureSnapshots = "FailureSnapshots"
+
+	// FeatureGateTestSummary - publish the TestSummaryAnnotation
+	FeatureGateTestSummary = "TestSummary"
+
+	// FeatureGateWorkflowSpecOverride - merge the specOverride of a workflow
+	// step over the spec of the instance
+	FeatureGateWorkflowSpecOverride = "WorkflowSpecOverride"
+
+	// FeatureGatePodTemplateOverrides - apply spec.podTemplateOverrides to
+	// the test pods
+	FeatureGatePodTemplateOverrides = "PodTemplateOverrides"
+
+	// FeatureGateResourceAdoption - adopt the pods and the PVCs of a run that
+	// are owned by a previous incarnation of the instance (e.g. after a
+	// restore from a backup)
+	FeatureGateResourceAdoption = "ResourceAdoption"
+
+	// FeatureGateTimeline - record the test pods and the waits for the
+	// test-operator-lock in status.timeline
+	FeatureGateTimeline = "Timeline"
+
+	// FeatureGateTestResults - store the TestResults of a finished run in a
+	// ConfigMap owned by the instance
+	FeatureGateTestResults = "TestResults"
+)
+
+const (
+	// ErrUnknownFeatureGate
+	ErrUnknownFeatureGate = "unknown feature gate %s, known feature gates: %s"
+
+	// ErrInvalidFeatureGate
+	ErrInvalidFeatureGate = "invalid feature gate %q, expected <name>=<true|false>"
+)
+
+// DefaultFeatureGates contains all known feature gates and their default
+// state. The gated behaviors are enabled by default and a gate only lets the
+// operator or a single instance (see FeatureGatesAnnotation) switch its
+// behavior off. The behaviors that are not listed here can not be switched
+// off, i.e. a CR can not opt out of the behavior that predates the feature
+// gates.
+var DefaultFeatureGates = map[string]bool{
+	FeatureGateFailureSnapshots:     true,
+	FeatureGateTestSummary:          true,
+	FeatureGateWorkflowSpecOverride: true,
+	FeatureGatePodTemplateOverrides: true,
+	FeatureGateResourceAdoption:     true,
+	FeatureGateTimeline:             true,
+	FeatureGateTestResults:          true,
+}
+
+// ParseFeatureGates parses a comma separated list of <name>=<true|false>
+// pairs. Only the feature gates listed in DefaultFeatureGates are accepted.
+func ParseFeatureGates(value string) (map[string]bool, error) {
+	featureGates := map[string]bool{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, enabled, found := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !found {
+			return nil, fmt.Errorf(ErrInvalidFeatureGate, pair)
+		}
+
+		if _, ok := DefaultFeatureGates[name]; !ok {
+			return nil, fmt.Errorf(ErrUnknownFeatureGate, name, strings.Join(knownFeatureGates(), ", "))
+		}
+
+		parsedEnabled, err := strconv.ParseBool(strings.TrimSpace(enabled))
+		if err != nil {
+			return nil, fmt.Errorf(ErrInvalidFeatureGate, pair)
+		}
+
+		featureGates[name] = parsedEnabled
+	}
+
+	return featureGates, nil
+}
+
+func knownFeatureGates() []string {
+	names := make([]string, 0, len(DefaultFeatureGates))
+	for name := range DefaultFeatureGates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FioTarget - storage the fio profiles are executed against
+// +kubebuilder:validation:Enum:=pvc;cinder
+type FioTarget string
+
+const (
+	// FioTargetPVC - the fio profiles are executed against a PVC provisioned
+	// from the target storage class and mounted to the test pod
+	FioTargetPVC FioTarget = "pvc"
+
+	// FioTargetCinder - the fio profiles are executed against a cinder volume
+	// attached to a test VM created by the test pod
+	FioTargetCinder FioTarget = "cinder"
+)
+
+// FioPVCTarget - PVC the fio profiles are executed against
+type FioPVCTarget struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Storage class the PVC is provisioned from. The storage class of the
+	// logs PVC (storageClass) is used when it is not set.
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="10Gi"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Size of the PVC
+	Size resource.Quantity `json:"size,omitempty"`
+}
+
+// FioCinderTarget - cinder volume the fio profiles are executed against
+type FioCinderTarget struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Volume type of the cinder volume. The default volume type is used when
+	// it is not set.
+	VolumeType string `json:"volumeType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum:=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Size of the cinder volume in GiB
+	Size int32 `json:"size,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the glance image the test VM is booted from. The image has to
+	// provide fio.
+	Image string `json:"image"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the flavor the test VM is booted with
+	Flavor string `json:"flavor"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the network the test VM is attached to. The test pod has to be
+	// able to reach the test VM via SSH on this network.
+	Network string `json:"network"`
+}
+
+// FioProfile - a fio job that is executed against the target
+// +kubebuilder:validation:XValidation:rule="!has(self.rwMixRead) || self.readWrite in ['rw', 'randrw']",message="rwMixRead can be used only with the rw and randrw readWrite modes"
+type FioProfile struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern:=`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`
+	// Name of the profile
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="randrw"
+	// +kubebuilder:validation:Enum:=read;write;randread;randwrite;rw;randrw
+	// Type of the I/O pattern (rw)
+	ReadWrite string `json:"readWrite,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="4k"
+	// Block size of the I/O operations (bs)
+	BlockSize string `json:"blockSize,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=16
+	// +kubebuilder:validation:Minimum:=1
+	// Number of I/O operations in flight (iodepth)
+	IODepth int32 `json:"ioDepth,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	// Number of jobs running the profile in parallel (numjobs)
+	NumJobs int32 `json:"numJobs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="1G"
+	// Size of the file each job performs I/O on (size)
+	Size string `json:"size,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="60s"
+	// How long the profile runs (runtime)
+	Runtime metav1.Duration `json:"runtime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// Percentage of the read operations of a mixed workload (rwmixread)
+	RWMixRead *int32 `json:"rwMixRead,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	// Use non-buffered I/O (direct)
+	Direct bool `json:"direct"`
+}
+
+// FioTestSpec defines the desired state of FioTest
+type FioTestSpec struct {
+	CommonOptions `json:",inline"`
+
+	CommonOpenstackConfig `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "2000m", memory: "2Gi"}, requests: {cpu: "1000m", memory: "1Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the FioTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Activate debug mode. When debug mode is activated any error encountered
+	// inside the test-pod causes that the pod will be kept alive indefinitely
+	// (stuck in "Running" phase) or until the corresponding FioTest CR is deleted.
+	// This allows the user to debug any potential troubles with `oc rsh`.
+	Debug bool `json:"debug"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// By default test-operator executes the test-pods sequentially if multiple
+	// instances of test-operator related CRs exist. To run test-pods in parallel
+	// set this option to true.
+	Parallel bool `json:"parallel"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="pvc"
+	// Storage the fio profiles are executed against. The pvc target
+	// provisions a PVC from pvc.storageClass, the cinder target attaches a
+	// cinder volume to a test VM.
+	Target FioTarget `json:"target"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// PVC the fio profiles are executed against when target is pvc
+	PVC *FioPVCTarget `json:"pvc,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Cinder volume the fio profiles are executed against when target is
+	// cinder. It is required by the cinder target.
+	Cinder *FioCinderTarget `json:"cinder,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="default"
+	// Name of the cloud in the clouds.yaml the test VM is created in
+	Cloud string `json:"cloud"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=20
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.name == x.name))",message="profile names must be unique"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Fio profiles that are executed one after another. The IOPS, bandwidth
+	// and latency measured by each profile are reported in status.fioResults.
+	Profiles []FioProfile `json:"profiles"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
+	// A parameter  that contains a workflow definition.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	Workflow []FioTestWorkflowSpec `json:"workflow,omitempty"`
+}
+
+type FioTestWorkflowSpec struct {
+	WorkflowCommonParameters `json:",inline"`
+
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the FioTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Storage the fio profiles are executed against
+	Target FioTarget `json:"target,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// PVC the fio profiles are executed against when target is pvc
+	PVC *FioPVCTarget `json:"pvc,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Cinder volume the fio profiles are executed against when target is
+	// cinder
+	Cinder *FioCinderTarget `json:"cinder,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the cloud in the clouds.yaml the test VM is created in
+	Cloud string `json:"cloud,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems:=20
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.name == x.name))",message="profile names must be unique"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Fio profiles that are executed one after another
+	Profiles []FioProfile `json:"profiles,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// A parameter that contains a definition of a single workflow step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StepName string `json:"stepName"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// FioTest is the Schema for the fiotests API
+type FioTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FioTestSpec      `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FioTestList contains a list of FioTest
+type FioTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FioTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FioTest{}, &FioTestList{})
+}
+
+// RbacConditionsSet - set the conditions for the rbac object
+func (instance FioTest) RbacConditionsSet(c *condition.Condition) {
+	instance.Status.Conditions.Set(c)
+}
+
+// RbacNamespace - return the namespace
+func (instance FioTest) RbacNamespace() string {
+	return instance.Namespace
+}
+
+// RbacResourceName - return the name to be used for rbac objects (serviceaccount, role, rolebinding)
+func (instance FioTest) RbacResourceName() string {
+	return instance.Name
+}
+
+// GetCommonStatus - return the status of the instance
+func (instance *FioTest) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps
+func (instance *FioTest) GetStepNames() []string {
+	stepNames := []string{}
+	for _, step := range instance.Spec.Workflow {
+		stepNames = append(stepNames, step.StepName)
+	}
+
+	return stepNames
+}
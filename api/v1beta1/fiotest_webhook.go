@@ -0,0 +1,235 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// ErrFioCinderRequired
+	ErrFioCinderRequired = "cinder must be specified when the cinder target is used"
+)
+
+// log is for logging in this package.
+var fiotestlog = logf.Log.WithName("fiotest-resource")
+
+func (r *FioTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-fiotest,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=fiotests,verbs=create;update,versions=v1beta1,name=mfiotest.kb.io,admissionReviewVersions=v1
+
+var _ testWebhookObject = &FioTest{}
+
+// setDefaults fills in the defaults of the FioTest CR
+func (r *FioTest) setDefaults(ctx context.Context, reader client.Reader) {
+	fiotestlog.Info("default", "name", r.Name)
+
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "FioTest", &r.Spec.CommonOptions)
+}
+
+//+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-fiotest,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=fiotests,verbs=create;update,versions=v1beta1,name=vfiotest.kb.io,admissionReviewVersions=v1
+
+// validateCreate checks the FioTest CR on create
+func (r *FioTest) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
+	fiotestlog.Info("validate create", "name", r.Name)
+
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the FioTest CR on both create and update. The old object is
+// nil on create.
+func (r *FioTest) validate(ctx context.Context, reader client.Reader, old *FioTest) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var allWarnings admission.Warnings
+
+	if len(r.Spec.Workflow) > 0 && r.Spec.Debug {
+		allErrs = append(allErrs, &field.Error{
+			Type:     field.ErrorTypeForbidden,
+			BadValue: r.Spec.Workflow,
+			Detail:   fmt.Sprintf(ErrDebug, "FioTest"),
+		})
+	}
+
+	if r.Spec.Privileged {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOn, "FioTest"))
+	}
+
+	privileged := r.Spec.Privileged
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("openStackConfigSecret"), r.Spec.OpenStackConfigSecret},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+
+		target := r.Spec.Target
+		if len(step.Target) > 0 {
+			target = step.Target
+		}
+
+		if target == FioTargetCinder && step.Cinder == nil && r.Spec.Cinder == nil {
+			allErrs = append(allErrs, field.Required(stepPath.Child("cinder"), ErrFioCinderRequired))
+		}
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		overrideSpec := FioTestSpec{}
+		err := validateSpecOverride(stepPath.Child("specOverride"), "FioTest", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else if overrideSpec.Privileged {
+			privileged = true
+		}
+	}
+
+	if len(r.Spec.Workflow) == 0 && r.Spec.Target == FioTargetCinder && r.Spec.Cinder == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("cinder"), ErrFioCinderRequired))
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("FioTest", stepNames)...)
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "FioTest", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "FioTest", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "FioTest"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "FioTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "FioTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "FioTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return allWarnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: GroupVersion.WithKind("FioTest").Group,
+				Kind:  GroupVersion.WithKind("FioTest").Kind,
+			}, r.GetName(), allErrs)
+	}
+
+	if r.Spec.Privileged && len(r.Spec.Workflow) > 0 && len(r.Spec.SELinuxLevel) == 0 {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnSELinuxLevel, r.Kind))
+	}
+
+	return allWarnings, nil
+}
+
+// validateUpdate checks the FioTest CR on update
+func (r *FioTest) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
+	fiotestlog.Info("validate update", "name", r.Name)
+
+	oldFioTest, ok := old.(*FioTest)
+	if !ok || oldFioTest == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldFioTest)
+}
+
+// validateDelete checks the FioTest CR on delete
+func (r *FioTest) validateDelete() (admission.Warnings, error) {
+	fiotestlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+// simulatedSteps returns the test pods that would be spawned for the FioTest CR
+func (r *FioTest) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
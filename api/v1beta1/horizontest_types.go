@@ -25,6 +25,17 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// HorizonTestBrowser - browser used to execute the Horizon tests
+type HorizonTestBrowser string
+
+const (
+	// HorizonTestBrowserChrome - the tests are executed in Chrome
+	HorizonTestBrowserChrome HorizonTestBrowser = "chrome"
+
+	// HorizonTestBrowserFirefox - the tests are executed in Firefox
+	HorizonTestBrowserFirefox HorizonTestBrowser = "firefox"
+)
+
 // HorizonTestSpec defines the desired state of HorizonTest
 type HorizonTestSpec struct {
 	CommonOptions `json:",inline"`
@@ -136,6 +147,59 @@ type HorizonTestSpec struct {
 	// Parallel
 	Parallel bool `json:"parallel"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// VideoRecording - when set to true, the browser sessions of the tests
+	// are recorded. The recordings are stored in the videos directory of the
+	// logs directory of the test pod so that failed UI tests can be reviewed
+	// without executing them once more.
+	VideoRecording bool `json:"videoRecording,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// FailureScreenshots - when set to true, a screenshot and the page source
+	// are captured when a test fails. The files are stored in the screenshots
+	// directory of the logs directory of the test pod and listed in
+	// status.steps[].failureCaptures.
+	FailureScreenshots bool `json:"failureScreenshots,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MaxItems:=2
+	// +kubebuilder:validation:items:Enum:=chrome;firefox
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y == x))",message="browsers must be unique"
+	// Browsers - the tests are executed once for each browser by a separate
+	// test pod. The result of each browser is reported in status.steps under
+	// the name of the browser. When empty, a single test pod uses the
+	// default browser of the container image.
+	Browsers []HorizonTestBrowser `json:"browsers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// ParallelBrowsers - when set to true, the test pods of all the browsers
+	// run at the same time, each one with its own logs PVC. Otherwise the
+	// browsers are tested one after another.
+	ParallelBrowsers bool `json:"parallelBrowsers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern:=`^https?://[^\s]+$`
+	// SeleniumGridURL - URL of an existing Selenium Grid (or Moon)
+	// deployment, e.g. http://selenium-hub:4444/wd/hub. When set, the tests
+	// drive the browsers of the grid instead of a browser started in the test
+	// pod.
+	SeleniumGridURL string `json:"seleniumGridUrl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// SeleniumGridCredentialsSecretName - name of the k8s secret with the
+	// credentials (username and password keys) used to access the Selenium
+	// Grid
+	SeleniumGridCredentialsSecretName string `json:"seleniumGridCredentialsSecretName,omitempty"`
+
 	// Name of a secret that contains a kubeconfig. The kubeconfig is mounted under /var/lib/horizontest/.kube/config
 	// in the test pod.
 	// +kubebuilder:validation:Optional
@@ -145,7 +209,9 @@ type HorizonTestSpec struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
 
 type HorizonTest struct {
@@ -183,3 +249,18 @@ func (instance HorizonTest) RbacNamespace() string {
 func (instance HorizonTest) RbacResourceName() string {
 	return instance.Name
 }
+
+// GetCommonStatus - return the status of the instance
+func (instance *HorizonTest) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps, i.e. the browsers
+func (instance *HorizonTest) GetStepNames() []string {
+	stepNames := []string{}
+	for _, browser := range instance.Spec.Browsers {
+		stepNames = append(stepNames, string(browser))
+	}
+
+	return stepNames
+}
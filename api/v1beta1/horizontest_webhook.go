@@ -23,12 +23,17 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -36,52 +41,146 @@ import (
 var horizontestlog = logf.Log.WithName("horizontest-resource")
 
 func (r *HorizonTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-horizontest,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=horizontests,verbs=create;update,versions=v1beta1,name=mhorizontest.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Defaulter = &HorizonTest{}
+var _ testWebhookObject = &HorizonTest{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type
-func (r *HorizonTest) Default() {
+// setDefaults fills in the defaults of the HorizonTest CR
+func (r *HorizonTest) setDefaults(ctx context.Context, reader client.Reader) {
 	horizontestlog.Info("default", "name", r.Name)
 
-	// TODO(user): fill in your defaulting logic.
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "HorizonTest", &r.Spec.CommonOptions)
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-horizontest,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=horizontests,verbs=create;update,versions=v1beta1,name=vhorizontest.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Validator = &HorizonTest{}
-
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (r *HorizonTest) ValidateCreate() (admission.Warnings, error) {
+// validateCreate checks the HorizonTest CR on create
+func (r *HorizonTest) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
 	horizontestlog.Info("validate create", "name", r.Name)
 
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the HorizonTest CR on both create and update. The old object is
+// nil on create.
+func (r *HorizonTest) validate(ctx context.Context, reader client.Reader, old *HorizonTest) (admission.Warnings, error) {
+	var allErrs field.ErrorList
 	var allWarnings admission.Warnings
 
 	if r.Spec.Privileged {
 		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOn, "HorizonTest"))
 	}
 
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "HorizonTest", r.Spec.ContainerImage, nil); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "HorizonTest", r.Spec.Privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("kubeconfigSecretName"), r.Spec.KubeconfigSecretName},
+		{
+			field.NewPath("spec").Child("seleniumGridCredentialsSecretName"),
+			r.Spec.SeleniumGridCredentialsSecretName,
+		},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "HorizonTest"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "HorizonTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validateNoNetworkOptions(field.NewPath("spec"), "HorizonTest", r.Spec.CommonOptions)...)
+
+	if err := validateSecurityContext(field.NewPath("spec"), "HorizonTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "HorizonTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return allWarnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: GroupVersion.WithKind("HorizonTest").Group,
+				Kind:  GroupVersion.WithKind("HorizonTest").Kind,
+			}, r.GetName(), allErrs)
+	}
+
 	return allWarnings, nil
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *HorizonTest) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+// validateUpdate checks the HorizonTest CR on update
+func (r *HorizonTest) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
 	horizontestlog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	oldHorizonTest, ok := old.(*HorizonTest)
+	if !ok || oldHorizonTest == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldHorizonTest)
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *HorizonTest) ValidateDelete() (admission.Warnings, error) {
+// validateDelete checks the HorizonTest CR on delete
+func (r *HorizonTest) validateDelete() (admission.Warnings, error) {
 	horizontestlog.Info("validate delete", "name", r.Name)
 
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil, nil
 }
+
+// simulatedSteps returns the test pods that would be spawned for the HorizonTest CR
+func (r *HorizonTest) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Browsers) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, browser := range r.Spec.Browsers {
+		steps = append(steps, simulatedStep{Name: string(browser), ContainerImage: r.Spec.ContainerImage})
+	}
+
+	return steps
+}
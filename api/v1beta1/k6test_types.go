@@ -0,0 +1,280 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// K6ScriptConfigMap - config map that contains the k6 script
+type K6ScriptConfigMap struct {
+	// +kubebuilder:validation:Required
+	// Name of the config map that contains the script
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="script.js"
+	// Key of the config map with the content of the script
+	Key string `json:"key,omitempty"`
+}
+
+// K6Stage - a stage of the load ramping the number of virtual users
+type K6Stage struct {
+	// +kubebuilder:validation:Required
+	// Duration of the stage, e.g. 30s
+	Duration metav1.Duration `json:"duration"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	// Number of virtual users at the end of the stage
+	Target int32 `json:"target"`
+}
+
+// K6Threshold - pass/fail criteria of a k6 metric. The test pod fails when a
+// threshold is crossed.
+type K6Threshold struct {
+	// +kubebuilder:validation:Required
+	// Name of the metric, e.g. http_req_duration or
+	// http_req_duration{expected_response:true}
+	Metric string `json:"metric"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems:=1
+	// Threshold expressions of the metric, e.g. p(95)<500
+	Conditions []string `json:"conditions"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	// Abort the test as soon as the threshold is crossed
+	AbortOnFail bool `json:"abortOnFail"`
+}
+
+// K6TestSpec defines the desired state of K6Test
+type K6TestSpec struct {
+	CommonOptions `json:",inline"`
+
+	CommonOpenstackConfig `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "2000m", memory: "2Gi"}, requests: {cpu: "1000m", memory: "1Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the K6Test CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Activate debug mode. When debug mode is activated any error encountered
+	// inside the test-pod causes that the pod will be kept alive indefinitely
+	// (stuck in "Running" phase) or until the corresponding K6Test CR is deleted.
+	// This allows the user to debug any potential troubles with `oc rsh`.
+	Debug bool `json:"debug"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// By default test-operator executes the test-pods sequentially if multiple
+	// instances of test-operator related CRs exist. To run test-pods in parallel
+	// set this option to true.
+	Parallel bool `json:"parallel"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Config map that contains the k6 script. It has to be specified either
+	// here or for every workflow step.
+	ScriptConfigMap *K6ScriptConfigMap `json:"scriptConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="default"
+	// Name of the cloud in the clouds.yaml the endpoints of targetServices
+	// are looked up in
+	Cloud string `json:"cloud"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Types of the services (e.g. compute, identity) whose public endpoints
+	// from the service catalog are passed to the script
+	TargetServices []string `json:"targetServices,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// URLs that are passed to the script in addition to the endpoints of
+	// targetServices
+	TargetURLs []string `json:"targetURLs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum:=1
+	// Number of virtual users. It is ignored when stages are specified.
+	VUs int32 `json:"vus"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="1m"
+	// How long the test runs. It is ignored when stages are specified.
+	Duration metav1.Duration `json:"duration"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MaxItems:=20
+	// Stages that ramp the number of virtual users up and down
+	Stages []K6Stage `json:"stages,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Thresholds of the metrics. The test pod, and so the K6Test, fails when
+	// a threshold is crossed. The result is reported in
+	// status.k6ThresholdResults.
+	Thresholds []K6Threshold `json:"thresholds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
+	// A parameter  that contains a workflow definition.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	Workflow []K6TestWorkflowSpec `json:"workflow,omitempty"`
+}
+
+type K6TestWorkflowSpec struct {
+	WorkflowCommonParameters `json:",inline"`
+
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the K6Test CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Config map that contains the k6 script
+	ScriptConfigMap *K6ScriptConfigMap `json:"scriptConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the cloud in the clouds.yaml the endpoints of targetServices
+	// are looked up in
+	Cloud string `json:"cloud,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Types of the services whose public endpoints are passed to the script
+	TargetServices []string `json:"targetServices,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// URLs that are passed to the script
+	TargetURLs []string `json:"targetURLs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum:=1
+	// Number of virtual users
+	VUs *int32 `json:"vus,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// How long the test runs
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MaxItems:=20
+	// Stages that ramp the number of virtual users up and down
+	Stages []K6Stage `json:"stages,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Thresholds of the metrics
+	Thresholds []K6Threshold `json:"thresholds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// A parameter that contains a definition of a single workflow step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StepName string `json:"stepName"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// K6Test is the Schema for the k6tests API
+type K6Test struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   K6TestSpec       `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// K6TestList contains a list of K6Test
+type K6TestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []K6Test `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&K6Test{}, &K6TestList{})
+}
+
+// RbacConditionsSet - set the conditions for the rbac object
+func (instance K6Test) RbacConditionsSet(c *condition.Condition) {
+	instance.Status.Conditions.Set(c)
+}
+
+// RbacNamespace - return the namespace
+func (instance K6Test) RbacNamespace() string {
+	return instance.Namespace
+}
+
+// RbacResourceName - return the name to be used for rbac objects (serviceaccount, role, rolebinding)
+func (instance K6Test) RbacResourceName() string {
+	return instance.Name
+}
+
+// GetCommonStatus - return the status of the instance
+func (instance *K6Test) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps
+func (instance *K6Test) GetStepNames() []string {
+	stepNames := []string{}
+	for _, step := range instance.Spec.Workflow {
+		stepNames = append(stepNames, step.StepName)
+	}
+
+	return stepNames
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// ErrK6ScriptRequired
+	ErrK6ScriptRequired = "scriptConfigMap must be specified either in the spec or for every workflow step"
+)
+
+// log is for logging in this package.
+var k6testlog = logf.Log.WithName("k6test-resource")
+
+func (r *K6Test) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-k6test,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=k6tests,verbs=create;update,versions=v1beta1,name=mk6test.kb.io,admissionReviewVersions=v1
+
+var _ testWebhookObject = &K6Test{}
+
+// setDefaults fills in the defaults of the K6Test CR
+func (r *K6Test) setDefaults(ctx context.Context, reader client.Reader) {
+	k6testlog.Info("default", "name", r.Name)
+
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "K6Test", &r.Spec.CommonOptions)
+}
+
+//+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-k6test,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=k6tests,verbs=create;update,versions=v1beta1,name=vk6test.kb.io,admissionReviewVersions=v1
+
+// validateCreate checks the K6Test CR on create
+func (r *K6Test) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
+	k6testlog.Info("validate create", "name", r.Name)
+
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the K6Test CR on both create and update. The old object is
+// nil on create.
+func (r *K6Test) validate(ctx context.Context, reader client.Reader, old *K6Test) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var allWarnings admission.Warnings
+
+	if len(r.Spec.Workflow) > 0 && r.Spec.Debug {
+		allErrs = append(allErrs, &field.Error{
+			Type:     field.ErrorTypeForbidden,
+			BadValue: r.Spec.Workflow,
+			Detail:   fmt.Sprintf(ErrDebug, "K6Test"),
+		})
+	}
+
+	if r.Spec.Privileged {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOn, "K6Test"))
+	}
+
+	privileged := r.Spec.Privileged
+	scriptRequired := len(r.Spec.Workflow) == 0
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("openStackConfigSecret"), r.Spec.OpenStackConfigSecret},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+
+		if step.ScriptConfigMap == nil {
+			scriptRequired = true
+		}
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		overrideSpec := K6TestSpec{}
+		err := validateSpecOverride(stepPath.Child("specOverride"), "K6Test", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else if overrideSpec.Privileged {
+			privileged = true
+		}
+	}
+
+	if scriptRequired && r.Spec.ScriptConfigMap == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("scriptConfigMap"), ErrK6ScriptRequired))
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("K6Test", stepNames)...)
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "K6Test", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "K6Test", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "K6Test"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "K6Test", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "K6Test", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "K6Test", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return allWarnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: GroupVersion.WithKind("K6Test").Group,
+				Kind:  GroupVersion.WithKind("K6Test").Kind,
+			}, r.GetName(), allErrs)
+	}
+
+	if r.Spec.Privileged && len(r.Spec.Workflow) > 0 && len(r.Spec.SELinuxLevel) == 0 {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnSELinuxLevel, r.Kind))
+	}
+
+	return allWarnings, nil
+}
+
+// validateUpdate checks the K6Test CR on update
+func (r *K6Test) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
+	k6testlog.Info("validate update", "name", r.Name)
+
+	oldK6Test, ok := old.(*K6Test)
+	if !ok || oldK6Test == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldK6Test)
+}
+
+// validateDelete checks the K6Test CR on delete
+func (r *K6Test) validateDelete() (admission.Warnings, error) {
+	k6testlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+// simulatedSteps returns the test pods that would be spawned for the K6Test CR
+func (r *K6Test) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// ErrOfflineModeRemoteLocation
+	ErrOfflineModeRemoteLocation = "can not be fetched from the network while offlineMode is enabled, " +
+		"use a local path (e.g. file:///var/lib/ansible-offline-bundle/repo)"
+
+	// ErrOfflineModeBundleRequired
+	ErrOfflineModeBundleRequired = "can not be installed without spec.offlineBundle while offlineMode is enabled"
+)
+
+// ValidateOfflineMode returns an error for each source that the test pod
+// spawned for the spec would have to fetch from the network while
+// offlineMode is enabled. The workflow of the spec is not validated.
+func ValidateOfflineMode(path *field.Path, spec AnsibleTestSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if !spec.OfflineMode {
+		return allErrs
+	}
+
+	if isRemoteLocation(spec.AnsibleGitRepo) {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("ansibleGitRepo"), spec.AnsibleGitRepo, ErrOfflineModeRemoteLocation))
+	}
+
+	if spec.AnsibleCollections != "" && spec.OfflineBundle == nil {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("ansibleCollections"), spec.AnsibleCollections, ErrOfflineModeBundleRequired))
+	}
+
+	if spec.AnsibleGalaxyRequirements != nil && isRemoteLocation(spec.AnsibleGalaxyRequirements.Server) {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("ansibleGalaxyRequirements", "server"), spec.AnsibleGalaxyRequirements.Server,
+			ErrOfflineModeRemoteLocation))
+	}
+
+	return allErrs
+}
+
+// isRemoteLocation returns true when the location is a URL (other than
+// file://) or an scp-like git location (e.g. git@github.com:org/repo)
+func isRemoteLocation(location string) bool {
+	if location == "" || strings.HasPrefix(location, "/") || strings.HasPrefix(location, "file://") {
+		return false
+	}
+
+	return strings.Contains(location, "://") || strings.Contains(location, "@")
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RallyTaskConfigMap - config map that contains the rally task file
+type RallyTaskConfigMap struct {
+	// +kubebuilder:validation:Required
+	// Name of the config map that contains the task file
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="task.yaml"
+	// Key of the config map with the content of the task file
+	Key string `json:"key,omitempty"`
+}
+
+// RallySLA - SLA thresholds that are applied to every workload of the rally
+// task. The thresholds override the sla section of the workloads defined in
+// the task file.
+type RallySLA struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Maximum percentage of the failed iterations of a workload
+	// (failure_rate)
+	MaxFailureRate *int32 `json:"maxFailureRate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Maximum average duration of the iterations of a workload
+	// (max_avg_duration), e.g. 30s
+	MaxAvgDuration *metav1.Duration `json:"maxAvgDuration,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Maximum duration of a single iteration of a workload
+	// (max_seconds_per_iteration), e.g. 1m
+	MaxIterationDuration *metav1.Duration `json:"maxIterationDuration,omitempty"`
+}
+
+// RallyTestSpec defines the desired state of RallyTest
+type RallyTestSpec struct {
+	CommonOptions `json:",inline"`
+
+	CommonOpenstackConfig `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "4000m", memory: "4Gi"}, requests: {cpu: "2000m", memory: "2Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the RallyTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Activate debug mode. When debug mode is activated any error encountered
+	// inside the test-pod causes that the pod will be kept alive indefinitely
+	// (stuck in "Running" phase) or until the corresponding RallyTest CR is deleted.
+	// This allows the user to debug any potential troubles with `oc rsh`.
+	Debug bool `json:"debug"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// By default test-operator executes the test-pods sequentially if multiple
+	// instances of test-operator related CRs exist. To run test-pods in parallel
+	// set this option to true.
+	Parallel bool `json:"parallel"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="default"
+	// Name of the cloud in the clouds.yaml the rally deployment is created
+	// from
+	Cloud string `json:"cloud"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Config map that contains the rally task file (JSON or YAML). It has to
+	// be specified either here or for every workflow step.
+	TaskConfigMap *RallyTaskConfigMap `json:"taskConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Arguments that are used to render the task file (passed to rally as
+	// --task-args), e.g. '{"times": 10, "concurrency": 2}'
+	TaskArgs string `json:"taskArgs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// SLA thresholds that are applied to every workload of the task. The
+	// result of the SLA check is reported in status.rallySLAResults.
+	SLA *RallySLA `json:"sla,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
+	// A parameter  that contains a workflow definition.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	Workflow []RallyTestWorkflowSpec `json:"workflow,omitempty"`
+}
+
+type RallyTestWorkflowSpec struct {
+	WorkflowCommonParameters `json:",inline"`
+
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the RallyTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the cloud in the clouds.yaml the rally deployment is created
+	// from
+	Cloud string `json:"cloud,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Config map that contains the rally task file (JSON or YAML)
+	TaskConfigMap *RallyTaskConfigMap `json:"taskConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Arguments that are used to render the task file (passed to rally as
+	// --task-args)
+	TaskArgs string `json:"taskArgs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// SLA thresholds that are applied to every workload of the task
+	SLA *RallySLA `json:"sla,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// A parameter that contains a definition of a single workflow step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StepName string `json:"stepName"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// RallyTest is the Schema for the rallytests API
+type RallyTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RallyTestSpec    `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RallyTestList contains a list of RallyTest
+type RallyTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RallyTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RallyTest{}, &RallyTestList{})
+}
+
+// RbacConditionsSet - set the conditions for the rbac object
+func (instance RallyTest) RbacConditionsSet(c *condition.Condition) {
+	instance.Status.Conditions.Set(c)
+}
+
+// RbacNamespace - return the namespace
+func (instance RallyTest) RbacNamespace() string {
+	return instance.Namespace
+}
+
+// RbacResourceName - return the name to be used for rbac objects (serviceaccount, role, rolebinding)
+func (instance RallyTest) RbacResourceName() string {
+	return instance.Name
+}
+
+// GetCommonStatus - return the status of the instance
+func (instance *RallyTest) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps
+func (instance *RallyTest) GetStepNames() []string {
+	stepNames := []string{}
+	for _, step := range instance.Spec.Workflow {
+		stepNames = append(stepNames, step.StepName)
+	}
+
+	return stepNames
+}
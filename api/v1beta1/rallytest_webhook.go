@@ -0,0 +1,231 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// ErrRallyTaskRequired
+	ErrRallyTaskRequired = "taskConfigMap must be specified either in the spec or for every workflow step"
+)
+
+// log is for logging in this package.
+var rallytestlog = logf.Log.WithName("rallytest-resource")
+
+func (r *RallyTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-rallytest,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=rallytests,verbs=create;update,versions=v1beta1,name=mrallytest.kb.io,admissionReviewVersions=v1
+
+var _ testWebhookObject = &RallyTest{}
+
+// setDefaults fills in the defaults of the RallyTest CR
+func (r *RallyTest) setDefaults(ctx context.Context, reader client.Reader) {
+	rallytestlog.Info("default", "name", r.Name)
+
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "RallyTest", &r.Spec.CommonOptions)
+}
+
+//+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-rallytest,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=rallytests,verbs=create;update,versions=v1beta1,name=vrallytest.kb.io,admissionReviewVersions=v1
+
+// validateCreate checks the RallyTest CR on create
+func (r *RallyTest) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
+	rallytestlog.Info("validate create", "name", r.Name)
+
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the RallyTest CR on both create and update. The old object is
+// nil on create.
+func (r *RallyTest) validate(ctx context.Context, reader client.Reader, old *RallyTest) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var allWarnings admission.Warnings
+
+	if len(r.Spec.Workflow) > 0 && r.Spec.Debug {
+		allErrs = append(allErrs, &field.Error{
+			Type:     field.ErrorTypeForbidden,
+			BadValue: r.Spec.Workflow,
+			Detail:   fmt.Sprintf(ErrDebug, "RallyTest"),
+		})
+	}
+
+	if r.Spec.Privileged {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOn, "RallyTest"))
+	}
+
+	privileged := r.Spec.Privileged
+	taskRequired := len(r.Spec.Workflow) == 0
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("openStackConfigSecret"), r.Spec.OpenStackConfigSecret},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+
+		if step.TaskConfigMap == nil {
+			taskRequired = true
+		}
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		overrideSpec := RallyTestSpec{}
+		err := validateSpecOverride(stepPath.Child("specOverride"), "RallyTest", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else if overrideSpec.Privileged {
+			privileged = true
+		}
+	}
+
+	if taskRequired && r.Spec.TaskConfigMap == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("taskConfigMap"), ErrRallyTaskRequired))
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("RallyTest", stepNames)...)
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "RallyTest", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "RallyTest", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "RallyTest"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "RallyTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "RallyTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "RallyTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return allWarnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: GroupVersion.WithKind("RallyTest").Group,
+				Kind:  GroupVersion.WithKind("RallyTest").Kind,
+			}, r.GetName(), allErrs)
+	}
+
+	if r.Spec.Privileged && len(r.Spec.Workflow) > 0 && len(r.Spec.SELinuxLevel) == 0 {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnSELinuxLevel, r.Kind))
+	}
+
+	return allWarnings, nil
+}
+
+// validateUpdate checks the RallyTest CR on update
+func (r *RallyTest) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
+	rallytestlog.Info("validate update", "name", r.Name)
+
+	oldRallyTest, ok := old.(*RallyTest)
+	if !ok || oldRallyTest == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldRallyTest)
+}
+
+// validateDelete checks the RallyTest CR on delete
+func (r *RallyTest) validateDelete() (admission.Warnings, error) {
+	rallytestlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+// simulatedSteps returns the test pods that would be spawned for the RallyTest CR
+func (r *RallyTest) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
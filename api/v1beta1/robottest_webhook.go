@@ -0,0 +1,262 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// ErrRobotSuitesSource
+	ErrRobotSuitesSource = "exactly one of suitesGitRepo and suitesConfigMap has to be specified"
+)
+
+// log is for logging in this package.
+var robottestlog = logf.Log.WithName("robottest-resource")
+
+func (r *RobotTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-robottest,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=robottests,verbs=create;update,versions=v1beta1,name=mrobottest.kb.io,admissionReviewVersions=v1
+
+var _ testWebhookObject = &RobotTest{}
+
+// setDefaults fills in the defaults of the RobotTest CR
+func (r *RobotTest) setDefaults(ctx context.Context, reader client.Reader) {
+	robottestlog.Info("default", "name", r.Name)
+
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "RobotTest", &r.Spec.CommonOptions)
+}
+
+//+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-robottest,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=robottests,verbs=create;update,versions=v1beta1,name=vrobottest.kb.io,admissionReviewVersions=v1
+
+// validateCreate checks the RobotTest CR on create
+func (r *RobotTest) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
+	robottestlog.Info("validate create", "name", r.Name)
+
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the RobotTest CR on both create and update. The old object is
+// nil on create.
+func (r *RobotTest) validate(ctx context.Context, reader client.Reader, old *RobotTest) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var allWarnings admission.Warnings
+
+	if len(r.Spec.Workflow) > 0 && r.Spec.Debug {
+		allErrs = append(allErrs, &field.Error{
+			Type:     field.ErrorTypeForbidden,
+			BadValue: r.Spec.Workflow,
+			Detail:   fmt.Sprintf(ErrDebug, "RobotTest"),
+		})
+	}
+
+	if r.Spec.Privileged {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOn, "RobotTest"))
+	}
+
+	privileged := r.Spec.Privileged
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("openStackConfigSecret"), r.Spec.OpenStackConfigSecret},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("variablesFromSecrets"), r.Spec.VariablesFromSecrets)...)
+	if len(r.Spec.SuitesGitAuthSecretName) > 0 {
+		secretRefs = append(secretRefs, secretReference{
+			field.NewPath("spec").Child("suitesGitAuthSecretName"), r.Spec.SuitesGitAuthSecretName})
+	}
+
+	if len(r.Spec.Workflow) == 0 {
+		if err := validateRobotSuitesSource(field.NewPath("spec"), r.Spec.SuitesGitRepo, r.Spec.SuitesConfigMap); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+
+		suitesGitRepo := r.Spec.SuitesGitRepo
+		suitesConfigMap := r.Spec.SuitesConfigMap
+		if len(step.SuitesGitRepo) > 0 || len(step.SuitesConfigMap) > 0 {
+			suitesGitRepo = step.SuitesGitRepo
+			suitesConfigMap = step.SuitesConfigMap
+		}
+
+		if err := validateRobotSuitesSource(stepPath, suitesGitRepo, suitesConfigMap); err != nil {
+			allErrs = append(allErrs, err)
+		}
+
+		secretRefs = append(secretRefs,
+			getSecretListReferences(stepPath.Child("variablesFromSecrets"), step.VariablesFromSecrets)...)
+		if len(step.SuitesGitAuthSecretName) > 0 {
+			secretRefs = append(secretRefs, secretReference{
+				stepPath.Child("suitesGitAuthSecretName"), step.SuitesGitAuthSecretName})
+		}
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		overrideSpec := RobotTestSpec{}
+		err := validateSpecOverride(stepPath.Child("specOverride"), "RobotTest", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else if overrideSpec.Privileged {
+			privileged = true
+		}
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("RobotTest", stepNames)...)
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "RobotTest", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "RobotTest", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "RobotTest"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "RobotTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "RobotTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "RobotTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return allWarnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: GroupVersion.WithKind("RobotTest").Group,
+				Kind:  GroupVersion.WithKind("RobotTest").Kind,
+			}, r.GetName(), allErrs)
+	}
+
+	if r.Spec.Privileged && len(r.Spec.Workflow) > 0 && len(r.Spec.SELinuxLevel) == 0 {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnSELinuxLevel, r.Kind))
+	}
+
+	return allWarnings, nil
+}
+
+// validateUpdate checks the RobotTest CR on update
+func (r *RobotTest) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
+	robottestlog.Info("validate update", "name", r.Name)
+
+	oldRobotTest, ok := old.(*RobotTest)
+	if !ok || oldRobotTest == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldRobotTest)
+}
+
+// validateDelete checks the RobotTest CR on delete
+func (r *RobotTest) validateDelete() (admission.Warnings, error) {
+	robottestlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+// simulatedSteps returns the test pods that would be spawned for the RobotTest CR
+func (r *RobotTest) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
+
+// validateRobotSuitesSource checks that the suites of a test pod come from
+// exactly one source
+func validateRobotSuitesSource(path *field.Path, suitesGitRepo string, suitesConfigMap string) *field.Error {
+	if (len(suitesGitRepo) > 0) == (len(suitesConfigMap) > 0) {
+		return field.Invalid(path.Child("suitesGitRepo"), suitesGitRepo, ErrRobotSuitesSource)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+const (
+	// RunIDAnnotation is set on every test instance. It identifies the test
+	// run of the instance and is used to name and label the pods and the
+	// PVCs of the run. Unlike the UID and the creation timestamp it survives
+	// a backup and restore of the instance, which allows the test-operator
+	// to adopt the resources of the run once the instance is restored.
+	RunIDAnnotation = "test.openstack.org/run-id"
+
+	// RerunAnnotation can be set on a completed test instance to execute the
+	// whole workflow once more. Every new value of the annotation (e.g. a
+	// timestamp or a build ID of the CI job) triggers a new run with a new
+	// run ID. The value that triggered the current run is stored in
+	// status.observedRerun.
+	RerunAnnotation = "test.openstack.org/rerun"
+)
@@ -0,0 +1,269 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ShakerPlacement - how the shaker agents are placed on the compute nodes
+// +kubebuilder:validation:Enum:=pair;single_room;double_room
+type ShakerPlacement string
+
+const (
+	// ShakerPlacementPair - the agents are deployed in pairs, the primary
+	// agent sends the traffic to its minion
+	ShakerPlacementPair ShakerPlacement = "pair"
+
+	// ShakerPlacementSingleRoom - at most one agent is deployed on a
+	// compute node
+	ShakerPlacementSingleRoom ShakerPlacement = "single_room"
+
+	// ShakerPlacementDoubleRoom - at most two agents are deployed on a
+	// compute node
+	ShakerPlacementDoubleRoom ShakerPlacement = "double_room"
+)
+
+// ShakerAccommodation - placement of the shaker agents. It overrides the
+// accommodation of the deployment of the executed scenarios.
+type ShakerAccommodation struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems:=2
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// How the agents are placed on the compute nodes, e.g. [pair, single_room].
+	// The placement of the scenario is used when it is not set.
+	Placement []ShakerPlacement `json:"placement,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Number of agents deployed on a single compute node
+	Density *int32 `json:"density,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Number of compute nodes the agents are deployed on. All compute nodes
+	// are used when it is not set.
+	ComputeNodes *int32 `json:"computeNodes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Availability zones the agents are deployed in
+	Zones []string `json:"zones,omitempty"`
+}
+
+// ShakerTestSpec defines the desired state of ShakerTest
+type ShakerTestSpec struct {
+	CommonOptions `json:",inline"`
+
+	CommonOpenstackConfig `json:",inline"`
+
+	// +kubebuilder:default:={limits: {cpu: "2000m", memory: "2Gi"}, requests: {cpu: "1000m", memory: "1Gi"}}
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the ShakerTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Activate debug mode. When debug mode is activated any error encountered
+	// inside the test-pod causes that the pod will be kept alive indefinitely
+	// (stuck in "Running" phase) or until the corresponding ShakerTest CR is deleted.
+	// This allows the user to debug any potential troubles with `oc rsh`.
+	Debug bool `json:"debug"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// By default test-operator executes the test-pods sequentially if multiple
+	// instances of test-operator related CRs exist. To run test-pods in parallel
+	// set this option to true.
+	Parallel bool `json:"parallel"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="default"
+	// Name of the cloud in the clouds.yaml that shaker runs against
+	Cloud string `json:"cloud"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:={"openstack/full_l2","openstack/full_l3_east_west","openstack/full_l3_north_south"}
+	// +kubebuilder:validation:MinItems:=1
+	// Scenarios that are executed. Built-in scenarios are referenced by their
+	// name (e.g. openstack/perf_l3_east_west), custom scenarios from
+	// scenarioConfigMap by the name of their file without the extension.
+	Scenarios []string `json:"scenarios,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of a config map that contains custom scenario files. The files
+	// are mounted to the custom scenarios directory of the test pod.
+	ScenarioConfigMap string `json:"scenarioConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Placement of the shaker agents. When it is not set, the accommodation
+	// defined by the scenarios is used.
+	Accommodation *ShakerAccommodation `json:"accommodation,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=5999
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// Port of the shaker server the agents connect to. The server has to be
+	// reachable from the instances of the agents (see networkAttachments).
+	ServerEndpointPort int32 `json:"serverEndpointPort"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="shaker-image"
+	// Name of the glance image the agents are booted from. The image is
+	// built by the test pod when it does not exist.
+	ImageName string `json:"imageName"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="shaker-flavor"
+	// Name of the flavor the agents are booted with
+	FlavorName string `json:"flavorName"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the external network used by the north-south scenarios
+	ExternalNetwork string `json:"externalNetwork,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
+	// A parameter  that contains a workflow definition.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Optional
+	Workflow []ShakerTestWorkflowSpec `json:"workflow,omitempty"`
+}
+
+type ShakerTestWorkflowSpec struct {
+	WorkflowCommonParameters `json:",inline"`
+
+	// The desired amount of resources that should be assigned to each test pod
+	// spawned using the ShakerTest CR. https://pkg.go.dev/k8s.io/api/core/v1#ResourceRequirements
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the cloud in the clouds.yaml that shaker runs against
+	Cloud string `json:"cloud,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Scenarios that are executed
+	Scenarios []string `json:"scenarios,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of a config map that contains custom scenario files
+	ScenarioConfigMap string `json:"scenarioConfigMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Placement of the shaker agents
+	Accommodation *ShakerAccommodation `json:"accommodation,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the external network used by the north-south scenarios
+	ExternalNetwork string `json:"externalNetwork,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// NetworkAttachments is a list of NetworkAttachment resource names to expose
+	// the services to the given network
+	NetworkAttachments []string `json:"networkAttachments,omitempty"`
+
+	// A parameter that contains a definition of a single workflow step.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength:=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StepName string `json:"stepName"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// ShakerTest is the Schema for the shakertests API
+type ShakerTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ShakerTestSpec   `json:"spec,omitempty"`
+	Status CommonTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ShakerTestList contains a list of ShakerTest
+type ShakerTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ShakerTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ShakerTest{}, &ShakerTestList{})
+}
+
+// RbacConditionsSet - set the conditions for the rbac object
+func (instance ShakerTest) RbacConditionsSet(c *condition.Condition) {
+	instance.Status.Conditions.Set(c)
+}
+
+// RbacNamespace - return the namespace
+func (instance ShakerTest) RbacNamespace() string {
+	return instance.Namespace
+}
+
+// RbacResourceName - return the name to be used for rbac objects (serviceaccount, role, rolebinding)
+func (instance ShakerTest) RbacResourceName() string {
+	return instance.Name
+}
+
+// GetCommonStatus - return the status of the instance
+func (instance *ShakerTest) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps
+func (instance *ShakerTest) GetStepNames() []string {
+	stepNames := []string{}
+	for _, step := range instance.Spec.Workflow {
+		stepNames = append(stepNames, step.StepName)
+	}
+
+	return stepNames
+}
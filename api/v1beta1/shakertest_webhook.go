@@ -0,0 +1,255 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// ErrShakerPlacement
+	ErrShakerPlacement = "single_room and double_room placements can not be combined"
+)
+
+// log is for logging in this package.
+var shakertestlog = logf.Log.WithName("shakertest-resource")
+
+func (r *ShakerTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-shakertest,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=shakertests,verbs=create;update,versions=v1beta1,name=mshakertest.kb.io,admissionReviewVersions=v1
+
+var _ testWebhookObject = &ShakerTest{}
+
+// setDefaults fills in the defaults of the ShakerTest CR
+func (r *ShakerTest) setDefaults(ctx context.Context, reader client.Reader) {
+	shakertestlog.Info("default", "name", r.Name)
+
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "ShakerTest", &r.Spec.CommonOptions)
+}
+
+//+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-shakertest,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=shakertests,verbs=create;update,versions=v1beta1,name=vshakertest.kb.io,admissionReviewVersions=v1
+
+// validateCreate checks the ShakerTest CR on create
+func (r *ShakerTest) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
+	shakertestlog.Info("validate create", "name", r.Name)
+
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the ShakerTest CR on both create and update. The old object is
+// nil on create.
+func (r *ShakerTest) validate(ctx context.Context, reader client.Reader, old *ShakerTest) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var allWarnings admission.Warnings
+
+	if len(r.Spec.Workflow) > 0 && r.Spec.Debug {
+		allErrs = append(allErrs, &field.Error{
+			Type:     field.ErrorTypeForbidden,
+			BadValue: r.Spec.Workflow,
+			Detail:   fmt.Sprintf(ErrDebug, "ShakerTest"),
+		})
+	}
+
+	if r.Spec.Privileged {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOn, "ShakerTest"))
+	}
+
+	privileged := r.Spec.Privileged
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("openStackConfigSecret"), r.Spec.OpenStackConfigSecret},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+
+	if err := validateShakerAccommodation(field.NewPath("spec").Child("accommodation"), r.Spec.Accommodation); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+
+		if err := validateShakerAccommodation(stepPath.Child("accommodation"), step.Accommodation); err != nil {
+			allErrs = append(allErrs, err)
+		}
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		overrideSpec := ShakerTestSpec{}
+		err := validateSpecOverride(stepPath.Child("specOverride"), "ShakerTest", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else if overrideSpec.Privileged {
+			privileged = true
+		}
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("ShakerTest", stepNames)...)
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "ShakerTest", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "ShakerTest", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "ShakerTest"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "ShakerTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "ShakerTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "ShakerTest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return allWarnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: GroupVersion.WithKind("ShakerTest").Group,
+				Kind:  GroupVersion.WithKind("ShakerTest").Kind,
+			}, r.GetName(), allErrs)
+	}
+
+	if r.Spec.Privileged && len(r.Spec.Workflow) > 0 && len(r.Spec.SELinuxLevel) == 0 {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnSELinuxLevel, r.Kind))
+	}
+
+	return allWarnings, nil
+}
+
+// validateUpdate checks the ShakerTest CR on update
+func (r *ShakerTest) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
+	shakertestlog.Info("validate update", "name", r.Name)
+
+	oldShakerTest, ok := old.(*ShakerTest)
+	if !ok || oldShakerTest == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldShakerTest)
+}
+
+// validateDelete checks the ShakerTest CR on delete
+func (r *ShakerTest) validateDelete() (admission.Warnings, error) {
+	shakertestlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+// validateShakerAccommodation checks that the placement of the agents is
+// not ambiguous
+func validateShakerAccommodation(path *field.Path, accommodation *ShakerAccommodation) *field.Error {
+	if accommodation == nil {
+		return nil
+	}
+
+	singleRoom := false
+	doubleRoom := false
+	for _, placement := range accommodation.Placement {
+		switch placement {
+		case ShakerPlacementSingleRoom:
+			singleRoom = true
+		case ShakerPlacementDoubleRoom:
+			doubleRoom = true
+		}
+	}
+
+	if singleRoom && doubleRoom {
+		return field.Invalid(path.Child("placement"), accommodation.Placement, ErrShakerPlacement)
+	}
+
+	return nil
+}
+
+// simulatedSteps returns the test pods that would be spawned for the ShakerTest CR
+func (r *ShakerTest) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
@@ -0,0 +1,299 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// WarnSimulatedStep
+	WarnSimulatedStep = "Simulation: workflow step %d (%s) would run the %s container " +
+		"image, estimated duration: %s."
+
+	// WarnSimulatedWorkflow
+	WarnSimulatedWorkflow = "Simulation: %s would spawn %d test pod(s), estimated " +
+		"duration: %s (based on %d previous run(s) in the %s namespace)."
+
+	// simulationUnknown is used when there is no historical data or when the
+	// container image can not be resolved
+	simulationUnknown = "unknown"
+)
+
+// simulatedStep is a test pod that would be spawned for a test CR
+type simulatedStep struct {
+	Name           string
+	ContainerImage string
+}
+
+// workflowSimulator is implemented by the test CRs which can describe the
+// test pods they would spawn
+type workflowSimulator interface {
+	client.Object
+	simulatedSteps() []simulatedStep
+}
+
+// testWebhookObject is implemented by the test CRs that are defaulted and
+// validated by testWebhook. The reader is used to look up the objects that are
+// referenced by the CR (e.g. secrets or namespaces). It is nil when there is
+// no API server to ask, the lookups are skipped then.
+type testWebhookObject interface {
+	client.Object
+	setDefaults(ctx context.Context, reader client.Reader)
+	validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error)
+	validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error)
+	validateDelete() (admission.Warnings, error)
+}
+
+// testWebhook defaults and validates the test CRs. For dry-run requests (e.g.
+// kubectl apply --dry-run=server) that pass the validation it also returns
+// the simulated workflow as warnings so that users can review the test pods
+// before the CR is created.
+type testWebhook struct {
+	reader client.Reader
+}
+
+var _ admission.CustomDefaulter = &testWebhook{}
+var _ admission.CustomValidator = &testWebhook{}
+
+// Default implements admission.CustomDefaulter
+func (w *testWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	object, ok := obj.(testWebhookObject)
+	if !ok {
+		return fmt.Errorf(ErrUnexpectedObject, obj)
+	}
+
+	object.setDefaults(ctx, w.reader)
+
+	return nil
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (w *testWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	object, ok := obj.(testWebhookObject)
+	if !ok {
+		return nil, fmt.Errorf(ErrUnexpectedObject, obj)
+	}
+
+	warnings, err := object.validateCreate(ctx, w.reader)
+	if err != nil {
+		return warnings, err
+	}
+
+	return append(warnings, w.simulateDryRun(ctx, obj)...), nil
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (w *testWebhook) ValidateUpdate(
+	ctx context.Context,
+	oldObj runtime.Object,
+	newObj runtime.Object,
+) (admission.Warnings, error) {
+	object, ok := newObj.(testWebhookObject)
+	if !ok {
+		return nil, fmt.Errorf(ErrUnexpectedObject, newObj)
+	}
+
+	warnings, err := object.validateUpdate(ctx, w.reader, oldObj)
+	if err != nil {
+		return warnings, err
+	}
+
+	return append(warnings, w.simulateDryRun(ctx, newObj)...), nil
+}
+
+// ValidateDelete implements admission.CustomValidator
+func (w *testWebhook) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	object, ok := obj.(testWebhookObject)
+	if !ok {
+		return nil, fmt.Errorf(ErrUnexpectedObject, obj)
+	}
+
+	return object.validateDelete()
+}
+
+// simulateDryRun returns the simulated workflow of the object when it is
+// validated as part of a dry-run request
+func (w *testWebhook) simulateDryRun(ctx context.Context, obj runtime.Object) admission.Warnings {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.DryRun == nil || !*req.DryRun {
+		return nil
+	}
+
+	simulator, ok := obj.(workflowSimulator)
+	if !ok {
+		return nil
+	}
+
+	kind := req.Kind.Kind
+	return simulateWorkflow(ctx, w.reader, simulator.GetNamespace(), kind, simulator.simulatedSteps())
+}
+
+// simulateWorkflow returns a warning for each simulated step and a warning
+// with the estimated duration of the whole workflow. The durations are
+// estimated from the finished steps of the test CRs of the same kind in the
+// namespace. Steps are matched by their name (or by their position when they
+// are not named).
+func simulateWorkflow(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	kind string,
+	steps []simulatedStep,
+) admission.Warnings {
+	var warnings admission.Warnings
+
+	history, runs := getStepDurations(ctx, reader, namespace, kind)
+
+	total := time.Duration(0)
+	totalKnown := true
+	for idx, step := range steps {
+		image := step.ContainerImage
+		if len(image) == 0 {
+			image = getDefaultContainerImage(ctx, reader, namespace, kind)
+		}
+
+		if len(image) == 0 {
+			image = simulationUnknown
+		}
+
+		estimate := simulationUnknown
+		if durations := history[getSimulationKey(idx, step.Name)]; len(durations) > 0 {
+			duration := averageDuration(durations)
+			total += duration
+			estimate = duration.String()
+		} else {
+			totalKnown = false
+		}
+
+		warnings = append(warnings, fmt.Sprintf(WarnSimulatedStep, idx, getSimulationStepName(step.Name), image, estimate))
+	}
+
+	totalEstimate := simulationUnknown
+	if totalKnown && len(steps) > 0 {
+		totalEstimate = total.String()
+	}
+
+	warnings = append(warnings, fmt.Sprintf(WarnSimulatedWorkflow, kind, len(steps), totalEstimate, runs, namespace))
+
+	return warnings
+}
+
+// getStepDurations returns the durations of the finished steps of the test
+// CRs of the given kind in the namespace indexed by getSimulationKey and the
+// number of the test CRs that have at least one finished step
+func getStepDurations(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	kind string,
+) (map[string][]time.Duration, int) {
+	durations := map[string][]time.Duration{}
+	if reader == nil {
+		return durations, 0
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(GroupVersion.WithKind(kind + "List"))
+	if err := reader.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return durations, 0
+	}
+
+	runs := 0
+	for _, item := range list.Items {
+		statusObject, ok := item.Object["status"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		status := CommonTestStatus{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusObject, &status)
+		if err != nil {
+			continue
+		}
+
+		shardStartTimes := getShardStartTimes(status.Steps)
+		finished := false
+		for _, step := range status.Steps {
+			// The duration of a rerun of the failed tests is not part of
+			// the duration of the workflow step. The duration of a sharded
+			// workflow step is measured by the pod that merges the shards.
+			if step.Rerun || step.Shard != nil {
+				continue
+			}
+
+			if step.Phase != corev1.PodSucceeded && step.Phase != corev1.PodFailed {
+				continue
+			}
+
+			if step.StartTime == nil || step.FinishTime == nil {
+				continue
+			}
+
+			startTime := step.StartTime.Time
+			if shardStartTime, ok := shardStartTimes[step.WorkflowStep]; ok {
+				startTime = shardStartTime
+			}
+
+			key := getSimulationKey(step.WorkflowStep, step.StepName)
+			duration := step.FinishTime.Sub(startTime)
+			durations[key] = append(durations[key], duration)
+			finished = true
+		}
+
+		if finished {
+			runs++
+		}
+	}
+
+	return durations, runs
+}
+
+// getShardStartTimes returns the time when the first shard of each sharded
+// workflow step started
+func getShardStartTimes(steps []TestStepStatus) map[int]time.Time {
+	startTimes := map[int]time.Time{}
+	for _, step := range steps {
+		if step.Shard == nil || step.StartTime == nil {
+			continue
+		}
+
+		startTime, ok := startTimes[step.WorkflowStep]
+		if !ok || step.StartTime.Time.Before(startTime) {
+			startTimes[step.WorkflowStep] = step.StartTime.Time
+		}
+	}
+
+	return startTimes
+}
+
+func getSimulationKey(workflowStep int, stepName string) string {
+	if len(stepName) > 0 {
+		return stepName
+	}
+
+	return fmt.Sprintf("#%d", workflowStep)
+}
+
+func getSimulationStepName(stepName string) string {
+	if len(stepName) > 0 {
+		return stepName
+	}
+
+	return "unnamed"
+}
+
+func averageDuration(durations []time.Duration) time.Duration {
+	total := time.Duration(0)
+	for _, duration := range durations {
+		total += duration
+	}
+
+	return (total / time.Duration(len(durations))).Round(time.Second)
+}
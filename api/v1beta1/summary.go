@@ -0,0 +1,189 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TestSummaryAnnotation is set on every test instance (Tempest, Tobiko,
+	// AnsibleTest, HorizonTest, RallyTest, ShakerTest, FioTest, K6Test,
+	// RobotTest, CustomTest). It contains the TestSummary of the instance
+	// encoded as JSON.
+	TestSummaryAnnotation = "test.openstack.org/summary"
+
+	// TestSummaryVersion is the version of the TestSummary schema. Fields are
+	// only ever added within a version. Any other change to the schema bumps
+	// the version.
+	TestSummaryVersion = "v1"
+
+	// TestResultsConfigMapKey is the key of the ConfigMap referenced by
+	// status.resultsConfigMap that contains the TestResults encoded as JSON
+	TestResultsConfigMapKey = "results.json"
+
+	// TestResultsVersion is the version of the TestResults schema. It follows
+	// the same rules as TestSummaryVersion.
+	TestResultsVersion = "v1"
+
+	// MaxTestResultsFailedTests limits the number of the failed tests stored
+	// for a single test pod so that the TestResults fit into a ConfigMap
+	MaxTestResultsFailedTests = 500
+)
+
+// TestSummaryVerdict is the overall result of a test instance
+type TestSummaryVerdict string
+
+const (
+	// TestSummaryVerdictPending - no test pod has been created yet
+	TestSummaryVerdictPending TestSummaryVerdict = "Pending"
+
+	// TestSummaryVerdictRunning - not all test pods have finished yet
+	TestSummaryVerdictRunning TestSummaryVerdict = "Running"
+
+	// TestSummaryVerdictPassed - all test pods finished successfully
+	TestSummaryVerdictPassed TestSummaryVerdict = "Passed"
+
+	// TestSummaryVerdictFailed - all test pods finished and at least one of
+	// them failed
+	TestSummaryVerdictFailed TestSummaryVerdict = "Failed"
+)
+
+// TestSummary is a compact machine-readable summary of a test instance. It is
+// meant for lightweight scrapers that do not want to interpret the conditions
+// and the rest of the status of the instance.
+type TestSummary struct {
+	// Version of the schema (TestSummaryVersion)
+	Version string `json:"version"`
+
+	// Verdict of the test run
+	Verdict TestSummaryVerdict `json:"verdict"`
+
+	// Number of test pods that are expected to run (one per workflow step)
+	Total int `json:"total"`
+
+	// Number of test pods that finished successfully
+	Succeeded int `json:"succeeded"`
+
+	// Number of test pods that failed
+	Failed int `json:"failed"`
+
+	// Number of seconds between the start of the first test pod and the
+	// completion of the last finished test pod
+	DurationSeconds int64 `json:"durationSeconds"`
+
+	// Location of the logs of the last finished test pod in the form of
+	// pvc://<namespace>/<persistent volume claim name>
+	ArtifactURL string `json:"artifactURL,omitempty"`
+}
+
+// TestResults is a machine-readable summary of a finished run of a test
+// instance. Unlike the status of the instance, it contains the names of the
+// failed tests so that external tools can consume the results without
+// access to the logs PVC.
+type TestResults struct {
+	// Version of the schema (TestResultsVersion)
+	Version string `json:"version"`
+
+	// Kind of the test instance (e.g. Tempest)
+	Kind string `json:"kind"`
+
+	// Name of the test instance
+	Name string `json:"name"`
+
+	// Namespace of the test instance
+	Namespace string `json:"namespace"`
+
+	// ID of the run (see RunIDAnnotation)
+	RunID string `json:"runID"`
+
+	// Summary of the test pods of the run
+	Summary TestSummary `json:"summary"`
+
+	// Numbers of the tests executed by the test pods whose results are
+	// final, i.e. the test pods that were not superseded by a rerun. It is
+	// set only when at least one test pod reported the numbers.
+	Counts *TestCounts `json:"counts,omitempty"`
+
+	// Steps contains the results of each test pod of the run
+	Steps []TestStepResults `json:"steps"`
+}
+
+// TestCounts contains the numbers of the tests executed by a test pod
+type TestCounts struct {
+	// Total number of the executed tests
+	Total int `json:"total"`
+
+	// Number of the passed tests
+	Passed int `json:"passed"`
+
+	// Number of the failed tests
+	Failed int `json:"failed"`
+
+	// Number of the skipped tests
+	Skipped int `json:"skipped"`
+}
+
+// TestStepResults contains the results of a single test pod
+type TestStepResults struct {
+	// Index of the workflow step
+	WorkflowStep int `json:"workflowStep"`
+
+	// Name of the workflow step
+	StepName string `json:"stepName,omitempty"`
+
+	// Name of the test pod
+	PodName string `json:"podName"`
+
+	// Phase of the test pod
+	Phase corev1.PodPhase `json:"phase"`
+
+	// Indicate whether the test pod executed the failed tests of the
+	// workflow step once more
+	Rerun bool `json:"rerun,omitempty"`
+
+	// Index of the shard of the tests executed by the test pod
+	Shard *int `json:"shard,omitempty"`
+
+	// Time when the test pod started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Time when the test pod finished
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+
+	// Number of seconds between the start and the finish of the test pod
+	DurationSeconds int64 `json:"durationSeconds"`
+
+	// Exit code of the container of the test pod
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Location of the logs of the test pod
+	ArtifactURL string `json:"artifactURL,omitempty"`
+
+	// Numbers of the tests executed by the test pod. It is not set when the
+	// test pod does not report them or it was deleted before the run
+	// finished.
+	Counts *TestCounts `json:"counts,omitempty"`
+
+	// Names of the failed tests. At most MaxTestResultsFailedTests names are
+	// stored for each test pod.
+	FailedTests []string `json:"failedTests,omitempty"`
+
+	// Indicate whether FailedTests was truncated
+	FailedTestsTruncated bool `json:"failedTestsTruncated,omitempty"`
+}
@@ -147,11 +147,23 @@ type TempestRunSpec struct {
 	// A content of include.txt file that is passed to tempest via --include-list
 	IncludeList string `json:"includeList"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A key of a config map with the content of include.txt, e.g. a list
+	// shared by several Tempest CRs. When it is set includeList is ignored.
+	IncludeListConfigMap *corev1.ConfigMapKeySelector `json:"includeListConfigMap,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// A content of exclude.txt file that is passed to tempest via --exclude-list
 	ExcludeList string `json:"excludeList"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A key of a config map with the content of exclude.txt, e.g. a skip list
+	// shared by several Tempest CRs. When it is set excludeList is ignored.
+	ExcludeListConfigMap *corev1.ConfigMapKeySelector `json:"excludeListConfigMap,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// The expectedFailuresList parameter contains tests that should not count
@@ -180,7 +192,8 @@ type TempestRunSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:default:=false
-	// Indicate whether tempest should be executed with --serial
+	// Indicate whether tempest should be executed with --serial. Serial
+	// takes precedence over parallel.
 	Serial bool `json:"serial"`
 
 	// +kubebuilder:validation:Optional
@@ -207,6 +220,15 @@ type TempestRunSpec struct {
 	// Extra images that should be downloaded inside the test pod and uploaded to
 	// openstack.
 	ExtraImages []ExtraImagesType `json:"extraImages,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// Indicate whether the failed tests should be executed once more when
+	// the tempest run fails. The failed tests are read from the stestr state
+	// in the logs PVC and executed by a second test pod. The result of the
+	// second test pod decides the result of the workflow step.
+	RerunFailed bool `json:"rerunFailed,omitempty"`
 }
 
 // TempestconfRunSpec - is used to configure execution of discover-tempest-config
@@ -373,6 +395,107 @@ type TempestconfRunSpec struct {
 	Timeout int64 `json:"timeout"`
 }
 
+// TempestPlugin - an out-of-tree tempest plugin that is installed into the
+// test pod before the tests are executed
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.gitUrl)",message="exactly one of name and gitUrl must be set"
+type TempestPlugin struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the python package of the plugin (e.g. whitebox-tempest-plugin)
+	// that is installed from the package index
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// URL of the git repository of the plugin
+	GitURL string `json:"gitUrl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Version of the package when name is set, or a branch, tag or commit of
+	// the git repository when gitUrl is set. The latest version or the
+	// default branch is installed when it is not set.
+	Ref string `json:"ref,omitempty"`
+}
+
+// WhiteboxContainerRuntime - container runtime that runs the OpenStack services
+// on the compute nodes
+type WhiteboxContainerRuntime string
+
+const (
+	// WhiteboxContainerRuntimePodman - the services run in podman containers
+	WhiteboxContainerRuntimePodman WhiteboxContainerRuntime = "podman"
+
+	// WhiteboxContainerRuntimeDocker - the services run in docker containers
+	WhiteboxContainerRuntimeDocker WhiteboxContainerRuntime = "docker"
+
+	// WhiteboxContainerRuntimeNone - the services are not containerized
+	WhiteboxContainerRuntimeNone WhiteboxContainerRuntime = "none"
+)
+
+// WhiteboxNode - a compute node that the whitebox tempest plugin accesses
+type WhiteboxNode struct {
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the hypervisor as reported by nova
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Address (IP or hostname) used to access the node over SSH
+	Address string `json:"address"`
+}
+
+// WhiteboxSpec - configuration of the access of the whitebox tempest plugin to
+// the compute nodes. It is used to generate the whitebox section of
+// tempest.conf.
+type WhiteboxSpec struct {
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the k8s secret that contains the private SSH key (under the
+	// ssh-privatekey key) used to access the compute nodes
+	SSHKeySecretName string `json:"sshKeySecretName"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:="cloud-admin"
+	// User used to access the compute nodes over SSH
+	SSHUser string `json:"sshUser,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Compute nodes that are accessed by the whitebox tests
+	Nodes []WhiteboxNode `json:"nodes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum:=podman;docker;none
+	// +kubebuilder:default:=podman
+	// Container runtime that runs the OpenStack services on the compute
+	// nodes
+	ContainerRuntime WhiteboxContainerRuntime `json:"containerRuntime,omitempty"`
+}
+
+// StestrHistorySpec - configuration of the stestr history that is kept across
+// the executions of the Tempest CR
+type StestrHistorySpec struct {
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Name of the PVC that stores the stestr repositories. The PVC is created
+	// by the test-operator when it does not exist and it is not deleted
+	// together with the Tempest CR so that it can be reused by the next
+	// execution. Defaults to <name of the Tempest CR>-stestr-history.
+	ClaimName string `json:"claimName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=50
+	// +kubebuilder:validation:Minimum=0
+	// Percentage by which the duration of a test has to grow compared with
+	// the previous run to be reported as a timing regression
+	TimingRegressionThreshold int64 `json:"timingRegressionThreshold,omitempty"`
+}
+
 // TempestSpec - configuration of execution of tempest. For specific configuration
 // of tempest see TempestRunSpec and for discover-tempest-config see TempestconfRunSpec.
 type TempestSpec struct {
@@ -404,9 +527,11 @@ type TempestSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:default:=false
-	// Activate tempest cleanup. When activated, tempest will run tempest cleanup
-	// after test execution is complete to delete any resources created by tempest
-	// that may have been left out.
+	// Activate tempest cleanup. When activated, the state of the cloud is
+	// recorded (tempest cleanup --init-saved-state) before the tests are
+	// executed and tempest cleanup is run after test execution is complete
+	// to delete any resources created by tempest that may have been left out.
+	// The resources recorded in the saved state are preserved.
 	Cleanup bool `json:"cleanup"`
 
 	// +kubebuilder:validation:Optional
@@ -430,6 +555,63 @@ type TempestSpec struct {
 	// The key is mounted to ~/.ssh/id_ecdsa in the tempest pod
 	SSHKeySecretName string `json:"SSHKeySecretName"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TempestconfSecretName is the name of the k8s secret that contains a
+	// complete tempest.conf under the tempest.conf key. When it is set,
+	// discover-tempest-config is not executed and the provided tempest.conf
+	// is used verbatim. The tempestconfRun section is ignored in that case.
+	TempestconfSecretName string `json:"tempestconfSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// AccountsSecretName is the name of the k8s secret that contains
+	// pre-provisioned credentials for tempest under the accounts.yaml key.
+	// The file is mounted to /etc/tempest/accounts.yaml and tempest is
+	// configured to use it instead of creating the credentials dynamically so
+	// that the tests can run without admin credentials (see also
+	// tempestconfRun.nonAdmin). It takes precedence over
+	// tempestconfRun.testAccounts.
+	AccountsSecretName string `json:"accountsSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=false
+	// ListTestsOnly - when set to true, the tests are not executed. Tempest
+	// only lists the tests selected by the configured regexes and include /
+	// exclude lists (tempest run --list-tests). The list of each workflow
+	// step is stored in a ConfigMap referred from status.testLists.
+	ListTestsOnly bool `json:"listTestsOnly,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	// Shards - number of test pods that execute the tests of a workflow step
+	// concurrently. The selected tests are split across the shards and each
+	// shard stores its logs in its own directory of the logs PVC. Once all
+	// the shards finish, a merge pod combines their subunit streams and its
+	// result decides the result of the workflow step. Sharding is disabled
+	// when listTestsOnly is set.
+	Shards int `json:"shards,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Plugins - out-of-tree tempest plugins that are installed by an init
+	// container into a virtual environment shared with the test container.
+	// The resolved versions of the installed packages are recorded in
+	// status.steps[].plugins so that the runs are reproducible.
+	Plugins []TempestPlugin `json:"plugins,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Whitebox - access of the whitebox tempest plugin to the compute nodes.
+	// The SSH key is mounted to the test pod and the whitebox section of
+	// tempest.conf is generated from these values. It is ignored when
+	// tempestconfSecretName is set.
+	Whitebox *WhiteboxSpec `json:"whitebox,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// ConfigOverwrite - interface to overwrite default config files like e.g. logging.conf
@@ -437,6 +619,16 @@ type TempestSpec struct {
 	// service config dir in /etc/test_operator/<file>
 	ConfigOverwrite map[string]string `json:"configOverwrite,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// StestrHistory enables the persistence of the stestr repository of each
+	// workflow step across the executions of the Tempest CR. The latest run
+	// is compared with the previous one and the result is stored in
+	// status.stestrComparisons.
+	StestrHistory *StestrHistorySpec `json:"stestrHistory,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Workflow - can be used to specify a multiple executions of tempest with
@@ -450,7 +642,9 @@ type TempestSpec struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
 
 type Tempest struct {
@@ -488,3 +682,18 @@ func (instance Tempest) RbacNamespace() string {
 func (instance Tempest) RbacResourceName() string {
 	return instance.Name
 }
+
+// GetCommonStatus - return the status of the instance
+func (instance *Tempest) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps
+func (instance *Tempest) GetStepNames() []string {
+	stepNames := []string{}
+	for _, step := range instance.Spec.Workflow {
+		stepNames = append(stepNames, step.StepName)
+	}
+
+	return stepNames
+}
@@ -27,11 +27,23 @@ type WorkflowTempestRunSpec struct {
 	// A content of include.txt file that is passed to tempest via --include-list
 	IncludeList *string `json:"includeList,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A key of a config map with the content of include.txt, e.g. a list
+	// shared by several Tempest CRs. When it is set includeList is ignored.
+	IncludeListConfigMap *corev1.ConfigMapKeySelector `json:"includeListConfigMap,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// A content of exclude.txt file that is passed to tempest via --exclude-list
 	ExcludeList *string `json:"excludeList,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// A key of a config map with the content of exclude.txt, e.g. a skip list
+	// shared by several Tempest CRs. When it is set excludeList is ignored.
+	ExcludeListConfigMap *corev1.ConfigMapKeySelector `json:"excludeListConfigMap,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// The expectedFailuresList parameter contains tests that should not count
@@ -56,7 +68,8 @@ type WorkflowTempestRunSpec struct {
 
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	// Indicate whether tempest should be executed with --serial
+	// Indicate whether tempest should be executed with --serial. Serial
+	// takes precedence over parallel.
 	Serial *bool `json:"serial,omitempty"`
 
 	// +kubebuilder:validation:Optional
@@ -82,6 +95,12 @@ type WorkflowTempestRunSpec struct {
 	// Extra images that should be downloaded inside the test pod and uploaded to
 	// openstack.
 	ExtraImages *[]ExtraImagesType `json:"extraImagesType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Indicate whether the failed tests should be executed once more when
+	// the tempest run fails. See TempestRunSpec.
+	RerunFailed *bool `json:"rerunFailed,omitempty"`
 }
 
 // TempestconfRunSpec - is used to configure execution of discover-tempest-config
@@ -250,6 +269,13 @@ type WorkflowTempestSpec struct {
 	// behaviour then set this option to true.
 	Parallel *bool `json:"parallel,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Activate tempest cleanup for the workflow step. It can be used to clean
+	// up only after specific workflow steps (e.g. the last one). See
+	// TempestSpec.Cleanup.
+	Cleanup *bool `json:"cleanup,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// NetworkAttachments is a list of NetworkAttachment resource names to expose
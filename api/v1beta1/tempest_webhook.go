@@ -23,8 +23,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -32,11 +36,37 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+const (
+	// ErrInvalidTempestPluginName
+	ErrInvalidTempestPluginName = "the name of a tempest plugin must be a valid python package name"
+
+	// ErrInvalidTempestPluginGitURL
+	ErrInvalidTempestPluginGitURL = "the gitUrl of a tempest plugin must be a http, https, ssh or git URL " +
+		"without whitespace, # and @ in the path"
+
+	// ErrInvalidTempestPluginRef
+	ErrInvalidTempestPluginRef = "the ref of a tempest plugin must not contain whitespace, # or ; and it must " +
+		"not start with a dash"
+
+	// ErrInvalidWhiteboxNodeName
+	ErrInvalidWhiteboxNodeName = "the name of a whitebox node must not be empty or contain whitespace, commas or colons"
+
+	// ErrInvalidWhiteboxNodeAddress
+	ErrInvalidWhiteboxNodeAddress = "the address of a whitebox node must not be empty or contain whitespace or commas"
+
+	// ErrTempestSpecUpdate
+	ErrTempestSpecUpdate = "updating the spec of an existing Tempest CR is supported only when " +
+		"Tempest.Spec.RerunOnSpecChange is set to true"
+)
+
+// tempestPluginNameRegexp matches valid python package names (PEP 508)
+var tempestPluginNameRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
 // TempestDefaults -
 type TempestDefaults struct {
 	ContainerImageURL string
@@ -48,20 +78,25 @@ var tempestDefaults TempestDefaults
 var tempestlog = logf.Log.WithName("tempest-resource")
 
 func (r *Tempest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-tempest,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=tempests,verbs=create;update,versions=v1beta1,name=mtempest.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Defaulter = &Tempest{}
+var _ testWebhookObject = &Tempest{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type
-func (r *Tempest) Default() {
+// setDefaults fills in the defaults of the Tempest CR
+func (r *Tempest) setDefaults(ctx context.Context, reader client.Reader) {
 	tempestlog.Info("default", "name", r.Name)
 
 	r.Spec.Default()
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "Tempest", &r.Spec.CommonOptions)
 }
 
 // Default - set defaults for this Tempest spec.
@@ -82,12 +117,16 @@ func (r *Tempest) PrivilegedRequired() bool {
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-tempest,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=tempests,verbs=create;update,versions=v1beta1,name=vtempest.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Validator = &Tempest{}
-
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (r *Tempest) ValidateCreate() (admission.Warnings, error) {
+// validateCreate checks the Tempest CR on create
+func (r *Tempest) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
 	tempestlog.Info("validate create", "name", r.Name)
 
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the Tempest CR on both create and update. The old object is
+// nil on create.
+func (r *Tempest) validate(ctx context.Context, reader client.Reader, old *Tempest) (admission.Warnings, error) {
 	var allErrs field.ErrorList
 	var allWarnings admission.Warnings
 
@@ -112,6 +151,112 @@ func (r *Tempest) ValidateCreate() (admission.Warnings, error) {
 		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOn, "Tempest"))
 	}
 
+	privileged := r.Spec.Privileged
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("SSHKeySecretName"), r.Spec.SSHKeySecretName},
+		{field.NewPath("spec").Child("openStackConfigSecret"), r.Spec.OpenStackConfigSecret},
+		{field.NewPath("spec").Child("tempestconfSecretName"), r.Spec.TempestconfSecretName},
+		{field.NewPath("spec").Child("accountsSecretName"), r.Spec.AccountsSecretName},
+	}
+	if r.Spec.Whitebox != nil {
+		secretRefs = append(secretRefs, secretReference{
+			field.NewPath("spec").Child("whitebox").Child("sshKeySecretName"), r.Spec.Whitebox.SSHKeySecretName,
+		})
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+		secretRefs = append(secretRefs, secretReference{
+			stepPath.Child("openStackConfigSecret"), step.OpenStackConfigSecret,
+		})
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.SSHKeySecretName != nil {
+			secretRefs = append(secretRefs, secretReference{
+				stepPath.Child("SSHKeySecretName"), *step.SSHKeySecretName,
+			})
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		overrideSpec := TempestSpec{}
+		err := validateSpecOverride(stepPath.Child("specOverride"), "Tempest", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else if overrideSpec.Privileged {
+			privileged = true
+		}
+	}
+
+	// Changes of the spec are executed only by a new run
+	if old != nil && !r.Spec.RerunOnSpecChange && !cmp.Equal(old.Spec, r.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), ErrTempestSpecUpdate))
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("Tempest", stepNames)...)
+	allErrs = append(allErrs, validateTempestPlugins(field.NewPath("spec").Child("plugins"), r.Spec.Plugins)...)
+	if r.Spec.Whitebox != nil {
+		allErrs = append(allErrs, validateWhiteboxNodes(
+			field.NewPath("spec").Child("whitebox").Child("nodes"), r.Spec.Whitebox.Nodes)...)
+	}
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "Tempest", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "Tempest", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "Tempest"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	if err := validateHostNetwork(field.NewPath("spec"), "Tempest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "Tempest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "Tempest", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateTempestLogsPersistence(field.NewPath("spec"), r.Spec); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if r.Spec.Privileged && len(r.Spec.Workflow) > 0 && len(r.Spec.SELinuxLevel) == 0 {
 		allWarnings = append(allWarnings, fmt.Sprintf(WarnSELinuxLevel, r.Kind))
 	}
@@ -127,29 +272,134 @@ func (r *Tempest) ValidateCreate() (admission.Warnings, error) {
 	return allWarnings, nil
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *Tempest) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+// validateUpdate checks the Tempest CR on update
+func (r *Tempest) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
 	tempestlog.Info("validate update", "name", r.Name)
 
 	oldTempest, ok := old.(*Tempest)
 	if !ok || oldTempest == nil {
-		return nil, errors.New("unable to convert existing object")
+		return nil, errors.New(ErrConvertOldObject)
 	}
 
-	if !cmp.Equal(oldTempest.Spec, r.Spec) {
-		warnings := admission.Warnings{}
-		warnings = append(warnings, "You are updating an already existing instance of a "+
-			"Tempest CR! Be aware that changes won't be applied.")
-
-		return warnings, errors.New("updating an existing Tempest CR is not supported")
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
 	}
-	return nil, nil
+
+	return r.validate(ctx, reader, oldTempest)
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *Tempest) ValidateDelete() (admission.Warnings, error) {
+// validateDelete checks the Tempest CR on delete
+func (r *Tempest) validateDelete() (admission.Warnings, error) {
 	tempestlog.Info("validate delete", "name", r.Name)
 
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil, nil
 }
+
+// simulatedSteps returns the test pods that would be spawned for the Tempest CR
+func (r *Tempest) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
+
+// validateTempestPlugins checks that the plugins can be safely passed to pip
+// as requirements
+func validateTempestPlugins(fldPath *field.Path, plugins []TempestPlugin) field.ErrorList {
+	var allErrs field.ErrorList
+	for idx, plugin := range plugins {
+		pluginPath := fldPath.Index(idx)
+		if len(plugin.Name) > 0 && !tempestPluginNameRegexp.MatchString(plugin.Name) {
+			allErrs = append(allErrs, field.Invalid(pluginPath.Child("name"), plugin.Name, ErrInvalidTempestPluginName))
+		}
+
+		if len(plugin.GitURL) > 0 && !isValidTempestPluginGitURL(plugin.GitURL) {
+			allErrs = append(allErrs, field.Invalid(pluginPath.Child("gitUrl"), plugin.GitURL, ErrInvalidTempestPluginGitURL))
+		}
+
+		if strings.ContainsAny(plugin.Ref, " \t\n#;") || strings.HasPrefix(plugin.Ref, "-") {
+			allErrs = append(allErrs, field.Invalid(pluginPath.Child("ref"), plugin.Ref, ErrInvalidTempestPluginRef))
+		}
+	}
+
+	return allErrs
+}
+
+func isValidTempestPluginGitURL(gitURL string) bool {
+	// The ref is appended to the URL after @ and #egg= may follow it
+	if strings.ContainsAny(gitURL, " \t\n#") {
+		return false
+	}
+
+	parsedURL, err := url.Parse(gitURL)
+	if err != nil || len(parsedURL.Host) == 0 || strings.Contains(parsedURL.Path, "@") {
+		return false
+	}
+
+	switch parsedURL.Scheme {
+	case "http", "https", "ssh", "git":
+		return true
+	default:
+		return false
+	}
+}
+
+// validateWhiteboxNodes checks that the nodes can be passed to the
+// whitebox.hypervisors option of tempest.conf (name:address,...)
+func validateWhiteboxNodes(fldPath *field.Path, nodes []WhiteboxNode) field.ErrorList {
+	var allErrs field.ErrorList
+	for idx, node := range nodes {
+		if node.Name == "" || strings.ContainsAny(node.Name, " \t\n,:") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(idx).Child("name"), node.Name, ErrInvalidWhiteboxNodeName))
+		}
+
+		if node.Address == "" || strings.ContainsAny(node.Address, " \t\n,") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(idx).Child("address"), node.Address,
+				ErrInvalidWhiteboxNodeAddress))
+		}
+	}
+
+	return allErrs
+}
+
+// validateTempestLogsPersistence returns an error when the failed tests are
+// rerun or the tests are sharded while the logs are not stored in the logs
+// PVC. The rerun pod and the merge pod read the logs of the other test pods.
+func validateTempestLogsPersistence(path *field.Path, spec TempestSpec) *field.Error {
+	if spec.Persistence == "" || spec.Persistence == LogsPersistencePVC {
+		return nil
+	}
+
+	rerunFailed := spec.TempestRun.RerunFailed
+	for _, step := range spec.Workflow {
+		if step.TempestRun.RerunFailed != nil && *step.TempestRun.RerunFailed {
+			rerunFailed = true
+		}
+	}
+
+	if rerunFailed {
+		return field.Invalid(path.Child("persistence"), spec.Persistence,
+			fmt.Sprintf(ErrLogsPersistenceRequired, "Tempest", "rerunFailed"))
+	}
+
+	if spec.Shards > 1 {
+		return field.Invalid(path.Child("persistence"), spec.Persistence,
+			fmt.Sprintf(ErrLogsPersistenceRequired, "Tempest", "shards"))
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultTestRunHistoryLimit is the number of the TestRun records kept
+	// for a test instance when spec.historyLimit is not set
+	DefaultTestRunHistoryLimit = 10
+)
+
+// TestRunSpec records a single finished run of a test instance. The record is
+// written once by the test-operator when all test pods of the run finish.
+type TestRunSpec struct {
+	// Kind of the test instance (e.g. AnsibleTest)
+	TestKind string `json:"testKind"`
+
+	// Name of the test instance
+	TestName string `json:"testName"`
+
+	// UID of the test instance. It distinguishes the runs of the test
+	// instances that were deleted and created again with the same name.
+	TestUID string `json:"testUID,omitempty"`
+
+	// ID of the run (see RunIDAnnotation)
+	RunID string `json:"runID"`
+
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// Spec of the test instance as it was when the run finished
+	TestSpec runtime.RawExtension `json:"testSpec"`
+
+	// Container images of the test pods indexed by the name of the pod
+	Images map[string]string `json:"images,omitempty"`
+
+	// ImageDigests contains the container images of the test pods pinned to
+	// a digest indexed by the container image they were resolved from
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+
+	// Time when the first test pod of the run started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Time when the last test pod of the run finished
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+
+	// Summary of the test pods of the run. It contains the verdict, the
+	// duration and the location of the logs of the last test pod.
+	Summary TestSummary `json:"summary"`
+
+	// Steps contains the test pods of the run and the location of their logs
+	Steps []TestStepStatus `json:"steps,omitempty"`
+
+	// Name of the ConfigMap that contains the TestResults of the run. The
+	// ConfigMap is owned by the test instance and it is deleted with it.
+	ResultsConfigMap string `json:"resultsConfigMap,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.testKind",description="Kind"
+//+kubebuilder:printcolumn:name="Test",type="string",JSONPath=".spec.testName",description="Test"
+//+kubebuilder:printcolumn:name="Verdict",type="string",JSONPath=".spec.summary.verdict",description="Verdict"
+//+kubebuilder:printcolumn:name="Duration",type="integer",JSONPath=".spec.summary.durationSeconds",description="Duration in seconds"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TestRun is the Schema for the testruns API. A TestRun is not owned by the
+// test instance so that the history of the runs outlives the instance.
+type TestRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TestRunSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TestRunList contains a list of TestRun
+type TestRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TestRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TestRun{}, &TestRunList{})
+}
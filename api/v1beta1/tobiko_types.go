@@ -25,6 +25,22 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// DisruptionWindow - daily maintenance window in which the test pods can be
+// created
+type DisruptionWindow struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Time of the day (HH:MM, UTC) when the window opens
+	Start string `json:"start"`
+
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// How long the window stays open (e.g. 2h30m). It must be greater than
+	// zero and at most 24h.
+	Duration metav1.Duration `json:"duration"`
+}
+
 // TobikoSpec defines the desired state of Tobiko
 type TobikoSpec struct {
 	CommonOptions `json:",inline"`
@@ -54,6 +70,19 @@ type TobikoSpec struct {
 	// String including any options to pass to pytest when it runs tobiko tests
 	PytestAddopts string `json:"pytestAddopts"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Marker expression that selects the tobiko tests to run (passed to
+	// pytest as -m), e.g. "ha and not slow"
+	PytestMarkers string `json:"pytestMarkers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Expression matched against the names of the tobiko tests that selects
+	// the tests to run (passed to pytest as -k), e.g. "test_reboot or
+	// test_network"
+	TestPattern string `json:"testPattern,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:default:=false
@@ -111,6 +140,17 @@ type TobikoSpec struct {
 	// the services to the given network
 	NetworkAttachments []string `json:"networkAttachments,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// DisruptionWindow - restricts the execution of the disruptive (e.g.
+	// faults) tests to a daily maintenance window. The test pods are created
+	// only while the window is open, a test pod that is already running is
+	// not stopped when the window closes. Until the window opens the
+	// DeploymentReady condition has the WaitingForWindow reason.
+	DisruptionWindow *DisruptionWindow `json:"disruptionWindow,omitempty"`
+
+	// +kubebuilder:validation:MaxItems:=100
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.stepName == x.stepName))",message="workflow step names must be unique"
 	// A parameter  that contains a workflow definition.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:validation:Optional
@@ -136,6 +176,18 @@ type TobikoWorkflowSpec struct {
 	// String including any options to pass to pytest when it runs tobiko tests
 	PytestAddopts string `json:"pytestAddopts,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Marker expression that selects the tobiko tests to run (passed to
+	// pytest as -m)
+	PytestMarkers string `json:"pytestMarkers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Expression matched against the names of the tobiko tests that selects
+	// the tests to run (passed to pytest as -k)
+	TestPattern string `json:"testPattern,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// Boolean specifying whether tobiko tests create new resources or re-use those previously created
@@ -188,7 +240,9 @@ type TobikoWorkflowSpec struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
 
 type Tobiko struct {
@@ -226,3 +280,18 @@ func (instance Tobiko) RbacNamespace() string {
 func (instance Tobiko) RbacResourceName() string {
 	return instance.Name
 }
+
+// GetCommonStatus - return the status of the instance
+func (instance *Tobiko) GetCommonStatus() *CommonTestStatus {
+	return &instance.Status
+}
+
+// GetStepNames - return the names of the workflow steps
+func (instance *Tobiko) GetStepNames() []string {
+	stepNames := []string{}
+	for _, step := range instance.Spec.Workflow {
+		stepNames = append(stepNames, step.StepName)
+	}
+
+	return stepNames
+}
@@ -23,47 +23,63 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+const (
+	// ErrInvalidDisruptionWindowDuration
+	ErrInvalidDisruptionWindowDuration = "the duration of the disruption window must be greater than 0 and at most 24h"
+)
+
 // log is for logging in this package.
 var tobikolog = logf.Log.WithName("tobiko-resource")
 
 func (r *Tobiko) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	hook := &testWebhook{reader: mgr.GetAPIReader()}
+
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(hook).
+		WithValidator(hook).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/mutate-test-openstack-org-v1beta1-tobiko,mutating=true,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=tobikoes,verbs=create;update,versions=v1beta1,name=mtobiko.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Defaulter = &Tobiko{}
+var _ testWebhookObject = &Tobiko{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type
-func (r *Tobiko) Default() {
+// setDefaults fills in the defaults of the Tobiko CR
+func (r *Tobiko) setDefaults(ctx context.Context, reader client.Reader) {
 	tobikolog.Info("default", "name", r.Name)
 
-	// TODO(user): fill in your defaulting logic.
+	defaultCommonOptions(ctx, reader, r.GetNamespace(), "Tobiko", &r.Spec.CommonOptions)
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:path=/validate-test-openstack-org-v1beta1-tobiko,mutating=false,failurePolicy=fail,sideEffects=None,groups=test.openstack.org,resources=tobikoes,verbs=create;update,versions=v1beta1,name=vtobiko.kb.io,admissionReviewVersions=v1
 
-var _ webhook.Validator = &Tobiko{}
-
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (r *Tobiko) ValidateCreate() (admission.Warnings, error) {
+// validateCreate checks the Tobiko CR on create
+func (r *Tobiko) validateCreate(ctx context.Context, reader client.Reader) (admission.Warnings, error) {
 	tobikolog.Info("validate create", "name", r.Name)
 
+	return r.validate(ctx, reader, nil)
+}
+
+// validate checks the Tobiko CR on both create and update. The old object is
+// nil on create.
+func (r *Tobiko) validate(ctx context.Context, reader client.Reader, old *Tobiko) (admission.Warnings, error) {
 	var allErrs field.ErrorList
 	var allWarnings admission.Warnings
 
@@ -81,6 +97,89 @@ func (r *Tobiko) ValidateCreate() (admission.Warnings, error) {
 		allWarnings = append(allWarnings, fmt.Sprintf(WarnPrivilegedModeOff, "Tobiko"))
 	}
 
+	privileged := r.Spec.Privileged
+	stepNames := []string{}
+	workflowImages := []string{}
+	secretRefs := []secretReference{
+		{field.NewPath("spec").Child("kubeconfigSecretName"), r.Spec.KubeconfigSecretName},
+	}
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("envFromSecrets"), r.Spec.EnvFromSecrets)...)
+	secretRefs = append(secretRefs,
+		getSecretListReferences(field.NewPath("spec").Child("imagePullSecrets"), r.Spec.ImagePullSecrets)...)
+
+	for idx, step := range r.Spec.Workflow {
+		stepPath := field.NewPath("spec").Child("workflow").Index(idx)
+		stepNames = append(stepNames, step.StepName)
+		workflowImages = append(workflowImages, step.ContainerImage)
+		secretRefs = append(secretRefs, secretReference{
+			stepPath.Child("kubeconfigSecretName"), step.KubeconfigSecretName,
+		})
+
+		if step.EnvFromSecrets != nil {
+			secretRefs = append(secretRefs,
+				getSecretListReferences(stepPath.Child("envFromSecrets"), *step.EnvFromSecrets)...)
+		}
+
+		if step.Privileged != nil && *step.Privileged {
+			privileged = true
+		}
+
+		overrideSpec := TobikoSpec{}
+		err := validateSpecOverride(stepPath.Child("specOverride"), "Tobiko", step.SpecOverride, &overrideSpec)
+		if err != nil {
+			allErrs = append(allErrs, err)
+		} else if overrideSpec.Privileged {
+			privileged = true
+		}
+	}
+
+	allErrs = append(allErrs, validateWorkflowStepNames("Tobiko", stepNames)...)
+
+	if err := validateContainerImage(ctx, reader, r.GetNamespace(), "Tobiko", r.Spec.ContainerImage, workflowImages); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validatePrivilegedNamespace(ctx, reader, r.GetNamespace(), "Tobiko", privileged); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	secretErrs, secretWarnings := validateSecretReferences(ctx, reader, r.GetNamespace(), secretRefs)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
+	if r.Spec.PodTemplateOverrides != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf(WarnPodTemplateOverrides, "Tobiko"))
+	}
+
+	podOverridesPath := field.NewPath("spec").Child("podTemplateOverrides")
+	if err := validatePodTemplateOverrides(podOverridesPath, r.Spec.PodTemplateOverrides); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateFeatureGatesAnnotation(r.GetAnnotations()); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validatePodMetadata(field.NewPath("spec"), r.Spec.CommonOptions)...)
+
+	windowPath := field.NewPath("spec").Child("disruptionWindow")
+	if err := validateDisruptionWindow(windowPath, r.Spec.DisruptionWindow); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateHostNetwork(field.NewPath("spec"), "Tobiko", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContext(field.NewPath("spec"), "Tobiko", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateSecurityContextConstraint(field.NewPath("spec"), "Tobiko", r.Spec.CommonOptions); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) > 0 {
 		return allWarnings, apierrors.NewInvalid(
 			schema.GroupKind{
@@ -96,18 +195,59 @@ func (r *Tobiko) ValidateCreate() (admission.Warnings, error) {
 	return allWarnings, nil
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *Tobiko) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+// validateUpdate checks the Tobiko CR on update
+func (r *Tobiko) validateUpdate(ctx context.Context, reader client.Reader, old runtime.Object) (admission.Warnings, error) {
 	tobikolog.Info("validate update", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	oldTobiko, ok := old.(*Tobiko)
+	if !ok || oldTobiko == nil {
+		return nil, errors.New(ErrConvertOldObject)
+	}
+
+	// The finalizers of a CR that is being deleted have to be removable even
+	// when the CR would no longer pass the validation
+	if !r.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return r.validate(ctx, reader, oldTobiko)
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *Tobiko) ValidateDelete() (admission.Warnings, error) {
+// validateDelete checks the Tobiko CR on delete
+func (r *Tobiko) validateDelete() (admission.Warnings, error) {
 	tobikolog.Info("validate delete", "name", r.Name)
 
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil, nil
 }
+
+// simulatedSteps returns the test pods that would be spawned for the Tobiko CR
+func (r *Tobiko) simulatedSteps() []simulatedStep {
+	if len(r.Spec.Workflow) == 0 {
+		return []simulatedStep{{ContainerImage: r.Spec.ContainerImage}}
+	}
+
+	steps := []simulatedStep{}
+	for _, step := range r.Spec.Workflow {
+		containerImage := r.Spec.ContainerImage
+		if len(step.ContainerImage) > 0 {
+			containerImage = step.ContainerImage
+		}
+
+		steps = append(steps, simulatedStep{Name: step.StepName, ContainerImage: containerImage})
+	}
+
+	return steps
+}
+
+func validateDisruptionWindow(fldPath *field.Path, window *DisruptionWindow) *field.Error {
+	if window == nil {
+		return nil
+	}
+
+	if window.Duration.Duration <= 0 || window.Duration.Duration > 24*time.Hour {
+		return field.Invalid(fldPath.Child("duration"), window.Duration.String(), ErrInvalidDisruptionWindowDuration)
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ package v1beta1
 import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -91,6 +92,82 @@ func (in *AnsibleTestSpec) DeepCopyInto(out *AnsibleTestSpec) {
 	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
 	out.CommonOpenstackConfig = in.CommonOpenstackConfig
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.AnsiblePlaybooks != nil {
+		in, out := &in.AnsiblePlaybooks, &out.AnsiblePlaybooks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnsibleVarFilesFrom != nil {
+		in, out := &in.AnsibleVarFilesFrom, &out.AnsibleVarFilesFrom
+		*out = make([]AnsibleVarFileSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnsibleExtraVarsMap != nil {
+		in, out := &in.AnsibleExtraVarsMap, &out.AnsibleExtraVarsMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AnsibleInventoryConfigMap != nil {
+		in, out := &in.AnsibleInventoryConfigMap, &out.AnsibleInventoryConfigMap
+		*out = new(InventoryConfigMap)
+		**out = **in
+	}
+	if in.AnsibleInventoryFromNodeSets != nil {
+		in, out := &in.AnsibleInventoryFromNodeSets, &out.AnsibleInventoryFromNodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnsibleCallbacks != nil {
+		in, out := &in.AnsibleCallbacks, &out.AnsibleCallbacks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnsibleTags != nil {
+		in, out := &in.AnsibleTags, &out.AnsibleTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnsibleSkipTags != nil {
+		in, out := &in.AnsibleSkipTags, &out.AnsibleSkipTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnsibleExtraArgs != nil {
+		in, out := &in.AnsibleExtraArgs, &out.AnsibleExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OfflineBundle != nil {
+		in, out := &in.OfflineBundle, &out.OfflineBundle
+		*out = new(OfflineBundle)
+		**out = **in
+	}
+	if in.AnsibleGalaxyRequirements != nil {
+		in, out := &in.AnsibleGalaxyRequirements, &out.AnsibleGalaxyRequirements
+		*out = new(GalaxyRequirements)
+		**out = **in
+	}
+	if in.AnsibleAra != nil {
+		in, out := &in.AnsibleAra, &out.AnsibleAra
+		*out = new(AraConfig)
+		**out = **in
+	}
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = make([]ExtraMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Workflow != nil {
 		in, out := &in.Workflow, &out.Workflow
 		*out = make([]AnsibleTestWorkflowSpec, len(*in))
@@ -120,6 +197,128 @@ func (in *AnsibleTestWorkflowSpec) DeepCopyInto(out *AnsibleTestWorkflowSpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AnsiblePlaybooks != nil {
+		in, out := &in.AnsiblePlaybooks, &out.AnsiblePlaybooks
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.AnsibleVarFilesFrom != nil {
+		in, out := &in.AnsibleVarFilesFrom, &out.AnsibleVarFilesFrom
+		*out = new([]AnsibleVarFileSource)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]AnsibleVarFileSource, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.AnsibleExtraVarsMap != nil {
+		in, out := &in.AnsibleExtraVarsMap, &out.AnsibleExtraVarsMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AnsibleInventoryConfigMap != nil {
+		in, out := &in.AnsibleInventoryConfigMap, &out.AnsibleInventoryConfigMap
+		*out = new(InventoryConfigMap)
+		**out = **in
+	}
+	if in.AnsibleInventoryFromNodeSets != nil {
+		in, out := &in.AnsibleInventoryFromNodeSets, &out.AnsibleInventoryFromNodeSets
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.AnsibleCallbacks != nil {
+		in, out := &in.AnsibleCallbacks, &out.AnsibleCallbacks
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.AnsibleTags != nil {
+		in, out := &in.AnsibleTags, &out.AnsibleTags
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.AnsibleSkipTags != nil {
+		in, out := &in.AnsibleSkipTags, &out.AnsibleSkipTags
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.AnsibleExtraArgs != nil {
+		in, out := &in.AnsibleExtraArgs, &out.AnsibleExtraArgs
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AnsibleCheckMode != nil {
+		in, out := &in.AnsibleCheckMode, &out.AnsibleCheckMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AnsibleDiff != nil {
+		in, out := &in.AnsibleDiff, &out.AnsibleDiff
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AnsibleVerbosity != nil {
+		in, out := &in.AnsibleVerbosity, &out.AnsibleVerbosity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AnsibleForks != nil {
+		in, out := &in.AnsibleForks, &out.AnsibleForks
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = new([]ExtraMount)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]ExtraMount, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleTestWorkflowSpec.
@@ -132,6 +331,86 @@ func (in *AnsibleTestWorkflowSpec) DeepCopy() *AnsibleTestWorkflowSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnsibleVarFileSource) DeepCopyInto(out *AnsibleVarFileSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnsibleVarFileSource.
+func (in *AnsibleVarFileSource) DeepCopy() *AnsibleVarFileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AnsibleVarFileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AraConfig) DeepCopyInto(out *AraConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AraConfig.
+func (in *AraConfig) DeepCopy() *AraConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AraConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupAssertion) DeepCopyInto(out *CleanupAssertion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupAssertion.
+func (in *CleanupAssertion) DeepCopy() *CleanupAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonOpenstackConfig) DeepCopyInto(out *CommonOpenstackConfig) {
 	*out = *in
@@ -150,6 +429,16 @@ func (in *CommonOpenstackConfig) DeepCopy() *CommonOpenstackConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonOptions) DeepCopyInto(out *CommonOptions) {
 	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CosignPublicKey != nil {
+		in, out := &in.CosignPublicKey, &out.CosignPublicKey
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BackoffLimit != nil {
 		in, out := &in.BackoffLimit, &out.BackoffLimit
 		*out = new(int32)
@@ -160,6 +449,16 @@ func (in *CommonOptions) DeepCopyInto(out *CommonOptions) {
 		*out = make([]ExtraConfigmapsMounts, len(*in))
 		copy(*out, *in)
 	}
+	if in.EnvFromSecrets != nil {
+		in, out := &in.EnvFromSecrets, &out.EnvFromSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvFromConfigMaps != nil {
+		in, out := &in.EnvFromConfigMaps, &out.EnvFromConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -167,57 +466,295 @@ func (in *CommonOptions) DeepCopyInto(out *CommonOptions) {
 			(*out)[key] = val
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(TestSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
+	if in.MountCABundle != nil {
+		in, out := &in.MountCABundle, &out.MountCABundle
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CABundleSources != nil {
+		in, out := &in.CABundleSources, &out.CABundleSources
+		*out = make([]CABundleSource, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonOptions.
-func (in *CommonOptions) DeepCopy() *CommonOptions {
-	if in == nil {
-		return nil
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(CommonOptions)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CommonTestStatus) DeepCopyInto(out *CommonTestStatus) {
-	*out = *in
-	if in.Hash != nil {
-		in, out := &in.Hash, &out.Hash
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(condition.Conditions, len(*in))
+	if in.IOLimits != nil {
+		in, out := &in.IOLimits, &out.IOLimits
+		*out = new(IOLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.NetworkAttachments != nil {
-		in, out := &in.NetworkAttachments, &out.NetworkAttachments
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
+	if in.CleanupAssertions != nil {
+		in, out := &in.CleanupAssertions, &out.CleanupAssertions
+		*out = make([]CleanupAssertion, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogForwarding != nil {
+		in, out := &in.LogForwarding, &out.LogForwarding
+		*out = new(LogForwarding)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogsRotation != nil {
+		in, out := &in.LogsRotation, &out.LogsRotation
+		*out = new(LogsRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogRetention != nil {
+		in, out := &in.LogRetention, &out.LogRetention
+		*out = new(LogRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogsServer != nil {
+		in, out := &in.LogsServer, &out.LogsServer
+		*out = new(LogsServer)
+		**out = **in
+	}
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(Report)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReportPortal != nil {
+		in, out := &in.ReportPortal, &out.ReportPortal
+		*out = new(ReportPortal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Polarion != nil {
+		in, out := &in.Polarion, &out.Polarion
+		*out = new(Polarion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DebugHold != nil {
+		in, out := &in.DebugHold, &out.DebugHold
+		*out = new(DebugHold)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkAttachmentIPFamilies != nil {
+		in, out := &in.NetworkAttachmentIPFamilies, &out.NetworkAttachmentIPFamilies
+		*out = make(map[string]IPFamily, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NetworkSelections != nil {
+		in, out := &in.NetworkSelections, &out.NetworkSelections
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTemplateOverrides != nil {
+		in, out := &in.PodTemplateOverrides, &out.PodTemplateOverrides
+		*out = new(PodTemplateOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonOptions.
+func (in *CommonOptions) DeepCopy() *CommonOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonTestStatus) DeepCopyInto(out *CommonTestStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TestStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.LockWaitStartTime != nil {
+		in, out := &in.LockWaitStartTime, &out.LockWaitStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndpointChecks != nil {
+		in, out := &in.EndpointChecks, &out.EndpointChecks
+		*out = make([]EndpointCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureSnapshots != nil {
+		in, out := &in.FailureSnapshots, &out.FailureSnapshots
+		*out = make([]FailureSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make([]TimelineEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IOLimits != nil {
+		in, out := &in.IOLimits, &out.IOLimits
+		*out = make(map[string]IOLimits, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ImageDigests != nil {
+		in, out := &in.ImageDigests, &out.ImageDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StestrComparisons != nil {
+		in, out := &in.StestrComparisons, &out.StestrComparisons
+		*out = make([]StestrComparison, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TestLists != nil {
+		in, out := &in.TestLists, &out.TestLists
+		*out = make([]TestList, len(*in))
+		copy(*out, *in)
+	}
+	if in.RallySLAResults != nil {
+		in, out := &in.RallySLAResults, &out.RallySLAResults
+		*out = make([]RallySLAResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FioResults != nil {
+		in, out := &in.FioResults, &out.FioResults
+		*out = make([]FioResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.K6ThresholdResults != nil {
+		in, out := &in.K6ThresholdResults, &out.K6ThresholdResults
+		*out = make([]K6ThresholdResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RobotResults != nil {
+		in, out := &in.RobotResults, &out.RobotResults
+		*out = make([]RobotResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomTestResults != nil {
+		in, out := &in.CustomTestResults, &out.CustomTestResults
+		*out = make([]CustomTestResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrunedLogs != nil {
+		in, out := &in.PrunedLogs, &out.PrunedLogs
+		*out = make([]PrunedLogs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReportPortalLaunches != nil {
+		in, out := &in.ReportPortalLaunches, &out.ReportPortalLaunches
+		*out = make([]ReportPortalLaunch, len(*in))
+		copy(*out, *in)
 	}
 }
 
@@ -232,87 +769,2098 @@ func (in *CommonTestStatus) DeepCopy() *CommonTestStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalPluginType) DeepCopyInto(out *ExternalPluginType) {
+func (in *CustomTest) DeepCopyInto(out *CustomTest) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPluginType.
-func (in *ExternalPluginType) DeepCopy() *ExternalPluginType {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTest.
+func (in *CustomTest) DeepCopy() *CustomTest {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomTestList) DeepCopyInto(out *CustomTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CustomTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTestList.
+func (in *CustomTestList) DeepCopy() *CustomTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomTestResult) DeepCopyInto(out *CustomTestResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTestResult.
+func (in *CustomTestResult) DeepCopy() *CustomTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomTestSpec) DeepCopyInto(out *CustomTestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]CustomTestWorkflowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTestSpec.
+func (in *CustomTestSpec) DeepCopy() *CustomTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomTestWorkflowSpec) DeepCopyInto(out *CustomTestWorkflowSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTestWorkflowSpec.
+func (in *CustomTestWorkflowSpec) DeepCopy() *CustomTestWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomTestWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugHold) DeepCopyInto(out *DebugHold) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugHold.
+func (in *DebugHold) DeepCopy() *DebugHold {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugHold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionWindow) DeepCopyInto(out *DisruptionWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionWindow.
+func (in *DisruptionWindow) DeepCopy() *DisruptionWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointCheck) DeepCopyInto(out *EndpointCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointCheck.
+func (in *EndpointCheck) DeepCopy() *EndpointCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalPluginType) DeepCopyInto(out *ExternalPluginType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPluginType.
+func (in *ExternalPluginType) DeepCopy() *ExternalPluginType {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalPluginType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraConfigmapsMounts) DeepCopyInto(out *ExtraConfigmapsMounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraConfigmapsMounts.
+func (in *ExtraConfigmapsMounts) DeepCopy() *ExtraConfigmapsMounts {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraConfigmapsMounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraImagesFlavorType) DeepCopyInto(out *ExtraImagesFlavorType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraImagesFlavorType.
+func (in *ExtraImagesFlavorType) DeepCopy() *ExtraImagesFlavorType {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraImagesFlavorType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraImagesType) DeepCopyInto(out *ExtraImagesType) {
+	*out = *in
+	out.Flavor = in.Flavor
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraImagesType.
+func (in *ExtraImagesType) DeepCopy() *ExtraImagesType {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraImagesType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraMount) DeepCopyInto(out *ExtraMount) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(v1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraMount.
+func (in *ExtraMount) DeepCopy() *ExtraMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureSnapshot) DeepCopyInto(out *FailureSnapshot) {
+	*out = *in
+	in.CaptureTime.DeepCopyInto(&out.CaptureTime)
+	if in.NodeConditions != nil {
+		in, out := &in.NodeConditions, &out.NodeConditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureSnapshot.
+func (in *FailureSnapshot) DeepCopy() *FailureSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioCinderTarget) DeepCopyInto(out *FioCinderTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioCinderTarget.
+func (in *FioCinderTarget) DeepCopy() *FioCinderTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(FioCinderTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioPVCTarget) DeepCopyInto(out *FioPVCTarget) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioPVCTarget.
+func (in *FioPVCTarget) DeepCopy() *FioPVCTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(FioPVCTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioProfile) DeepCopyInto(out *FioProfile) {
+	*out = *in
+	out.Runtime = in.Runtime
+	if in.RWMixRead != nil {
+		in, out := &in.RWMixRead, &out.RWMixRead
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioProfile.
+func (in *FioProfile) DeepCopy() *FioProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(FioProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioProfileResult) DeepCopyInto(out *FioProfileResult) {
+	*out = *in
+	if in.ReadLatencyMean != nil {
+		in, out := &in.ReadLatencyMean, &out.ReadLatencyMean
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReadLatencyP99 != nil {
+		in, out := &in.ReadLatencyP99, &out.ReadLatencyP99
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WriteLatencyMean != nil {
+		in, out := &in.WriteLatencyMean, &out.WriteLatencyMean
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WriteLatencyP99 != nil {
+		in, out := &in.WriteLatencyP99, &out.WriteLatencyP99
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioProfileResult.
+func (in *FioProfileResult) DeepCopy() *FioProfileResult {
+	if in == nil {
+		return nil
+	}
+	out := new(FioProfileResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioResult) DeepCopyInto(out *FioResult) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]FioProfileResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioResult.
+func (in *FioResult) DeepCopy() *FioResult {
+	if in == nil {
+		return nil
+	}
+	out := new(FioResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioTest) DeepCopyInto(out *FioTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioTest.
+func (in *FioTest) DeepCopy() *FioTest {
+	if in == nil {
+		return nil
+	}
+	out := new(FioTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FioTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioTestList) DeepCopyInto(out *FioTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FioTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioTestList.
+func (in *FioTestList) DeepCopy() *FioTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(FioTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FioTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioTestSpec) DeepCopyInto(out *FioTestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(FioPVCTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cinder != nil {
+		in, out := &in.Cinder, &out.Cinder
+		*out = new(FioCinderTarget)
+		**out = **in
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]FioProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]FioTestWorkflowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioTestSpec.
+func (in *FioTestSpec) DeepCopy() *FioTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FioTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FioTestWorkflowSpec) DeepCopyInto(out *FioTestWorkflowSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(FioPVCTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cinder != nil {
+		in, out := &in.Cinder, &out.Cinder
+		*out = new(FioCinderTarget)
+		**out = **in
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]FioProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioTestWorkflowSpec.
+func (in *FioTestWorkflowSpec) DeepCopy() *FioTestWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FioTestWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GalaxyRequirements) DeepCopyInto(out *GalaxyRequirements) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GalaxyRequirements.
+func (in *GalaxyRequirements) DeepCopy() *GalaxyRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(GalaxyRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonTest) DeepCopyInto(out *HorizonTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTest.
+func (in *HorizonTest) DeepCopy() *HorizonTest {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HorizonTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonTestList) DeepCopyInto(out *HorizonTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HorizonTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTestList.
+func (in *HorizonTestList) DeepCopy() *HorizonTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HorizonTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonTestSpec) DeepCopyInto(out *HorizonTestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Browsers != nil {
+		in, out := &in.Browsers, &out.Browsers
+		*out = make([]HorizonTestBrowser, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTestSpec.
+func (in *HorizonTestSpec) DeepCopy() *HorizonTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOLimits) DeepCopyInto(out *IOLimits) {
+	*out = *in
+	if in.IngressBandwidth != nil {
+		in, out := &in.IngressBandwidth, &out.IngressBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EgressBandwidth != nil {
+		in, out := &in.EgressBandwidth, &out.EgressBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOLimits.
+func (in *IOLimits) DeepCopy() *IOLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(IOLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryConfigMap) DeepCopyInto(out *InventoryConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryConfigMap.
+func (in *InventoryConfigMap) DeepCopy() *InventoryConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6ScriptConfigMap) DeepCopyInto(out *K6ScriptConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6ScriptConfigMap.
+func (in *K6ScriptConfigMap) DeepCopy() *K6ScriptConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(K6ScriptConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6Stage) DeepCopyInto(out *K6Stage) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6Stage.
+func (in *K6Stage) DeepCopy() *K6Stage {
+	if in == nil {
+		return nil
+	}
+	out := new(K6Stage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6Test) DeepCopyInto(out *K6Test) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6Test.
+func (in *K6Test) DeepCopy() *K6Test {
+	if in == nil {
+		return nil
+	}
+	out := new(K6Test)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *K6Test) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6TestList) DeepCopyInto(out *K6TestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]K6Test, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6TestList.
+func (in *K6TestList) DeepCopy() *K6TestList {
+	if in == nil {
+		return nil
+	}
+	out := new(K6TestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *K6TestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6TestSpec) DeepCopyInto(out *K6TestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ScriptConfigMap != nil {
+		in, out := &in.ScriptConfigMap, &out.ScriptConfigMap
+		*out = new(K6ScriptConfigMap)
+		**out = **in
+	}
+	if in.TargetServices != nil {
+		in, out := &in.TargetServices, &out.TargetServices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetURLs != nil {
+		in, out := &in.TargetURLs, &out.TargetURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]K6Stage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make([]K6Threshold, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]K6TestWorkflowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6TestSpec.
+func (in *K6TestSpec) DeepCopy() *K6TestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(K6TestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6TestWorkflowSpec) DeepCopyInto(out *K6TestWorkflowSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScriptConfigMap != nil {
+		in, out := &in.ScriptConfigMap, &out.ScriptConfigMap
+		*out = new(K6ScriptConfigMap)
+		**out = **in
+	}
+	if in.TargetServices != nil {
+		in, out := &in.TargetServices, &out.TargetServices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetURLs != nil {
+		in, out := &in.TargetURLs, &out.TargetURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VUs != nil {
+		in, out := &in.VUs, &out.VUs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]K6Stage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make([]K6Threshold, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6TestWorkflowSpec.
+func (in *K6TestWorkflowSpec) DeepCopy() *K6TestWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(K6TestWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6Threshold) DeepCopyInto(out *K6Threshold) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6Threshold.
+func (in *K6Threshold) DeepCopy() *K6Threshold {
+	if in == nil {
+		return nil
+	}
+	out := new(K6Threshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K6ThresholdResult) DeepCopyInto(out *K6ThresholdResult) {
+	*out = *in
+	if in.FailedThresholds != nil {
+		in, out := &in.FailedThresholds, &out.FailedThresholds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K6ThresholdResult.
+func (in *K6ThresholdResult) DeepCopy() *K6ThresholdResult {
+	if in == nil {
+		return nil
+	}
+	out := new(K6ThresholdResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogForwarding) DeepCopyInto(out *LogForwarding) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogForwarding.
+func (in *LogForwarding) DeepCopy() *LogForwarding {
+	if in == nil {
+		return nil
+	}
+	out := new(LogForwarding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRetention) DeepCopyInto(out *LogRetention) {
+	*out = *in
+	if in.KeepLastRuns != nil {
+		in, out := &in.KeepLastRuns, &out.KeepLastRuns
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRetention.
+func (in *LogRetention) DeepCopy() *LogRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsRotation) DeepCopyInto(out *LogsRotation) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsRotation.
+func (in *LogsRotation) DeepCopy() *LogsRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsServer) DeepCopyInto(out *LogsServer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsServer.
+func (in *LogsServer) DeepCopy() *LogsServer {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineBundle) DeepCopyInto(out *OfflineBundle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineBundle.
+func (in *OfflineBundle) DeepCopy() *OfflineBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateOverrides) DeepCopyInto(out *PodTemplateOverrides) {
+	*out = *in
+	if in.StrategicMergePatch != nil {
+		in, out := &in.StrategicMergePatch, &out.StrategicMergePatch
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateOverrides.
+func (in *PodTemplateOverrides) DeepCopy() *PodTemplateOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Polarion) DeepCopyInto(out *Polarion) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Polarion.
+func (in *Polarion) DeepCopy() *Polarion {
+	if in == nil {
+		return nil
+	}
+	out := new(Polarion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrunedLogs) DeepCopyInto(out *PrunedLogs) {
+	*out = *in
+	if in.Directories != nil {
+		in, out := &in.Directories, &out.Directories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrunedLogs.
+func (in *PrunedLogs) DeepCopy() *PrunedLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(PrunedLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RallySLA) DeepCopyInto(out *RallySLA) {
+	*out = *in
+	if in.MaxFailureRate != nil {
+		in, out := &in.MaxFailureRate, &out.MaxFailureRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAvgDuration != nil {
+		in, out := &in.MaxAvgDuration, &out.MaxAvgDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxIterationDuration != nil {
+		in, out := &in.MaxIterationDuration, &out.MaxIterationDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RallySLA.
+func (in *RallySLA) DeepCopy() *RallySLA {
+	if in == nil {
+		return nil
+	}
+	out := new(RallySLA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RallySLAResult) DeepCopyInto(out *RallySLAResult) {
+	*out = *in
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RallySLAResult.
+func (in *RallySLAResult) DeepCopy() *RallySLAResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RallySLAResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RallyTaskConfigMap) DeepCopyInto(out *RallyTaskConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RallyTaskConfigMap.
+func (in *RallyTaskConfigMap) DeepCopy() *RallyTaskConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(RallyTaskConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RallyTest) DeepCopyInto(out *RallyTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RallyTest.
+func (in *RallyTest) DeepCopy() *RallyTest {
+	if in == nil {
+		return nil
+	}
+	out := new(RallyTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RallyTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RallyTestList) DeepCopyInto(out *RallyTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RallyTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RallyTestList.
+func (in *RallyTestList) DeepCopy() *RallyTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(RallyTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RallyTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RallyTestSpec) DeepCopyInto(out *RallyTestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.TaskConfigMap != nil {
+		in, out := &in.TaskConfigMap, &out.TaskConfigMap
+		*out = new(RallyTaskConfigMap)
+		**out = **in
+	}
+	if in.SLA != nil {
+		in, out := &in.SLA, &out.SLA
+		*out = new(RallySLA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]RallyTestWorkflowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RallyTestSpec.
+func (in *RallyTestSpec) DeepCopy() *RallyTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RallyTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RallyTestWorkflowSpec) DeepCopyInto(out *RallyTestWorkflowSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskConfigMap != nil {
+		in, out := &in.TaskConfigMap, &out.TaskConfigMap
+		*out = new(RallyTaskConfigMap)
+		**out = **in
+	}
+	if in.SLA != nil {
+		in, out := &in.SLA, &out.SLA
+		*out = new(RallySLA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RallyTestWorkflowSpec.
+func (in *RallyTestWorkflowSpec) DeepCopy() *RallyTestWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RallyTestWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Report) DeepCopyInto(out *Report) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Report.
+func (in *Report) DeepCopy() *Report {
+	if in == nil {
+		return nil
+	}
+	out := new(Report)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportPortal) DeepCopyInto(out *ReportPortal) {
+	*out = *in
+	if in.LaunchAttributes != nil {
+		in, out := &in.LaunchAttributes, &out.LaunchAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportPortal.
+func (in *ReportPortal) DeepCopy() *ReportPortal {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportPortal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportPortalLaunch) DeepCopyInto(out *ReportPortalLaunch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportPortalLaunch.
+func (in *ReportPortalLaunch) DeepCopy() *ReportPortalLaunch {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportPortalLaunch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotResult) DeepCopyInto(out *RobotResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotResult.
+func (in *RobotResult) DeepCopy() *RobotResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotTest) DeepCopyInto(out *RobotTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotTest.
+func (in *RobotTest) DeepCopy() *RobotTest {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RobotTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotTestList) DeepCopyInto(out *RobotTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RobotTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotTestList.
+func (in *RobotTestList) DeepCopy() *RobotTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RobotTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotTestSpec) DeepCopyInto(out *RobotTestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VariablesFromSecrets != nil {
+		in, out := &in.VariablesFromSecrets, &out.VariablesFromSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeTags != nil {
+		in, out := &in.IncludeTags, &out.IncludeTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeTags != nil {
+		in, out := &in.ExcludeTags, &out.ExcludeTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]RobotTestWorkflowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotTestSpec.
+func (in *RobotTestSpec) DeepCopy() *RobotTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotTestWorkflowSpec) DeepCopyInto(out *RobotTestWorkflowSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VariablesFromSecrets != nil {
+		in, out := &in.VariablesFromSecrets, &out.VariablesFromSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeTags != nil {
+		in, out := &in.IncludeTags, &out.IncludeTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeTags != nil {
+		in, out := &in.ExcludeTags, &out.ExcludeTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotTestWorkflowSpec.
+func (in *RobotTestWorkflowSpec) DeepCopy() *RobotTestWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotTestWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShakerAccommodation) DeepCopyInto(out *ShakerAccommodation) {
+	*out = *in
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = make([]ShakerPlacement, len(*in))
+		copy(*out, *in)
+	}
+	if in.Density != nil {
+		in, out := &in.Density, &out.Density
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ComputeNodes != nil {
+		in, out := &in.ComputeNodes, &out.ComputeNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShakerAccommodation.
+func (in *ShakerAccommodation) DeepCopy() *ShakerAccommodation {
+	if in == nil {
+		return nil
+	}
+	out := new(ShakerAccommodation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShakerTest) DeepCopyInto(out *ShakerTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShakerTest.
+func (in *ShakerTest) DeepCopy() *ShakerTest {
+	if in == nil {
+		return nil
+	}
+	out := new(ShakerTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShakerTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShakerTestList) DeepCopyInto(out *ShakerTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ShakerTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShakerTestList.
+func (in *ShakerTestList) DeepCopy() *ShakerTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(ShakerTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShakerTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShakerTestSpec) DeepCopyInto(out *ShakerTestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Scenarios != nil {
+		in, out := &in.Scenarios, &out.Scenarios
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Accommodation != nil {
+		in, out := &in.Accommodation, &out.Accommodation
+		*out = new(ShakerAccommodation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]ShakerTestWorkflowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShakerTestSpec.
+func (in *ShakerTestSpec) DeepCopy() *ShakerTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShakerTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShakerTestWorkflowSpec) DeepCopyInto(out *ShakerTestWorkflowSpec) {
+	*out = *in
+	in.WorkflowCommonParameters.DeepCopyInto(&out.WorkflowCommonParameters)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scenarios != nil {
+		in, out := &in.Scenarios, &out.Scenarios
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Accommodation != nil {
+		in, out := &in.Accommodation, &out.Accommodation
+		*out = new(ShakerAccommodation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShakerTestWorkflowSpec.
+func (in *ShakerTestWorkflowSpec) DeepCopy() *ShakerTestWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShakerTestWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StestrComparison) DeepCopyInto(out *StestrComparison) {
+	*out = *in
+	if in.NewFailures != nil {
+		in, out := &in.NewFailures, &out.NewFailures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FixedTests != nil {
+		in, out := &in.FixedTests, &out.FixedTests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimingRegressions != nil {
+		in, out := &in.TimingRegressions, &out.TimingRegressions
+		*out = make([]StestrTimingRegression, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StestrComparison.
+func (in *StestrComparison) DeepCopy() *StestrComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(StestrComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StestrHistorySpec) DeepCopyInto(out *StestrHistorySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StestrHistorySpec.
+func (in *StestrHistorySpec) DeepCopy() *StestrHistorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StestrHistorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StestrTimingRegression) DeepCopyInto(out *StestrTimingRegression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StestrTimingRegression.
+func (in *StestrTimingRegression) DeepCopy() *StestrTimingRegression {
+	if in == nil {
+		return nil
+	}
+	out := new(StestrTimingRegression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tempest) DeepCopyInto(out *Tempest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tempest.
+func (in *Tempest) DeepCopy() *Tempest {
+	if in == nil {
+		return nil
+	}
+	out := new(Tempest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Tempest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestDefaults) DeepCopyInto(out *TempestDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestDefaults.
+func (in *TempestDefaults) DeepCopy() *TempestDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestList) DeepCopyInto(out *TempestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Tempest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestList.
+func (in *TempestList) DeepCopy() *TempestList {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TempestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestPlugin) DeepCopyInto(out *TempestPlugin) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestPlugin.
+func (in *TempestPlugin) DeepCopy() *TempestPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestRunSpec) DeepCopyInto(out *TempestRunSpec) {
+	*out = *in
+	if in.IncludeListConfigMap != nil {
+		in, out := &in.IncludeListConfigMap, &out.IncludeListConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeListConfigMap != nil {
+		in, out := &in.ExcludeListConfigMap, &out.ExcludeListConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalPlugin != nil {
+		in, out := &in.ExternalPlugin, &out.ExternalPlugin
+		*out = make([]ExternalPluginType, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraRPMs != nil {
+		in, out := &in.ExtraRPMs, &out.ExtraRPMs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraImages != nil {
+		in, out := &in.ExtraImages, &out.ExtraImages
+		*out = make([]ExtraImagesType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestRunSpec.
+func (in *TempestRunSpec) DeepCopy() *TempestRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestSpec) DeepCopyInto(out *TempestSpec) {
+	*out = *in
+	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
+	out.CommonOpenstackConfig = in.CommonOpenstackConfig
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.TempestRun.DeepCopyInto(&out.TempestRun)
+	out.TempestconfRun = in.TempestconfRun
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]TempestPlugin, len(*in))
+		copy(*out, *in)
+	}
+	if in.Whitebox != nil {
+		in, out := &in.Whitebox, &out.Whitebox
+		*out = new(WhiteboxSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigOverwrite != nil {
+		in, out := &in.ConfigOverwrite, &out.ConfigOverwrite
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StestrHistory != nil {
+		in, out := &in.StestrHistory, &out.StestrHistory
+		*out = new(StestrHistorySpec)
+		**out = **in
+	}
+	if in.Workflow != nil {
+		in, out := &in.Workflow, &out.Workflow
+		*out = make([]WorkflowTempestSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestSpec.
+func (in *TempestSpec) DeepCopy() *TempestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TempestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TempestconfRunSpec) DeepCopyInto(out *TempestconfRunSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestconfRunSpec.
+func (in *TempestconfRunSpec) DeepCopy() *TempestconfRunSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalPluginType)
+	out := new(TempestconfRunSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExtraConfigmapsMounts) DeepCopyInto(out *ExtraConfigmapsMounts) {
+func (in *TestCounts) DeepCopyInto(out *TestCounts) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraConfigmapsMounts.
-func (in *ExtraConfigmapsMounts) DeepCopy() *ExtraConfigmapsMounts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestCounts.
+func (in *TestCounts) DeepCopy() *TestCounts {
 	if in == nil {
 		return nil
 	}
-	out := new(ExtraConfigmapsMounts)
+	out := new(TestCounts)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExtraImagesFlavorType) DeepCopyInto(out *ExtraImagesFlavorType) {
+func (in *TestList) DeepCopyInto(out *TestList) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraImagesFlavorType.
-func (in *ExtraImagesFlavorType) DeepCopy() *ExtraImagesFlavorType {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestList.
+func (in *TestList) DeepCopy() *TestList {
 	if in == nil {
 		return nil
 	}
-	out := new(ExtraImagesFlavorType)
+	out := new(TestList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExtraImagesType) DeepCopyInto(out *ExtraImagesType) {
+func (in *TestResults) DeepCopyInto(out *TestResults) {
 	*out = *in
-	out.Flavor = in.Flavor
+	out.Summary = in.Summary
+	if in.Counts != nil {
+		in, out := &in.Counts, &out.Counts
+		*out = new(TestCounts)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TestStepResults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraImagesType.
-func (in *ExtraImagesType) DeepCopy() *ExtraImagesType {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestResults.
+func (in *TestResults) DeepCopy() *TestResults {
 	if in == nil {
 		return nil
 	}
-	out := new(ExtraImagesType)
+	out := new(TestResults)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HorizonTest) DeepCopyInto(out *HorizonTest) {
+func (in *TestRun) DeepCopyInto(out *TestRun) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTest.
-func (in *HorizonTest) DeepCopy() *HorizonTest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestRun.
+func (in *TestRun) DeepCopy() *TestRun {
 	if in == nil {
 		return nil
 	}
-	out := new(HorizonTest)
+	out := new(TestRun)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HorizonTest) DeepCopyObject() runtime.Object {
+func (in *TestRun) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -320,31 +2868,31 @@ func (in *HorizonTest) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HorizonTestList) DeepCopyInto(out *HorizonTestList) {
+func (in *TestRunList) DeepCopyInto(out *TestRunList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]HorizonTest, len(*in))
+		*out = make([]TestRun, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTestList.
-func (in *HorizonTestList) DeepCopy() *HorizonTestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestRunList.
+func (in *TestRunList) DeepCopy() *TestRunList {
 	if in == nil {
 		return nil
 	}
-	out := new(HorizonTestList)
+	out := new(TestRunList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HorizonTestList) DeepCopyObject() runtime.Object {
+func (in *TestRunList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -352,176 +2900,213 @@ func (in *HorizonTestList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HorizonTestSpec) DeepCopyInto(out *HorizonTestSpec) {
+func (in *TestRunSpec) DeepCopyInto(out *TestRunSpec) {
 	*out = *in
-	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
-	in.Resources.DeepCopyInto(&out.Resources)
+	in.TestSpec.DeepCopyInto(&out.TestSpec)
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImageDigests != nil {
+		in, out := &in.ImageDigests, &out.ImageDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	out.Summary = in.Summary
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TestStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonTestSpec.
-func (in *HorizonTestSpec) DeepCopy() *HorizonTestSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestRunSpec.
+func (in *TestRunSpec) DeepCopy() *TestRunSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HorizonTestSpec)
+	out := new(TestRunSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Tempest) DeepCopyInto(out *Tempest) {
+func (in *TestSecurityContext) DeepCopyInto(out *TestSecurityContext) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tempest.
-func (in *Tempest) DeepCopy() *Tempest {
-	if in == nil {
-		return nil
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
 	}
-	out := new(Tempest)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Tempest) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SeccompProfile != nil {
+		in, out := &in.SeccompProfile, &out.SeccompProfile
+		*out = new(v1.SeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(v1.Capabilities)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TempestDefaults) DeepCopyInto(out *TempestDefaults) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestDefaults.
-func (in *TempestDefaults) DeepCopy() *TempestDefaults {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestSecurityContext.
+func (in *TestSecurityContext) DeepCopy() *TestSecurityContext {
 	if in == nil {
 		return nil
 	}
-	out := new(TempestDefaults)
+	out := new(TestSecurityContext)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TempestList) DeepCopyInto(out *TempestList) {
+func (in *TestStepResults) DeepCopyInto(out *TestStepResults) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Tempest, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Shard != nil {
+		in, out := &in.Shard, &out.Shard
+		*out = new(int)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Counts != nil {
+		in, out := &in.Counts, &out.Counts
+		*out = new(TestCounts)
+		**out = **in
+	}
+	if in.FailedTests != nil {
+		in, out := &in.FailedTests, &out.FailedTests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestList.
-func (in *TempestList) DeepCopy() *TempestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestStepResults.
+func (in *TestStepResults) DeepCopy() *TestStepResults {
 	if in == nil {
 		return nil
 	}
-	out := new(TempestList)
+	out := new(TestStepResults)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TempestList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TempestRunSpec) DeepCopyInto(out *TempestRunSpec) {
+func (in *TestStepStatus) DeepCopyInto(out *TestStepStatus) {
 	*out = *in
-	if in.ExternalPlugin != nil {
-		in, out := &in.ExternalPlugin, &out.ExternalPlugin
-		*out = make([]ExternalPluginType, len(*in))
-		copy(*out, *in)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	if in.ExtraRPMs != nil {
-		in, out := &in.ExtraRPMs, &out.ExtraRPMs
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Shard != nil {
+		in, out := &in.Shard, &out.Shard
+		*out = new(int)
+		**out = **in
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ExtraImages != nil {
-		in, out := &in.ExtraImages, &out.ExtraImages
-		*out = make([]ExtraImagesType, len(*in))
+	if in.FailureCaptures != nil {
+		in, out := &in.FailureCaptures, &out.FailureCaptures
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestRunSpec.
-func (in *TempestRunSpec) DeepCopy() *TempestRunSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestStepStatus.
+func (in *TestStepStatus) DeepCopy() *TestStepStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TempestRunSpec)
+	out := new(TestStepStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TempestSpec) DeepCopyInto(out *TempestSpec) {
+func (in *TestSummary) DeepCopyInto(out *TestSummary) {
 	*out = *in
-	in.CommonOptions.DeepCopyInto(&out.CommonOptions)
-	out.CommonOpenstackConfig = in.CommonOpenstackConfig
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.NetworkAttachments != nil {
-		in, out := &in.NetworkAttachments, &out.NetworkAttachments
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.TempestRun.DeepCopyInto(&out.TempestRun)
-	out.TempestconfRun = in.TempestconfRun
-	if in.ConfigOverwrite != nil {
-		in, out := &in.ConfigOverwrite, &out.ConfigOverwrite
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Workflow != nil {
-		in, out := &in.Workflow, &out.Workflow
-		*out = make([]WorkflowTempestSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestSpec.
-func (in *TempestSpec) DeepCopy() *TempestSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestSummary.
+func (in *TestSummary) DeepCopy() *TestSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(TempestSpec)
+	out := new(TestSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TempestconfRunSpec) DeepCopyInto(out *TempestconfRunSpec) {
+func (in *TimelineEntry) DeepCopyInto(out *TimelineEntry) {
 	*out = *in
+	if in.WorkflowStep != nil {
+		in, out := &in.WorkflowStep, &out.WorkflowStep
+		*out = new(int)
+		**out = **in
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TempestconfRunSpec.
-func (in *TempestconfRunSpec) DeepCopy() *TempestconfRunSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimelineEntry.
+func (in *TimelineEntry) DeepCopy() *TimelineEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(TempestconfRunSpec)
+	out := new(TimelineEntry)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -595,6 +3180,11 @@ func (in *TobikoSpec) DeepCopyInto(out *TobikoSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DisruptionWindow != nil {
+		in, out := &in.DisruptionWindow, &out.DisruptionWindow
+		*out = new(DisruptionWindow)
+		**out = **in
+	}
 	if in.Workflow != nil {
 		in, out := &in.Workflow, &out.Workflow
 		*out = make([]TobikoWorkflowSpec, len(*in))
@@ -650,6 +3240,41 @@ func (in *TobikoWorkflowSpec) DeepCopy() *TobikoWorkflowSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhiteboxNode) DeepCopyInto(out *WhiteboxNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhiteboxNode.
+func (in *WhiteboxNode) DeepCopy() *WhiteboxNode {
+	if in == nil {
+		return nil
+	}
+	out := new(WhiteboxNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhiteboxSpec) DeepCopyInto(out *WhiteboxSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]WhiteboxNode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhiteboxSpec.
+func (in *WhiteboxSpec) DeepCopy() *WhiteboxSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WhiteboxSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowCommonParameters) DeepCopyInto(out *WorkflowCommonParameters) {
 	*out = *in
@@ -682,6 +3307,24 @@ func (in *WorkflowCommonParameters) DeepCopyInto(out *WorkflowCommonParameters)
 			copy(*out, *in)
 		}
 	}
+	if in.EnvFromSecrets != nil {
+		in, out := &in.EnvFromSecrets, &out.EnvFromSecrets
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.EnvFromConfigMaps != nil {
+		in, out := &in.EnvFromConfigMaps, &out.EnvFromConfigMaps
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = new(map[string]string)
@@ -693,6 +3336,43 @@ func (in *WorkflowCommonParameters) DeepCopyInto(out *WorkflowCommonParameters)
 			}
 		}
 	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = new([]v1.TopologySpreadConstraint)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]v1.TopologySpreadConstraint, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.IOLimits != nil {
+		in, out := &in.IOLimits, &out.IOLimits
+		*out = new(IOLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkSelections != nil {
+		in, out := &in.NetworkSelections, &out.NetworkSelections
+		*out = new([]runtime.RawExtension)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]runtime.RawExtension, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.SecurityContextConstraint != nil {
+		in, out := &in.SecurityContextConstraint, &out.SecurityContextConstraint
+		*out = new(string)
+		**out = **in
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = new([]v1.Toleration)
@@ -704,6 +3384,11 @@ func (in *WorkflowCommonParameters) DeepCopyInto(out *WorkflowCommonParameters)
 			}
 		}
 	}
+	if in.SpecOverride != nil {
+		in, out := &in.SpecOverride, &out.SpecOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowCommonParameters.
@@ -724,11 +3409,21 @@ func (in *WorkflowTempestRunSpec) DeepCopyInto(out *WorkflowTempestRunSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.IncludeListConfigMap != nil {
+		in, out := &in.IncludeListConfigMap, &out.IncludeListConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExcludeList != nil {
 		in, out := &in.ExcludeList, &out.ExcludeList
 		*out = new(string)
 		**out = **in
 	}
+	if in.ExcludeListConfigMap != nil {
+		in, out := &in.ExcludeListConfigMap, &out.ExcludeListConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExpectedFailuresList != nil {
 		in, out := &in.ExpectedFailuresList, &out.ExpectedFailuresList
 		*out = new(string)
@@ -786,6 +3481,11 @@ func (in *WorkflowTempestRunSpec) DeepCopyInto(out *WorkflowTempestRunSpec) {
 			copy(*out, *in)
 		}
 	}
+	if in.RerunFailed != nil {
+		in, out := &in.RerunFailed, &out.RerunFailed
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowTempestRunSpec.
@@ -813,6 +3513,11 @@ func (in *WorkflowTempestSpec) DeepCopyInto(out *WorkflowTempestSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(bool)
+		**out = **in
+	}
 	if in.NetworkAttachments != nil {
 		in, out := &in.NetworkAttachments, &out.NetworkAttachments
 		*out = new([]string)
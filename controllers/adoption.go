@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	runIDLabel  = "runID"
+	runIDLength = 5
+)
+
+const (
+	InfoAdoptingResource = "Adopting %s %s of run %s that is owned by a previous incarnation of the instance."
+)
+
+// GetRunID returns the run ID of the instance. The run ID is stored in the
+// RunIDAnnotation. Instances that do not have the annotation yet use the
+// hash of their name and creation timestamp, which keeps the names of the
+// PVCs of the instances created by older versions of the test-operator.
+func GetRunID(instance client.Object) string {
+	if runID := instance.GetAnnotations()[v1beta1.RunIDAnnotation]; runID != "" {
+		return runID
+	}
+
+	instanceCreationTimestamp := instance.GetCreationTimestamp().Format(time.UnixDate)
+	return GetStringHash(instance.GetName()+instanceCreationTimestamp, runIDLength)
+}
+
+// EnsureRunID stores the run ID of the instance in the RunIDAnnotation. The
+// change is persisted together with the rest of the instance at the end of
+// the reconciliation.
+func EnsureRunID(instance client.Object) {
+	annotations := instance.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if annotations[v1beta1.RunIDAnnotation] != "" {
+		return
+	}
+
+	annotations[v1beta1.RunIDAnnotation] = GetRunID(instance)
+	instance.SetAnnotations(annotations)
+}
+
+// AdoptResources relinks the pods and the PVCs of the run of the instance
+// that are controlled by a different object of the same kind (e.g. the
+// instance before it was restored from a backup) or by no object at all.
+// Resources are matched using the instance name and the run ID so that
+// resources of a different run with the same instance name are left alone.
+func (r *Reconciler) AdoptResources(
+	ctx context.Context,
+	instance client.Object,
+	Log logr.Logger,
+) error {
+	instanceGVK, err := apiutil.GVKForObject(instance, r.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	runID := GetRunID(instance)
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels{
+		instanceNameLabel: instance.GetName(),
+		runIDLabel:        runID,
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(ctx, pvcList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	objects := []client.Object{}
+	for i := range podList.Items {
+		objects = append(objects, &podList.Items[i])
+	}
+
+	for i := range pvcList.Items {
+		objects = append(objects, &pvcList.Items[i])
+	}
+
+	for _, object := range objects {
+		if !needsAdoption(instance, instanceGVK, object) {
+			continue
+		}
+
+		objectGVK, err := apiutil.GVKForObject(object, r.GetScheme())
+		if err != nil {
+			return err
+		}
+
+		Log.Info(fmt.Sprintf(InfoAdoptingResource, objectGVK.Kind, object.GetName(), runID))
+
+		patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+		object.SetOwnerReferences(removeOwnerReferences(object.GetOwnerReferences(), instanceGVK))
+
+		err = controllerutil.SetControllerReference(instance, object, r.GetScheme())
+		if err != nil {
+			return err
+		}
+
+		if err := r.Client.Patch(ctx, object, patch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// needsAdoption returns true when the object is not controlled by anything
+// or when it is controlled by a different object of the same kind as the
+// instance
+func needsAdoption(instance client.Object, instanceGVK schema.GroupVersionKind, object client.Object) bool {
+	controllerRef := metav1.GetControllerOf(object)
+	if controllerRef == nil {
+		return true
+	}
+
+	if controllerRef.UID == instance.GetUID() {
+		return false
+	}
+
+	return isOwnerReferenceOfKind(*controllerRef, instanceGVK)
+}
+
+// removeOwnerReferences removes the owner references to objects of the
+// given kind
+func removeOwnerReferences(
+	ownerReferences []metav1.OwnerReference,
+	gvk schema.GroupVersionKind,
+) []metav1.OwnerReference {
+	keptReferences := []metav1.OwnerReference{}
+	for _, ownerReference := range ownerReferences {
+		if !isOwnerReferenceOfKind(ownerReference, gvk) {
+			keptReferences = append(keptReferences, ownerReference)
+		}
+	}
+
+	return keptReferences
+}
+
+func isOwnerReferenceOfKind(ownerReference metav1.OwnerReference, gvk schema.GroupVersionKind) bool {
+	ownerGV, err := schema.ParseGroupVersion(ownerReference.APIVersion)
+	if err != nil {
+		return false
+	}
+
+	return ownerGV.Group == gvk.Group && ownerReference.Kind == gvk.Kind
+}
@@ -18,26 +18,36 @@ package controllers
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"slices"
 	"strconv"
-
-	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
-	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
 	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
-	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/test-operator/pkg/ansibletest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const (
+	ErrNodeSetInventoryNotFound = "inventory of the %s node set was not found, the %s secret does not exist"
+)
+
+// nodeSetInventoryLabel marks the secrets with the inventories rendered from
+// the node sets so that they can be deleted once the testing is completed
+const nodeSetInventoryLabel = "nodeSetInventory"
+
 type AnsibleTestReconciler struct {
 	Reconciler
 }
@@ -50,278 +60,457 @@ func (r *AnsibleTestReconciler) GetLogger(ctx context.Context) logr.Logger {
 // +kubebuilder:rbac:groups=test.openstack.org,resources=ansibletests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=test.openstack.org,resources=ansibletests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=test.openstack.org,resources=ansibletests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
-// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2,resources=securitycontextconstraints,verbs=use
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
 // +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
 
 // Reconcile - AnsibleTest
-func (r *AnsibleTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
-	Log := r.GetLogger(ctx)
+func (r *AnsibleTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &ansibleTestKind{r: r, instance: &testv1beta1.AnsibleTest{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
 
-	// Fetch the ansible instance
-	instance := &testv1beta1.AnsibleTest{}
-	err := r.Client.Get(ctx, req.NamespacedName, instance)
-	if err != nil {
-		if k8s_errors.IsNotFound(err) {
-			return ctrl.Result{}, nil
-		}
-		return ctrl.Result{}, err
+// SetupWithManager sets up the controller with the Manager.
+func (r *AnsibleTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.AnsibleTest{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+// ansibleTestKind provides the parts of the reconcile flow of AnsibleTest that
+// differ between the test kinds (see testKind)
+type ansibleTestKind struct {
+	r                      *AnsibleTestReconciler
+	instance               *testv1beta1.AnsibleTest
+	stepInstance           *testv1beta1.AnsibleTest
+	stepSpec               testv1beta1.AnsibleTestSpec
+	step                   int
+	workflowOverrideParams map[string]string
+	araURL                 string
+}
+
+func (k *ansibleTestKind) Instance() testKindObject {
+	return k.instance
+}
+
+func (k *ansibleTestKind) Conditions() []*condition.Condition {
+	return []*condition.Condition{
+		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyMessage),
 	}
+}
 
-	// Create a helper
-	helper, err := helper.NewHelper(
-		instance,
-		r.Client,
-		r.Kclient,
-		r.Scheme,
-		r.Log,
-	)
-	if err != nil {
-		return ctrl.Result{}, err
+func (k *ansibleTestKind) ServiceName() string {
+	return ansibletest.ServiceName
+}
+
+func (k *ansibleTestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
+
+// Parallel is always false, the test pods of AnsibleTest never run in
+// parallel with the test pods of other instances
+func (k *ansibleTestKind) Parallel() bool {
+	return false
+}
+
+// CheckCreate fails before any test pod is spawned when a workflow step would
+// have to fetch something from the network in the offline mode
+func (k *ansibleTestKind) CheckCreate(_ context.Context, nextAction NextAction, mergeSpecOverride bool) (ctrl.Result, error) {
+	if nextAction != CreateFirstPod {
+		return ctrl.Result{}, nil
 	}
 
-	// initialize status
-	isNewInstance := instance.Status.Conditions == nil
-	if isNewInstance {
-		instance.Status.Conditions = condition.Conditions{}
+	if err := validateOfflineMode(k.instance, mergeSpecOverride); err != nil {
+		k.instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
 	}
 
-	// Save a copy of the condtions so that we can restore the LastTransitionTime
-	// when a condition's state doesn't change.
-	savedConditions := instance.Status.Conditions.DeepCopy()
+	return ctrl.Result{}, nil
+}
 
-	// Always patch the instance status when exiting this function so we
-	// can persist any changes.
-	defer func() {
-		// update the overall status condition if service is ready
-		if instance.Status.Conditions.AllSubConditionIsTrue() {
-			instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
-		}
-		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
-		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
-			instance.Status.Conditions.Set(
-				instance.Status.Conditions.Mirror(condition.ReadyCondition))
-		}
-		err := helper.PatchInstance(ctx, instance)
+func (k *ansibleTestKind) SelectStep(_ NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	k.step = step
+	k.stepInstance = k.instance.DeepCopy()
+	if mergeSpecOverride && step < len(k.instance.Spec.Workflow) {
+		var err error
+		k.stepInstance.Spec, err = MergeSpecOverride(
+			k.instance.Spec,
+			k.instance.Spec.Workflow[step].SpecOverride,
+		)
 		if err != nil {
-			_err = err
-			return
+			return testKindStep{}, err
 		}
-	}()
-
-	if isNewInstance {
-		// Initialize conditions used later as Status=Unknown
-		cl := condition.CreateList(
-			condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
-			condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyMessage),
-			condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
-		)
-		instance.Status.Conditions.Init(&cl)
+	}
 
-		// Register overall status immediately to have an early feedback
-		// e.g. in the cli
-		return ctrl.Result{}, nil
+	spec := k.stepInstance.Spec
+	k.stepSpec = getAnsibleTestStepSpec(spec, step)
 
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        k.stepSpec.CommonOptions,
+		NetworkAttachments: k.stepSpec.NetworkAttachments,
+	}
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
 	}
 
-	workflowLength := len(instance.Spec.Workflow)
-	nextAction, nextWorkflowStep, err := r.NextAction(ctx, instance, workflowLength)
+	return kindStep, nil
+}
+
+// EnsureStepResources renders the node set inventory of the test pod and looks
+// up the location of its ARA records
+func (k *ansibleTestKind) EnsureStepResources(
+	ctx context.Context,
+	helper *helper.Helper,
+	labels map[string]string,
+	podName string,
+	logsPVCName string,
+) (ctrl.Result, error) {
+	k.instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
+
+	err := k.r.ensureNodeSetInventory(ctx, helper, k.instance, k.stepInstance.Spec, k.step, podName, labels)
+	if err != nil {
+		if _, lockErr := k.r.ReleaseLock(ctx, k.instance); lockErr != nil {
+			return ctrl.Result{}, lockErr
+		}
 
-	switch nextAction {
-	case Failure:
 		return ctrl.Result{}, err
+	}
 
-	case Wait:
-		Log.Info(InfoWaitingOnPod)
-		return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+	k.araURL, err = k.r.getAraURL(ctx, k.stepInstance, podName, logsPVCName)
+	return ctrl.Result{}, err
+}
 
-	case EndTesting:
-		// All pods created by the instance were completed. Release the lock
-		// so that other instances can spawn their pods.
-		if lockReleased, err := r.ReleaseLock(ctx, instance); !lockReleased {
-			Log.Info(fmt.Sprintf(InfoCanNotReleaseLock, testOperatorLockName))
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
+func (k *ansibleTestKind) EnvVars(_ string) (map[string]env.Setter, error) {
+	var envVars map[string]env.Setter
+	envVars, k.workflowOverrideParams = k.r.PrepareAnsibleEnv(k.stepInstance, k.step)
+	return envVars, nil
+}
 
-		instance.Status.Conditions.MarkTrue(
-			condition.DeploymentReadyCondition,
-			condition.DeploymentReadyMessage)
+func (k *ansibleTestKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	podDef := ansibletest.Pod(
+		k.stepInstance,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		logsPVCName,
+		mountCerts,
+		envVars,
+		k.workflowOverrideParams,
+		k.step,
+		containerImage,
+		k.stepSpec.Privileged,
+	)
 
-		Log.Info(InfoTestingCompleted)
-		return ctrl.Result{}, nil
+	if k.araURL != "" {
+		podDef.Annotations[ansibletest.AraURLAnnotation] = k.araURL
+	}
 
-	case CreateFirstPod:
-		lockAcquired, err := r.AcquireLock(ctx, instance, helper, false)
-		if !lockAcquired {
-			Log.Info(fmt.Sprintf(InfoCanNotAcquireLock, testOperatorLockName))
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
+	return podDef
+}
 
-		Log.Info(fmt.Sprintf(InfoCreatingFirstPod, nextWorkflowStep))
+func (k *ansibleTestKind) CleanupResources(ctx context.Context) error {
+	return k.r.deleteNodeSetInventories(ctx, k.instance)
+}
 
-	case CreateNextPod:
-		// Confirm that we still hold the lock. This is useful to check if for
-		// example somebody / something deleted the lock and it got claimed by
-		// another instance. This is considered to be an error state.
-		lockAcquired, err := r.AcquireLock(ctx, instance, helper, false)
-		if !lockAcquired {
-			Log.Error(err, ErrConfirmLockOwnership, testOperatorLockName)
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
+// getAnsiblePlaybookArgs returns the arguments of the ansible-playbook command
+// that are passed to the test pod in POD_ANSIBLE_EXTRA_VARS. The var files
+// are passed first so that ansibleExtraVars take precedence over them.
+func getAnsiblePlaybookArgs(stepSpec testv1beta1.AnsibleTestSpec) string {
+	args := []string{}
+	if len(stepSpec.AnsibleVarFilesFrom) > 0 {
+		args = append(args, ansibletest.GetVarFilesExtraVars(stepSpec.AnsibleVarFilesFrom))
+	}
 
-		Log.Info(fmt.Sprintf(InfoCreatingNextPod, nextWorkflowStep))
+	if len(stepSpec.AnsibleTags) > 0 {
+		args = append(args, "--tags "+strings.Join(stepSpec.AnsibleTags, ","))
+	}
 
-	default:
-		return ctrl.Result{}, errors.New(ErrReceivedUnexpectedAction)
+	if len(stepSpec.AnsibleSkipTags) > 0 {
+		args = append(args, "--skip-tags "+strings.Join(stepSpec.AnsibleSkipTags, ","))
 	}
 
-	serviceLabels := map[string]string{
-		common.AppSelector: ansibletest.ServiceName,
-		workflowStepLabel:  strconv.Itoa(nextWorkflowStep),
-		instanceNameLabel:  instance.Name,
-		operatorNameLabel:  "test-operator",
+	if stepSpec.AnsibleLimit != "" {
+		args = append(args, "--limit "+stepSpec.AnsibleLimit)
 	}
 
-	// Create PersistentVolumeClaim
-	ctrlResult, err := r.EnsureLogsPVCExists(
-		ctx,
-		instance,
-		helper,
-		serviceLabels,
-		instance.Spec.StorageClass,
-		0,
-	)
-	if err != nil {
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
+	if stepSpec.AnsibleExtraVars != "" {
+		args = append(args, stepSpec.AnsibleExtraVars)
 	}
-	// Create PersistentVolumeClaim - end
 
-	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
+	return strings.Join(args, " ")
+}
 
-	// Create a new pod
-	mountCerts := r.CheckSecretExists(ctx, instance, "combined-ca-bundle")
-	podName := r.GetPodName(instance, nextWorkflowStep)
-	envVars, workflowOverrideParams := r.PrepareAnsibleEnv(instance, nextWorkflowStep)
-	logsPVCName := r.GetPVCLogsName(instance, 0)
-	containerImage, err := r.GetContainerImage(ctx, workflowOverrideParams["ContainerImage"], instance)
-	privileged := r.OverwriteAnsibleWithWorkflow(instance.Spec, "Privileged", "pbool", nextWorkflowStep).(bool)
-	if err != nil {
-		return ctrl.Result{}, err
+// getAnsiblePlaybook returns the playbook that is passed to the test pod in
+// POD_ANSIBLE_PLAYBOOK. For ansiblePlaybooks it is the first of them, the
+// ansible-playbook wrapper runs the others (POD_ANSIBLE_PLAYBOOKS).
+func getAnsiblePlaybook(stepSpec testv1beta1.AnsibleTestSpec) string {
+	if len(stepSpec.AnsiblePlaybooks) > 0 {
+		return stepSpec.AnsiblePlaybooks[0]
 	}
 
-	if nextWorkflowStep < len(instance.Spec.Workflow) {
-		if instance.Spec.Workflow[nextWorkflowStep].NodeSelector != nil {
-			instance.Spec.NodeSelector = *instance.Spec.Workflow[nextWorkflowStep].NodeSelector
-		}
+	return stepSpec.AnsiblePlaybookPath
+}
 
-		if instance.Spec.Workflow[nextWorkflowStep].Tolerations != nil {
-			instance.Spec.Tolerations = *instance.Spec.Workflow[nextWorkflowStep].Tolerations
-		}
+// getAraURL returns the location of the ARA records of the test pod. For an
+// external ARA API server the URL is read from the server secret. An empty
+// string is returned when ARA is not enabled or when the URL is not known
+// yet.
+func (r *AnsibleTestReconciler) getAraURL(
+	ctx context.Context,
+	instance *testv1beta1.AnsibleTest,
+	podName string,
+	logsPVCName string,
+) (string, error) {
+	ara := instance.Spec.AnsibleAra
+	if ara == nil {
+		return "", nil
+	}
 
-		if instance.Spec.Workflow[nextWorkflowStep].SELinuxLevel != nil {
-			instance.Spec.SELinuxLevel = *instance.Spec.Workflow[nextWorkflowStep].SELinuxLevel
-		}
+	if ara.ServerSecretName == "" {
+		return ansibletest.GetAraSidecarURL(instance.Namespace, logsPVCName, podName), nil
+	}
 
-		if instance.Spec.Workflow[nextWorkflowStep].Resources != nil {
-			instance.Spec.Resources = *instance.Spec.Workflow[nextWorkflowStep].Resources
-		}
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: ara.ServerSecretName}, secret)
+	if k8s_errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
 	}
 
-	podDef := ansibletest.Pod(
-		instance,
-		serviceLabels,
-		podName,
-		logsPVCName,
-		mountCerts,
-		envVars,
-		workflowOverrideParams,
-		nextWorkflowStep,
-		containerImage,
-		privileged,
-	)
+	serverURL := strings.TrimSuffix(string(secret.Data["url"]), "/")
+	if serverURL == "" {
+		return "", nil
+	}
 
-	ctrlResult, err = r.CreatePod(ctx, *helper, podDef)
-	if err != nil {
-		// Creation of the ansibleTests pod was not successfull.
-		// Release the lock and allow other controllers to spawn
-		// a pod.
-		if lockReleased, err := r.ReleaseLock(ctx, instance); !lockReleased {
-			return ctrl.Result{}, err
-		}
+	return ansibletest.GetAraServerURL(serverURL, podName), nil
+}
 
+// ensureNodeSetInventory renders the inventory of the workflow step from the
+// inventories of the OpenStackDataPlaneNodeSets into the secret that is read
+// by the test pod. The secret is owned by the instance and labelled with
+// nodeSetInventoryLabel. When the inventory can not be rendered (e.g. the
+// secret of a node set does not exist) the DeploymentReady condition tells
+// why and no test pod is spawned.
+func (r *AnsibleTestReconciler) ensureNodeSetInventory(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *testv1beta1.AnsibleTest,
+	spec testv1beta1.AnsibleTestSpec,
+	step int,
+	podName string,
+	labels map[string]string,
+) error {
+	nodeSets := getAnsibleTestStepSpec(spec, step).AnsibleInventoryFromNodeSets
+	if len(nodeSets) == 0 {
+		return nil
+	}
+
+	inventory, err := r.renderNodeSetInventory(ctx, instance, nodeSets)
+	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
 			condition.ErrorReason,
 			condition.SeverityWarning,
 			condition.DeploymentReadyErrorMessage,
 			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
+		return err
 	}
-	// Create a new pod - end
-	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
-}
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *AnsibleTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&testv1beta1.AnsibleTest{}).
-		Owns(&corev1.Pod{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.ConfigMap{}).
-		Complete(r)
+	inventoryLabels := map[string]string{nodeSetInventoryLabel: "true"}
+	for key, value := range labels {
+		inventoryLabels[key] = value
+	}
+
+	inventorySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ansibletest.GetInventorySecretName(podName),
+			Namespace: instance.Namespace,
+			Labels:    inventoryLabels,
+		},
+		Data: map[string][]byte{
+			ansibletest.InventoryKey: []byte(inventory),
+		},
+	}
+
+	_, _, err = secret.CreateOrPatchSecret(ctx, helper, instance, inventorySecret)
+	return err
 }
 
-func (r *Reconciler) OverwriteAnsibleWithWorkflow(
-	instance v1beta1.AnsibleTestSpec,
-	sectionName string,
-	workflowValueType string,
-	workflowStepNum int,
-) interface{} {
-	if len(instance.Workflow)-1 < workflowStepNum {
-		reflected := reflect.ValueOf(instance)
-		fieldValue := reflected.FieldByName(sectionName)
-		return fieldValue.Interface()
+// renderNodeSetInventory reads the inventories of the node sets from their
+// secrets and merges them
+func (r *AnsibleTestReconciler) renderNodeSetInventory(
+	ctx context.Context,
+	instance *testv1beta1.AnsibleTest,
+	nodeSets []string,
+) (string, error) {
+	inventories := map[string][]byte{}
+	for _, nodeSet := range nodeSets {
+		secretName := ansibletest.GetNodeSetInventorySecretName(nodeSet)
+		nodeSetSecret := &corev1.Secret{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: secretName}, nodeSetSecret)
+		if k8s_errors.IsNotFound(err) {
+			return "", fmt.Errorf(ErrNodeSetInventoryNotFound, nodeSet, secretName)
+		} else if err != nil {
+			return "", err
+		}
+
+		inventories[nodeSet] = nodeSetSecret.Data[ansibletest.InventoryKey]
 	}
 
-	reflected := reflect.ValueOf(instance)
-	SpecValue := reflected.FieldByName(sectionName).Interface()
+	return ansibletest.RenderNodeSetInventory(inventories, nodeSets)
+}
 
-	reflected = reflect.ValueOf(instance.Workflow[workflowStepNum])
-	WorkflowValue := reflected.FieldByName(sectionName).Interface()
+// deleteNodeSetInventories deletes the inventory secrets rendered for the
+// test pods of the instance. Only the secrets that are labelled with
+// nodeSetInventoryLabel and owned by the instance are deleted.
+func (r *AnsibleTestReconciler) deleteNodeSetInventories(
+	ctx context.Context,
+	instance *testv1beta1.AnsibleTest,
+) error {
+	secretList := &corev1.SecretList{}
+	err := r.Client.List(ctx, secretList,
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{instanceNameLabel: instance.Name, nodeSetInventoryLabel: "true"})
+	if err != nil {
+		return err
+	}
 
-	if workflowValueType == "pbool" {
-		if val, ok := WorkflowValue.(*bool); ok && val != nil {
-			return *(WorkflowValue.(*bool))
-		}
-		return SpecValue.(bool)
-	} else if workflowValueType == "puint8" {
-		if val, ok := WorkflowValue.(*uint8); ok && val != nil {
-			return *(WorkflowValue.(*uint8))
+	for i := range secretList.Items {
+		inventorySecret := &secretList.Items[i]
+		if !metav1.IsControlledBy(inventorySecret, instance) {
+			continue
 		}
-		return SpecValue
-	} else if workflowValueType == "string" {
-		if val, ok := WorkflowValue.(string); ok && val != "" {
-			return WorkflowValue
+
+		if err := r.Client.Delete(ctx, inventorySecret); err != nil && !k8s_errors.IsNotFound(err) {
+			return err
 		}
-		return SpecValue
 	}
 
 	return nil
 }
 
+// getAnsibleTestStepSpec returns the spec that applies to a single workflow
+// step, i.e. the spec of the instance with the values overridden by the
+// workflow step. The workflow of the returned spec is not set.
+func getAnsibleTestStepSpec(spec testv1beta1.AnsibleTestSpec, step int) testv1beta1.AnsibleTestSpec {
+	stepSpec := *spec.DeepCopy()
+	stepSpec.Workflow = nil
+
+	workflowStep := testv1beta1.AnsibleTestWorkflowSpec{}
+	if step < len(spec.Workflow) {
+		workflowStep = spec.Workflow[step]
+	}
+
+	stepSpec.Privileged = mergeWithWorkflow(spec.Privileged, workflowStep.Privileged)
+	stepSpec.ContainerImage = mergeNonZeroWithWorkflow(spec.ContainerImage, workflowStep.ContainerImage)
+	stepSpec.Debug = mergeWithWorkflow(spec.Debug, workflowStep.Debug)
+	stepSpec.AnsibleCheckMode = mergeWithWorkflow(spec.AnsibleCheckMode, workflowStep.AnsibleCheckMode)
+	stepSpec.AnsibleDiff = mergeWithWorkflow(spec.AnsibleDiff, workflowStep.AnsibleDiff)
+	stepSpec.AnsibleVerbosity = mergeWithWorkflow(spec.AnsibleVerbosity, workflowStep.AnsibleVerbosity)
+	stepSpec.AnsibleForks = mergeWithWorkflow(spec.AnsibleForks, workflowStep.AnsibleForks)
+	stepSpec.WorkloadSSHKeySecretName = mergeNonZeroWithWorkflow(spec.WorkloadSSHKeySecretName, workflowStep.WorkloadSSHKeySecretName)
+	stepSpec.ComputesSSHKeySecretName = mergeNonZeroWithWorkflow(spec.ComputesSSHKeySecretName, workflowStep.ComputesSSHKeySecretName)
+	stepSpec.AnsibleExtraVars = mergeNonZeroWithWorkflow(spec.AnsibleExtraVars, workflowStep.AnsibleExtraVars)
+	if len(workflowStep.AnsibleExtraVarsMap) > 0 {
+		stepSpec.AnsibleExtraVarsMap = workflowStep.AnsibleExtraVarsMap
+	}
+	stepSpec.AnsibleVarFiles = mergeNonZeroWithWorkflow(spec.AnsibleVarFiles, workflowStep.AnsibleVarFiles)
+	stepSpec.AnsibleVarFilesFrom = mergeWithWorkflow(spec.AnsibleVarFilesFrom, workflowStep.AnsibleVarFilesFrom)
+	// The inventory sources are mutually exclusive so a workflow step that
+	// sets one of them replaces the inventory of the spec
+	if workflowStep.AnsibleInventory != "" || workflowStep.AnsibleInventoryConfigMap != nil ||
+		workflowStep.AnsibleInventoryFromNodeSets != nil {
+		stepSpec.AnsibleInventory = workflowStep.AnsibleInventory
+		stepSpec.AnsibleInventoryConfigMap = workflowStep.AnsibleInventoryConfigMap.DeepCopy()
+		stepSpec.AnsibleInventoryFromNodeSets = mergeWithWorkflow(nil, workflowStep.AnsibleInventoryFromNodeSets)
+	}
+	stepSpec.AnsibleStdoutCallback = mergeNonZeroWithWorkflow(spec.AnsibleStdoutCallback, workflowStep.AnsibleStdoutCallback)
+	stepSpec.AnsibleCallbacks = mergeWithWorkflow(spec.AnsibleCallbacks, workflowStep.AnsibleCallbacks)
+	stepSpec.AnsibleTags = mergeWithWorkflow(spec.AnsibleTags, workflowStep.AnsibleTags)
+	stepSpec.AnsibleSkipTags = mergeWithWorkflow(spec.AnsibleSkipTags, workflowStep.AnsibleSkipTags)
+	stepSpec.AnsibleLimit = mergeNonZeroWithWorkflow(spec.AnsibleLimit, workflowStep.AnsibleLimit)
+	stepSpec.AnsibleExtraArgs = mergeWithWorkflow(spec.AnsibleExtraArgs, workflowStep.AnsibleExtraArgs)
+	stepSpec.AnsibleGitRepo = mergeNonZeroWithWorkflow(spec.AnsibleGitRepo, workflowStep.AnsibleGitRepo)
+	stepSpec.AnsibleGitAuthSecretName = mergeNonZeroWithWorkflow(spec.AnsibleGitAuthSecretName, workflowStep.AnsibleGitAuthSecretName)
+	stepSpec.AnsibleVaultPasswordSecretName = mergeNonZeroWithWorkflow(
+		spec.AnsibleVaultPasswordSecretName, workflowStep.AnsibleVaultPasswordSecretName)
+	stepSpec.AnsibleGitRef = mergeNonZeroWithWorkflow(spec.AnsibleGitRef, workflowStep.AnsibleGitRef)
+	// ansiblePlaybookPath and ansiblePlaybooks are mutually exclusive so a
+	// workflow step that sets one of them replaces the playbooks of the spec
+	if workflowStep.AnsiblePlaybookPath != "" || workflowStep.AnsiblePlaybooks != nil {
+		stepSpec.AnsiblePlaybookPath = workflowStep.AnsiblePlaybookPath
+		stepSpec.AnsiblePlaybooks = mergeWithWorkflow(nil, workflowStep.AnsiblePlaybooks)
+	}
+	stepSpec.AnsiblePlaybookFailurePolicy = mergeNonZeroWithWorkflow(
+		spec.AnsiblePlaybookFailurePolicy, workflowStep.AnsiblePlaybookFailurePolicy)
+	stepSpec.AnsibleCollections = mergeNonZeroWithWorkflow(spec.AnsibleCollections, workflowStep.AnsibleCollections)
+	stepSpec.NetworkAttachments = mergeWithWorkflow(spec.NetworkAttachments, workflowStep.NetworkAttachments)
+
+	return stepSpec
+}
+
+// validateOfflineMode checks that none of the workflow steps of the instance
+// has to fetch anything from the network when offlineMode is enabled
+func validateOfflineMode(instance *testv1beta1.AnsibleTest, specOverrideEnabled bool) error {
+	var allErrs field.ErrorList
+	for step := 0; step < max(len(instance.Spec.Workflow), 1); step++ {
+		spec := instance.Spec
+		path := field.NewPath("spec")
+		if step < len(instance.Spec.Workflow) {
+			path = path.Child("workflow").Index(step)
+		}
+
+		if specOverrideEnabled && step < len(instance.Spec.Workflow) {
+			var err error
+			spec, err = MergeSpecOverride(spec, instance.Spec.Workflow[step].SpecOverride)
+			if err != nil {
+				return err
+			}
+		}
+
+		stepSpec := getAnsibleTestStepSpec(spec, step)
+		allErrs = append(allErrs, testv1beta1.ValidateOfflineMode(path, stepSpec)...)
+	}
+
+	return allErrs.ToAggregate()
+}
+
 // This function prepares env variables for a single workflow step.
 func (r *AnsibleTestReconciler) PrepareAnsibleEnv(
 	instance *testv1beta1.AnsibleTest,
@@ -331,35 +520,75 @@ func (r *AnsibleTestReconciler) PrepareAnsibleEnv(
 	envVars := make(map[string]env.Setter)
 	workflowOverrideParams := make(map[string]string)
 
+	stepSpec := getAnsibleTestStepSpec(instance.Spec, step)
+
 	// volumes workflow override
-	workflowOverrideParams["WorkloadSSHKeySecretName"] = r.OverwriteAnsibleWithWorkflow(instance.Spec, "WorkloadSSHKeySecretName", "string", step).(string)
-	workflowOverrideParams["ComputesSSHKeySecretName"] = r.OverwriteAnsibleWithWorkflow(instance.Spec, "ComputesSSHKeySecretName", "string", step).(string)
-	workflowOverrideParams["ContainerImage"] = r.OverwriteAnsibleWithWorkflow(instance.Spec, "ContainerImage", "string", step).(string)
+	workflowOverrideParams["WorkloadSSHKeySecretName"] = stepSpec.WorkloadSSHKeySecretName
+	workflowOverrideParams["ComputesSSHKeySecretName"] = stepSpec.ComputesSSHKeySecretName
+	workflowOverrideParams["ContainerImage"] = stepSpec.ContainerImage
+	workflowOverrideParams["AnsibleGitAuthSecretName"] = stepSpec.AnsibleGitAuthSecretName
+	workflowOverrideParams["AnsibleVaultPasswordSecretName"] = stepSpec.AnsibleVaultPasswordSecretName
+	workflowOverrideParams["AnsibleGitRepo"] = stepSpec.AnsibleGitRepo
+	workflowOverrideParams["AnsibleGitRef"] = stepSpec.AnsibleGitRef
+	workflowOverrideParams["AnsibleExtraArgs"] = strings.Join(stepSpec.AnsibleExtraArgs, "\n")
+	workflowOverrideParams["AnsiblePlaybookFailurePolicy"] = string(stepSpec.AnsiblePlaybookFailurePolicy)
+	if len(stepSpec.AnsiblePlaybooks) > 1 {
+		workflowOverrideParams["AnsiblePlaybooks"] = strings.Join(stepSpec.AnsiblePlaybooks, "\n")
+	}
 
 	// bool
-	debug := r.OverwriteAnsibleWithWorkflow(instance.Spec, "Debug", "pbool", step).(bool)
-	if debug {
+	if stepSpec.Debug {
 		envVars["POD_DEBUG"] = env.SetValue("true")
 	}
 
-	// strings
-	extraVars := r.OverwriteAnsibleWithWorkflow(instance.Spec, "AnsibleExtraVars", "string", step).(string)
-	envVars["POD_ANSIBLE_EXTRA_VARS"] = env.SetValue(extraVars)
+	if stepSpec.AnsibleCheckMode {
+		envVars["POD_ANSIBLE_CHECK_MODE"] = env.SetValue("true")
+	}
+
+	if stepSpec.AnsibleDiff {
+		envVars["POD_ANSIBLE_DIFF"] = env.SetValue("true")
+	}
+
+	// ints
+	if stepSpec.AnsibleVerbosity > 0 {
+		envVars["ANSIBLE_VERBOSITY"] = env.SetValue(strconv.FormatInt(stepSpec.AnsibleVerbosity, 10))
+	}
+
+	if stepSpec.AnsibleForks > 0 {
+		envVars["ANSIBLE_FORKS"] = env.SetValue(strconv.FormatInt(stepSpec.AnsibleForks, 10))
+	}
 
-	extraVarsFile := r.OverwriteAnsibleWithWorkflow(instance.Spec, "AnsibleVarFiles", "string", step).(string)
-	envVars["POD_ANSIBLE_FILE_EXTRA_VARS"] = env.SetValue(extraVarsFile)
+	// strings
+	envVars["POD_ANSIBLE_EXTRA_VARS"] = env.SetValue(getAnsiblePlaybookArgs(stepSpec))
+	envVars["POD_ANSIBLE_FILE_EXTRA_VARS"] = env.SetValue(
+		ansibletest.RenderVarFile(stepSpec.AnsibleVarFiles, stepSpec.AnsibleExtraVarsMap))
+	envVars["POD_ANSIBLE_INVENTORY"] = ansibletest.GetInventoryEnvVar(stepSpec, r.GetPodName(instance, step))
+	envVars["POD_ANSIBLE_GIT_REPO"] = env.SetValue(BracketIPv6URL(stepSpec.AnsibleGitRepo))
+	envVars["POD_ANSIBLE_GIT_REF"] = env.SetValue(stepSpec.AnsibleGitRef)
+	envVars["POD_ANSIBLE_PLAYBOOK"] = env.SetValue(getAnsiblePlaybook(stepSpec))
+	envVars["POD_INSTALL_COLLECTIONS"] = env.SetValue(stepSpec.AnsibleCollections)
+
+	if stepSpec.AnsibleGitAuthSecretName != "" {
+		for name, value := range ansibletest.GetGitAuthEnvVars() {
+			envVars[name] = env.SetValue(value)
+		}
+	}
 
-	inventory := r.OverwriteAnsibleWithWorkflow(instance.Spec, "AnsibleInventory", "string", step).(string)
-	envVars["POD_ANSIBLE_INVENTORY"] = env.SetValue(inventory)
+	if stepSpec.AnsibleStdoutCallback != "" {
+		envVars["ANSIBLE_STDOUT_CALLBACK"] = env.SetValue(stepSpec.AnsibleStdoutCallback)
+	}
 
-	gitRepo := r.OverwriteAnsibleWithWorkflow(instance.Spec, "AnsibleGitRepo", "string", step).(string)
-	envVars["POD_ANSIBLE_GIT_REPO"] = env.SetValue(gitRepo)
+	if len(stepSpec.AnsibleCallbacks) > 0 {
+		envVars["ANSIBLE_CALLBACKS_ENABLED"] = env.SetValue(strings.Join(stepSpec.AnsibleCallbacks, ","))
+	}
 
-	playbookPath := r.OverwriteAnsibleWithWorkflow(instance.Spec, "AnsiblePlaybookPath", "string", step).(string)
-	envVars["POD_ANSIBLE_PLAYBOOK"] = env.SetValue(playbookPath)
+	if stepSpec.AnsibleStdoutCallback == "junit" || slices.Contains(stepSpec.AnsibleCallbacks, "junit") {
+		envVars["JUNIT_OUTPUT_DIR"] = env.SetValue(ansibletest.JUnitOutputDir)
+	}
 
-	ansibleCollections := r.OverwriteAnsibleWithWorkflow(instance.Spec, "AnsibleCollections", "string", step).(string)
-	envVars["POD_INSTALL_COLLECTIONS"] = env.SetValue(ansibleCollections)
+	if stepSpec.AnsibleVaultPasswordSecretName != "" {
+		envVars["ANSIBLE_VAULT_PASSWORD_FILE"] = env.SetValue(ansibletest.VaultPasswordFile)
+	}
 
 	return envVars, workflowOverrideParams
 }
@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/ansibletest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMergeWithWorkflow(t *testing.T) {
+	trueVar := true
+	falseVar := false
+
+	if got := mergeWithWorkflow(true, nil); got != true {
+		t.Errorf("mergeWithWorkflow(true, nil) = %v, want true", got)
+	}
+
+	if got := mergeWithWorkflow(true, &falseVar); got != false {
+		t.Errorf("mergeWithWorkflow(true, &false) = %v, want false", got)
+	}
+
+	if got := mergeWithWorkflow(false, &trueVar); got != true {
+		t.Errorf("mergeWithWorkflow(false, &true) = %v, want true", got)
+	}
+}
+
+func TestMergeNonZeroWithWorkflow(t *testing.T) {
+	if got := mergeNonZeroWithWorkflow("spec", ""); got != "spec" {
+		t.Errorf(`mergeNonZeroWithWorkflow("spec", "") = %q, want "spec"`, got)
+	}
+
+	if got := mergeNonZeroWithWorkflow("spec", "step"); got != "step" {
+		t.Errorf(`mergeNonZeroWithWorkflow("spec", "step") = %q, want "step"`, got)
+	}
+
+	if got := mergeNonZeroWithWorkflow(false, true); got != true {
+		t.Errorf("mergeNonZeroWithWorkflow(false, true) = %v, want true", got)
+	}
+
+	if got := mergeNonZeroWithWorkflow(true, false); got != true {
+		t.Errorf("mergeNonZeroWithWorkflow(true, false) = %v, want true", got)
+	}
+}
+
+func TestGetAnsibleTestStepSpec(t *testing.T) {
+	trueVar := true
+	falseVar := false
+
+	spec := testv1beta1.AnsibleTestSpec{
+		ComputesSSHKeySecretName: "spec-computes-key",
+		WorkloadSSHKeySecretName: "spec-workload-key",
+		AnsibleGitRepo:           "https://example.com/spec.git",
+		AnsiblePlaybookPath:      "spec.yaml",
+		AnsibleExtraVars:         "-e spec=true",
+		Workflow: []testv1beta1.AnsibleTestWorkflowSpec{
+			{
+				StepName: "inherit",
+			},
+			{
+				StepName:                 "override",
+				WorkloadSSHKeySecretName: "step-workload-key",
+				AnsiblePlaybookPath:      "step.yaml",
+				AnsibleInventory:         "localhost",
+				Debug:                    &trueVar,
+			},
+		},
+	}
+	spec.Privileged = true
+	spec.ContainerImage = "spec-image"
+	spec.Workflow[1].Privileged = &falseVar
+	spec.Workflow[1].ContainerImage = "step-image"
+
+	expectedSpec := *spec.DeepCopy()
+	expectedSpec.Workflow = nil
+
+	expectedOverride := *expectedSpec.DeepCopy()
+	expectedOverride.Privileged = false
+	expectedOverride.ContainerImage = "step-image"
+	expectedOverride.WorkloadSSHKeySecretName = "step-workload-key"
+	expectedOverride.AnsiblePlaybookPath = "step.yaml"
+	expectedOverride.AnsibleInventory = "localhost"
+	expectedOverride.Debug = true
+
+	tests := []struct {
+		name     string
+		step     int
+		expected testv1beta1.AnsibleTestSpec
+	}{
+		{name: "step without overrides", step: 0, expected: expectedSpec},
+		{name: "step with overrides", step: 1, expected: expectedOverride},
+		{name: "step outside of the workflow", step: 2, expected: expectedSpec},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stepSpec := getAnsibleTestStepSpec(spec, test.step)
+			if !reflect.DeepEqual(stepSpec, test.expected) {
+				t.Errorf("getAnsibleTestStepSpec() = %+v, want %+v", stepSpec, test.expected)
+			}
+		})
+	}
+
+	if len(spec.Workflow) != 2 || spec.Privileged != true || spec.ContainerImage != "spec-image" {
+		t.Errorf("getAnsibleTestStepSpec() modified the spec of the instance")
+	}
+}
+
+// newNodeSetInventoryTest returns an AnsibleTest that renders its inventory
+// from the compute node set and a reconciler whose client contains the
+// objects
+func newNodeSetInventoryTest(t *testing.T, objects ...client.Object) (
+	*AnsibleTestReconciler,
+	*helper.Helper,
+	*testv1beta1.AnsibleTest,
+) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := testv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	instance := &testv1beta1.AnsibleTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "ansible", Namespace: "openstack", UID: "ansible-uid"},
+		Spec: testv1beta1.AnsibleTestSpec{
+			AnsibleInventoryFromNodeSets: []string{"compute"},
+		},
+	}
+	instance.Status.Conditions.Init(nil)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	h, err := helper.NewHelper(instance, fakeClient, nil, scheme, logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &AnsibleTestReconciler{Reconciler: Reconciler{Client: fakeClient, Scheme: scheme}}
+	return r, h, instance
+}
+
+func TestEnsureNodeSetInventoryMissingNodeSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []client.Object
+	}{
+		{
+			name: "missing secret",
+		},
+		{
+			name: "renamed secret",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "dataplanenodeset-compute-old", Namespace: "openstack"},
+					Data:       map[string][]byte{ansibletest.InventoryKey: []byte("all: {}")},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, h, instance := newNodeSetInventoryTest(t, tt.objects...)
+			wantErr := fmt.Sprintf(ErrNodeSetInventoryNotFound, "compute", "dataplanenodeset-compute")
+
+			err := r.ensureNodeSetInventory(context.Background(), h, instance, instance.Spec, 0, "ansible-pod", nil)
+			if err == nil || err.Error() != wantErr {
+				t.Fatalf("ensureNodeSetInventory() error = %v, want %q", err, wantErr)
+			}
+
+			readyCondition := instance.Status.Conditions.Get(condition.DeploymentReadyCondition)
+			if readyCondition == nil || readyCondition.Status != corev1.ConditionFalse ||
+				readyCondition.Message != fmt.Sprintf(condition.DeploymentReadyErrorMessage, wantErr) {
+				t.Errorf("DeploymentReady condition = %+v, want False with %q", readyCondition, wantErr)
+			}
+
+			secretList := &corev1.SecretList{}
+			if err := r.Client.List(context.Background(), secretList, client.InNamespace("openstack")); err != nil {
+				t.Fatal(err)
+			}
+			if len(secretList.Items) != len(tt.objects) {
+				t.Errorf("ensureNodeSetInventory() created an inventory secret for a missing node set")
+			}
+		})
+	}
+}
+
+func TestNodeSetInventoryCleanup(t *testing.T) {
+	nodeSetSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "dataplanenodeset-compute", Namespace: "openstack"},
+		Data:       map[string][]byte{ansibletest.InventoryKey: []byte("computes:\n  hosts:\n    compute-0: {}\n")},
+	}
+	otherSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ansible-other",
+			Namespace: "openstack",
+			Labels:    map[string]string{instanceNameLabel: "ansible", nodeSetInventoryLabel: "true"},
+		},
+	}
+
+	r, h, instance := newNodeSetInventoryTest(t, nodeSetSecret, otherSecret)
+	ctx := context.Background()
+	labels := map[string]string{instanceNameLabel: instance.Name}
+
+	if err := r.ensureNodeSetInventory(ctx, h, instance, instance.Spec, 0, "ansible-pod", labels); err != nil {
+		t.Fatalf("ensureNodeSetInventory() error = %v", err)
+	}
+
+	inventorySecret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: "openstack", Name: ansibletest.GetInventorySecretName("ansible-pod")}
+	if err := r.Client.Get(ctx, key, inventorySecret); err != nil {
+		t.Fatalf("inventory secret was not created: %v", err)
+	}
+
+	if !metav1.IsControlledBy(inventorySecret, instance) {
+		t.Errorf("inventory secret is not owned by the instance")
+	}
+
+	if inventorySecret.Labels[nodeSetInventoryLabel] != "true" || inventorySecret.Labels[instanceNameLabel] != instance.Name {
+		t.Errorf("inventory secret labels = %v", inventorySecret.Labels)
+	}
+
+	if err := r.deleteNodeSetInventories(ctx, instance); err != nil {
+		t.Fatalf("deleteNodeSetInventories() error = %v", err)
+	}
+
+	if err := r.Client.Get(ctx, key, &corev1.Secret{}); err == nil {
+		t.Errorf("deleteNodeSetInventories() did not delete the inventory secret")
+	}
+
+	// Secrets that are not owned by the instance are kept even when they
+	// carry the labels
+	for _, kept := range []client.Object{nodeSetSecret, otherSecret} {
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(kept), &corev1.Secret{}); err != nil {
+			t.Errorf("deleteNodeSetInventories() deleted the %s secret: %v", kept.GetName(), err)
+		}
+	}
+}
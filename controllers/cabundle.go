@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"fmt"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// caCertsVolumeName is the name of the volume with the CA bundle secret
+	// created by the pod definitions of all test kinds
+	caCertsVolumeName = "ca-certs"
+
+	// caTrustBundleVolumeName is the name of the volume that holds the
+	// trust bundle merged by the init container
+	caTrustBundleVolumeName = "ca-trust-bundle"
+
+	caSourceVolumePrefix = "ca-source-"
+	caSourcesPath        = "/var/lib/test-operator/ca-sources"
+	caTrustBundlePath    = "/var/lib/test-operator/ca-trust"
+	caTrustBundleFile    = "tls-ca-bundle.pem"
+
+	// caTrustBundleMountPath is where the trust bundle is mounted to the
+	// test containers that do not mount the CA bundle secret
+	caTrustBundleMountPath = "/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem"
+
+	mergeCABundlesContainerName = "merge-ca-bundles"
+)
+
+// mergeCABundlesScript concatenates the mounted CA sources into a single trust
+// bundle. A newline is added after each source as PEM files do not always
+// end with one.
+var mergeCABundlesScript = fmt.Sprintf(
+	"set -e; for source in %[1]s/*.pem; do cat \"$source\"; echo; done > %[2]s/%[3]s",
+	caSourcesPath, caTrustBundlePath, caTrustBundleFile)
+
+// ApplyCABundleSources adds an init container to the pod that merges the CA
+// sources and the CA bundle secret (when it is mounted) into a single trust
+// bundle. The test containers mount the merged bundle instead of the CA
+// bundle secret.
+func ApplyCABundleSources(pod *corev1.Pod, sources []v1beta1.CABundleSource) {
+	if len(sources) == 0 || len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	testContainer := pod.Spec.Containers[0]
+	initContainer := corev1.Container{
+		Name:            mergeCABundlesContainerName,
+		Image:           testContainer.Image,
+		ImagePullPolicy: testContainer.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", mergeCABundlesScript},
+		SecurityContext: testContainer.SecurityContext.DeepCopy(),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      caTrustBundleVolumeName,
+				MountPath: caTrustBundlePath,
+			},
+		},
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == caCertsVolumeName {
+			initContainer.VolumeMounts = append(initContainer.VolumeMounts, corev1.VolumeMount{
+				Name:      caCertsVolumeName,
+				MountPath: caSourcesPath + "/" + caCertsVolumeName + ".pem",
+				SubPath:   caTrustBundleFile,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	for idx, source := range sources {
+		volumeName := fmt.Sprintf("%s%d", caSourceVolumePrefix, idx)
+		volume := corev1.Volume{Name: volumeName}
+		subPath := ""
+
+		if source.Secret != nil {
+			subPath = source.Secret.Key
+			volume.VolumeSource.Secret = &corev1.SecretVolumeSource{
+				SecretName: source.Secret.Name,
+				Optional:   source.Secret.Optional,
+			}
+		} else if source.ConfigMap != nil {
+			subPath = source.ConfigMap.Key
+			volume.VolumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: source.ConfigMap.LocalObjectReference,
+				Optional:             source.ConfigMap.Optional,
+			}
+		} else {
+			continue
+		}
+
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		initContainer.VolumeMounts = append(initContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: caSourcesPath + "/" + volumeName + ".pem",
+			SubPath:   subPath,
+			ReadOnly:  true,
+		})
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: caTrustBundleVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	// The other init containers (e.g. the git checkout of AnsibleTest) use
+	// the merged bundle as well so it has to be created first
+	containers := []*corev1.Container{}
+	for i := range pod.Spec.InitContainers {
+		containers = append(containers, &pod.Spec.InitContainers[i])
+	}
+
+	for i := range pod.Spec.Containers {
+		containers = append(containers, &pod.Spec.Containers[i])
+	}
+
+	for _, container := range containers {
+		mountsTrustBundle := false
+		for j := range container.VolumeMounts {
+			if container.VolumeMounts[j].Name == caCertsVolumeName {
+				container.VolumeMounts[j].Name = caTrustBundleVolumeName
+				container.VolumeMounts[j].SubPath = caTrustBundleFile
+				mountsTrustBundle = true
+			}
+		}
+
+		if !mountsTrustBundle {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      caTrustBundleVolumeName,
+				MountPath: caTrustBundleMountPath,
+				SubPath:   caTrustBundleFile,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	pod.Spec.InitContainers = append([]corev1.Container{initContainer}, pod.Spec.InitContainers...)
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/cleanup"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	cleanupVerificationLabel = "cleanupVerification"
+)
+
+const (
+	InfoVerifyingCleanup = "Waiting for the cleanup verification pod to finish."
+)
+
+// VerifyCleanup spawns a pod that checks that no resources described by the
+// cleanup assertions remained in the cloud after the tests finished. The
+// result is reported via the CleanupVerified condition. The returned value
+// is true once the verification is finished (or when there is nothing to
+// verify).
+func (r *Reconciler) VerifyCleanup(
+	ctx context.Context,
+	instance client.Object,
+	h *helper.Helper,
+	status *v1beta1.CommonTestStatus,
+	assertions []v1beta1.CleanupAssertion,
+	containerImage string,
+	imagePullSecrets []string,
+	imagePullPolicy corev1.PullPolicy,
+	caBundleSecretName string,
+) (bool, error) {
+	if len(assertions) == 0 {
+		return true, nil
+	}
+
+	containerImage, err := r.GetContainerImage(ctx, containerImage, instance)
+	if err != nil {
+		return false, err
+	}
+
+	podName := instance.GetName() + cleanup.PodNameSuffix
+	pod, err := r.GetPod(ctx, podName, instance.GetNamespace())
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if k8s_errors.IsNotFound(err) {
+		labels := map[string]string{
+			cleanupVerificationLabel: instance.GetName(),
+			operatorNameLabel:        "test-operator",
+		}
+
+		podDef := cleanup.Pod(
+			instance.GetNamespace(),
+			labels,
+			podName,
+			containerImage,
+			caBundleSecretName,
+			assertions,
+		)
+		podDef.Spec.ImagePullSecrets = operatorutil.GetImagePullSecrets(imagePullSecrets)
+		for i := range podDef.Spec.Containers {
+			podDef.Spec.Containers[i].ImagePullPolicy = imagePullPolicy
+		}
+
+		if _, err := r.CreatePod(ctx, *h, podDef); err != nil {
+			return false, err
+		}
+
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.CleanupVerifiedCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			v1beta1.CleanupVerifiedRunningMessage))
+
+		return false, nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		status.Conditions.MarkTrue(
+			v1beta1.CleanupVerifiedCondition,
+			v1beta1.CleanupVerifiedMessage)
+
+		return true, nil
+
+	case corev1.PodFailed:
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.CleanupVerifiedCondition,
+			v1beta1.CleanupFailedReason,
+			condition.SeverityError,
+			v1beta1.CleanupVerifiedErrorMessage,
+			podName))
+
+		return true, nil
+	}
+
+	return false, nil
+}
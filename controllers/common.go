@@ -9,13 +9,17 @@ import (
 	"time"
 
 	"crypto/sha256"
+	"encoding/json"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/go-logr/logr"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/pvc"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -23,9 +27,12 @@ import (
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -39,15 +46,19 @@ const (
 	workflowStepLabel        = "workflowStep"
 	instanceNameLabel        = "instanceName"
 	operatorNameLabel        = "operator"
+	rerunLabel               = "rerun"
+	shardLabel               = "shard"
 
 	testOperatorLockName       = "test-operator-lock"
 	testOperatorLockOnwerField = "owner"
 )
 
 const (
-	ErrNetworkAttachments       = "not all pods have interfaces with ips as configured in NetworkAttachments: %s"
-	ErrReceivedUnexpectedAction = "unexpected action received"
-	ErrConfirmLockOwnership     = "can not confirm ownership of %s lock"
+	ErrNetworkAttachments          = "not all pods have interfaces with ips as configured in NetworkAttachments: %s"
+	ErrReceivedUnexpectedAction    = "unexpected action received"
+	ErrConfirmLockOwnership        = "can not confirm ownership of %s lock"
+	ErrInvalidSpecOverride         = "invalid specOverride of the workflow step: %w"
+	ErrInvalidPodTemplateOverrides = "invalid podTemplateOverrides: %w"
 )
 
 const (
@@ -55,6 +66,8 @@ const (
 	InfoTestingCompleted  = "Testing completed. All pods spawned by the test-operator finished."
 	InfoCreatingFirstPod  = "Creating first test pod (workflow step %d)."
 	InfoCreatingNextPod   = "Creating next test pod (workflow step %d)."
+	InfoCreatingRerunPod  = "Creating test pod that reruns the failed tests (workflow step %d)."
+	InfoCreatingMergePod  = "Creating test pod that merges the results of the shards (workflow step %d)."
 	InfoCanNotAcquireLock = "Can not acquire %s lock."
 	InfoCanNotReleaseLock = "Can not release %s lock."
 )
@@ -63,6 +76,10 @@ const (
 	// RequeueAfterValue tells how much time should we wait before calling Reconcile
 	// loop again.
 	RequeueAfterValue = time.Second * 60
+
+	// DefaultLockStarvationThreshold tells how long an instance can wait for
+	// the test-operator-lock before it is considered to be starved.
+	DefaultLockStarvationThreshold = time.Hour * 6
 )
 
 type Reconciler struct {
@@ -70,6 +87,25 @@ type Reconciler struct {
 	Kclient kubernetes.Interface
 	Log     logr.Logger
 	Scheme  *runtime.Scheme
+
+	// LockStarvationThreshold tells how long an instance can wait for the
+	// test-operator-lock before the Starved condition is set. When it is not
+	// set the DefaultLockStarvationThreshold is used.
+	LockStarvationThreshold time.Duration
+
+	// FeatureGates configured for the operator. They override the
+	// v1beta1.DefaultFeatureGates and can be overridden per instance using
+	// the v1beta1.FeatureGatesAnnotation.
+	FeatureGates map[string]bool
+
+	// LogStreamer forwards the output of the test pods to the operator log
+	// for the instances with spec.streamLogs enabled. Streaming is disabled
+	// when it is not set.
+	LogStreamer *LogStreamer
+
+	// Recorder records the Events of the instances. No Events are recorded
+	// when it is not set.
+	Recorder record.EventRecorder
 }
 
 // NextAction holds an action that should be performed by the Reconcile loop.
@@ -88,6 +124,14 @@ const (
 	// specified in the .Spec.Workflow section (if .Spec.Workflow is defined)
 	CreateNextPod
 
+	// CreateRerunPod indicates that the Reconcile loop should create a pod
+	// that executes the failed tests of the last workflow step once more
+	CreateRerunPod
+
+	// CreateMergePod indicates that the Reconcile loop should create a pod
+	// that merges the results of the shards of the last workflow step
+	CreateMergePod
+
 	// EndTesting indicates that all pods have already finished. The Reconcile
 	// loop should end the testing and release resources that are required to
 	// be release (e.g., global lock)
@@ -138,6 +182,83 @@ func (r *Reconciler) CreatePod(
 	return ctrl.Result{}, nil
 }
 
+// CreateTestPod applies the pod template overrides of the instance to the
+// definition of a test pod and creates the pod.
+func (r *Reconciler) CreateTestPod(
+	ctx context.Context,
+	h helper.Helper,
+	podSpec *corev1.Pod,
+	overrides *v1beta1.PodTemplateOverrides,
+) (ctrl.Result, error) {
+	podSpec, err := ApplyPodTemplateOverrides(podSpec, overrides)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.CreatePod(ctx, h, podSpec)
+}
+
+// AddPodMetadata adds the podLabels and the podAnnotations from the spec to
+// the pod. The labels and the annotations set by the test-operator take
+// precedence.
+func AddPodMetadata(pod *corev1.Pod, options v1beta1.CommonOptions) {
+	pod.Labels = util.MergeStringMaps(pod.Labels, options.PodLabels)
+	pod.Annotations = util.MergeStringMaps(pod.Annotations, options.PodAnnotations)
+}
+
+// ApplyPodTemplateOverrides applies the strategic merge patch and then the
+// JSON patch from overrides to the pod. The name, the namespace and the
+// labels the test-operator uses to track the pod can not be overridden.
+func ApplyPodTemplateOverrides(
+	pod *corev1.Pod,
+	overrides *v1beta1.PodTemplateOverrides,
+) (*corev1.Pod, error) {
+	if overrides == nil {
+		return pod, nil
+	}
+
+	podJSON, err := json.Marshal(pod)
+	if err != nil {
+		return pod, err
+	}
+
+	if overrides.StrategicMergePatch != nil && len(overrides.StrategicMergePatch.Raw) > 0 {
+		podJSON, err = strategicpatch.StrategicMergePatch(podJSON, overrides.StrategicMergePatch.Raw, corev1.Pod{})
+		if err != nil {
+			return pod, fmt.Errorf(ErrInvalidPodTemplateOverrides, err)
+		}
+	}
+
+	if overrides.JSONPatch != "" {
+		patch, err := jsonpatch.DecodePatch([]byte(overrides.JSONPatch))
+		if err != nil {
+			return pod, fmt.Errorf(ErrInvalidPodTemplateOverrides, err)
+		}
+
+		podJSON, err = patch.Apply(podJSON)
+		if err != nil {
+			return pod, fmt.Errorf(ErrInvalidPodTemplateOverrides, err)
+		}
+	}
+
+	patchedPod := &corev1.Pod{}
+	if err := json.Unmarshal(podJSON, patchedPod); err != nil {
+		return pod, fmt.Errorf(ErrInvalidPodTemplateOverrides, err)
+	}
+
+	patchedPod.Name = pod.Name
+	patchedPod.Namespace = pod.Namespace
+	if patchedPod.Labels == nil {
+		patchedPod.Labels = map[string]string{}
+	}
+
+	for key, value := range pod.Labels {
+		patchedPod.Labels[key] = value
+	}
+
+	return patchedPod, nil
+}
+
 // NextAction indicates what action needs to be performed by the Reconcile loop
 // based on the current state of the OpenShift cluster.
 func (r *Reconciler) NextAction(
@@ -199,8 +320,32 @@ func isLastPodIndex(podIndex int, workflowLength int) bool {
 	}
 }
 
+func isRerunPod(pod corev1.Pod) bool {
+	return pod.Labels[rerunLabel] == "true"
+}
+
+func isShardPod(pod corev1.Pod) bool {
+	_, ok := pod.Labels[shardLabel]
+	return ok
+}
+
+// getPodPrecedence orders the pods of the same workflow step. The shards run
+// first, then the pod of the workflow step (which merges the shards) and then
+// the pod that reruns the failed tests of the workflow step.
+func getPodPrecedence(pod corev1.Pod) int {
+	switch {
+	case isRerunPod(pod):
+		return 2
+	case isShardPod(pod):
+		return 0
+	default:
+		return 1
+	}
+}
+
 // GetLastPod returns pod associated with an instance which has the highest value
-// stored in the workflowStep label
+// stored in the workflowStep label. The pods of the same workflow step are
+// ordered by getPodPrecedence.
 func (r *Reconciler) GetLastPod(
 	ctx context.Context,
 	instance client.Object,
@@ -223,7 +368,8 @@ func (r *Reconciler) GetLastPod(
 			return &corev1.Pod{}, err
 		}
 
-		if workflowStep >= maxPodWorkflowStep {
+		if workflowStep > maxPodWorkflowStep ||
+			(workflowStep == maxPodWorkflowStep && (maxPod == nil || getPodPrecedence(pod) >= getPodPrecedence(*maxPod))) {
 			maxPodWorkflowStep = workflowStep
 			newMaxPod := pod
 			maxPod = &newMaxPod
@@ -233,160 +379,116 @@ func (r *Reconciler) GetLastPod(
 	return maxPod, nil
 }
 
-func GetEnvVarsConfigMapName(instance interface{}, workflowStepNum int) string {
-	if _, ok := instance.(*v1beta1.Tobiko); ok {
-		return "not-implemented"
-	} else if typedInstance, ok := instance.(*v1beta1.Tempest); ok {
-		return typedInstance.Name + envVarsConfigMapinfix + strconv.Itoa(workflowStepNum)
+// AllTestPodsFinished returns true when all the test pods spawned by the
+// instance finished
+func (r *Reconciler) AllTestPodsFinished(ctx context.Context, instance client.Object) (bool, error) {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return false, err
 	}
 
-	return "not-implemented"
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			return false, nil
+		}
+	}
+
+	return true, nil
 }
 
-func GetCustomDataConfigMapName(instance interface{}, workflowStepNum int) string {
-	if _, ok := instance.(*v1beta1.Tobiko); ok {
-		return "not-implemented"
-	} else if typedInstance, ok := instance.(*v1beta1.Tempest); ok {
-		return typedInstance.Name + customDataConfigMapinfix + strconv.Itoa(workflowStepNum)
-	}
+func GetEnvVarsConfigMapName(instance client.Object, workflowStepNum int) string {
+	return instance.GetName() + envVarsConfigMapinfix + strconv.Itoa(workflowStepNum)
+}
+
+func GetCustomDataConfigMapName(instance client.Object, workflowStepNum int) string {
+	return instance.GetName() + customDataConfigMapinfix + strconv.Itoa(workflowStepNum)
+}
 
-	return "not-implemented"
+// containerImageDefault is the key of the test-operator-config config map and
+// the env variable with the default container image of a test kind
+type containerImageDefault struct {
+	configMapKey string
+	envVar       string
+}
+
+// containerImageDefaults of the test kinds. There is no default image of
+// CustomTest, it can only be set via the test-operator-config config map.
+var containerImageDefaults = map[string]containerImageDefault{
+	"Tempest":     {"tempest-image", "RELATED_IMAGE_TEST_TEMPEST_IMAGE_URL_DEFAULT"},
+	"Tobiko":      {"tobiko-image", "RELATED_IMAGE_TEST_TOBIKO_IMAGE_URL_DEFAULT"},
+	"HorizonTest": {"horizontest-image", "RELATED_IMAGE_TEST_HORIZONTEST_IMAGE_URL_DEFAULT"},
+	"AnsibleTest": {"ansibletest-image", "RELATED_IMAGE_TEST_ANSIBLETEST_IMAGE_URL_DEFAULT"},
+	"RallyTest":   {"rallytest-image", "RELATED_IMAGE_TEST_RALLYTEST_IMAGE_URL_DEFAULT"},
+	"ShakerTest":  {"shakertest-image", "RELATED_IMAGE_TEST_SHAKERTEST_IMAGE_URL_DEFAULT"},
+	"FioTest":     {"fiotest-image", "RELATED_IMAGE_TEST_FIOTEST_IMAGE_URL_DEFAULT"},
+	"K6Test":      {"k6test-image", "RELATED_IMAGE_TEST_K6TEST_IMAGE_URL_DEFAULT"},
+	"RobotTest":   {"robottest-image", "RELATED_IMAGE_TEST_ROBOTTEST_IMAGE_URL_DEFAULT"},
+	"CustomTest":  {"customtest-image", ""},
 }
 
 func (r *Reconciler) GetContainerImage(
 	ctx context.Context,
 	containerImage string,
-	instance interface{},
+	instance client.Object,
 ) (string, error) {
-	cm := &corev1.ConfigMap{}
-	testOperatorConfigMapName := "test-operator-config"
-	if typedInstance, ok := instance.(*v1beta1.Tempest); ok {
-		if len(containerImage) > 0 {
-			return containerImage, nil
-		}
-
-		objectKey := client.ObjectKey{Namespace: typedInstance.Namespace, Name: testOperatorConfigMapName}
-		err := r.Client.Get(ctx, objectKey, cm)
-		if err != nil {
-			return "", err
-		}
-
-		if cm.Data == nil {
-			return util.GetEnvVar("RELATED_IMAGE_TEST_TEMPEST_IMAGE_URL_DEFAULT", ""), nil
-
-		}
-
-		if cmImage, exists := cm.Data["tempest-image"]; exists {
-			return cmImage, nil
-		}
-
-		return util.GetEnvVar("RELATED_IMAGE_TEST_TEMPEST_IMAGE_URL_DEFAULT", ""), nil
-	} else if typedInstance, ok := instance.(*v1beta1.Tobiko); ok {
-		if len(containerImage) > 0 {
-			return containerImage, nil
-		}
-
-		objectKey := client.ObjectKey{Namespace: typedInstance.Namespace, Name: testOperatorConfigMapName}
-		err := r.Client.Get(ctx, objectKey, cm)
-		if err != nil {
-			return "", err
-		}
-
-		if cm.Data == nil {
-			return util.GetEnvVar("RELATED_IMAGE_TEST_TOBIKO_IMAGE_URL_DEFAULT", ""), nil
-
-		}
-
-		if cmImage, exists := cm.Data["tobiko-image"]; exists {
-			return cmImage, nil
-		}
-
-		return util.GetEnvVar("RELATED_IMAGE_TEST_TOBIKO_IMAGE_URL_DEFAULT", ""), nil
-	} else if typedInstance, ok := instance.(*v1beta1.HorizonTest); ok {
-		if len(containerImage) > 0 {
-			return containerImage, nil
-		}
-
-		objectKey := client.ObjectKey{Namespace: typedInstance.Namespace, Name: testOperatorConfigMapName}
-		err := r.Client.Get(ctx, objectKey, cm)
-		if err != nil {
-			return "", err
-		}
-
-		if cm.Data == nil {
-			return util.GetEnvVar("RELATED_IMAGE_TEST_HORIZONTEST_IMAGE_URL_DEFAULT", ""), nil
-
-		}
-
-		if cmImage, exists := cm.Data["horizontest-image"]; exists {
-			return cmImage, nil
-		}
+	if len(containerImage) > 0 {
+		return containerImage, nil
+	}
 
-		return util.GetEnvVar("RELATED_IMAGE_TEST_HORIZONTEST_IMAGE_URL_DEFAULT", ""), nil
-	} else if typedInstance, ok := instance.(*v1beta1.AnsibleTest); ok {
-		if len(containerImage) > 0 {
-			return containerImage, nil
-		}
+	gvk, err := apiutil.GVKForObject(instance, r.GetScheme())
+	if err != nil {
+		return "", err
+	}
 
-		objectKey := client.ObjectKey{Namespace: typedInstance.Namespace, Name: testOperatorConfigMapName}
-		err := r.Client.Get(ctx, objectKey, cm)
-		if err != nil {
-			return "", err
-		}
+	imageDefault, ok := containerImageDefaults[gvk.Kind]
+	if !ok {
+		return "", nil
+	}
 
-		if cm.Data == nil {
-			return util.GetEnvVar("RELATED_IMAGE_TEST_ANSIBLETEST_IMAGE_URL_DEFAULT", ""), nil
-		}
+	cm := &corev1.ConfigMap{}
+	testOperatorConfigMapName := "test-operator-config"
+	objectKey := client.ObjectKey{Namespace: instance.GetNamespace(), Name: testOperatorConfigMapName}
+	err = r.Client.Get(ctx, objectKey, cm)
+	if err != nil {
+		return "", err
+	}
 
-		if cmImage, exists := cm.Data["ansibletest-image"]; exists {
-			return cmImage, nil
-		}
+	if cmImage, exists := cm.Data[imageDefault.configMapKey]; exists {
+		return cmImage, nil
+	}
 
-		return util.GetEnvVar("RELATED_IMAGE_TEST_ANSIBLETEST_IMAGE_URL_DEFAULT", ""), nil
+	if len(imageDefault.envVar) == 0 {
+		return "", nil
 	}
 
-	return "", nil
+	return util.GetEnvVar(imageDefault.envVar, ""), nil
 }
 
-func (r *Reconciler) GetPodName(instance interface{}, workflowStepNum int) string {
-	if typedInstance, ok := instance.(*v1beta1.Tobiko); ok {
-		if len(typedInstance.Spec.Workflow) == 0 || workflowStepNum == workflowStepNumInvalid {
-			return typedInstance.Name
-		}
-
-		workflowStepName := workflowStepNameInvalid
-		if workflowStepNum < len(typedInstance.Spec.Workflow) {
-			workflowStepName = typedInstance.Spec.Workflow[workflowStepNum].StepName
-		}
-
-		return typedInstance.Name + podNameStepInfix + fmt.Sprintf("%02d", workflowStepNum) + "-" + workflowStepName
-	} else if typedInstance, ok := instance.(*v1beta1.Tempest); ok {
-		if len(typedInstance.Spec.Workflow) == 0 || workflowStepNum == workflowStepNumInvalid {
-			return typedInstance.Name
-		}
-
-		workflowStepName := workflowStepNameInvalid
-		if workflowStepNum < len(typedInstance.Spec.Workflow) {
-			workflowStepName = typedInstance.Spec.Workflow[workflowStepNum].StepName
-		}
-
-		return typedInstance.Name + podNameStepInfix + fmt.Sprintf("%02d", workflowStepNum) + "-" + workflowStepName
-	} else if typedInstance, ok := instance.(*v1beta1.HorizonTest); ok {
-		return typedInstance.Name
-	} else if typedInstance, ok := instance.(*v1beta1.AnsibleTest); ok {
-		if len(typedInstance.Spec.Workflow) == 0 || workflowStepNum == workflowStepNumInvalid {
-			return typedInstance.Name
-		}
+// testObject is an instance of a test kind. The test pods of the instance are
+// named after its workflow steps (see GetPodName).
+type testObject interface {
+	client.Object
+	GetCommonStatus() *v1beta1.CommonTestStatus
+	GetStepNames() []string
+}
 
-		workflowStepName := workflowStepNameInvalid
-		if workflowStepNum < len(typedInstance.Spec.Workflow) {
-			workflowStepName = typedInstance.Spec.Workflow[workflowStepNum].StepName
-		}
+func (r *Reconciler) GetPodName(instance testObject, workflowStepNum int) string {
+	rerunPodNameSuffix := getRerunPodNameSuffix(instance, instance.GetCommonStatus())
+	stepNames := instance.GetStepNames()
+	if len(stepNames) == 0 || workflowStepNum == workflowStepNumInvalid {
+		return instance.GetName() + rerunPodNameSuffix
+	}
 
-		return typedInstance.Name + podNameStepInfix + fmt.Sprintf("%02d", workflowStepNum) + "-" + workflowStepName
+	workflowStepName := workflowStepNameInvalid
+	if workflowStepNum < len(stepNames) {
+		workflowStepName = stepNames[workflowStepNum]
 	}
 
-	return workflowStepNameInvalid
+	return instance.GetName() + podNameStepInfix + fmt.Sprintf("%02d", workflowStepNum) + "-" + workflowStepName +
+		rerunPodNameSuffix
 }
 
 func (r *Reconciler) GetWorkflowConfigMapName(instance client.Object) string {
@@ -395,11 +497,8 @@ func (r *Reconciler) GetWorkflowConfigMapName(instance client.Object) string {
 
 func (r *Reconciler) GetPVCLogsName(instance client.Object, workflowStepNum int) string {
 	instanceName := instance.GetName()
-	instanceCreationTimestamp := instance.GetCreationTimestamp().Format(time.UnixDate)
-	suffixLength := 5
-	nameSuffix := GetStringHash(instanceName+instanceCreationTimestamp, suffixLength)
 	workflowStep := strconv.Itoa(workflowStepNum)
-	return instanceName + "-" + workflowStep + "-" + nameSuffix
+	return instanceName + "-" + workflowStep + "-" + GetRunID(instance)
 }
 
 func (r *Reconciler) CheckSecretExists(ctx context.Context, instance client.Object, secretName string) bool {
@@ -412,6 +511,22 @@ func (r *Reconciler) CheckSecretExists(ctx context.Context, instance client.Obje
 	return true
 }
 
+// GetMountedCABundle returns the name of the CA bundle secret that is mounted
+// to the pods of the instance. An empty string is returned when the mount is
+// disabled or when the secret does not exist.
+func (r *Reconciler) GetMountedCABundle(
+	ctx context.Context,
+	instance client.Object,
+	options v1beta1.CommonOptions,
+) string {
+	caBundleSecretName := operatorutil.GetCABundleSecretName(options)
+	if len(caBundleSecretName) == 0 || !r.CheckSecretExists(ctx, instance, caBundleSecretName) {
+		return ""
+	}
+
+	return caBundleSecretName
+}
+
 func GetStringHash(str string, hashLength int) string {
 	hash := sha256.New()
 	hash.Write([]byte(str))
@@ -421,14 +536,21 @@ func GetStringHash(str string, hashLength int) string {
 	return hashString[:hashLength]
 }
 
+// EnsureLogsPVCExists creates the logs PVC of the workflow step. No PVC is
+// created when the logs are not persisted in the logs PVC.
 func (r *Reconciler) EnsureLogsPVCExists(
 	ctx context.Context,
 	instance client.Object,
 	helper *helper.Helper,
 	labels map[string]string,
 	StorageClassName string,
+	persistence v1beta1.LogsPersistence,
 	workflowStepNum int,
 ) (ctrl.Result, error) {
+	if !isLogsPVCPersistence(persistence) {
+		return ctrl.Result{}, nil
+	}
+
 	instanceNamespace := instance.GetNamespace()
 	pvcName := r.GetPVCLogsName(instance, workflowStepNum)
 
@@ -438,23 +560,13 @@ func (r *Reconciler) EnsureLogsPVCExists(
 		return ctrl.Result{}, nil
 	}
 
-	pvcAccessMode := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
-
 	testOperatorPvcDef := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pvcName,
 			Namespace: instanceNamespace,
 			Labels:    labels,
 		},
-		Spec: corev1.PersistentVolumeClaimSpec{
-			AccessModes: pvcAccessMode,
-			Resources: corev1.VolumeResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceStorage: k8sresource.MustParse("1Gi"),
-				},
-			},
-			StorageClassName: &StorageClassName,
-		},
+		Spec: getLogsPVCSpec(StorageClassName),
 	}
 
 	timeDuration, _ := time.ParseDuration("2m")
@@ -469,6 +581,57 @@ func (r *Reconciler) EnsureLogsPVCExists(
 	return ctrlResult, nil
 }
 
+// getLogsPVCSpec returns the spec of the logs PVC. It is also used for the
+// ephemeral volumes of the test pods.
+func getLogsPVCSpec(storageClassName string) corev1.PersistentVolumeClaimSpec {
+	return corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: k8sresource.MustParse("1Gi"),
+			},
+		},
+		StorageClassName: &storageClassName,
+	}
+}
+
+// isLogsPVCPersistence returns true when the logs are stored in the logs PVC
+func isLogsPVCPersistence(persistence v1beta1.LogsPersistence) bool {
+	return persistence == "" || persistence == v1beta1.LogsPersistencePVC
+}
+
+// ApplyLogsPersistence replaces the logs PVC mounted to the pod with an
+// emptyDir volume or with an ephemeral volume according to the persistence
+func ApplyLogsPersistence(
+	pod *corev1.Pod,
+	persistence v1beta1.LogsPersistence,
+	storageClassName string,
+	logsPVCName string,
+) {
+	if isLogsPVCPersistence(persistence) {
+		return
+	}
+
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != logsPVCName {
+			continue
+		}
+
+		volume.VolumeSource = corev1.VolumeSource{}
+		switch persistence {
+		case v1beta1.LogsPersistenceNone:
+			volume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+		case v1beta1.LogsPersistenceEphemeralVolume:
+			volume.Ephemeral = &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					Spec: getLogsPVCSpec(storageClassName),
+				},
+			}
+		}
+	}
+}
+
 func (r *Reconciler) GetClient() client.Client {
 	return r.Client
 }
@@ -534,6 +697,12 @@ func (r *Reconciler) AcquireLock(
 	instanceGUID := string(instance.GetUID())
 	cm, err := r.GetLockInfo(ctx, instance)
 	if err != nil && k8s_errors.IsNotFound(err) {
+		// Do not take the lock while the operator is shutting down. The
+		// instance acquires the lock once the operator is running again.
+		if operatorShuttingDown.Load() {
+			return false, nil
+		}
+
 		cm := map[string]string{
 			testOperatorLockOnwerField: instanceGUID,
 		}
@@ -593,7 +762,83 @@ func (r *Reconciler) ReleaseLock(ctx context.Context, instance client.Object) (b
 	return false, errors.New("failed to delete test-operator-lock")
 }
 
-func (r *Reconciler) PodExists(ctx context.Context, instance client.Object, workflowStepNum int) bool {
+// GetLockStarvationThreshold returns how long an instance can wait for the
+// test-operator-lock before it is considered to be starved.
+func (r *Reconciler) GetLockStarvationThreshold() time.Duration {
+	if r.LockStarvationThreshold > 0 {
+		return r.LockStarvationThreshold
+	}
+
+	return DefaultLockStarvationThreshold
+}
+
+// UpdateLockWaitStatus tracks how long the instance has been waiting for the
+// test-operator-lock. Once the wait exceeds the lock starvation threshold the
+// Starved condition is set and the instance is reported as starved via metrics.
+func (r *Reconciler) UpdateLockWaitStatus(
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	lockAcquired bool,
+) {
+	if lockAcquired {
+		recordQueueWait(status, lockAcquired)
+		status.LockWaitStartTime = nil
+		status.Conditions.Remove(v1beta1.StarvedCondition)
+		r.DeleteLockWaitMetrics(instance, client.ObjectKeyFromObject(instance))
+		return
+	}
+
+	if status.LockWaitStartTime == nil {
+		now := metav1.Now()
+		status.LockWaitStartTime = &now
+	}
+	recordQueueWait(status, lockAcquired)
+
+	labels := r.getLockWaitMetricLabels(instance, client.ObjectKeyFromObject(instance))
+	waitDuration := time.Since(status.LockWaitStartTime.Time)
+	lockWaitSeconds.With(labels).Set(waitDuration.Seconds())
+
+	threshold := r.GetLockStarvationThreshold()
+	if waitDuration < threshold {
+		lockStarved.With(labels).Set(0)
+		return
+	}
+
+	lockStarved.With(labels).Set(1)
+	status.Conditions.MarkTrue(
+		v1beta1.StarvedCondition,
+		v1beta1.StarvedMessage,
+		testOperatorLockName,
+		waitDuration.Round(time.Second),
+		threshold,
+	)
+}
+
+// DeleteLockWaitMetrics removes the lock wait metrics reported for the
+// instance identified by objectKey
+func (r *Reconciler) DeleteLockWaitMetrics(instance client.Object, objectKey client.ObjectKey) {
+	labels := r.getLockWaitMetricLabels(instance, objectKey)
+	lockWaitSeconds.Delete(labels)
+	lockStarved.Delete(labels)
+}
+
+func (r *Reconciler) getLockWaitMetricLabels(
+	instance client.Object,
+	objectKey client.ObjectKey,
+) prometheus.Labels {
+	kind := ""
+	if gvk, err := apiutil.GVKForObject(instance, r.GetScheme()); err == nil {
+		kind = gvk.Kind
+	}
+
+	return prometheus.Labels{
+		"kind":      kind,
+		"namespace": objectKey.Namespace,
+		"name":      objectKey.Name,
+	}
+}
+
+func (r *Reconciler) PodExists(ctx context.Context, instance testObject, workflowStepNum int) bool {
 	pod := &corev1.Pod{}
 	podName := r.GetPodName(instance, workflowStepNum)
 	objectKey := client.ObjectKey{Namespace: instance.GetNamespace(), Name: podName}
@@ -611,6 +856,64 @@ func (r *Reconciler) setConfigOverwrite(customData map[string]string, configOver
 	}
 }
 
+// MergeSpecOverride returns the spec with the spec override of a workflow step
+// strategically merged over it. The workflow of the spec is never overridden.
+func MergeSpecOverride[T any](spec T, specOverride *runtime.RawExtension) (T, error) {
+	if specOverride == nil || len(specOverride.Raw) == 0 {
+		return spec, nil
+	}
+
+	override := map[string]interface{}{}
+	if err := json.Unmarshal(specOverride.Raw, &override); err != nil {
+		return spec, fmt.Errorf(ErrInvalidSpecOverride, err)
+	}
+	delete(override, "workflow")
+
+	patch, err := json.Marshal(override)
+	if err != nil {
+		return spec, err
+	}
+
+	original, err := json.Marshal(spec)
+	if err != nil {
+		return spec, err
+	}
+
+	merged, err := strategicpatch.StrategicMergePatch(original, patch, spec)
+	if err != nil {
+		return spec, fmt.Errorf(ErrInvalidSpecOverride, err)
+	}
+
+	var mergedSpec T
+	if err := json.Unmarshal(merged, &mergedSpec); err != nil {
+		return spec, fmt.Errorf(ErrInvalidSpecOverride, err)
+	}
+
+	return mergedSpec, nil
+}
+
+// mergeWithWorkflow returns the value from the workflow step when it is set.
+// Otherwise the value from the spec is returned.
+func mergeWithWorkflow[T any](value T, workflowValue *T) T {
+	if workflowValue == nil {
+		return value
+	}
+
+	return *workflowValue
+}
+
+// mergeNonZeroWithWorkflow returns the value from the workflow step unless it
+// is the zero value of its type (e.g. an empty string). Otherwise the value
+// from the spec is returned.
+func mergeNonZeroWithWorkflow[T comparable](value T, workflowValue T) T {
+	var zero T
+	if workflowValue == zero {
+		return value
+	}
+
+	return workflowValue
+}
+
 func (r *Reconciler) OverwriteValueWithWorkflow(
 	instance v1beta1.TobikoSpec,
 	sectionName string,
@@ -649,20 +952,85 @@ func (r *Reconciler) OverwriteValueWithWorkflow(
 	return nil
 }
 
-func GetCommonRbacRules(privileged bool) []rbacv1.PolicyRule {
+// GetEffectiveSpec returns the pod related values for a single workflow step.
+// workflowStep and workflowStepResources are nil when the step is not defined
+// in the workflow, in which case the values from the spec are used.
+func GetEffectiveSpec(
+	options v1beta1.CommonOptions,
+	resources corev1.ResourceRequirements,
+	workflowStep *v1beta1.WorkflowCommonParameters,
+	workflowStepResources *corev1.ResourceRequirements,
+) operatorutil.EffectiveSpec {
+	effectiveSpec := operatorutil.EffectiveSpec{
+		NodeSelector: options.NodeSelector,
+		Tolerations:  options.Tolerations,
+		SELinuxLevel: options.SELinuxLevel,
+		Resources:    resources,
+
+		Affinity:                  options.Affinity,
+		TopologySpreadConstraints: options.TopologySpreadConstraints,
+		RuntimeClassName:          options.RuntimeClassName,
+		HostNetwork:               options.Privileged && options.HostNetwork,
+		DNSPolicy:                 operatorutil.GetDNSPolicy(options.Privileged && options.HostNetwork, options.DNSPolicy),
+		DNSConfig:                 options.DNSConfig,
+		ImagePullSecrets:          operatorutil.GetImagePullSecrets(options.ImagePullSecrets),
+		ImagePullPolicy:           options.ImagePullPolicy,
+		SecurityContext:           options.SecurityContext,
+	}
+
+	envFromSecrets := options.EnvFromSecrets
+	envFromConfigMaps := options.EnvFromConfigMaps
+
+	if workflowStep != nil {
+		if workflowStep.EnvFromSecrets != nil {
+			envFromSecrets = *workflowStep.EnvFromSecrets
+		}
+
+		if workflowStep.EnvFromConfigMaps != nil {
+			envFromConfigMaps = *workflowStep.EnvFromConfigMaps
+		}
+
+		if workflowStep.NodeSelector != nil {
+			effectiveSpec.NodeSelector = *workflowStep.NodeSelector
+		}
+
+		if workflowStep.Tolerations != nil {
+			effectiveSpec.Tolerations = *workflowStep.Tolerations
+		}
+
+		if workflowStep.Affinity != nil {
+			effectiveSpec.Affinity = workflowStep.Affinity
+		}
+
+		if workflowStep.TopologySpreadConstraints != nil {
+			effectiveSpec.TopologySpreadConstraints = *workflowStep.TopologySpreadConstraints
+		}
+
+		if workflowStep.SELinuxLevel != nil {
+			effectiveSpec.SELinuxLevel = *workflowStep.SELinuxLevel
+		}
+	}
+
+	if workflowStepResources != nil {
+		effectiveSpec.Resources = *workflowStepResources
+	}
+
+	effectiveSpec.EnvFrom = operatorutil.GetEnvFromSources(envFromSecrets, envFromConfigMaps)
+
+	return effectiveSpec
+}
+
+// GetCommonRbacRules returns the rules of the role of the service account
+// used by the test pods. The service account is allowed to use the given SCC
+// only.
+func GetCommonRbacRules(securityContextConstraint string) []rbacv1.PolicyRule {
 	rbacPolicyRule := rbacv1.PolicyRule{
 		APIGroups:     []string{"security.openshift.io"},
-		ResourceNames: []string{"nonroot", "nonroot-v2"},
+		ResourceNames: []string{securityContextConstraint},
 		Resources:     []string{"securitycontextconstraints"},
 		Verbs:         []string{"use"},
 	}
 
-	if privileged {
-		rbacPolicyRule.ResourceNames = append(
-			rbacPolicyRule.ResourceNames,
-			[]string{"anyuid", "privileged"}...)
-	}
-
 	return []rbacv1.PolicyRule{rbacPolicyRule}
 }
 
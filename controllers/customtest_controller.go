@@ -0,0 +1,217 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"path"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/customtest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// CustomTestReconciler reconciles a CustomTest object
+type CustomTestReconciler struct {
+	Reconciler
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *CustomTestReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("CustomTest")
+}
+
+// +kubebuilder:rbac:groups=test.openstack.org,resources=customtests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=test.openstack.org,resources=customtests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=customtests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
+
+// Reconcile - CustomTest
+func (r *CustomTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &customTestKind{r: r, instance: &testv1beta1.CustomTest{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CustomTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.CustomTest{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+// customTestKind provides the parts of the reconcile flow of CustomTest that differ
+// between the test kinds (see testKind)
+type customTestKind struct {
+	r        *CustomTestReconciler
+	instance *testv1beta1.CustomTest
+	stepSpec testv1beta1.CustomTestSpec
+}
+
+func (k *customTestKind) Instance() testKindObject {
+	return k.instance
+}
+
+func (k *customTestKind) ServiceName() string {
+	return customtest.ServiceName
+}
+
+func (k *customTestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
+
+func (k *customTestKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
+
+func (k *customTestKind) SelectStep(_ NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	spec := *k.instance.Spec.DeepCopy()
+	if mergeSpecOverride && step < len(spec.Workflow) {
+		var err error
+		spec, err = MergeSpecOverride(spec, spec.Workflow[step].SpecOverride)
+		if err != nil {
+			return testKindStep{}, err
+		}
+	}
+
+	k.stepSpec = getCustomTestStepSpec(spec, step)
+
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        k.stepSpec.CommonOptions,
+		NetworkAttachments: k.stepSpec.NetworkAttachments,
+	}
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
+	}
+
+	return kindStep, nil
+}
+
+func (k *customTestKind) EnvVars(podName string) (map[string]env.Setter, error) {
+	return k.r.PrepareCustomTestEnvVars(k.stepSpec, podName)
+}
+
+func (k *customTestKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	return customtest.Pod(
+		k.instance,
+		k.stepSpec,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		logsPVCName,
+		mountCerts,
+		envVars,
+		containerImage,
+		k.stepSpec.Privileged,
+	)
+}
+
+func (k *customTestKind) UpdateResults(ctx context.Context, stepNames []string) error {
+	return k.r.UpdateCustomTestResults(ctx, k.instance, &k.instance.Status, stepNames)
+}
+
+// This function prepares env variables for a single workflow step.
+func (r *CustomTestReconciler) PrepareCustomTestEnvVars(
+	stepSpec testv1beta1.CustomTestSpec,
+	podName string,
+) (map[string]env.Setter, error) {
+	envVars := make(map[string]env.Setter)
+	envVars["HOME"] = env.SetValue(customtest.HomeDir)
+	envVars["TEST_OPERATOR_POD_NAME"] = env.SetValue(podName)
+	envVars["TEST_OPERATOR_LOGS_DIR"] = env.SetValue(path.Join(stepSpec.LogsMountPath, podName))
+
+	if len(stepSpec.Cloud) > 0 {
+		envVars["OS_CLOUD"] = env.SetValue(stepSpec.Cloud)
+	}
+
+	return envVars, nil
+}
+
+// getCustomTestStepSpec returns the spec that applies to a single workflow
+// step, i.e. the spec of the instance with the values overridden by the
+// workflow step. The workflow of the returned spec is not set.
+func getCustomTestStepSpec(spec testv1beta1.CustomTestSpec, step int) testv1beta1.CustomTestSpec {
+	stepSpec := *spec.DeepCopy()
+	stepSpec.Workflow = nil
+
+	workflowStep := testv1beta1.CustomTestWorkflowSpec{}
+	if step < len(spec.Workflow) {
+		workflowStep = spec.Workflow[step]
+	}
+
+	stepSpec.Privileged = mergeWithWorkflow(spec.Privileged, workflowStep.Privileged)
+	stepSpec.ContainerImage = mergeNonZeroWithWorkflow(spec.ContainerImage, workflowStep.ContainerImage)
+	stepSpec.WorkingDir = mergeNonZeroWithWorkflow(spec.WorkingDir, workflowStep.WorkingDir)
+	stepSpec.Cloud = mergeNonZeroWithWorkflow(spec.Cloud, workflowStep.Cloud)
+	stepSpec.ResultFile = mergeNonZeroWithWorkflow(spec.ResultFile, workflowStep.ResultFile)
+	if len(workflowStep.Command) > 0 {
+		stepSpec.Command = workflowStep.Command
+	}
+	if len(workflowStep.Args) > 0 {
+		stepSpec.Args = workflowStep.Args
+	}
+	for _, envVar := range workflowStep.Env {
+		idx := slices.IndexFunc(stepSpec.Env, func(e corev1.EnvVar) bool { return e.Name == envVar.Name })
+		if idx >= 0 {
+			stepSpec.Env[idx] = *envVar.DeepCopy()
+		} else {
+			stepSpec.Env = append(stepSpec.Env, *envVar.DeepCopy())
+		}
+	}
+	if len(workflowStep.NetworkAttachments) > 0 {
+		stepSpec.NetworkAttachments = workflowStep.NetworkAttachments
+	}
+
+	return stepSpec
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/debughold"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DebugHoldReason is the reason of the Events that record the test pods
+	// kept alive for debugging
+	DebugHoldReason = "DebugHold"
+)
+
+// AddDebugHold adds the debug-hold container to the test pod when
+// spec.debugHold.keepPodOnFailure is set and the debug-pause init container
+// when spec.debugHold.pauseBeforeRun is set. The debug-hold container keeps
+// the pod alive until the test pod is released by ReleaseDebugHolds or by the
+// user. The debug-pause init container runs after the other init containers
+// and it delays the test container until the user removes the
+// debughold.PauseAnnotation of the test pod.
+func AddDebugHold(pod *corev1.Pod, options v1beta1.CommonOptions) {
+	if options.DebugHold == nil || len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	debugHold := options.DebugHold
+	if !debugHold.KeepPodOnFailure && !debugHold.PauseBeforeRun {
+		return
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+
+	testContainer := pod.Spec.Containers[0]
+	if debugHold.KeepPodOnFailure {
+		if debugHold.Duration != nil {
+			pod.Annotations[debughold.DurationAnnotation] = debugHold.Duration.Duration.String()
+		}
+
+		pod.Spec.Containers = append(pod.Spec.Containers, debughold.Container(testContainer))
+	}
+
+	if debugHold.PauseBeforeRun {
+		pod.Annotations[debughold.PauseAnnotation] = "true"
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, debughold.PauseContainer(testContainer))
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, debughold.GetVolumes(debugHold.PauseBeforeRun)...)
+}
+
+// ReleaseDebugHolds releases the test pods of the instance held by the
+// debug-hold container. A test pod is released right away when its test
+// container succeeded and once the duration of the hold elapses when the
+// test container failed. The start of the hold is recorded in an annotation
+// of the test pod and using an Event.
+func (r *Reconciler) ReleaseDebugHolds(
+	ctx context.Context,
+	instance client.Object,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !hasDebugHold(*pod) || pod.Annotations[debughold.ReleaseAnnotation] == "true" {
+			continue
+		}
+
+		terminated := getTestContainerTerminatedState(*pod)
+		if terminated == nil {
+			continue
+		}
+
+		annotations := map[string]string{}
+		if terminated.ExitCode == 0 {
+			annotations[debughold.ReleaseAnnotation] = "true"
+		} else if startTime, found := pod.Annotations[debughold.StartTimeAnnotation]; !found {
+			annotations[debughold.StartTimeAnnotation] = terminated.FinishedAt.UTC().Format(time.RFC3339)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, DebugHoldReason,
+					"Test pod %s failed and it is kept for debugging: oc rsh -n %s -c %s %s",
+					pod.Name, pod.Namespace, debughold.ContainerName, pod.Name)
+			}
+		} else if isDebugHoldExpired(pod.Annotations[debughold.DurationAnnotation], startTime) {
+			annotations[debughold.ReleaseAnnotation] = "true"
+		}
+
+		if len(annotations) == 0 {
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		pod.Annotations = util.MergeStringMaps(pod.Annotations, annotations)
+
+		if err := r.Client.Patch(ctx, pod, patch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasDebugHold returns true when the pod contains the debug-hold container
+func hasDebugHold(pod corev1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == debughold.ContainerName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getTestContainerTerminatedState returns the terminated state of the test
+// container (the first container) of the pod or nil when it is still running
+func getTestContainerTerminatedState(pod corev1.Pod) *corev1.ContainerStateTerminated {
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == pod.Spec.Containers[0].Name {
+			return containerStatus.State.Terminated
+		}
+	}
+
+	return nil
+}
+
+// isDebugHoldExpired returns true once the duration of the hold that started
+// at startTime elapses. Holds without a duration never expire.
+func isDebugHoldExpired(duration string, startTime string) bool {
+	if duration == "" {
+		return false
+	}
+
+	holdDuration, err := time.ParseDuration(duration)
+	if err != nil {
+		return true
+	}
+
+	holdStartTime, err := time.Parse(time.RFC3339, startTime)
+	if err != nil {
+		return true
+	}
+
+	return !time.Now().Before(holdStartTime.Add(holdDuration))
+}
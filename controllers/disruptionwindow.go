@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"time"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+const (
+	InfoWaitingForDisruptionWindow = "Waiting for the disruption window that opens at %s."
+)
+
+// getDisruptionWindowWait returns how long the creation of a test pod has to
+// be postponed until the daily disruption window opens and the time when the
+// window opens. Zero is returned while the window is open or when no window
+// is configured.
+func getDisruptionWindowWait(window *v1beta1.DisruptionWindow, now time.Time) (time.Duration, time.Time) {
+	if window == nil {
+		return 0, now
+	}
+
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return 0, now
+	}
+
+	now = now.UTC()
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+
+	// The window that opened yesterday can still be open when it spans
+	// midnight
+	for _, windowStart := range []time.Time{todayStart.AddDate(0, 0, -1), todayStart} {
+		if !now.Before(windowStart) && now.Before(windowStart.Add(window.Duration.Duration)) {
+			return 0, windowStart
+		}
+	}
+
+	nextStart := todayStart
+	if !now.Before(todayStart) {
+		nextStart = todayStart.AddDate(0, 0, 1)
+	}
+
+	return nextStart.Sub(now), nextStart
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"fmt"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ErrInvalidFeatureGatesAnnotation = "invalid %s annotation: %w"
+)
+
+// GetFeatureGates returns the state of all known feature gates for the
+// instance. The defaults are overridden by the feature gates of the operator
+// and those by the FeatureGatesAnnotation of the instance so that individual
+// instances can opt out of (or into) a new behavior.
+func (r *Reconciler) GetFeatureGates(instance client.Object) (map[string]bool, error) {
+	featureGates := map[string]bool{}
+	for name, enabled := range v1beta1.DefaultFeatureGates {
+		featureGates[name] = enabled
+	}
+
+	for name, enabled := range r.FeatureGates {
+		featureGates[name] = enabled
+	}
+
+	annotation, ok := instance.GetAnnotations()[v1beta1.FeatureGatesAnnotation]
+	if !ok {
+		return featureGates, nil
+	}
+
+	instanceFeatureGates, err := v1beta1.ParseFeatureGates(annotation)
+	if err != nil {
+		return featureGates, fmt.Errorf(ErrInvalidFeatureGatesAnnotation, v1beta1.FeatureGatesAnnotation, err)
+	}
+
+	for name, enabled := range instanceFeatureGates {
+		featureGates[name] = enabled
+	}
+
+	return featureGates, nil
+}
+
+// getPodTemplateOverrides returns the pod template overrides of the instance
+// when the PodTemplateOverrides feature gate is enabled
+func getPodTemplateOverrides(
+	overrides *v1beta1.PodTemplateOverrides,
+	featureGates map[string]bool,
+) *v1beta1.PodTemplateOverrides {
+	if !featureGates[v1beta1.FeatureGatePodTemplateOverrides] {
+		return nil
+	}
+
+	return overrides
+}
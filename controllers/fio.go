@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pvc"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fioReport is the summary of the fio run reported by the test container of
+// a FioTest via its termination message
+type fioReport struct {
+	Profiles []fioProfileReport `json:"profiles"`
+}
+
+// fioProfileReport is the summary of a single fio profile. The latencies are
+// reported in nanoseconds.
+type fioProfileReport struct {
+	Name               string  `json:"name"`
+	ReadIOPS           float64 `json:"readIOPS"`
+	WriteIOPS          float64 `json:"writeIOPS"`
+	ReadBandwidthKiB   float64 `json:"readBandwidthKiB"`
+	WriteBandwidthKiB  float64 `json:"writeBandwidthKiB"`
+	ReadLatencyMeanNs  float64 `json:"readLatencyMeanNs"`
+	ReadLatencyP99Ns   float64 `json:"readLatencyP99Ns"`
+	WriteLatencyMeanNs float64 `json:"writeLatencyMeanNs"`
+	WriteLatencyP99Ns  float64 `json:"writeLatencyP99Ns"`
+}
+
+// EnsureFioTargetPVCExists creates the PVC the fio profiles of a test pod are
+// executed against. Like the logs PVC, the PVC is owned by the instance.
+func (r *Reconciler) EnsureFioTargetPVCExists(
+	ctx context.Context,
+	instance client.Object,
+	helper *helper.Helper,
+	labels map[string]string,
+	claimName string,
+	storageClassName string,
+	size k8sresource.Quantity,
+) (ctrl.Result, error) {
+	existingPVC := &corev1.PersistentVolumeClaim{}
+	objectKey := client.ObjectKey{Namespace: instance.GetNamespace(), Name: claimName}
+	if err := r.Client.Get(ctx, objectKey, existingPVC); err == nil {
+		return ctrl.Result{}, nil
+	}
+
+	targetPVCDef := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: instance.GetNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+			StorageClassName: &storageClassName,
+		},
+	}
+
+	targetPVC := pvc.NewPvc(targetPVCDef, 2*time.Minute)
+	return targetPVC.CreateOrPatch(ctx, helper)
+}
+
+// UpdateFioResults stores the numbers measured by the fio profiles of each
+// finished test pod in status.FioResults. Results of the test pods that were
+// already deleted are kept.
+func (r *Reconciler) UpdateFioResults(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	stepNames []string,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	results := []v1beta1.FioResult{}
+	for _, result := range status.FioResults {
+		if !containsPod(podList.Items, result.PodName) {
+			results = append(results, result)
+		}
+	}
+
+	for _, pod := range podList.Items {
+		workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel])
+		if err != nil {
+			continue
+		}
+
+		result := getFioResult(pod)
+		if result == nil {
+			continue
+		}
+
+		result.WorkflowStep = workflowStep
+		result.PodName = pod.Name
+		if workflowStep < len(stepNames) {
+			result.StepName = stepNames[workflowStep]
+		}
+
+		results = append(results, *result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].WorkflowStep < results[j].WorkflowStep
+	})
+
+	status.FioResults = nil
+	if len(results) > 0 {
+		status.FioResults = results
+	}
+
+	return nil
+}
+
+// getFioResult returns the numbers reported by the test container of a
+// finished test pod. Nil is returned when the test container did not report
+// them (e.g. when the target could not be prepared).
+func getFioResult(pod corev1.Pod) *v1beta1.FioResult {
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != pod.Spec.Containers[0].Name || terminated == nil {
+			continue
+		}
+
+		report := fioReport{}
+		if err := json.Unmarshal([]byte(terminated.Message), &report); err != nil || len(report.Profiles) == 0 {
+			return nil
+		}
+
+		result := &v1beta1.FioResult{}
+		for _, profile := range report.Profiles {
+			result.Profiles = append(result.Profiles, v1beta1.FioProfileResult{
+				Name:              profile.Name,
+				ReadIOPS:          int64(math.Round(profile.ReadIOPS)),
+				WriteIOPS:         int64(math.Round(profile.WriteIOPS)),
+				ReadBandwidthKiB:  int64(math.Round(profile.ReadBandwidthKiB)),
+				WriteBandwidthKiB: int64(math.Round(profile.WriteBandwidthKiB)),
+				ReadLatencyMean:   getFioLatency(profile.ReadLatencyMeanNs),
+				ReadLatencyP99:    getFioLatency(profile.ReadLatencyP99Ns),
+				WriteLatencyMean:  getFioLatency(profile.WriteLatencyMeanNs),
+				WriteLatencyP99:   getFioLatency(profile.WriteLatencyP99Ns),
+			})
+		}
+
+		return result
+	}
+
+	return nil
+}
+
+// getFioLatency converts a latency reported in nanoseconds. Nil is returned
+// for the operations the profile did not perform.
+func getFioLatency(nanoseconds float64) *metav1.Duration {
+	if nanoseconds <= 0 {
+		return nil
+	}
+
+	return &metav1.Duration{Duration: time.Duration(math.Round(nanoseconds))}
+}
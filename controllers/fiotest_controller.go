@@ -0,0 +1,267 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/fiotest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// FioTestReconciler reconciles a FioTest object
+type FioTestReconciler struct {
+	Reconciler
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *FioTestReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("FioTest")
+}
+
+// +kubebuilder:rbac:groups=test.openstack.org,resources=fiotests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=test.openstack.org,resources=fiotests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=fiotests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
+
+// Reconcile - FioTest
+func (r *FioTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &fioTestKind{r: r, instance: &testv1beta1.FioTest{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FioTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.FioTest{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+// fioTestKind provides the parts of the reconcile flow of FioTest that differ
+// between the test kinds (see testKind)
+type fioTestKind struct {
+	r             *FioTestReconciler
+	instance      *testv1beta1.FioTest
+	stepSpec      testv1beta1.FioTestSpec
+	targetPVCName string
+}
+
+func (k *fioTestKind) Instance() testKindObject {
+	return k.instance
+}
+
+func (k *fioTestKind) ServiceName() string {
+	return fiotest.ServiceName
+}
+
+func (k *fioTestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
+
+func (k *fioTestKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
+
+func (k *fioTestKind) SelectStep(_ NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	spec := *k.instance.Spec.DeepCopy()
+	if mergeSpecOverride && step < len(spec.Workflow) {
+		var err error
+		spec, err = MergeSpecOverride(spec, spec.Workflow[step].SpecOverride)
+		if err != nil {
+			return testKindStep{}, err
+		}
+	}
+
+	k.stepSpec = getFioTestStepSpec(spec, step)
+
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        k.stepSpec.CommonOptions,
+		NetworkAttachments: k.stepSpec.NetworkAttachments,
+	}
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
+	}
+
+	return kindStep, nil
+}
+
+func (k *fioTestKind) EnvVars(podName string) (map[string]env.Setter, error) {
+	return k.r.PrepareFioTestEnvVars(k.stepSpec, podName)
+}
+
+func (k *fioTestKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	return fiotest.Pod(
+		k.instance,
+		k.stepSpec,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		logsPVCName,
+		k.targetPVCName,
+		mountCerts,
+		envVars,
+		containerImage,
+		k.stepSpec.Privileged,
+	)
+}
+
+func (k *fioTestKind) UpdateResults(ctx context.Context, stepNames []string) error {
+	return k.r.UpdateFioResults(ctx, k.instance, &k.instance.Status, stepNames)
+}
+
+// EnsureStepResources creates the target PVC of the test pod when the pvc
+// target is used
+func (k *fioTestKind) EnsureStepResources(
+	ctx context.Context,
+	helper *helper.Helper,
+	labels map[string]string,
+	podName string,
+	_ string,
+) (ctrl.Result, error) {
+	k.targetPVCName = ""
+	if k.stepSpec.Target != testv1beta1.FioTargetPVC {
+		return ctrl.Result{}, nil
+	}
+
+	k.targetPVCName = podName + fiotest.TargetPVCSuffix
+	return k.r.EnsureFioTargetPVCExists(
+		ctx,
+		k.instance,
+		helper,
+		labels,
+		k.targetPVCName,
+		k.stepSpec.PVC.StorageClass,
+		k.stepSpec.PVC.Size,
+	)
+}
+
+// This function prepares env variables for a single workflow step.
+func (r *FioTestReconciler) PrepareFioTestEnvVars(
+	stepSpec testv1beta1.FioTestSpec,
+	podName string,
+) (map[string]env.Setter, error) {
+	envVars := make(map[string]env.Setter)
+	envVars["USE_EXTERNAL_FILES"] = env.SetValue("True")
+	envVars["FIO_LOGS_DIR_NAME"] = env.SetValue(podName)
+	envVars["FIO_TARGET"] = env.SetValue(string(stepSpec.Target))
+	envVars["FIO_JOB_FILE"] = env.SetValue(fiotest.GetJobFile(stepSpec.Profiles))
+	envVars["FIO_DEBUG_MODE"] = env.SetValue(r.GetDefaultBool(stepSpec.Debug))
+
+	switch stepSpec.Target {
+	case testv1beta1.FioTargetPVC:
+		envVars["FIO_TARGET_DIR"] = env.SetValue(fiotest.TargetDir)
+
+	case testv1beta1.FioTargetCinder:
+		if stepSpec.Cinder == nil {
+			return nil, errors.New(testv1beta1.ErrFioCinderRequired)
+		}
+
+		envVars["FIO_OS_CLOUD"] = env.SetValue(stepSpec.Cloud)
+		envVars["FIO_CINDER_VOLUME_SIZE"] = env.SetValue(strconv.Itoa(int(stepSpec.Cinder.Size)))
+		envVars["FIO_CINDER_IMAGE"] = env.SetValue(stepSpec.Cinder.Image)
+		envVars["FIO_CINDER_FLAVOR"] = env.SetValue(stepSpec.Cinder.Flavor)
+		envVars["FIO_CINDER_NETWORK"] = env.SetValue(stepSpec.Cinder.Network)
+
+		if len(stepSpec.Cinder.VolumeType) > 0 {
+			envVars["FIO_CINDER_VOLUME_TYPE"] = env.SetValue(stepSpec.Cinder.VolumeType)
+		}
+	}
+
+	return envVars, nil
+}
+
+// getFioTestStepSpec returns the spec that applies to a single workflow
+// step, i.e. the spec of the instance with the values overridden by the
+// workflow step. The workflow of the returned spec is not set. The PVC of
+// the returned spec is always set when the pvc target is used.
+func getFioTestStepSpec(spec testv1beta1.FioTestSpec, step int) testv1beta1.FioTestSpec {
+	stepSpec := *spec.DeepCopy()
+	stepSpec.Workflow = nil
+
+	workflowStep := testv1beta1.FioTestWorkflowSpec{}
+	if step < len(spec.Workflow) {
+		workflowStep = spec.Workflow[step]
+	}
+
+	stepSpec.Privileged = mergeWithWorkflow(spec.Privileged, workflowStep.Privileged)
+	stepSpec.ContainerImage = mergeNonZeroWithWorkflow(spec.ContainerImage, workflowStep.ContainerImage)
+	stepSpec.Target = mergeNonZeroWithWorkflow(spec.Target, workflowStep.Target)
+	stepSpec.Cloud = mergeNonZeroWithWorkflow(spec.Cloud, workflowStep.Cloud)
+	if workflowStep.PVC != nil {
+		stepSpec.PVC = workflowStep.PVC.DeepCopy()
+	}
+	if workflowStep.Cinder != nil {
+		stepSpec.Cinder = workflowStep.Cinder.DeepCopy()
+	}
+	if len(workflowStep.Profiles) > 0 {
+		stepSpec.Profiles = workflowStep.Profiles
+	}
+	if len(workflowStep.NetworkAttachments) > 0 {
+		stepSpec.NetworkAttachments = workflowStep.NetworkAttachments
+	}
+
+	if stepSpec.PVC == nil {
+		stepSpec.PVC = &testv1beta1.FioPVCTarget{Size: k8sresource.MustParse("10Gi")}
+	}
+	if len(stepSpec.PVC.StorageClass) == 0 {
+		stepSpec.PVC.StorageClass = stepSpec.StorageClass
+	}
+
+	return stepSpec
+}
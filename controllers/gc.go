@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	InfoDeletingFinishedPod = "Deleting finished test pod %s. Its exit code and timestamps are recorded in the status."
+)
+
+// DeleteFinishedPods deletes the finished test pods of the instance whose
+// state was recorded in status.Steps (and in status.FailureSnapshots for
+// failed pods when the snapshots are enabled) by a previous reconciliation.
+// The test pod of the most recent workflow step is never deleted as it is
+// used to decide which workflow step runs next.
+func (r *Reconciler) DeleteFinishedPods(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	Log logr.Logger,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	lastWorkflowStep := -1
+	for _, pod := range podList.Items {
+		workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel])
+		if err == nil && workflowStep > lastWorkflowStep {
+			lastWorkflowStep = workflowStep
+		}
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel])
+		if err != nil || workflowStep >= lastWorkflowStep || !isPodHarvested(status, pod) {
+			continue
+		}
+
+		Log.Info(fmt.Sprintf(InfoDeletingFinishedPod, pod.Name))
+		if err := r.Client.Delete(ctx, pod); err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isPodHarvested returns true when the pod is finished and everything the
+// test-operator records about finished pods is already in the status
+func isPodHarvested(status *v1beta1.CommonTestStatus, pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return false
+	}
+
+	harvested := false
+	for _, step := range status.Steps {
+		if step.PodName == pod.Name && step.Phase == pod.Status.Phase {
+			harvested = true
+		}
+	}
+
+	if pod.Status.Phase == corev1.PodFailed && status.FeatureGates[v1beta1.FeatureGateFailureSnapshots] {
+		harvested = harvested && hasFailureSnapshot(status, pod.Name)
+	}
+
+	return harvested
+}
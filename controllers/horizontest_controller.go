@@ -18,18 +18,14 @@ package controllers
 
 import (
 	"context"
-	"errors"
-	"fmt"
 
 	"github.com/go-logr/logr"
-	"github.com/openstack-k8s-operators/lib-common/modules/common"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/test-operator/pkg/horizontest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
-	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -47,240 +43,137 @@ func (r *HorizonTestReconciler) GetLogger(ctx context.Context) logr.Logger {
 // +kubebuilder:rbac:groups=test.openstack.org,resources=horizontests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=test.openstack.org,resources=horizontests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=test.openstack.org,resources=horizontests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
-// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
 // +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
 
 // Reconcile - HorizonTest
-func (r *HorizonTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
-	Log := r.GetLogger(ctx)
-	instance := &testv1beta1.HorizonTest{}
-	err := r.Client.Get(ctx, req.NamespacedName, instance)
-	if err != nil {
-		if k8s_errors.IsNotFound(err) {
-			return ctrl.Result{}, nil
-		}
-		return ctrl.Result{}, err
-	}
-
-	helper, err := helper.NewHelper(
-		instance,
-		r.Client,
-		r.Kclient,
-		r.Scheme,
-		r.Log,
-	)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// initialize status
-	isNewInstance := instance.Status.Conditions == nil
-	if isNewInstance {
-		instance.Status.Conditions = condition.Conditions{}
-	}
-
-	// Save a copy of the condtions so that we can restore the LastTransitionTime
-	// when a condition's state doesn't change.
-	savedConditions := instance.Status.Conditions.DeepCopy()
-
-	// Always patch the instance status when exiting this function so we
-	// can persist any changes.
-	defer func() {
-		// update the overall status condition if service is ready
-		if instance.Status.Conditions.AllSubConditionIsTrue() {
-			instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
-		}
-		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
-		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
-			instance.Status.Conditions.Set(
-				instance.Status.Conditions.Mirror(condition.ReadyCondition))
-		}
-		err := helper.PatchInstance(ctx, instance)
-		if err != nil {
-			_err = err
-			return
-		}
-	}()
-
-	if isNewInstance {
-		// Initialize conditions used later as Status=Unknown
-		cl := condition.CreateList(
-			condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
-			condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
-		)
-		instance.Status.Conditions.Init(&cl)
-
-		// Register overall status immediately to have an early feedback
-		// e.g. in the cli
-		return ctrl.Result{}, nil
-
-	}
-
-	workflowLength := 0
-	nextAction, nextWorkflowStep, err := r.NextAction(ctx, instance, workflowLength)
-
-	switch nextAction {
-	case Failure:
-		return ctrl.Result{}, err
-
-	case Wait:
-		Log.Info(InfoWaitingOnPod)
-		return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
-
-	case EndTesting:
-		// All pods created by the instance were completed. Release the lock
-		// so that other instances can spawn their pods.
-		if lockReleased, err := r.ReleaseLock(ctx, instance); !lockReleased {
-			Log.Info(fmt.Sprintf(InfoCanNotReleaseLock, testOperatorLockName))
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
-
-		instance.Status.Conditions.MarkTrue(
-			condition.DeploymentReadyCondition,
-			condition.DeploymentReadyMessage)
-
-		Log.Info(InfoTestingCompleted)
-		return ctrl.Result{}, nil
-
-	case CreateFirstPod:
-		lockAcquired, err := r.AcquireLock(ctx, instance, helper, instance.Spec.Parallel)
-		if !lockAcquired {
-			Log.Info(fmt.Sprintf(InfoCanNotAcquireLock, testOperatorLockName))
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
-
-		Log.Info(fmt.Sprintf(InfoCreatingFirstPod, nextWorkflowStep))
-
-	case CreateNextPod:
-		// Confirm that we still hold the lock. This is useful to check if for
-		// example somebody / something deleted the lock and it got claimed by
-		// another instance. This is considered to be an error state.
-		lockAcquired, err := r.AcquireLock(ctx, instance, helper, instance.Spec.Parallel)
-		if !lockAcquired {
-			Log.Error(err, ErrConfirmLockOwnership, testOperatorLockName)
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
+func (r *HorizonTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &horizonTestKind{r: r, instance: &testv1beta1.HorizonTest{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
 
-		Log.Info(fmt.Sprintf(InfoCreatingNextPod, nextWorkflowStep))
+// SetupWithManager sets up the controller with the Manager.
+func (r *HorizonTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.HorizonTest{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
 
-	default:
-		return ctrl.Result{}, errors.New(ErrReceivedUnexpectedAction)
-	}
+// horizonTestKind provides the parts of the reconcile flow of HorizonTest that
+// differ between the test kinds (see testKind). The HorizonTest CR does not
+// support workflows, each browser listed in the instance is tested by the test
+// pod of a separate workflow step.
+type horizonTestKind struct {
+	r        *HorizonTestReconciler
+	instance *testv1beta1.HorizonTest
+	browser  testv1beta1.HorizonTestBrowser
+}
 
-	serviceLabels := map[string]string{
-		common.AppSelector: horizontest.ServiceName,
-		instanceNameLabel:  instance.Name,
-		operatorNameLabel:  "test-operator",
+func (k *horizonTestKind) Instance() testKindObject {
+	return k.instance
+}
 
-		// NOTE(lpiwowar):  This is a workaround since the Horizontest CR does not support
-		//                  workflows. However, the label might be required by automation that
-		//                  consumes the test-operator (e.g., ci-framework).
-		workflowStepLabel: "0",
-	}
+func (k *horizonTestKind) ServiceName() string {
+	return horizontest.ServiceName
+}
 
-	yamlResult, err := EnsureCloudsConfigMapExists(ctx, instance, helper, serviceLabels)
+func (k *horizonTestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
 
-	if err != nil {
-		return yamlResult, err
-	}
+func (k *horizonTestKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
 
-	// Create PersistentVolumeClaim
-	ctrlResult, err := r.EnsureLogsPVCExists(
-		ctx,
-		instance,
-		helper,
-		serviceLabels,
-		instance.Spec.StorageClass,
-		0,
-	)
-	if err != nil {
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-	// Create PersistentVolumeClaim - end
+// ParallelSteps tells whether the test pods of all the browsers run at the
+// same time
+func (k *horizonTestKind) ParallelSteps() bool {
+	return k.instance.Spec.ParallelBrowsers
+}
 
-	// Create Job
-	mountCerts := r.CheckSecretExists(ctx, instance, "combined-ca-bundle")
+func (k *horizonTestKind) SelectStep(_ NextAction, step int, _ bool) (testKindStep, error) {
+	k.browser = horizontest.GetBrowser(k.instance, step)
 
-	mountKeys := false
+	return testKindStep{
+		Options:     k.instance.Spec.CommonOptions,
+		Resources:   k.instance.Spec.Resources,
+		StepOptions: k.instance.Spec.CommonOptions,
+	}, nil
+}
 
-	mountKubeconfig := false
-	if len(instance.Spec.KubeconfigSecretName) != 0 {
-		mountKubeconfig = true
-	}
+func (k *horizonTestKind) EnsureStepResources(
+	ctx context.Context,
+	helper *helper.Helper,
+	labels map[string]string,
+	_ string,
+	_ string,
+) (ctrl.Result, error) {
+	return EnsureCloudsConfigMapExists(ctx, k.instance, helper, labels)
+}
 
-	// Prepare HorizonTest env vars
-	envVars := r.PrepareHorizonTestEnvVars(instance)
-	podName := r.GetPodName(instance, 0)
-	logsPVCName := r.GetPVCLogsName(instance, 0)
-	containerImage, err := r.GetContainerImage(ctx, instance.Spec.ContainerImage, instance)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
+func (k *horizonTestKind) EnvVars(_ string) (map[string]env.Setter, error) {
+	return k.r.PrepareHorizonTestEnvVars(k.instance, k.browser), nil
+}
 
-	podDef := horizontest.Pod(
-		instance,
-		serviceLabels,
+func (k *horizonTestKind) Pod(
+	_ operatorutil.EffectiveSpec,
+	labels map[string]string,
+	_ map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	return horizontest.Pod(
+		k.instance,
+		labels,
 		podName,
+		horizontest.GetLogsDirName(k.browser),
 		logsPVCName,
 		mountCerts,
-		mountKeys,
-		mountKubeconfig,
+		false,
+		len(k.instance.Spec.KubeconfigSecretName) != 0,
 		envVars,
 		containerImage,
 	)
-
-	ctrlResult, err = r.CreatePod(ctx, *helper, podDef)
-	if err != nil {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			condition.DeploymentReadyErrorMessage,
-			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
-	}
-	// create Job - end
-	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
-}
-
-// SetupWithManager sets up the controller with the Manager.
-func (r *HorizonTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&testv1beta1.HorizonTest{}).
-		Owns(&corev1.Pod{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.ConfigMap{}).
-		Complete(r)
 }
 
 func (r *HorizonTestReconciler) PrepareHorizonTestEnvVars(
 	instance *testv1beta1.HorizonTest,
+	browser testv1beta1.HorizonTestBrowser,
 ) map[string]env.Setter {
+	logsDirName := horizontest.GetLogsDirName(browser)
+
 	// Prepare env vars
 	envVars := make(map[string]env.Setter)
 	envVars["USE_EXTERNAL_FILES"] = env.SetValue("True")
-	envVars["HORIZON_LOGS_DIR_NAME"] = env.SetValue("horizon")
+	envVars["HORIZON_LOGS_DIR_NAME"] = env.SetValue(logsDirName)
 
 	// Mandatory variables
 	envVars["ADMIN_USERNAME"] = env.SetValue(instance.Spec.AdminUsername)
 	envVars["ADMIN_PASSWORD"] = env.SetValue(instance.Spec.AdminPassword)
-	envVars["DASHBOARD_URL"] = env.SetValue(instance.Spec.DashboardUrl)
-	envVars["AUTH_URL"] = env.SetValue(instance.Spec.AuthUrl)
-	envVars["REPO_URL"] = env.SetValue(instance.Spec.RepoUrl)
+	envVars["DASHBOARD_URL"] = env.SetValue(BracketIPv6URL(instance.Spec.DashboardUrl))
+	envVars["AUTH_URL"] = env.SetValue(BracketIPv6URL(instance.Spec.AuthUrl))
+	envVars["REPO_URL"] = env.SetValue(BracketIPv6URL(instance.Spec.RepoUrl))
 	envVars["HORIZON_REPO_BRANCH"] = env.SetValue(instance.Spec.HorizonRepoBranch)
 
 	// Horizon specific configuration
@@ -295,6 +188,25 @@ func (r *HorizonTestReconciler) PrepareHorizonTestEnvVars(
 	envVars["HORIZONTEST_DEBUG_MODE"] = env.SetValue(r.GetDefaultBool(instance.Spec.Debug))
 	envVars["EXTRA_FLAG"] = env.SetValue(instance.Spec.ExtraFlag)
 	envVars["PROJECT_NAME_XPATH"] = env.SetValue(instance.Spec.ProjectNameXpath)
+	envVars["HORIZONTEST_VIDEO_RECORDING"] = env.SetValue(r.GetDefaultBool(instance.Spec.VideoRecording))
+	envVars["HORIZONTEST_VIDEO_DIR_NAME"] = env.SetValue(logsDirName + "/" + horizontest.VideoDirName)
+	envVars["HORIZONTEST_FAILURE_SCREENSHOTS"] = env.SetValue(r.GetDefaultBool(instance.Spec.FailureScreenshots))
+	envVars["HORIZONTEST_SCREENSHOTS_DIR_NAME"] = env.SetValue(logsDirName + "/" + horizontest.ScreenshotsDirName)
+
+	if len(browser) > 0 {
+		envVars["HORIZONTEST_BROWSER"] = env.SetValue(string(browser))
+	}
+
+	if len(instance.Spec.SeleniumGridURL) > 0 {
+		envVars["HORIZONTEST_SELENIUM_GRID_URL"] = env.SetValue(BracketIPv6URL(instance.Spec.SeleniumGridURL))
+	}
+
+	if secretName := instance.Spec.SeleniumGridCredentialsSecretName; len(secretName) > 0 {
+		envVars["HORIZONTEST_SELENIUM_GRID_USERNAME"] = horizontest.GetSecretKeyEnvVar(
+			secretName, horizontest.SeleniumGridUsernameKey)
+		envVars["HORIZONTEST_SELENIUM_GRID_PASSWORD"] = horizontest.GetSecretKeyEnvVar(
+			secretName, horizontest.SeleniumGridPasswordKey)
+	}
 
 	return envVars
 }
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/registry"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ErrCosignPublicKeyNotFound = "cosign public key %s was not found in the %s config map"
+)
+
+const (
+	InfoPinnedContainerImage = "Container image %s was pinned to %s."
+)
+
+// PinContainerImage returns the container image pinned to a digest when
+// spec.pinImageDigest or spec.cosignPublicKey is set. The digest is resolved
+// (and the cosign signature is verified) only once per container image. The
+// result is recorded in status.ImageDigests and reused by the following
+// workflow steps.
+func (r *Reconciler) PinContainerImage(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	options v1beta1.CommonOptions,
+	containerImage string,
+	Log logr.Logger,
+) (string, error) {
+	if !options.PinImageDigest && options.CosignPublicKey == nil {
+		return containerImage, nil
+	}
+
+	if pinnedImage, ok := status.ImageDigests[containerImage]; ok {
+		return pinnedImage, nil
+	}
+
+	ref, err := registry.ParseReference(containerImage)
+	if err != nil {
+		return "", err
+	}
+
+	credentials, err := r.getRegistryCredentials(ctx, instance.GetNamespace(), options.ImagePullSecrets)
+	if err != nil {
+		return "", err
+	}
+
+	registryClient := registry.NewClient(credentials)
+	digest, err := registryClient.ResolveDigest(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	if options.CosignPublicKey != nil {
+		publicKey, err := r.getCosignPublicKey(ctx, instance.GetNamespace(), options.CosignPublicKey)
+		if err != nil {
+			return "", err
+		}
+
+		err = registryClient.VerifyCosignSignature(ctx, ref, digest, publicKey)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	pinnedImage := ref.WithDigest(digest).String()
+	Log.Info(fmt.Sprintf(InfoPinnedContainerImage, containerImage, pinnedImage))
+
+	if status.ImageDigests == nil {
+		status.ImageDigests = map[string]string{}
+	}
+
+	status.ImageDigests[containerImage] = pinnedImage
+
+	return pinnedImage, nil
+}
+
+// getRegistryCredentials returns the registry credentials stored in the
+// image pull secrets. Secrets that do not exist or that are not of the
+// kubernetes.io/dockerconfigjson type are skipped.
+func (r *Reconciler) getRegistryCredentials(
+	ctx context.Context,
+	namespace string,
+	secretNames []string,
+) (map[string]registry.Credentials, error) {
+	credentials := map[string]registry.Credentials{}
+	for _, secretName := range secretNames {
+		secret := &corev1.Secret{}
+		objectKey := client.ObjectKey{Namespace: namespace, Name: secretName}
+		err := r.Client.Get(ctx, objectKey, secret)
+		if k8s_errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		data, ok := secret.Data[corev1.DockerConfigJsonKey]
+		if !ok {
+			continue
+		}
+
+		secretCredentials, err := registry.ParseDockerConfig(data)
+		if err != nil {
+			return nil, err
+		}
+
+		for host, hostCredentials := range secretCredentials {
+			if _, exists := credentials[host]; !exists {
+				credentials[host] = hostCredentials
+			}
+		}
+	}
+
+	return credentials, nil
+}
+
+func (r *Reconciler) getCosignPublicKey(
+	ctx context.Context,
+	namespace string,
+	selector *corev1.ConfigMapKeySelector,
+) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	objectKey := client.ObjectKey{Namespace: namespace, Name: selector.Name}
+	if err := r.Client.Get(ctx, objectKey, cm); err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := cm.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf(ErrCosignPublicKeyNotFound, selector.Key, selector.Name)
+	}
+
+	return []byte(publicKey), nil
+}
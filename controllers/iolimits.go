@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Annotations read by the CNI bandwidth plugin
+	ingressBandwidthAnnotation = "kubernetes.io/ingress-bandwidth"
+	egressBandwidthAnnotation  = "kubernetes.io/egress-bandwidth"
+
+	// Annotation read by the container runtime (CRI-O, containerd) to assign
+	// the containers of the pod to a block I/O class
+	blockIOClassAnnotation = "blockio.resources.beta.kubernetes.io/pod"
+)
+
+// GetIOLimits returns the ioLimits of the workflow step when they are set and
+// the ioLimits of the spec otherwise
+func GetIOLimits(
+	options v1beta1.CommonOptions,
+	workflowStep *v1beta1.WorkflowCommonParameters,
+) *v1beta1.IOLimits {
+	if workflowStep != nil && workflowStep.IOLimits != nil {
+		return workflowStep.IOLimits
+	}
+
+	return options.IOLimits
+}
+
+// ApplyIOLimits adds the annotations that throttle the disk and the network
+// I/O to the pod and records the applied limits in status.IOLimits
+func ApplyIOLimits(pod *corev1.Pod, ioLimits *v1beta1.IOLimits, status *v1beta1.CommonTestStatus) {
+	if ioLimits == nil {
+		return
+	}
+
+	annotations := map[string]string{}
+	if ioLimits.IngressBandwidth != nil {
+		annotations[ingressBandwidthAnnotation] = ioLimits.IngressBandwidth.String()
+	}
+
+	if ioLimits.EgressBandwidth != nil {
+		annotations[egressBandwidthAnnotation] = ioLimits.EgressBandwidth.String()
+	}
+
+	if ioLimits.BlockIOClass != "" {
+		annotations[blockIOClassAnnotation] = ioLimits.BlockIOClass
+	}
+
+	if len(annotations) == 0 {
+		return
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+
+	for key, value := range annotations {
+		pod.Annotations[key] = value
+	}
+
+	if status.IOLimits == nil {
+		status.IOLimits = map[string]v1beta1.IOLimits{}
+	}
+
+	status.IOLimits[pod.Name] = *ioLimits.DeepCopy()
+}
@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+const (
+	ErrNADIPFamily      = "network-attachment-definition %s does not assign %s addresses"
+	ErrInvalidNADConfig = "invalid config of network-attachment-definition %s: %w"
+)
+
+// nadIPAMConfig contains the fields of the IPAM configuration of the CNI
+// plugins that define the addresses assigned to the pods (host-local,
+// whereabouts and static)
+type nadIPAMConfig struct {
+	Subnet string `json:"subnet,omitempty"`
+	Range  string `json:"range,omitempty"`
+	Ranges [][]struct {
+		Subnet string `json:"subnet"`
+	} `json:"ranges,omitempty"`
+	IPRanges []struct {
+		Range string `json:"range"`
+	} `json:"ipRanges,omitempty"`
+	Addresses []struct {
+		Address string `json:"address"`
+	} `json:"addresses,omitempty"`
+}
+
+// nadConfig is the CNI configuration (or configuration list) of a
+// NetworkAttachmentDefinition
+type nadConfig struct {
+	IPAM    nadIPAMConfig `json:"ipam"`
+	Plugins []struct {
+		IPAM nadIPAMConfig `json:"ipam"`
+	} `json:"plugins,omitempty"`
+}
+
+// VerifyNetworkAttachmentIPFamilies checks that the network attachment
+// definitions assign addresses of the IP family requested in
+// spec.networkAttachmentIPFamilies. The network attachment definitions that
+// assign the addresses dynamically (e.g. using DHCP) are not verified.
+func VerifyNetworkAttachmentIPFamilies(
+	nadList []networkv1.NetworkAttachmentDefinition,
+	ipFamilies map[string]v1beta1.IPFamily,
+) error {
+	for _, nad := range nadList {
+		ipFamily, found := ipFamilies[nad.Name]
+		if !found {
+			continue
+		}
+
+		nadIPFamilies, err := getNADIPFamilies(nad)
+		if err != nil {
+			return err
+		}
+
+		if len(nadIPFamilies) == 0 {
+			continue
+		}
+
+		for _, requiredIPFamily := range getRequiredIPFamilies(ipFamily) {
+			if !nadIPFamilies[requiredIPFamily] {
+				return fmt.Errorf(ErrNADIPFamily, nad.Name, requiredIPFamily)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SelectNetworkAttachmentIPFamilies returns the network status of the test
+// pods (see nad.VerifyNetworkStatusFromAnnotation) with the addresses of the
+// IP family requested for each of the network attachments only. The second
+// returned value is false when the test pods did not get an address of each
+// of the requested IP families.
+func SelectNetworkAttachmentIPFamilies(
+	networkAttachmentStatus map[string][]string,
+	namespace string,
+	ipFamilies map[string]v1beta1.IPFamily,
+) (map[string][]string, bool) {
+	if len(ipFamilies) == 0 {
+		return networkAttachmentStatus, true
+	}
+
+	ready := true
+	selectedStatus := map[string][]string{}
+	for networkName, addresses := range networkAttachmentStatus {
+		ipFamily, found := ipFamilies[strings.TrimPrefix(networkName, namespace+"/")]
+		if !found {
+			selectedStatus[networkName] = addresses
+			continue
+		}
+
+		requiredIPFamilies := getRequiredIPFamilies(ipFamily)
+		addressIPFamilies := map[v1beta1.IPFamily]bool{}
+		selectedAddresses := []string{}
+		for _, address := range addresses {
+			addressIPFamily := getAddressIPFamily(address)
+			if ipFamily == v1beta1.IPFamilyDualStack || addressIPFamily == ipFamily {
+				addressIPFamilies[addressIPFamily] = true
+				selectedAddresses = append(selectedAddresses, address)
+			}
+		}
+
+		for _, requiredIPFamily := range requiredIPFamilies {
+			ready = ready && addressIPFamilies[requiredIPFamily]
+		}
+
+		selectedStatus[networkName] = selectedAddresses
+	}
+
+	return selectedStatus, ready
+}
+
+// BracketIPv6URL returns the URL with the IPv6 address in its host enclosed
+// in brackets (e.g. http://fd00::10/v3 becomes http://[fd00::10]/v3) so the
+// tools run by the test pods can parse it. The URL is returned as it is when
+// the host is not an IPv6 address or it is bracketed already. An IPv6
+// address followed by a port is ambiguous and has to be bracketed in the
+// spec.
+func BracketIPv6URL(rawURL string) string {
+	schemeEnd := strings.Index(rawURL, "://")
+	if schemeEnd < 0 {
+		return rawURL
+	}
+
+	authorityStart := schemeEnd + len("://")
+	authorityEnd := len(rawURL)
+	if idx := strings.IndexAny(rawURL[authorityStart:], "/?#"); idx >= 0 {
+		authorityEnd = authorityStart + idx
+	}
+
+	hostStart := authorityStart
+	if idx := strings.LastIndex(rawURL[authorityStart:authorityEnd], "@"); idx >= 0 {
+		hostStart = authorityStart + idx + 1
+	}
+
+	host := rawURL[hostStart:authorityEnd]
+	if net.ParseIP(host) == nil || !strings.Contains(host, ":") {
+		return rawURL
+	}
+
+	return rawURL[:hostStart] + "[" + host + "]" + rawURL[authorityEnd:]
+}
+
+// getNADIPFamilies returns the IP families of the addresses assigned by the
+// network attachment definition. The returned map is empty when the
+// addresses are not defined in the IPAM configuration.
+func getNADIPFamilies(nad networkv1.NetworkAttachmentDefinition) (map[v1beta1.IPFamily]bool, error) {
+	nadIPFamilies := map[v1beta1.IPFamily]bool{}
+	if nad.Spec.Config == "" {
+		return nadIPFamilies, nil
+	}
+
+	config := nadConfig{}
+	if err := json.Unmarshal([]byte(nad.Spec.Config), &config); err != nil {
+		return nil, fmt.Errorf(ErrInvalidNADConfig, nad.Name, err)
+	}
+
+	ipamConfigs := []nadIPAMConfig{config.IPAM}
+	for _, plugin := range config.Plugins {
+		ipamConfigs = append(ipamConfigs, plugin.IPAM)
+	}
+
+	for _, ipam := range ipamConfigs {
+		addresses := []string{ipam.Subnet, ipam.Range}
+		for _, rangeSet := range ipam.Ranges {
+			for _, ipRange := range rangeSet {
+				addresses = append(addresses, ipRange.Subnet)
+			}
+		}
+
+		for _, ipRange := range ipam.IPRanges {
+			addresses = append(addresses, ipRange.Range)
+		}
+
+		for _, address := range ipam.Addresses {
+			addresses = append(addresses, address.Address)
+		}
+
+		for _, address := range addresses {
+			if address != "" {
+				nadIPFamilies[getAddressIPFamily(address)] = true
+			}
+		}
+	}
+
+	return nadIPFamilies, nil
+}
+
+// getRequiredIPFamilies returns the IP families of the addresses a test pod
+// needs to get on a network with the requested IP family
+func getRequiredIPFamilies(ipFamily v1beta1.IPFamily) []v1beta1.IPFamily {
+	switch ipFamily {
+	case v1beta1.IPFamilyIPv4, v1beta1.IPFamilyIPv6:
+		return []v1beta1.IPFamily{ipFamily}
+	case v1beta1.IPFamilyDualStack:
+		return []v1beta1.IPFamily{v1beta1.IPFamilyIPv4, v1beta1.IPFamilyIPv6}
+	}
+
+	return nil
+}
+
+// getAddressIPFamily returns the IP family of an address, a subnet or a
+// range of addresses (e.g. 192.168.1.0/24 or fd00::10-fd00::20)
+func getAddressIPFamily(address string) v1beta1.IPFamily {
+	if strings.Contains(address, ":") {
+		return v1beta1.IPFamilyIPv6
+	}
+
+	return v1beta1.IPFamilyIPv4
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/k6test"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// K6TestReconciler reconciles a K6Test object
+type K6TestReconciler struct {
+	Reconciler
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *K6TestReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("K6Test")
+}
+
+// +kubebuilder:rbac:groups=test.openstack.org,resources=k6tests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=test.openstack.org,resources=k6tests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=k6tests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
+
+// Reconcile - K6Test
+func (r *K6TestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &k6TestKind{r: r, instance: &testv1beta1.K6Test{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *K6TestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.K6Test{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+// k6TestKind provides the parts of the reconcile flow of K6Test that differ
+// between the test kinds (see testKind)
+type k6TestKind struct {
+	r        *K6TestReconciler
+	instance *testv1beta1.K6Test
+	stepSpec testv1beta1.K6TestSpec
+}
+
+func (k *k6TestKind) Instance() testKindObject {
+	return k.instance
+}
+
+func (k *k6TestKind) ServiceName() string {
+	return k6test.ServiceName
+}
+
+func (k *k6TestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
+
+func (k *k6TestKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
+
+func (k *k6TestKind) SelectStep(_ NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	spec := *k.instance.Spec.DeepCopy()
+	if mergeSpecOverride && step < len(spec.Workflow) {
+		var err error
+		spec, err = MergeSpecOverride(spec, spec.Workflow[step].SpecOverride)
+		if err != nil {
+			return testKindStep{}, err
+		}
+	}
+
+	k.stepSpec = getK6TestStepSpec(spec, step)
+
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        k.stepSpec.CommonOptions,
+		NetworkAttachments: k.stepSpec.NetworkAttachments,
+	}
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
+	}
+
+	return kindStep, nil
+}
+
+func (k *k6TestKind) EnvVars(podName string) (map[string]env.Setter, error) {
+	return k.r.PrepareK6TestEnvVars(k.stepSpec, podName)
+}
+
+func (k *k6TestKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	return k6test.Pod(
+		k.instance,
+		k.stepSpec,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		logsPVCName,
+		mountCerts,
+		envVars,
+		containerImage,
+		k.stepSpec.Privileged,
+	)
+}
+
+func (k *k6TestKind) UpdateResults(ctx context.Context, stepNames []string) error {
+	return k.r.UpdateK6ThresholdResults(ctx, k.instance, &k.instance.Status, stepNames)
+}
+
+// This function prepares env variables for a single workflow step.
+func (r *K6TestReconciler) PrepareK6TestEnvVars(
+	stepSpec testv1beta1.K6TestSpec,
+	podName string,
+) (map[string]env.Setter, error) {
+	envVars := make(map[string]env.Setter)
+	envVars["USE_EXTERNAL_FILES"] = env.SetValue("True")
+	envVars["K6_LOGS_DIR_NAME"] = env.SetValue(podName)
+	envVars["K6_OS_CLOUD"] = env.SetValue(stepSpec.Cloud)
+	envVars["K6_SCRIPT_FILE"] = env.SetValue(k6test.ScriptDir + "/" + k6test.ScriptFileName)
+	envVars["K6_DEBUG_MODE"] = env.SetValue(r.GetDefaultBool(stepSpec.Debug))
+
+	// K6_VUS, K6_DURATION and K6_STAGES are read by k6 directly
+	if len(stepSpec.Stages) > 0 {
+		envVars["K6_STAGES"] = env.SetValue(k6test.GetStages(stepSpec.Stages))
+	} else {
+		envVars["K6_VUS"] = env.SetValue(strconv.Itoa(int(stepSpec.VUs)))
+		envVars["K6_DURATION"] = env.SetValue(stepSpec.Duration.Duration.String())
+	}
+
+	if len(stepSpec.TargetServices) > 0 {
+		envVars["K6_TARGET_SERVICES"] = env.SetValue(strings.Join(stepSpec.TargetServices, ","))
+	}
+
+	if len(stepSpec.TargetURLs) > 0 {
+		envVars["K6_TARGET_URLS"] = env.SetValue(strings.Join(stepSpec.TargetURLs, ","))
+	}
+
+	thresholds, err := k6test.GetThresholds(stepSpec.Thresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(thresholds) > 0 {
+		envVars["K6_THRESHOLDS"] = env.SetValue(thresholds)
+	}
+
+	return envVars, nil
+}
+
+// getK6TestStepSpec returns the spec that applies to a single workflow
+// step, i.e. the spec of the instance with the values overridden by the
+// workflow step. The workflow of the returned spec is not set.
+func getK6TestStepSpec(spec testv1beta1.K6TestSpec, step int) testv1beta1.K6TestSpec {
+	stepSpec := *spec.DeepCopy()
+	stepSpec.Workflow = nil
+
+	workflowStep := testv1beta1.K6TestWorkflowSpec{}
+	if step < len(spec.Workflow) {
+		workflowStep = spec.Workflow[step]
+	}
+
+	stepSpec.Privileged = mergeWithWorkflow(spec.Privileged, workflowStep.Privileged)
+	stepSpec.ContainerImage = mergeNonZeroWithWorkflow(spec.ContainerImage, workflowStep.ContainerImage)
+	stepSpec.Cloud = mergeNonZeroWithWorkflow(spec.Cloud, workflowStep.Cloud)
+	stepSpec.VUs = mergeWithWorkflow(spec.VUs, workflowStep.VUs)
+	stepSpec.Duration = mergeWithWorkflow(spec.Duration, workflowStep.Duration)
+	if workflowStep.ScriptConfigMap != nil {
+		stepSpec.ScriptConfigMap = workflowStep.ScriptConfigMap.DeepCopy()
+	}
+	if len(workflowStep.TargetServices) > 0 {
+		stepSpec.TargetServices = workflowStep.TargetServices
+	}
+	if len(workflowStep.TargetURLs) > 0 {
+		stepSpec.TargetURLs = workflowStep.TargetURLs
+	}
+	if len(workflowStep.Stages) > 0 {
+		stepSpec.Stages = workflowStep.Stages
+	}
+	if len(workflowStep.Thresholds) > 0 {
+		stepSpec.Thresholds = workflowStep.Thresholds
+	}
+	if len(workflowStep.NetworkAttachments) > 0 {
+		stepSpec.NetworkAttachments = workflowStep.NetworkAttachments
+	}
+
+	return stepSpec
+}
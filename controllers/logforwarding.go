@@ -0,0 +1,367 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	logForwarderContainerName = "log-forwarder"
+	logForwarderConfigFile    = "fluent-bit.conf"
+	logForwarderConfigPath    = "/fluent-bit/etc/test-operator"
+	logForwarderLogsPath      = "/var/log/test-operator"
+
+	// logForwarderCABundlePath is where the fluent-bit image expects the
+	// system trust bundle
+	logForwarderCABundlePath = "/etc/ssl/certs/ca-certificates.crt"
+
+	logForwardingConfigVolumeName = "log-forwarding-config"
+	logForwardingLogsVolumeName   = "log-forwarding-logs"
+
+	// logForwardingCredentialsUsernameKey and logForwardingCredentialsPasswordKey
+	// are the keys of the credentials secret
+	logForwardingCredentialsUsernameKey = "username"
+	logForwardingCredentialsPasswordKey = "password"
+
+	defaultLogForwarderImageEnvVar = "RELATED_IMAGE_TEST_LOG_FORWARDER_IMAGE_URL_DEFAULT"
+)
+
+// logForwardingExcludedFiles are the files of the artifact directory that are
+// not shipped as they do not contain text
+var logForwardingExcludedFiles = []string{
+	"*.gz", "*.tgz", "*.tar", "*.zip", "*.png", "*.jpg", "*.db", "*.sqlite", "*.subunit",
+}
+
+// GetLogForwardingConfigMapName returns the name of the config map with the
+// configuration of the log forwarding sidecar of the instance
+func GetLogForwardingConfigMapName(instance client.Object) string {
+	return instance.GetName() + "-log-forwarding"
+}
+
+// ApplyLogForwarding adds a fluent-bit sidecar to the pod that ships the files
+// the pod writes to its directory of the logs PVC to the endpoint configured
+// in spec.logForwarding. The configuration is shared by all test pods of the
+// instance, the values that differ per test pod are passed to the sidecar
+// using the downward API.
+func (r *Reconciler) ApplyLogForwarding(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance client.Object,
+	pod *corev1.Pod,
+	options v1beta1.CommonOptions,
+	logsPVCName string,
+) error {
+	logForwarding := options.LogForwarding
+	if logForwarding == nil || len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	logsVolumeName := getClaimVolumeName(pod, logsPVCName)
+	if logsVolumeName == "" {
+		return fmt.Errorf("the logs PVC %s is not mounted to the test pod %s", logsPVCName, pod.Name)
+	}
+
+	config, err := getLogForwardingConfig(instance, logForwarding)
+	if err != nil {
+		return err
+	}
+
+	cms := []util.Template{
+		{
+			Name:      GetLogForwardingConfigMapName(instance),
+			Namespace: instance.GetNamespace(),
+			Labels:    map[string]string{instanceNameLabel: instance.GetName()},
+			CustomData: map[string]string{
+				logForwarderConfigFile: config,
+			},
+		},
+	}
+
+	err = configmap.EnsureConfigMaps(ctx, helper, instance, cms, nil)
+	if err != nil {
+		return err
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: logForwardingConfigVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: GetLogForwardingConfigMapName(instance),
+				},
+			},
+		},
+	})
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers,
+		getLogForwarderContainer(pod, logForwarding, logsVolumeName))
+
+	return nil
+}
+
+// getLogForwarderContainer returns the definition of the sidecar. The sidecar
+// is an init container that keeps running next to the test container and
+// that is stopped once the test container terminates.
+func getLogForwarderContainer(
+	pod *corev1.Pod,
+	logForwarding *v1beta1.LogForwarding,
+	logsVolumeName string,
+) corev1.Container {
+	testContainer := pod.Spec.Containers[0]
+	restartPolicyAlways := corev1.ContainerRestartPolicyAlways
+
+	image := logForwarding.Image
+	if image == "" {
+		image = util.GetEnvVar(defaultLogForwarderImageEnvVar, "")
+	}
+
+	container := corev1.Container{
+		Name:            logForwarderContainerName,
+		Image:           image,
+		ImagePullPolicy: testContainer.ImagePullPolicy,
+		Args:            []string{"-c", logForwarderConfigPath + "/" + logForwarderConfigFile},
+		RestartPolicy:   &restartPolicyAlways,
+		SecurityContext: testContainer.SecurityContext.DeepCopy(),
+		Env: []corev1.EnvVar{
+			{
+				Name: "TEST_OPERATOR_POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			},
+			{
+				Name: "TEST_OPERATOR_ARTIFACT_DIRECTORY",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.annotations['%s']", operatorutil.ArtifactDirectoryAnnotation),
+					},
+				},
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    k8sresource.MustParse("200m"),
+				corev1.ResourceMemory: k8sresource.MustParse("128Mi"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    k8sresource.MustParse("10m"),
+				corev1.ResourceMemory: k8sresource.MustParse("32Mi"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      logForwardingConfigVolumeName,
+				MountPath: logForwarderConfigPath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      logsVolumeName,
+				MountPath: logForwarderLogsPath,
+				ReadOnly:  true,
+			},
+		},
+	}
+
+	if logForwarding.CredentialsSecretName != "" {
+		for _, credential := range []struct{ name, key string }{
+			{"LOG_FORWARDING_USERNAME", logForwardingCredentialsUsernameKey},
+			{"LOG_FORWARDING_PASSWORD", logForwardingCredentialsPasswordKey},
+		} {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name: credential.name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: logForwarding.CredentialsSecretName,
+						},
+						Key: credential.key,
+					},
+				},
+			})
+		}
+	}
+
+	// Trust the same CAs as the test container. The merged trust bundle
+	// takes precedence over the CA bundle secret.
+	for _, caVolumeName := range []string{caTrustBundleVolumeName, caCertsVolumeName} {
+		if !hasVolume(pod.Spec.Volumes, caVolumeName) {
+			continue
+		}
+
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      caVolumeName,
+			MountPath: logForwarderCABundlePath,
+			SubPath:   caTrustBundleFile,
+			ReadOnly:  true,
+		})
+		break
+	}
+
+	return container
+}
+
+// getLogForwardingConfig renders the fluent-bit configuration. The sidecar
+// tails the files of the artifact directory of the test pod (up to three
+// levels deep) and adds the namespace, the name of the instance, the name
+// of the test pod and the user provided labels to each record.
+func getLogForwardingConfig(
+	instance client.Object,
+	logForwarding *v1beta1.LogForwarding,
+) (string, error) {
+	endpoint, err := url.Parse(BracketIPv6URL(logForwarding.URL))
+	if err != nil {
+		return "", fmt.Errorf("invalid log forwarding URL %s: %w", logForwarding.URL, err)
+	}
+
+	port := endpoint.Port()
+	if port == "" {
+		port = "80"
+		if endpoint.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	artifactDir := logForwarderLogsPath + "/${TEST_OPERATOR_ARTIFACT_DIRECTORY}"
+	paths := []string{artifactDir + "/*", artifactDir + "/*/*", artifactDir + "/*/*/*"}
+
+	records := map[string]string{
+		"namespace": instance.GetNamespace(),
+		"instance":  instance.GetName(),
+		"pod":       "${TEST_OPERATOR_POD_NAME}",
+	}
+
+	for key, value := range logForwarding.Labels {
+		if _, exists := records[key]; !exists {
+			records[key] = value
+		}
+	}
+
+	recordKeys := make([]string, 0, len(records))
+	for key := range records {
+		recordKeys = append(recordKeys, key)
+	}
+	sort.Strings(recordKeys)
+
+	var config strings.Builder
+	writeSection := func(name string, properties [][2]string) {
+		fmt.Fprintf(&config, "[%s]\n", name)
+		for _, property := range properties {
+			fmt.Fprintf(&config, "    %-18s %s\n", property[0], property[1])
+		}
+		config.WriteString("\n")
+	}
+
+	writeSection("SERVICE", [][2]string{
+		{"Flush", "1"},
+		{"Grace", "10"},
+		{"Log_Level", "warn"},
+	})
+
+	writeSection("INPUT", [][2]string{
+		{"Name", "tail"},
+		{"Path", strings.Join(paths, ",")},
+		{"Exclude_Path", strings.Join(logForwardingExcludedFiles, ",")},
+		{"Path_Key", "file"},
+		{"Read_From_Head", "true"},
+		{"Refresh_Interval", "5"},
+		{"Skip_Long_Lines", "On"},
+	})
+
+	filter := [][2]string{
+		{"Name", "record_modifier"},
+		{"Match", "*"},
+	}
+	for _, key := range recordKeys {
+		filter = append(filter, [2]string{"Record", key + " " + records[key]})
+	}
+	writeSection("FILTER", filter)
+
+	output := [][2]string{
+		{"Match", "*"},
+		{"Host", endpoint.Hostname()},
+		{"Port", port},
+	}
+
+	switch logForwarding.Output {
+	case v1beta1.LogForwardingOutputLoki:
+		uri := endpoint.RequestURI()
+		if endpoint.Path == "" || endpoint.Path == "/" {
+			uri = "/loki/api/v1/push"
+		}
+
+		labels := []string{"job=test-operator", "file=$file"}
+		for _, key := range recordKeys {
+			labels = append(labels, fmt.Sprintf("%s=$%s", key, key))
+		}
+
+		output = append([][2]string{{"Name", "loki"}}, output...)
+		output = append(output,
+			[2]string{"Uri", uri},
+			[2]string{"Labels", strings.Join(labels, ", ")},
+			[2]string{"Remove_Keys", strings.Join(append([]string{"file"}, recordKeys...), ",")},
+			[2]string{"Drop_Single_Key", "raw"},
+		)
+	case v1beta1.LogForwardingOutputElasticsearch:
+		index := logForwarding.Index
+		if index == "" {
+			index = "test-operator"
+		}
+
+		output = append([][2]string{{"Name", "es"}}, output...)
+		output = append(output,
+			[2]string{"Index", index},
+			[2]string{"Suppress_Type_Name", "On"},
+		)
+		if path := strings.TrimSuffix(endpoint.Path, "/"); path != "" {
+			output = append(output, [2]string{"Path", path})
+		}
+	case v1beta1.LogForwardingOutputHTTP:
+		output = append([][2]string{{"Name", "http"}}, output...)
+		output = append(output,
+			[2]string{"Uri", endpoint.RequestURI()},
+			[2]string{"Format", "json"},
+		)
+	default:
+		return "", fmt.Errorf("unsupported log forwarding output %s", logForwarding.Output)
+	}
+
+	if logForwarding.CredentialsSecretName != "" {
+		output = append(output,
+			[2]string{"HTTP_User", "${LOG_FORWARDING_USERNAME}"},
+			[2]string{"HTTP_Passwd", "${LOG_FORWARDING_PASSWORD}"},
+		)
+	}
+
+	if endpoint.Scheme == "https" {
+		output = append(output, [2]string{"tls", "On"})
+		if logForwarding.TLSInsecureSkipVerify {
+			output = append(output, [2]string{"tls.verify", "Off"})
+		}
+	}
+
+	writeSection("OUTPUT", output)
+
+	return config.String(), nil
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
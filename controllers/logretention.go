@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/tempest"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	pruneLogsContainerName = "prune-logs"
+	pruneLogsPath          = "/var/lib/test-operator/logs-retention"
+
+	// PrunedLogsReason is the reason of the Events that record the pruned
+	// directories of the logs PVC
+	PrunedLogsReason = "PrunedLogs"
+)
+
+// pruneLogsScript deletes the directories (and their tarballs created by the
+// logs rotation) listed in LOG_RETENTION_EXPIRED. Of the directories listed in
+// LOG_RETENTION_DIRECTORIES (ordered from the most recent) that exist in the
+// logs PVC, it keeps the first LOG_RETENTION_KEEP_LAST_RUNS ones. Then it
+// deletes the oldest kept directories until the logs fit
+// LOG_RETENTION_MAX_SIZE_KB. The pruned directories are reported via the
+// termination message. Failures are ignored so that the pruning never
+// prevents the tests from running.
+var pruneLogsScript = fmt.Sprintf(`cd %s || exit 0
+mkdir .prune-logs.lock 2>/dev/null || exit 0
+trap 'rmdir .prune-logs.lock' EXIT
+pruned=""
+exists() { [ -e "$1" ] || [ -e "$1.tar.gz" ]; }
+prune() { rm -rf -- "$1" "$1.tar.gz" && pruned="$pruned $1"; }
+for dir in $LOG_RETENTION_EXPIRED; do
+    exists "$dir" && prune "$dir"
+done
+kept=""
+count=0
+for dir in $LOG_RETENTION_DIRECTORIES; do
+    exists "$dir" || continue
+    if [ -n "$LOG_RETENTION_KEEP_LAST_RUNS" ] && [ "$count" -ge "$LOG_RETENTION_KEEP_LAST_RUNS" ]; then
+        prune "$dir"
+        continue
+    fi
+    count=$((count + 1))
+    kept="$dir $kept"
+done
+if [ -n "$LOG_RETENTION_MAX_SIZE_KB" ]; then
+    for dir in $kept; do
+        [ "$(du -sk . | cut -f1)" -le "$LOG_RETENTION_MAX_SIZE_KB" ] && break
+        prune "$dir"
+    done
+fi
+echo $pruned > /dev/termination-log
+exit 0`, pruneLogsPath)
+
+// ApplyLogRetention adds an init container to the pod that prunes the logs of
+// the finished test pods recorded in status.steps from the logs PVC according
+// to the logRetention. The directories the test pod reads (e.g. the
+// directory of the failed test pod of a rerun pod) are never pruned.
+func ApplyLogRetention(
+	pod *corev1.Pod,
+	logRetention *v1beta1.LogRetention,
+	status *v1beta1.CommonTestStatus,
+	logsPVCName string,
+) {
+	if logRetention == nil || len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	logsVolumeName := getClaimVolumeName(pod, logsPVCName)
+	if logsVolumeName == "" {
+		return
+	}
+
+	readDirectories := map[string]bool{pod.Name: true}
+	for _, directory := range tempest.GetReadArtifactDirectories(pod) {
+		readDirectories[directory] = true
+	}
+
+	finishedSteps := []v1beta1.TestStepStatus{}
+	for _, step := range status.Steps {
+		if step.FinishTime != nil && step.ArtifactDirectory != "" && !readDirectories[step.ArtifactDirectory] {
+			finishedSteps = append(finishedSteps, step)
+		}
+	}
+
+	sort.SliceStable(finishedSteps, func(i, j int) bool {
+		return finishedSteps[j].FinishTime.Before(finishedSteps[i].FinishTime)
+	})
+
+	expired := []string{}
+	directories := []string{}
+	for _, step := range finishedSteps {
+		if logRetention.MaxAge != nil && time.Since(step.FinishTime.Time) > logRetention.MaxAge.Duration {
+			expired = append(expired, step.ArtifactDirectory)
+		} else {
+			directories = append(directories, step.ArtifactDirectory)
+		}
+	}
+
+	keepLastRuns := ""
+	if logRetention.KeepLastRuns != nil {
+		keepLastRuns = strconv.Itoa(int(*logRetention.KeepLastRuns))
+	}
+
+	maxSizeKB := ""
+	if logRetention.MaxSize != nil {
+		maxSizeKB = strconv.FormatInt(logRetention.MaxSize.Value()/1024, 10)
+	}
+
+	testContainer := pod.Spec.Containers[0]
+	initContainer := corev1.Container{
+		Name:                     pruneLogsContainerName,
+		Image:                    testContainer.Image,
+		ImagePullPolicy:          testContainer.ImagePullPolicy,
+		Command:                  []string{"/bin/sh", "-c", pruneLogsScript},
+		SecurityContext:          testContainer.SecurityContext.DeepCopy(),
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		Env: []corev1.EnvVar{
+			{Name: "LOG_RETENTION_EXPIRED", Value: strings.Join(expired, " ")},
+			{Name: "LOG_RETENTION_DIRECTORIES", Value: strings.Join(directories, " ")},
+			{Name: "LOG_RETENTION_KEEP_LAST_RUNS", Value: keepLastRuns},
+			{Name: "LOG_RETENTION_MAX_SIZE_KB", Value: maxSizeKB},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      logsVolumeName,
+				MountPath: pruneLogsPath,
+			},
+		},
+	}
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, initContainer)
+}
+
+// RecordPrunedLogs stores the directories pruned by the test pods of the
+// instance in status.PrunedLogs and records an Event for each test pod that
+// pruned any directory. Each test pod is recorded only once.
+func (r *Reconciler) RecordPrunedLogs(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	recorded := map[string]bool{}
+	for _, prunedLogs := range status.PrunedLogs {
+		recorded[prunedLogs.PodName] = true
+	}
+
+	for _, pod := range podList.Items {
+		if recorded[pod.Name] {
+			continue
+		}
+
+		directories := getPrunedDirectories(pod)
+		if len(directories) == 0 {
+			continue
+		}
+
+		status.PrunedLogs = append(status.PrunedLogs, v1beta1.PrunedLogs{
+			PodName:     pod.Name,
+			Directories: directories,
+		})
+
+		if r.Recorder != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, PrunedLogsReason,
+				"Test pod %s pruned the logs of %s from the logs PVC",
+				pod.Name, strings.Join(directories, ", "))
+		}
+	}
+
+	return nil
+}
+
+// getPrunedDirectories returns the directories pruned by the init container
+// of the test pod. Nil is returned until the init container terminates.
+func getPrunedDirectories(pod corev1.Pod) []string {
+	for _, containerStatus := range pod.Status.InitContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != pruneLogsContainerName || terminated == nil {
+			continue
+		}
+
+		return strings.Fields(terminated.Message)
+	}
+
+	return nil
+}
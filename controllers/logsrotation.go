@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/tempest"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	rotateLogsContainerName = "rotate-logs"
+	rotateLogsPath          = "/var/lib/test-operator/logs-rotation"
+)
+
+// rotateLogsScript compresses the directories listed in
+// LOGS_ROTATION_DIRECTORIES (ordered from the most recent) except the first
+// LOGS_ROTATION_KEEP_UNCOMPRESSED ones that exist in the logs PVC. Then it
+// deletes the oldest tarballs until the logs fit LOGS_ROTATION_MAX_SIZE_KB.
+// Test pods that start at the same time (e.g. the shards of a workflow step)
+// skip the rotation while another one holds the lock. Failures are ignored
+// so that the rotation never prevents the tests from running.
+var rotateLogsScript = fmt.Sprintf(`cd %s || exit 0
+mkdir .rotate-logs.lock 2>/dev/null || exit 0
+trap 'rmdir .rotate-logs.lock' EXIT
+kept=0
+for dir in $LOGS_ROTATION_DIRECTORIES; do
+    [ -d "$dir" ] || continue
+    if [ "$kept" -lt "$LOGS_ROTATION_KEEP_UNCOMPRESSED" ]; then
+        kept=$((kept + 1))
+        continue
+    fi
+    tar -czf "$dir.tar.gz.tmp" "$dir" && mv "$dir.tar.gz.tmp" "$dir.tar.gz" && rm -rf "$dir" || rm -f "$dir.tar.gz.tmp"
+done
+if [ -n "$LOGS_ROTATION_MAX_SIZE_KB" ]; then
+    for archive in $(ls -1tr -- *.tar.gz 2>/dev/null); do
+        [ "$(du -sk . | cut -f1)" -le "$LOGS_ROTATION_MAX_SIZE_KB" ] && break
+        rm -f -- "$archive"
+    done
+fi
+exit 0`, rotateLogsPath)
+
+// ApplyLogsRotation adds an init container to the pod that compresses the logs
+// of the finished test pods recorded in status.steps and enforces the
+// maximum size of the logs stored in the logs PVC. The directories the test
+// pod reads (e.g. the directory of the failed test pod of a rerun pod) are
+// never compressed.
+func ApplyLogsRotation(
+	pod *corev1.Pod,
+	logsRotation *v1beta1.LogsRotation,
+	status *v1beta1.CommonTestStatus,
+	logsPVCName string,
+) {
+	if logsRotation == nil || len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	logsVolumeName := getClaimVolumeName(pod, logsPVCName)
+	if logsVolumeName == "" {
+		return
+	}
+
+	readDirectories := map[string]bool{pod.Name: true}
+	for _, directory := range tempest.GetReadArtifactDirectories(pod) {
+		readDirectories[directory] = true
+	}
+
+	finishedSteps := []v1beta1.TestStepStatus{}
+	for _, step := range status.Steps {
+		if step.FinishTime != nil && step.ArtifactDirectory != "" && !readDirectories[step.ArtifactDirectory] {
+			finishedSteps = append(finishedSteps, step)
+		}
+	}
+
+	sort.SliceStable(finishedSteps, func(i, j int) bool {
+		return finishedSteps[j].FinishTime.Before(finishedSteps[i].FinishTime)
+	})
+
+	directories := []string{}
+	for _, step := range finishedSteps {
+		directories = append(directories, step.ArtifactDirectory)
+	}
+
+	maxSizeKB := ""
+	if logsRotation.MaxSize != nil {
+		maxSizeKB = strconv.FormatInt(logsRotation.MaxSize.Value()/1024, 10)
+	}
+
+	testContainer := pod.Spec.Containers[0]
+	initContainer := corev1.Container{
+		Name:            rotateLogsContainerName,
+		Image:           testContainer.Image,
+		ImagePullPolicy: testContainer.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", rotateLogsScript},
+		SecurityContext: testContainer.SecurityContext.DeepCopy(),
+		Env: []corev1.EnvVar{
+			{Name: "LOGS_ROTATION_DIRECTORIES", Value: strings.Join(directories, " ")},
+			{Name: "LOGS_ROTATION_KEEP_UNCOMPRESSED", Value: strconv.Itoa(int(logsRotation.KeepUncompressed))},
+			{Name: "LOGS_ROTATION_MAX_SIZE_KB", Value: maxSizeKB},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      logsVolumeName,
+				MountPath: rotateLogsPath,
+			},
+		},
+	}
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, initContainer)
+}
+
+// getClaimVolumeName returns the name of the volume of the pod that mounts the
+// PVC. An empty string is returned when the PVC is not mounted.
+func getClaimVolumeName(pod *corev1.Pod, claimName string) string {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+			return volume.Name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,363 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	logsServerLabel      = "logsServer"
+	logsServerNameSuffix = "-logs-server"
+	logsServerPortName   = "http"
+	logsServerPort       = 8080
+	logsServerRoot       = "/srv/logs"
+	logsServerAuthPath   = "/etc/nginx/auth"
+	logsServerHtpasswd   = "htpasswd"
+
+	logsServerConfigFile      = "default.conf"
+	logsServerConfigMountPath = "/etc/nginx/conf.d/" + logsServerConfigFile
+
+	defaultLogsServerImageEnvVar = "RELATED_IMAGE_TEST_LOGS_SERVER_IMAGE_URL_DEFAULT"
+)
+
+// logsServerConfig is the configuration of nginx that lists the directories
+// of the mounted logs PVCs and serves the logs as plain text
+var logsServerConfig = fmt.Sprintf(`server {
+    listen %[1]d;
+    root %[2]s;
+    autoindex on;
+    default_type text/plain;
+    auth_basic "test-operator logs";
+    auth_basic_user_file %[3]s/%[4]s;
+}
+`, logsServerPort, logsServerRoot, logsServerAuthPath, logsServerHtpasswd)
+
+// routeGVK is the kind of the OpenShift Route. Like the cluster-wide Proxy, the
+// Route is managed as unstructured so that the test-operator does not depend
+// on the OpenShift API on other clusters.
+var routeGVK = schema.GroupVersionKind{
+	Group:   "route.openshift.io",
+	Version: "v1",
+	Kind:    "Route",
+}
+
+// GetLogsServerName returns the name of the Deployment, the Service and the
+// Route of the logs server of the instance
+func GetLogsServerName(instance client.Object) string {
+	return instance.GetName() + logsServerNameSuffix
+}
+
+// EnsureLogsServer deploys an nginx server that serves the logs PVCs of the
+// instance read-only over HTTP. The server is deployed once all test pods
+// (and the report generation pod) finish so that it does not compete with
+// them for the PVCs. The URL of the server is stored in
+// status.LogsServerURL.
+func (r *Reconciler) EnsureLogsServer(
+	ctx context.Context,
+	h *helper.Helper,
+	instance client.Object,
+	options v1beta1.CommonOptions,
+	status *v1beta1.CommonTestStatus,
+) error {
+	if options.LogsServer == nil || !isLogsPVCPersistence(options.Persistence) {
+		return nil
+	}
+
+	if status.Phase != v1beta1.TestPhaseSucceeded && status.Phase != v1beta1.TestPhaseFailed {
+		return nil
+	}
+
+	// The report generation pod writes to the logs PVCs
+	if isReportPending(options, status) {
+		return nil
+	}
+
+	claimNames, err := r.getLogsPVCNames(ctx, instance, status)
+	if err != nil || len(claimNames) == 0 {
+		return err
+	}
+
+	name := GetLogsServerName(instance)
+	labels := map[string]string{
+		logsServerLabel:   instance.GetName(),
+		operatorNameLabel: "test-operator",
+	}
+
+	cms := []util.Template{
+		{
+			Name:      name,
+			Namespace: instance.GetNamespace(),
+			Labels:    labels,
+			CustomData: map[string]string{
+				logsServerConfigFile: logsServerConfig,
+			},
+		},
+	}
+
+	if err := configmap.EnsureConfigMaps(ctx, h, instance, cms, nil); err != nil {
+		return err
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: instance.GetNamespace()},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.Client, deployment, func() error {
+		deployment.Labels = util.MergeStringMaps(deployment.Labels, labels)
+		deployment.Spec = getLogsServerDeploymentSpec(name, labels, options.LogsServer, claimNames)
+		return controllerutil.SetControllerReference(instance, deployment, r.GetScheme())
+	})
+	if err != nil {
+		return err
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: instance.GetNamespace()},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.Client, service, func() error {
+		service.Labels = util.MergeStringMaps(service.Labels, labels)
+		service.Spec.Selector = labels
+		service.Spec.Ports = []corev1.ServicePort{
+			{
+				Name:       logsServerPortName,
+				Port:       logsServerPort,
+				TargetPort: intstr.FromString(logsServerPortName),
+			},
+		}
+		return controllerutil.SetControllerReference(instance, service, r.GetScheme())
+	})
+	if err != nil {
+		return err
+	}
+
+	status.LogsServerURL = fmt.Sprintf("http://%s.%s.svc:%d", name, instance.GetNamespace(), logsServerPort)
+	if !options.LogsServer.Route {
+		return nil
+	}
+
+	host, err := r.ensureLogsServerRoute(ctx, instance, name, labels)
+	if err != nil {
+		return err
+	}
+
+	if host != "" {
+		status.LogsServerURL = "https://" + host
+	}
+
+	return nil
+}
+
+// getLogsPVCNames returns the names of the existing logs PVCs of the workflow
+// steps recorded in status.Steps
+func (r *Reconciler) getLogsPVCNames(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+) ([]string, error) {
+	workflowSteps := map[int]bool{0: true}
+	for _, step := range status.Steps {
+		workflowSteps[step.WorkflowStep] = true
+	}
+
+	claimNames := []string{}
+	for workflowStep := range workflowSteps {
+		claimName := r.GetPVCLogsName(instance, workflowStep)
+		objectKey := client.ObjectKey{Namespace: instance.GetNamespace(), Name: claimName}
+		err := r.Client.Get(ctx, objectKey, &corev1.PersistentVolumeClaim{})
+		if k8s_errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		claimNames = append(claimNames, claimName)
+	}
+
+	sort.Strings(claimNames)
+	return claimNames, nil
+}
+
+// getLogsServerDeploymentSpec returns the spec of the Deployment of the logs
+// server. Each logs PVC is mounted read-only to a directory named after the
+// PVC.
+func getLogsServerDeploymentSpec(
+	name string,
+	labels map[string]string,
+	logsServer *v1beta1.LogsServer,
+	claimNames []string,
+) appsv1.DeploymentSpec {
+	image := logsServer.Image
+	if image == "" {
+		image = util.GetEnvVar(defaultLogsServerImageEnvVar, "")
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			},
+		},
+		{
+			Name: "auth",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: logsServer.HtpasswdSecretName,
+					Items: []corev1.KeyToPath{
+						{Key: logsServerHtpasswd, Path: logsServerHtpasswd},
+					},
+				},
+			},
+		},
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "config",
+			MountPath: logsServerConfigMountPath,
+			SubPath:   logsServerConfigFile,
+			ReadOnly:  true,
+		},
+		{
+			Name:      "auth",
+			MountPath: logsServerAuthPath,
+			ReadOnly:  true,
+		},
+	}
+
+	for idx, claimName := range claimNames {
+		volumeName := fmt.Sprintf("logs-%d", idx)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: logsServerRoot + "/" + claimName,
+			ReadOnly:  true,
+		})
+	}
+
+	replicas := int32(1)
+	trueVar := true
+	falseVar := false
+
+	return appsv1.DeploymentSpec{
+		Replicas: &replicas,
+		Selector: &metav1.LabelSelector{MatchLabels: labels},
+		// The logs PVCs can be mounted by a single node only
+		Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec: corev1.PodSpec{
+				AutomountServiceAccountToken: &falseVar,
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot:   &trueVar,
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+				Containers: []corev1.Container{
+					{
+						Name:  "logs-server",
+						Image: image,
+						Ports: []corev1.ContainerPort{
+							{Name: logsServerPortName, ContainerPort: logsServerPort},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString(logsServerPortName)},
+							},
+						},
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    k8sresource.MustParse("200m"),
+								corev1.ResourceMemory: k8sresource.MustParse("128Mi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    k8sresource.MustParse("10m"),
+								corev1.ResourceMemory: k8sresource.MustParse("32Mi"),
+							},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &falseVar,
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+						},
+						VolumeMounts: volumeMounts,
+					},
+				},
+				Volumes: volumes,
+			},
+		},
+	}
+}
+
+// ensureLogsServerRoute exposes the Service of the logs server using a Route
+// and returns the host of the Route
+func (r *Reconciler) ensureLogsServerRoute(
+	ctx context.Context,
+	instance client.Object,
+	name string,
+	labels map[string]string,
+) (string, error) {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(routeGVK)
+	route.SetName(name)
+	route.SetNamespace(instance.GetNamespace())
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.Client, route, func() error {
+		route.SetLabels(util.MergeStringMaps(route.GetLabels(), labels))
+		spec := map[string]interface{}{
+			"to": map[string]interface{}{
+				"kind": "Service",
+				"name": name,
+			},
+			"port": map[string]interface{}{
+				"targetPort": logsServerPortName,
+			},
+			"tls": map[string]interface{}{
+				"termination":                   "edge",
+				"insecureEdgeTerminationPolicy": "Redirect",
+			},
+		}
+
+		if host, found, _ := unstructured.NestedString(route.Object, "spec", "host"); found {
+			spec["host"] = host
+		}
+
+		if err := unstructured.SetNestedMap(route.Object, spec, "spec"); err != nil {
+			return err
+		}
+
+		return controllerutil.SetControllerReference(instance, route, r.GetScheme())
+	})
+	if meta.IsNoMatchError(err) {
+		return "", fmt.Errorf("spec.logsServer.route is set but Routes are not available on the cluster: %w", err)
+	} else if err != nil {
+		return "", err
+	}
+
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	return host, nil
+}
@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxStreamedLineLength is the longest line of the output of a test container
+// that is forwarded to the operator log. Longer lines are truncated.
+const maxStreamedLineLength = 64 * 1024
+
+// LogStreamer forwards the output of the test containers to the log of the
+// operator. At most one stream is open per test pod. It is shared by all
+// reconcilers.
+type LogStreamer struct {
+	kclient kubernetes.Interface
+	log     logr.Logger
+
+	mu      sync.Mutex
+	streams map[types.UID]*podLogStream
+}
+
+// podLogStream is the state of the stream of a single test pod
+type podLogStream struct {
+	// instance that owns the test pod
+	instance types.NamespacedName
+
+	// active is true while the output of the test pod is being streamed
+	active bool
+
+	// lastTimestamp is the timestamp of the last forwarded line. A stream
+	// that is reopened (e.g. after an error) continues from it so that the
+	// lines are not forwarded twice.
+	lastTimestamp *metav1.Time
+}
+
+// NewLogStreamer returns a LogStreamer that reads the output of the test
+// containers using kclient and forwards it to log
+func NewLogStreamer(kclient kubernetes.Interface, log logr.Logger) *LogStreamer {
+	return &LogStreamer{
+		kclient: kclient,
+		log:     log,
+		streams: map[types.UID]*podLogStream{},
+	}
+}
+
+// StreamTestPodLogs starts to forward the output of the running test pods of
+// the instance to the operator log when spec.streamLogs is enabled. The
+// output is forwarded by a goroutine per test pod that ends when the test
+// container terminates.
+func (r *Reconciler) StreamTestPodLogs(
+	ctx context.Context,
+	instance client.Object,
+	options v1beta1.CommonOptions,
+) error {
+	if r.LogStreamer == nil || !options.StreamLogs {
+		return nil
+	}
+
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	r.LogStreamer.Prune(client.ObjectKeyFromObject(instance), podList.Items)
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning || len(pod.Spec.Containers) == 0 {
+			continue
+		}
+
+		r.LogStreamer.Stream(ctx, pod, pod.Spec.Containers[0].Name)
+	}
+
+	return nil
+}
+
+// Prune forgets the interrupted streams of the test pods of the instance that
+// no longer exist. pods are the existing test pods of the instance.
+func (s *LogStreamer) Prune(instance types.NamespacedName, pods []corev1.Pod) {
+	existingPods := map[types.UID]bool{}
+	for _, pod := range pods {
+		existingPods[pod.UID] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for uid, stream := range s.streams {
+		if stream.instance == instance && !stream.active && !existingPods[uid] {
+			delete(s.streams, uid)
+		}
+	}
+}
+
+// Stream forwards the output of the container of the pod to the operator log
+// unless it is already being forwarded
+func (s *LogStreamer) Stream(ctx context.Context, pod corev1.Pod, container string) {
+	s.mu.Lock()
+	stream, exists := s.streams[pod.UID]
+	if !exists {
+		stream = &podLogStream{
+			instance: types.NamespacedName{
+				Namespace: pod.Namespace,
+				Name:      pod.Labels[instanceNameLabel],
+			},
+		}
+		s.streams[pod.UID] = stream
+	}
+
+	if stream.active {
+		s.mu.Unlock()
+		return
+	}
+
+	stream.active = true
+	sinceTime := stream.lastTimestamp
+	s.mu.Unlock()
+
+	logger := s.log.WithValues(
+		"namespace", pod.Namespace,
+		"pod", pod.Name,
+		"workflowStep", pod.Labels[workflowStepLabel],
+	)
+
+	go func() {
+		err := s.forward(ctx, pod, container, sinceTime, stream, logger)
+		podDeleted := err != nil && s.podDeleted(ctx, pod)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err != nil && !podDeleted {
+			// Keep the state so that the next reconciliation reopens the
+			// stream where it ended
+			logger.Info("Streaming of the test pod output interrupted", "error", err.Error())
+			stream.active = false
+			return
+		}
+
+		// The test container terminated or the test pod was deleted
+		delete(s.streams, pod.UID)
+	}()
+}
+
+// podDeleted tells whether the test pod no longer exists
+func (s *LogStreamer) podDeleted(ctx context.Context, pod corev1.Pod) bool {
+	currentPod, err := s.kclient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if k8s_errors.IsNotFound(err) {
+		return true
+	}
+
+	return err == nil && currentPod.UID != pod.UID
+}
+
+// forward copies the lines of the output of the container to logger until
+// the container terminates
+func (s *LogStreamer) forward(
+	ctx context.Context,
+	pod corev1.Pod,
+	container string,
+	sinceTime *metav1.Time,
+	stream *podLogStream,
+	logger logr.Logger,
+) error {
+	logOptions := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  sinceTime,
+	}
+
+	reader, err := s.kclient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	lineReader := bufio.NewReader(reader)
+	for {
+		rawLine, err := readTruncatedLine(lineReader, maxStreamedLineLength)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		timestamp, line := splitLogTimestamp(rawLine)
+		if timestamp != nil {
+			if sinceTime != nil && !timestamp.After(sinceTime.Time) {
+				// SinceTime has a precision of seconds, skip the lines
+				// that were already forwarded
+				continue
+			}
+
+			s.mu.Lock()
+			stream.lastTimestamp = timestamp
+			s.mu.Unlock()
+		}
+
+		logger.Info(line)
+	}
+}
+
+// readTruncatedLine reads the next line of the container output. Lines longer
+// than maxLength are truncated to maxLength and the rest of the line is
+// skipped, so that a single long line does not stop the streaming.
+func readTruncatedLine(reader *bufio.Reader, maxLength int) (string, error) {
+	line := []byte{}
+	for {
+		fragment, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+
+		if remaining := maxLength - len(line); remaining > 0 {
+			if len(fragment) > remaining {
+				fragment = fragment[:remaining]
+			}
+			line = append(line, fragment...)
+		}
+
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+// splitLogTimestamp splits a line of the container output requested with
+// timestamps into the timestamp and the line itself
+func splitLogTimestamp(line string) (*metav1.Time, string) {
+	timestampValue, text, found := strings.Cut(line, " ")
+	if !found {
+		return nil, line
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, timestampValue)
+	if err != nil {
+		return nil, line
+	}
+
+	return &metav1.Time{Time: timestamp}, text
+}
@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// lockWaitSeconds reports how long an instance has been waiting for the
+	// test-operator-lock
+	lockWaitSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "test_operator_lock_wait_seconds",
+			Help: "Time in seconds an instance has been waiting for the test-operator-lock",
+		},
+		[]string{"kind", "namespace", "name"},
+	)
+
+	// lockStarved reports whether an instance has been waiting for the
+	// test-operator-lock longer than the lock starvation threshold
+	lockStarved = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "test_operator_lock_starved",
+			Help: "Whether an instance has been waiting for the test-operator-lock " +
+				"longer than the lock starvation threshold (1) or not (0)",
+		},
+		[]string{"kind", "namespace", "name"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(lockWaitSeconds, lockStarved)
+}
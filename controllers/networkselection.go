@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// networkResourceNameAnnotation - annotation of a network attachment
+	// definition with the name of the device plugin resource (e.g. SR-IOV
+	// virtual functions) the pods attached to the network request
+	networkResourceNameAnnotation = "k8s.v1.cni.cncf.io/resourceName"
+)
+
+const (
+	ErrInvalidNetworkSelection   = "invalid network selection %s: %w"
+	ErrNetworkSelectionName      = "the name of the network is not set"
+	ErrNetworkSelectionNamespace = "the network has to be in the %s namespace"
+)
+
+// GetNetworkSelections returns the network selection elements of the
+// workflow step. The elements of the workflow step replace the elements of
+// spec.networkSelections. The namespace of the elements defaults to the
+// namespace of the instance.
+func GetNetworkSelections(
+	options v1beta1.CommonOptions,
+	workflowStep *v1beta1.WorkflowCommonParameters,
+	namespace string,
+) ([]networkv1.NetworkSelectionElement, error) {
+	rawSelections := options.NetworkSelections
+	if workflowStep != nil && workflowStep.NetworkSelections != nil {
+		rawSelections = *workflowStep.NetworkSelections
+	}
+
+	selections := []networkv1.NetworkSelectionElement{}
+	for _, rawSelection := range rawSelections {
+		selection := networkv1.NetworkSelectionElement{}
+		if err := json.Unmarshal(rawSelection.Raw, &selection); err != nil {
+			return nil, fmt.Errorf(ErrInvalidNetworkSelection, string(rawSelection.Raw), err)
+		}
+
+		if selection.Name == "" {
+			return nil, fmt.Errorf(ErrInvalidNetworkSelection, string(rawSelection.Raw),
+				errors.New(ErrNetworkSelectionName))
+		}
+
+		// The network status of the test pods is verified in the namespace
+		// of the instance only
+		if selection.Namespace == "" {
+			selection.Namespace = namespace
+		} else if selection.Namespace != namespace {
+			return nil, fmt.Errorf(ErrInvalidNetworkSelection, string(rawSelection.Raw),
+				fmt.Errorf(ErrNetworkSelectionNamespace, namespace))
+		}
+
+		selections = append(selections, selection)
+	}
+
+	return selections, nil
+}
+
+// GetNetworkAttachmentNames returns the names of the network attachments
+// extended with the names of the networks of the network selection elements
+func GetNetworkAttachmentNames(
+	networkAttachments []string,
+	selections []networkv1.NetworkSelectionElement,
+) []string {
+	names := append([]string{}, networkAttachments...)
+	for _, selection := range selections {
+		if !slices.Contains(names, selection.Name) {
+			names = append(names, selection.Name)
+		}
+	}
+
+	return names
+}
+
+// MergeNetworkSelections adds the network selection elements to the
+// k8s.v1.cni.cncf.io/networks annotation generated by
+// nad.EnsureNetworksAnnotation. An element replaces the generated element of
+// the same network.
+func MergeNetworkSelections(
+	annotations map[string]string,
+	selections []networkv1.NetworkSelectionElement,
+) (map[string]string, error) {
+	if len(selections) == 0 {
+		return annotations, nil
+	}
+
+	elements := []networkv1.NetworkSelectionElement{}
+	if value := annotations[networkv1.NetworkAttachmentAnnot]; value != "" {
+		if err := json.Unmarshal([]byte(value), &elements); err != nil {
+			return annotations, err
+		}
+	}
+
+	for _, selection := range selections {
+		idx := slices.IndexFunc(elements, func(element networkv1.NetworkSelectionElement) bool {
+			return element.Name == selection.Name && element.Namespace == selection.Namespace
+		})
+		if idx >= 0 {
+			elements[idx] = selection
+		} else {
+			elements = append(elements, selection)
+		}
+	}
+
+	value, err := json.Marshal(elements)
+	if err != nil {
+		return annotations, err
+	}
+
+	mergedAnnotations := map[string]string{}
+	for key, value := range annotations {
+		mergedAnnotations[key] = value
+	}
+	mergedAnnotations[networkv1.NetworkAttachmentAnnot] = string(value)
+
+	return mergedAnnotations, nil
+}
+
+// ApplyNetworkResources adds the device plugin resources (e.g. SR-IOV virtual
+// functions) of the network attachment definitions the test pod is attached
+// to to the requests and the limits of the test container
+func ApplyNetworkResources(pod *corev1.Pod, nadList []networkv1.NetworkAttachmentDefinition) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	resourceCounts := map[corev1.ResourceName]int64{}
+	for _, nad := range nadList {
+		if resourceName := nad.Annotations[networkResourceNameAnnotation]; resourceName != "" {
+			resourceCounts[corev1.ResourceName(resourceName)]++
+		}
+	}
+
+	if len(resourceCounts) == 0 {
+		return
+	}
+
+	// The resource lists may be shared with the spec of the instance
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	container := &pod.Spec.Containers[0]
+	for resourceName, quantity := range container.Resources.Requests {
+		requests[resourceName] = quantity.DeepCopy()
+	}
+
+	for resourceName, quantity := range container.Resources.Limits {
+		limits[resourceName] = quantity.DeepCopy()
+	}
+
+	for resourceName, count := range resourceCounts {
+		quantity := *resource.NewQuantity(count, resource.DecimalSI)
+		requests[resourceName] = quantity
+		limits[resourceName] = quantity
+	}
+
+	container.Resources.Requests = requests
+	container.Resources.Limits = limits
+}
@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/polarion"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	polarionExportLabel = "polarionExport"
+)
+
+const (
+	InfoExportingToPolarion = "Waiting for the Polarion export pod to finish."
+)
+
+// ExportToPolarion spawns a pod that converts the test results of all test
+// pods of the instance stored in the logs PVCs to a single xUnit file and
+// imports it to Polarion. The result is reported via the PolarionExported
+// condition. The returned value is true once the export is finished (or
+// when there is nothing to export).
+func (r *Reconciler) ExportToPolarion(
+	ctx context.Context,
+	instance client.Object,
+	h *helper.Helper,
+	status *v1beta1.CommonTestStatus,
+	options v1beta1.CommonOptions,
+) (bool, error) {
+	if options.Polarion == nil || !isLogsPVCPersistence(options.Persistence) {
+		return true, nil
+	}
+
+	testRunID := options.Polarion.TestRunID
+	if testRunID == "" {
+		testRunID = instance.GetName() + "-" + GetRunID(instance)
+	}
+
+	podName := instance.GetName() + polarion.PodNameSuffix
+	pod, err := r.GetPod(ctx, podName, instance.GetNamespace())
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if k8s_errors.IsNotFound(err) {
+		podDef, err := getPolarionExportPod(instance, status, options, podName, testRunID)
+		if err != nil || podDef == nil {
+			return err == nil, err
+		}
+
+		if _, err := r.CreatePod(ctx, *h, podDef); err != nil {
+			return false, err
+		}
+
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.PolarionExportedCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			v1beta1.PolarionExportedRunningMessage))
+
+		return false, nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		status.Conditions.MarkTrue(
+			v1beta1.PolarionExportedCondition,
+			v1beta1.PolarionExportedMessage,
+			testRunID,
+			getTerminationMessage(*pod, polarion.ServiceName))
+
+		return true, nil
+
+	case corev1.PodFailed:
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.PolarionExportedCondition,
+			v1beta1.PolarionExportFailedReason,
+			condition.SeverityWarning,
+			v1beta1.PolarionExportedErrorMessage,
+			getTerminationMessage(*pod, polarion.ServiceName)))
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// getPolarionExportPod returns the pod that exports the results of the test
+// pods recorded in status.Steps. Nil is returned when no test pod stored its
+// logs in a logs PVC.
+func getPolarionExportPod(
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	options v1beta1.CommonOptions,
+	podName string,
+	testRunID string,
+) (*corev1.Pod, error) {
+	exportOptions := options.Polarion
+
+	steps := []v1beta1.TestStepStatus{}
+	for _, step := range status.Steps {
+		if step.FinishTime != nil && step.ArtifactDirectory != "" && getArtifactClaimName(step.ArtifactURL) != "" {
+			steps = append(steps, step)
+		}
+	}
+
+	if len(steps) == 0 {
+		return nil, nil
+	}
+
+	// The results of the later test pods (e.g. a rerun of the failed tests)
+	// replace the results of the earlier ones
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].FinishTime.Before(steps[j].FinishTime)
+	})
+
+	claimNames := []string{}
+	directories := []string{}
+	for _, step := range steps {
+		claimName := getArtifactClaimName(step.ArtifactURL)
+		if !slices.Contains(claimNames, claimName) {
+			claimNames = append(claimNames, claimName)
+		}
+
+		directories = append(directories, polarion.GetLogsDirectory(claimName)+"/"+step.ArtifactDirectory)
+	}
+
+	properties := map[string]string{}
+	for name, value := range exportOptions.Properties {
+		properties[name] = value
+	}
+
+	properties["polarion-project-id"] = exportOptions.ProjectID
+	properties["polarion-testrun-id"] = testRunID
+
+	encodedProperties, err := json.Marshal(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	testCaseIDProperty := exportOptions.TestCaseIDProperty
+	if testCaseIDProperty == "" {
+		testCaseIDProperty = polarion.DefaultTestCaseIDProperty
+	}
+
+	containerImage := exportOptions.Image
+	if containerImage == "" {
+		containerImage = util.GetEnvVar(defaultReportImageEnvVar, "")
+	}
+
+	labels := map[string]string{
+		polarionExportLabel: instance.GetName(),
+		operatorNameLabel:   "test-operator",
+	}
+
+	envVars := []corev1.EnvVar{
+		{Name: "POLARION_URL", Value: BracketIPv6URL(exportOptions.URL)},
+		{Name: "POLARION_DIRECTORIES", Value: strings.Join(directories, " ")},
+		{Name: "POLARION_PROPERTIES", Value: string(encodedProperties)},
+		{Name: "POLARION_TESTSUITE_NAME", Value: instance.GetName()},
+		{Name: "POLARION_TESTCASE_ID_PROPERTY", Value: testCaseIDProperty},
+		{Name: "POLARION_TESTCASE_ID_PATTERN", Value: exportOptions.TestCaseIDPattern},
+		{Name: "POLARION_TLS_INSECURE_SKIP_VERIFY", Value: strconv.FormatBool(exportOptions.TLSInsecureSkipVerify)},
+	}
+
+	podDef := polarion.Pod(
+		instance.GetNamespace(),
+		labels,
+		podName,
+		containerImage,
+		envVars,
+		claimNames,
+		exportOptions.CredentialsSecretName,
+	)
+	podDef.Spec.ImagePullSecrets = operatorutil.GetImagePullSecrets(options.ImagePullSecrets)
+	for i := range podDef.Spec.Containers {
+		podDef.Spec.Containers[i].ImagePullPolicy = options.ImagePullPolicy
+	}
+
+	return podDef, nil
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/preflight"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	endpointPreflightLabel = "endpointPreflight"
+)
+
+const (
+	InfoRunningEndpointPreflight = "Waiting for the endpoint preflight pod to finish."
+)
+
+const (
+	ErrInvalidEndpointChecks = "invalid results of the endpoint checks: %w"
+	ErrMissingEndpointChecks = "the results of the endpoint checks are missing"
+)
+
+// RunEndpointPreflight spawns a pod that checks the reachability of the
+// OpenStack endpoints listed in the service catalog. The results are stored in
+// status.EndpointChecks and reported via the EndpointsReachable condition.
+// Unreachable endpoints do not prevent the tests from being executed. The
+// returned value is true once the checks are finished (or when the preflight
+// is not enabled).
+func (r *Reconciler) RunEndpointPreflight(
+	ctx context.Context,
+	instance client.Object,
+	h *helper.Helper,
+	status *v1beta1.CommonTestStatus,
+	enabled bool,
+	containerImage string,
+	imagePullSecrets []string,
+	imagePullPolicy corev1.PullPolicy,
+	caBundleSecretName string,
+) (bool, error) {
+	if !enabled {
+		return true, nil
+	}
+
+	podName := instance.GetName() + preflight.PodNameSuffix
+	pod, err := r.GetPod(ctx, podName, instance.GetNamespace())
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if k8s_errors.IsNotFound(err) {
+		containerImage, err := r.GetContainerImage(ctx, containerImage, instance)
+		if err != nil {
+			return false, err
+		}
+
+		labels := map[string]string{
+			endpointPreflightLabel: instance.GetName(),
+			operatorNameLabel:      "test-operator",
+		}
+
+		podDef := preflight.Pod(
+			instance.GetNamespace(),
+			labels,
+			podName,
+			containerImage,
+			caBundleSecretName,
+		)
+		podDef.Spec.ImagePullSecrets = operatorutil.GetImagePullSecrets(imagePullSecrets)
+		for i := range podDef.Spec.Containers {
+			podDef.Spec.Containers[i].ImagePullPolicy = imagePullPolicy
+		}
+
+		if _, err := r.CreatePod(ctx, *h, podDef); err != nil {
+			return false, err
+		}
+
+		status.EndpointChecks = nil
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.EndpointsReachableCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			v1beta1.EndpointsReachableRunningMessage))
+
+		return false, nil
+	}
+
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return false, nil
+	}
+
+	endpointChecks, err := getEndpointChecks(pod)
+	status.EndpointChecks = endpointChecks
+
+	unreachable := []string{}
+	for _, check := range status.EndpointChecks {
+		if !check.Reachable {
+			unreachable = append(unreachable, check.Service)
+		}
+	}
+
+	switch {
+	case len(unreachable) > 0:
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.EndpointsReachableCondition,
+			v1beta1.EndpointsUnreachableReason,
+			condition.SeverityWarning,
+			v1beta1.EndpointsReachableErrorMessage,
+			strings.Join(unreachable, ", ")))
+
+	case pod.Status.Phase == corev1.PodFailed:
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.EndpointsReachableCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			v1beta1.EndpointsReachableFailedMessage,
+			podName))
+
+	case err != nil:
+		// Without the results it is unknown whether the endpoints are
+		// reachable
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.EndpointsReachableCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			v1beta1.EndpointsReachableUnknownMessage,
+			podName,
+			err.Error()))
+
+	default:
+		status.Conditions.MarkTrue(
+			v1beta1.EndpointsReachableCondition,
+			v1beta1.EndpointsReachableMessage)
+	}
+
+	return true, nil
+}
+
+// getEndpointChecks returns the results of the endpoint checks reported by
+// the preflight pod via the termination message of its container. An error is
+// returned when the results are missing or can not be parsed.
+func getEndpointChecks(pod *corev1.Pod) ([]v1beta1.EndpointCheck, error) {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != preflight.ServiceName || terminated == nil {
+			continue
+		}
+
+		endpointChecks := []v1beta1.EndpointCheck{}
+		if err := json.Unmarshal([]byte(terminated.Message), &endpointChecks); err != nil {
+			return nil, fmt.Errorf(ErrInvalidEndpointChecks, err)
+		}
+
+		return endpointChecks, nil
+	}
+
+	return nil, errors.New(ErrMissingEndpointChecks)
+}
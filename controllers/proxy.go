@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// clusterProxyName is the name of the cluster-wide Proxy of OpenShift
+	clusterProxyName = "cluster"
+)
+
+// clusterProxyGVK is the kind of the cluster-wide Proxy of OpenShift. The
+// Proxy is read as unstructured so that the test-operator does not depend on
+// the OpenShift API on other clusters.
+var clusterProxyGVK = schema.GroupVersionKind{
+	Group:   "config.openshift.io",
+	Version: "v1",
+	Kind:    "Proxy",
+}
+
+// GetProxy returns the proxy settings of the test pods. The settings from the
+// spec take precedence over the settings of the cluster-wide Proxy. Nil is
+// returned when no proxy is configured.
+func (r *Reconciler) GetProxy(
+	ctx context.Context,
+	options v1beta1.CommonOptions,
+) (*v1beta1.ProxyConfig, error) {
+	if options.Proxy != nil {
+		return options.Proxy, nil
+	}
+
+	clusterProxy := &unstructured.Unstructured{}
+	clusterProxy.SetGroupVersionKind(clusterProxyGVK)
+	err := r.Client.Get(ctx, client.ObjectKey{Name: clusterProxyName}, clusterProxy)
+	if k8s_errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	status, ok := clusterProxy.Object["status"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	proxy := &v1beta1.ProxyConfig{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(status, proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	if *proxy == (v1beta1.ProxyConfig{}) {
+		return nil, nil
+	}
+
+	return proxy, nil
+}
+
+// ApplyProxy exposes the proxy settings to the containers of the pod using
+// both the upper case and the lower case variants of the proxy environment
+// variables. Variables that are already set on a container are kept.
+func ApplyProxy(pod *corev1.Pod, proxy *v1beta1.ProxyConfig) {
+	if proxy == nil {
+		return
+	}
+
+	proxyEnv := []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: proxy.HTTPProxy},
+		{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy},
+		{Name: "NO_PROXY", Value: proxy.NoProxy},
+		{Name: "http_proxy", Value: proxy.HTTPProxy},
+		{Name: "https_proxy", Value: proxy.HTTPSProxy},
+		{Name: "no_proxy", Value: proxy.NoProxy},
+	}
+
+	containers := []*corev1.Container{}
+	for i := range pod.Spec.InitContainers {
+		containers = append(containers, &pod.Spec.InitContainers[i])
+	}
+
+	for i := range pod.Spec.Containers {
+		containers = append(containers, &pod.Spec.Containers[i])
+	}
+
+	for _, container := range containers {
+		for _, envVar := range proxyEnv {
+			if len(envVar.Value) == 0 || hasEnvVar(container.Env, envVar.Name) {
+				continue
+			}
+
+			container.Env = append(container.Env, envVar)
+		}
+	}
+}
+
+func hasEnvVar(envVars []corev1.EnvVar, name string) bool {
+	for _, envVar := range envVars {
+		if envVar.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
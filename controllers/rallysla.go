@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rallySLAReport is the result of the SLA check reported by the test
+// container of a RallyTest via its termination message
+type rallySLAReport struct {
+	Passed   *bool    `json:"passed"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// UpdateRallySLAResults stores the result of the SLA check of each finished
+// test pod in status.RallySLAResults. Results of the test pods that were
+// already deleted are kept.
+func (r *Reconciler) UpdateRallySLAResults(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	stepNames []string,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	results := []v1beta1.RallySLAResult{}
+	for _, result := range status.RallySLAResults {
+		if !containsPod(podList.Items, result.PodName) {
+			results = append(results, result)
+		}
+	}
+
+	for _, pod := range podList.Items {
+		workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel])
+		if err != nil {
+			continue
+		}
+
+		result := getRallySLAResult(pod)
+		if result == nil {
+			continue
+		}
+
+		result.WorkflowStep = workflowStep
+		result.PodName = pod.Name
+		if workflowStep < len(stepNames) {
+			result.StepName = stepNames[workflowStep]
+		}
+
+		results = append(results, *result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].WorkflowStep < results[j].WorkflowStep
+	})
+
+	status.RallySLAResults = nil
+	if len(results) > 0 {
+		status.RallySLAResults = results
+	}
+
+	return nil
+}
+
+// getRallySLAResult returns the result of the SLA check reported by the test
+// container of a finished test pod. Nil is returned when the test container
+// did not report the result (e.g. when the rally task could not be started).
+func getRallySLAResult(pod corev1.Pod) *v1beta1.RallySLAResult {
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != pod.Spec.Containers[0].Name || terminated == nil {
+			continue
+		}
+
+		report := rallySLAReport{}
+		if err := json.Unmarshal([]byte(terminated.Message), &report); err != nil || report.Passed == nil {
+			return nil
+		}
+
+		return &v1beta1.RallySLAResult{
+			Passed:   *report.Passed,
+			Failures: report.Failures,
+		}
+	}
+
+	return nil
+}
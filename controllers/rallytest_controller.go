@@ -0,0 +1,217 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/rallytest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RallyTestReconciler reconciles a RallyTest object
+type RallyTestReconciler struct {
+	Reconciler
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *RallyTestReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("RallyTest")
+}
+
+// +kubebuilder:rbac:groups=test.openstack.org,resources=rallytests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=test.openstack.org,resources=rallytests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=rallytests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
+
+// Reconcile - RallyTest
+func (r *RallyTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &rallyTestKind{r: r, instance: &testv1beta1.RallyTest{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RallyTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.RallyTest{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+// rallyTestKind provides the parts of the reconcile flow of RallyTest that differ
+// between the test kinds (see testKind)
+type rallyTestKind struct {
+	r        *RallyTestReconciler
+	instance *testv1beta1.RallyTest
+	stepSpec testv1beta1.RallyTestSpec
+}
+
+func (k *rallyTestKind) Instance() testKindObject {
+	return k.instance
+}
+
+func (k *rallyTestKind) ServiceName() string {
+	return rallytest.ServiceName
+}
+
+func (k *rallyTestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
+
+func (k *rallyTestKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
+
+func (k *rallyTestKind) SelectStep(_ NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	spec := *k.instance.Spec.DeepCopy()
+	if mergeSpecOverride && step < len(spec.Workflow) {
+		var err error
+		spec, err = MergeSpecOverride(spec, spec.Workflow[step].SpecOverride)
+		if err != nil {
+			return testKindStep{}, err
+		}
+	}
+
+	k.stepSpec = getRallyTestStepSpec(spec, step)
+
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        k.stepSpec.CommonOptions,
+		NetworkAttachments: k.stepSpec.NetworkAttachments,
+	}
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
+	}
+
+	return kindStep, nil
+}
+
+func (k *rallyTestKind) EnvVars(podName string) (map[string]env.Setter, error) {
+	return k.r.PrepareRallyTestEnvVars(k.stepSpec, podName)
+}
+
+func (k *rallyTestKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	return rallytest.Pod(
+		k.instance,
+		k.stepSpec,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		logsPVCName,
+		mountCerts,
+		envVars,
+		containerImage,
+		k.stepSpec.Privileged,
+	)
+}
+
+func (k *rallyTestKind) UpdateResults(ctx context.Context, stepNames []string) error {
+	return k.r.UpdateRallySLAResults(ctx, k.instance, &k.instance.Status, stepNames)
+}
+
+// This function prepares env variables for a single workflow step.
+func (r *RallyTestReconciler) PrepareRallyTestEnvVars(
+	stepSpec testv1beta1.RallyTestSpec,
+	podName string,
+) (map[string]env.Setter, error) {
+	envVars := make(map[string]env.Setter)
+	envVars["USE_EXTERNAL_FILES"] = env.SetValue("True")
+	envVars["RALLY_LOGS_DIR_NAME"] = env.SetValue(podName)
+	envVars["RALLY_OS_CLOUD"] = env.SetValue(stepSpec.Cloud)
+	envVars["RALLY_TASK_FILE"] = env.SetValue(rallytest.TaskDir + "/" + rallytest.TaskFileName)
+	envVars["RALLY_DEBUG_MODE"] = env.SetValue(r.GetDefaultBool(stepSpec.Debug))
+
+	if len(stepSpec.TaskArgs) > 0 {
+		envVars["RALLY_TASK_ARGS"] = env.SetValue(stepSpec.TaskArgs)
+	}
+
+	sla, err := rallytest.GetSLA(stepSpec.SLA)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sla) > 0 {
+		envVars["RALLY_SLA"] = env.SetValue(sla)
+	}
+
+	return envVars, nil
+}
+
+// getRallyTestStepSpec returns the spec that applies to a single workflow
+// step, i.e. the spec of the instance with the values overridden by the
+// workflow step. The workflow of the returned spec is not set.
+func getRallyTestStepSpec(spec testv1beta1.RallyTestSpec, step int) testv1beta1.RallyTestSpec {
+	stepSpec := *spec.DeepCopy()
+	stepSpec.Workflow = nil
+
+	workflowStep := testv1beta1.RallyTestWorkflowSpec{}
+	if step < len(spec.Workflow) {
+		workflowStep = spec.Workflow[step]
+	}
+
+	stepSpec.Privileged = mergeWithWorkflow(spec.Privileged, workflowStep.Privileged)
+	stepSpec.ContainerImage = mergeNonZeroWithWorkflow(spec.ContainerImage, workflowStep.ContainerImage)
+	stepSpec.Cloud = mergeNonZeroWithWorkflow(spec.Cloud, workflowStep.Cloud)
+	stepSpec.TaskArgs = mergeNonZeroWithWorkflow(spec.TaskArgs, workflowStep.TaskArgs)
+	if workflowStep.TaskConfigMap != nil {
+		stepSpec.TaskConfigMap = workflowStep.TaskConfigMap.DeepCopy()
+	}
+	if workflowStep.SLA != nil {
+		stepSpec.SLA = workflowStep.SLA.DeepCopy()
+	}
+	if len(workflowStep.NetworkAttachments) > 0 {
+		stepSpec.NetworkAttachments = workflowStep.NetworkAttachments
+	}
+
+	return stepSpec
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/report"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	reportLabel = "report"
+
+	defaultReportImageEnvVar = "RELATED_IMAGE_TEST_REPORT_IMAGE_URL_DEFAULT"
+)
+
+const (
+	InfoGeneratingReport = "Waiting for the report generation pod to finish."
+)
+
+// GenerateReport spawns a pod that generates the HTML report of the test
+// results in the logs PVCs of the instance. The result is reported via the
+// ReportGenerated condition. The returned value is true once the report
+// generation is finished (or when there is nothing to generate).
+func (r *Reconciler) GenerateReport(
+	ctx context.Context,
+	instance client.Object,
+	h *helper.Helper,
+	status *v1beta1.CommonTestStatus,
+	options v1beta1.CommonOptions,
+) (bool, error) {
+	if options.Report == nil || !isLogsPVCPersistence(options.Persistence) {
+		return true, nil
+	}
+
+	podName := instance.GetName() + report.PodNameSuffix
+	pod, err := r.GetPod(ctx, podName, instance.GetNamespace())
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if k8s_errors.IsNotFound(err) {
+		claimNames, err := r.getLogsPVCNames(ctx, instance, status)
+		if err != nil || len(claimNames) == 0 {
+			return err == nil, err
+		}
+
+		labels := map[string]string{
+			reportLabel:       instance.GetName(),
+			operatorNameLabel: "test-operator",
+		}
+
+		containerImage := options.Report.Image
+		if containerImage == "" {
+			containerImage = util.GetEnvVar(defaultReportImageEnvVar, "")
+		}
+
+		podDef := report.Pod(
+			instance.GetNamespace(),
+			labels,
+			podName,
+			containerImage,
+			options.Report.Command,
+			getReportEnvVars(instance, status, claimNames),
+			claimNames,
+		)
+		podDef.Spec.ImagePullSecrets = operatorutil.GetImagePullSecrets(options.ImagePullSecrets)
+		for i := range podDef.Spec.Containers {
+			podDef.Spec.Containers[i].ImagePullPolicy = options.ImagePullPolicy
+		}
+
+		if _, err := r.CreatePod(ctx, *h, podDef); err != nil {
+			return false, err
+		}
+
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.ReportGeneratedCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			v1beta1.ReportGeneratedRunningMessage))
+
+		return false, nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		status.Conditions.MarkTrue(
+			v1beta1.ReportGeneratedCondition,
+			v1beta1.ReportGeneratedMessage)
+
+		return true, nil
+
+	case corev1.PodFailed:
+		status.Conditions.Set(condition.FalseCondition(
+			v1beta1.ReportGeneratedCondition,
+			v1beta1.ReportFailedReason,
+			condition.SeverityWarning,
+			v1beta1.ReportGeneratedErrorMessage,
+			podName))
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// isReportPending returns true until the report generation of the instance
+// is finished
+func isReportPending(options v1beta1.CommonOptions, status *v1beta1.CommonTestStatus) bool {
+	if options.Report == nil {
+		return false
+	}
+
+	reportCondition := status.Conditions.Get(v1beta1.ReportGeneratedCondition)
+	return reportCondition == nil || reportCondition.Reason == condition.RequestedReason
+}
+
+// getReportEnvVars returns the environment variables of the report generation
+// pod that describe the test pods of the instance (see report.Script)
+func getReportEnvVars(
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	claimNames []string,
+) []corev1.EnvVar {
+	steps := []string{}
+	for _, step := range status.Steps {
+		if step.ArtifactDirectory == "" {
+			continue
+		}
+
+		stepName := step.StepName
+		if stepName == "" {
+			stepName = step.PodName
+		}
+
+		steps = append(steps, strings.Join([]string{step.ArtifactDirectory, stepName, string(step.Phase)}, "|"))
+	}
+
+	return []corev1.EnvVar{
+		{Name: "REPORT_DIRECTORIES", Value: report.GetDirectories(claimNames)},
+		{Name: "REPORT_STEPS", Value: strings.Join(steps, "\n")},
+		{Name: "REPORT_TITLE", Value: instance.GetName()},
+	}
+}
@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/reportportal"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	reportPortalLabel = "reportPortal"
+
+	// ReportPortalLaunchReason is the reason of the Events that record the
+	// launches the results of the test pods were imported to
+	ReportPortalLaunchReason = "ReportPortalLaunch"
+
+	// ReportPortalUploadFailedReason is the reason of the Events that record
+	// the failed uploads of the results of the test pods
+	ReportPortalUploadFailedReason = "ReportPortalUploadFailed"
+)
+
+// reportPortalLaunchRequest is the description of a launch imported to
+// ReportPortal (launchImportRq)
+type reportPortalLaunchRequest struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	Attributes  []reportPortalLaunchAttribute `json:"attributes,omitempty"`
+}
+
+type reportPortalLaunchAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// UploadToReportPortal spawns a pod for each finished test pod that imports
+// the results of the test pod from the logs PVC to ReportPortal. The URL of
+// the launch (or the error reported by the upload pod) is stored in
+// status.ReportPortalLaunches and recorded using an Event. The results of
+// each test pod are uploaded only once.
+func (r *Reconciler) UploadToReportPortal(
+	ctx context.Context,
+	instance client.Object,
+	h *helper.Helper,
+	status *v1beta1.CommonTestStatus,
+	options v1beta1.CommonOptions,
+) error {
+	if options.ReportPortal == nil || !isLogsPVCPersistence(options.Persistence) {
+		return nil
+	}
+
+	uploaded := map[string]bool{}
+	for _, launch := range status.ReportPortalLaunches {
+		uploaded[launch.PodName] = true
+	}
+
+	for _, step := range status.Steps {
+		claimName := getArtifactClaimName(step.ArtifactURL)
+		if step.FinishTime == nil || step.ArtifactDirectory == "" || claimName == "" || uploaded[step.PodName] {
+			continue
+		}
+
+		podName := step.PodName + reportportal.PodNameSuffix
+		pod, err := r.GetPod(ctx, podName, instance.GetNamespace())
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+
+		if k8s_errors.IsNotFound(err) {
+			podDef, err := getReportPortalPod(instance, step, options, podName, claimName)
+			if err != nil {
+				return err
+			}
+
+			if _, err := r.CreatePod(ctx, *h, podDef); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		launch := v1beta1.ReportPortalLaunch{
+			WorkflowStep: step.WorkflowStep,
+			StepName:     step.StepName,
+			PodName:      step.PodName,
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			launch.URL = getTerminationMessage(*pod, reportportal.ServiceName)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, ReportPortalLaunchReason,
+					"Results of the test pod %s were imported to ReportPortal: %s", step.PodName, launch.URL)
+			}
+
+		case corev1.PodFailed:
+			launch.Error = getTerminationMessage(*pod, reportportal.ServiceName)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, ReportPortalUploadFailedReason,
+					"Results of the test pod %s were not imported to ReportPortal: %s", step.PodName, launch.Error)
+			}
+
+		default:
+			continue
+		}
+
+		status.ReportPortalLaunches = append(status.ReportPortalLaunches, launch)
+	}
+
+	return nil
+}
+
+// getReportPortalPod returns the pod that uploads the results of the test pod
+// of the step to ReportPortal
+func getReportPortalPod(
+	instance client.Object,
+	step v1beta1.TestStepStatus,
+	options v1beta1.CommonOptions,
+	podName string,
+	claimName string,
+) (*corev1.Pod, error) {
+	reportPortal := options.ReportPortal
+
+	launchRequest := reportPortalLaunchRequest{
+		Name:        reportPortal.LaunchName,
+		Description: "Results of the test pod " + step.PodName,
+	}
+
+	if launchRequest.Name == "" {
+		launchRequest.Name = instance.GetName()
+	}
+
+	for key, value := range reportPortal.LaunchAttributes {
+		launchRequest.Attributes = append(launchRequest.Attributes, reportPortalLaunchAttribute{key, value})
+	}
+
+	sort.Slice(launchRequest.Attributes, func(i, j int) bool {
+		return launchRequest.Attributes[i].Key < launchRequest.Attributes[j].Key
+	})
+
+	launchRequest.Attributes = append(launchRequest.Attributes,
+		reportPortalLaunchAttribute{"instance", instance.GetName()},
+		reportPortalLaunchAttribute{"runID", GetRunID(instance)},
+		reportPortalLaunchAttribute{"workflowStep", strconv.Itoa(step.WorkflowStep)},
+		reportPortalLaunchAttribute{"pod", step.PodName},
+	)
+
+	encodedLaunchRequest, err := json.Marshal(launchRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	curlOptions := ""
+	if reportPortal.TLSInsecureSkipVerify {
+		curlOptions = "--insecure"
+	}
+
+	containerImage := reportPortal.Image
+	if containerImage == "" {
+		containerImage = util.GetEnvVar(defaultReportImageEnvVar, "")
+	}
+
+	labels := map[string]string{
+		reportPortalLabel: instance.GetName(),
+		operatorNameLabel: "test-operator",
+	}
+
+	envVars := []corev1.EnvVar{
+		{Name: "REPORTPORTAL_ENDPOINT", Value: BracketIPv6URL(strings.TrimSuffix(reportPortal.Endpoint, "/"))},
+		{Name: "REPORTPORTAL_PROJECT", Value: reportPortal.Project},
+		{Name: "REPORTPORTAL_LAUNCH", Value: string(encodedLaunchRequest)},
+		{Name: "REPORTPORTAL_ARTIFACT_DIRECTORY", Value: step.ArtifactDirectory},
+		{Name: "REPORTPORTAL_CURL_OPTIONS", Value: curlOptions},
+	}
+
+	podDef := reportportal.Pod(
+		instance.GetNamespace(),
+		labels,
+		podName,
+		containerImage,
+		envVars,
+		claimName,
+		reportPortal.TokenSecretName,
+	)
+	podDef.Spec.ImagePullSecrets = operatorutil.GetImagePullSecrets(options.ImagePullSecrets)
+	for i := range podDef.Spec.Containers {
+		podDef.Spec.Containers[i].ImagePullPolicy = options.ImagePullPolicy
+	}
+
+	return podDef, nil
+}
+
+// getArtifactClaimName returns the name of the PVC from the location of the
+// logs of a test pod (pvc://<namespace>/<persistent volume claim name>)
+func getArtifactClaimName(artifactURL string) string {
+	location, found := strings.CutPrefix(artifactURL, "pvc://")
+	if !found {
+		return ""
+	}
+
+	_, claimName, _ := strings.Cut(location, "/")
+	return claimName
+}
+
+// getTerminationMessage returns the termination message of the terminated
+// container of the pod
+func getTerminationMessage(pod corev1.Pod, containerName string) string {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name == containerName && terminated != nil {
+			return strings.TrimSpace(terminated.Message)
+		}
+	}
+
+	return ""
+}
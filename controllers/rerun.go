@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RerunTriggeredReason is the reason of the Events that record the runs
+	// triggered by the RerunAnnotation or by a change of the spec
+	RerunTriggeredReason = "RerunTriggered"
+)
+
+const (
+	InfoDeletingPreviousRunPods = "Deleting the pods of the previous run before the workflow is executed once more."
+	InfoRerunTriggered          = "The %s triggered a rerun. Executing the workflow once more as run %s."
+)
+
+const (
+	rerunAnnotationTrigger = "rerun annotation %s"
+	specChangeTrigger      = "spec change (generation %d)"
+)
+
+// TriggerRerun starts a new run of the whole workflow of a completed instance
+// when the RerunAnnotation is set to a value that differs from
+// status.observedRerun or when spec.rerunOnSpecChange is set and the spec
+// changed since the run started (see status.observedGeneration). The pods of
+// the previous run are deleted first. Then the instance gets a new run ID and
+// its status is reset so that the next reconciliation acquires the lock and
+// spawns the first test pod again. The logs PVCs, the results and the TestRun
+// of the previous run are kept. The returned value is true while the pods of
+// the previous run are being deleted.
+func (r *Reconciler) TriggerRerun(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	options v1beta1.CommonOptions,
+	Log logr.Logger,
+) (bool, error) {
+	rerun := instance.GetAnnotations()[v1beta1.RerunAnnotation]
+	if status.ObservedGeneration == 0 {
+		status.ObservedGeneration = instance.GetGeneration()
+	}
+
+	if !status.Conditions.IsTrue(condition.DeploymentReadyCondition) {
+		// The spec and the annotation are applied to the upcoming run until
+		// the first test pod is spawned
+		if len(status.Steps) == 0 {
+			status.ObservedRerun = rerun
+			status.ObservedGeneration = instance.GetGeneration()
+		}
+
+		return false, nil
+	}
+
+	rerunRequested := rerun != "" && rerun != status.ObservedRerun
+	specChanged := options.RerunOnSpecChange && instance.GetGeneration() != status.ObservedGeneration
+	if !rerunRequested && !specChanged {
+		return false, nil
+	}
+
+	podsDeleted, err := r.deleteInstancePods(ctx, instance)
+	if err != nil || !podsDeleted {
+		return !podsDeleted, err
+	}
+
+	annotations := instance.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	runIDSource := instance.GetName() + strconv.Itoa(status.Reruns) + time.Now().Format(time.RFC3339Nano)
+	annotations[v1beta1.RunIDAnnotation] = GetStringHash(runIDSource, runIDLength)
+	instance.SetAnnotations(annotations)
+
+	conditions := status.Conditions
+	for _, conditionType := range []condition.Type{
+		v1beta1.StarvedCondition,
+		v1beta1.CleanupVerifiedCondition,
+		v1beta1.EndpointsReachableCondition,
+		v1beta1.ReportGeneratedCondition,
+		v1beta1.PolarionExportedCondition,
+	} {
+		conditions.Remove(conditionType)
+	}
+
+	conditions.MarkUnknown(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
+	conditions.MarkUnknown(
+		condition.DeploymentReadyCondition,
+		condition.InitReason,
+		condition.DeploymentReadyInitMessage)
+
+	*status = v1beta1.CommonTestStatus{
+		Hash:               status.Hash,
+		Conditions:         conditions,
+		NetworkAttachments: status.NetworkAttachments,
+		FeatureGates:       status.FeatureGates,
+		ObservedRerun:      rerun,
+		ObservedGeneration: instance.GetGeneration(),
+		Reruns:             status.Reruns + 1,
+	}
+
+	trigger := fmt.Sprintf(rerunAnnotationTrigger, rerun)
+	if !rerunRequested {
+		trigger = fmt.Sprintf(specChangeTrigger, instance.GetGeneration())
+	}
+
+	Log.Info(fmt.Sprintf(InfoRerunTriggered, trigger, GetRunID(instance)))
+	if r.Recorder != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, RerunTriggeredReason,
+			"The %s triggered the run %s", trigger, GetRunID(instance))
+	}
+
+	return false, nil
+}
+
+// deleteInstancePods deletes the test pods and the auxiliary pods controlled
+// by the instance. The returned value is true once all of them are gone.
+func (r *Reconciler) deleteInstancePods(
+	ctx context.Context,
+	instance client.Object,
+) (bool, error) {
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels{operatorNameLabel: "test-operator"}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return false, err
+	}
+
+	podsDeleted := true
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !metav1.IsControlledBy(pod, instance) {
+			continue
+		}
+
+		podsDeleted = false
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		if err := r.Client.Delete(ctx, pod); err != nil && !k8s_errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+
+	return podsDeleted, nil
+}
+
+// getRerunPodNameSuffix returns the suffix of the names of the pods of a run
+// triggered by TriggerRerun so that they differ from the names of the pods of
+// the previous runs
+func getRerunPodNameSuffix(instance client.Object, status *v1beta1.CommonTestStatus) string {
+	if status.Reruns == 0 {
+		return ""
+	}
+
+	return "-" + GetRunID(instance)
+}
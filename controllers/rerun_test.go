@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newRerunTest returns a Tobiko instance whose first run spawned the test pod
+// of its only workflow step and a reconciler whose client contains the pod.
+// The first run is completed when completed is set.
+func newRerunTest(t *testing.T, completed bool) (*Reconciler, *testv1beta1.Tobiko) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := testv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	instance := &testv1beta1.Tobiko{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "tobiko",
+			Namespace:  "openstack",
+			UID:        "tobiko-uid",
+			Generation: 1,
+		},
+		Spec: testv1beta1.TobikoSpec{
+			Workflow: []testv1beta1.TobikoWorkflowSpec{{StepName: "podified"}},
+		},
+	}
+	instance.Spec.RerunOnSpecChange = true
+	instance.Status.Conditions.Init(nil)
+	instance.Status.ObservedGeneration = 1
+
+	r := &Reconciler{Scheme: scheme}
+	podName := r.GetPodName(instance, 0)
+	instance.Status.Steps = []testv1beta1.TestStepStatus{
+		{StepName: "podified", PodName: podName, Phase: corev1.PodSucceeded},
+	}
+	if completed {
+		instance.Status.Conditions.MarkTrue(condition.DeploymentReadyCondition, condition.DeploymentReadyMessage)
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: instance.Namespace,
+			Labels:    map[string]string{operatorNameLabel: "test-operator"},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(instance, testv1beta1.GroupVersion.WithKind("Tobiko")),
+			},
+		},
+	}
+
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build()
+	return r, instance
+}
+
+func countPods(t *testing.T, r *Reconciler) int {
+	t.Helper()
+
+	podList := &corev1.PodList{}
+	if err := r.Client.List(context.Background(), podList, client.InNamespace("openstack")); err != nil {
+		t.Fatal(err)
+	}
+
+	return len(podList.Items)
+}
+
+func TestTriggerRerun(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		generation  int64
+		wantTrigger bool
+	}{
+		{
+			name:        "rerun annotation",
+			annotations: map[string]string{testv1beta1.RerunAnnotation: "build-2"},
+			generation:  1,
+			wantTrigger: true,
+		},
+		{
+			name:        "spec change of an instance without annotations",
+			generation:  2,
+			wantTrigger: true,
+		},
+		{
+			name:        "no rerun requested",
+			annotations: map[string]string{testv1beta1.RerunAnnotation: ""},
+			generation:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, instance := newRerunTest(t, true)
+			instance.SetAnnotations(tt.annotations)
+			instance.SetGeneration(tt.generation)
+			firstRunID := GetRunID(instance)
+
+			// The pods of the previous run are deleted first
+			rerunPending, err := r.TriggerRerun(ctx, instance, &instance.Status, instance.Spec.CommonOptions, logr.Discard())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tt.wantTrigger {
+				if rerunPending || countPods(t, r) != 1 || instance.Status.Reruns != 0 {
+					t.Fatalf("TriggerRerun() triggered a rerun, pending = %v, reruns = %d", rerunPending, instance.Status.Reruns)
+				}
+				return
+			}
+
+			if !rerunPending || countPods(t, r) != 0 {
+				t.Fatalf("TriggerRerun() pending = %v, want the pods of the previous run deleted first", rerunPending)
+			}
+
+			if instance.Status.Reruns != 0 || GetRunID(instance) != firstRunID {
+				t.Fatalf("TriggerRerun() reset the status before the pods of the previous run were deleted")
+			}
+
+			// Then the instance gets a new run ID and the status is reset
+			rerunPending, err = r.TriggerRerun(ctx, instance, &instance.Status, instance.Spec.CommonOptions, logr.Discard())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rerunPending {
+				t.Fatalf("TriggerRerun() pending = true once the pods of the previous run were deleted")
+			}
+
+			if GetRunID(instance) == firstRunID {
+				t.Errorf("TriggerRerun() kept the run ID %s", firstRunID)
+			}
+
+			status := instance.Status
+			if status.Reruns != 1 || len(status.Steps) != 0 || status.ObservedGeneration != tt.generation ||
+				status.ObservedRerun != tt.annotations[testv1beta1.RerunAnnotation] {
+				t.Errorf("TriggerRerun() status = %+v, want the status of a new run", status)
+			}
+
+			if !status.Conditions.IsUnknown(condition.DeploymentReadyCondition) {
+				t.Errorf("TriggerRerun() DeploymentReady = %v, want Unknown", status.Conditions.Get(condition.DeploymentReadyCondition))
+			}
+
+			wantPodName := "tobiko-workflow-step-00-podified-" + GetRunID(instance)
+			if podName := r.GetPodName(instance, 0); podName != wantPodName {
+				t.Errorf("GetPodName() = %s, want %s", podName, wantPodName)
+			}
+
+			// The next reconciliation does not trigger another rerun
+			rerunPending, err = r.TriggerRerun(ctx, instance, &instance.Status, instance.Spec.CommonOptions, logr.Discard())
+			if err != nil || rerunPending || instance.Status.Reruns != 1 {
+				t.Errorf("TriggerRerun() of the new run pending = %v, reruns = %d, error = %v",
+					rerunPending, instance.Status.Reruns, err)
+			}
+		})
+	}
+}
+
+func TestTriggerRerunSpecChangeBeforeFirstPod(t *testing.T) {
+	ctx := context.Background()
+	r, instance := newRerunTest(t, false)
+	instance.Status.Steps = nil
+	instance.SetGeneration(2)
+
+	// The spec changed before the first test pod started, the change is
+	// applied to the upcoming run
+	rerunPending, err := r.TriggerRerun(ctx, instance, &instance.Status, instance.Spec.CommonOptions, logr.Discard())
+	if err != nil || rerunPending {
+		t.Fatalf("TriggerRerun() pending = %v, error = %v", rerunPending, err)
+	}
+
+	if instance.Status.ObservedGeneration != 2 {
+		t.Errorf("TriggerRerun() observedGeneration = %d, want 2", instance.Status.ObservedGeneration)
+	}
+
+	// The run completes without a rerun
+	instance.Status.Conditions.MarkTrue(condition.DeploymentReadyCondition, condition.DeploymentReadyMessage)
+	rerunPending, err = r.TriggerRerun(ctx, instance, &instance.Status, instance.Spec.CommonOptions, logr.Discard())
+	if err != nil || rerunPending || instance.Status.Reruns != 0 || countPods(t, r) != 1 {
+		t.Errorf("TriggerRerun() of the completed run pending = %v, reruns = %d, error = %v",
+			rerunPending, instance.Status.Reruns, err)
+	}
+}
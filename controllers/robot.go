@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// robotReport is the statistics of the executed suites reported by the test
+// container of a RobotTest via its termination message
+type robotReport struct {
+	Total   *int `json:"total"`
+	Passed  int  `json:"passed"`
+	Failed  int  `json:"failed"`
+	Skipped int  `json:"skipped"`
+}
+
+// UpdateRobotResults stores the statistics of the suites executed by each
+// finished test pod in status.RobotResults. Results of the test pods that
+// were already deleted are kept.
+func (r *Reconciler) UpdateRobotResults(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	stepNames []string,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	results := []v1beta1.RobotResult{}
+	for _, result := range status.RobotResults {
+		if !containsPod(podList.Items, result.PodName) {
+			results = append(results, result)
+		}
+	}
+
+	for _, pod := range podList.Items {
+		workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel])
+		if err != nil {
+			continue
+		}
+
+		result := getRobotResult(pod)
+		if result == nil {
+			continue
+		}
+
+		result.WorkflowStep = workflowStep
+		result.PodName = pod.Name
+		if workflowStep < len(stepNames) {
+			result.StepName = stepNames[workflowStep]
+		}
+
+		results = append(results, *result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].WorkflowStep < results[j].WorkflowStep
+	})
+
+	status.RobotResults = nil
+	if len(results) > 0 {
+		status.RobotResults = results
+	}
+
+	return nil
+}
+
+// getRobotResult returns the statistics reported by the test container of a
+// finished test pod. Nil is returned when the test container did not report
+// them (e.g. when the suites could not be fetched).
+func getRobotResult(pod corev1.Pod) *v1beta1.RobotResult {
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != pod.Spec.Containers[0].Name || terminated == nil {
+			continue
+		}
+
+		report := robotReport{}
+		if err := json.Unmarshal([]byte(terminated.Message), &report); err != nil || report.Total == nil {
+			return nil
+		}
+
+		return &v1beta1.RobotResult{
+			Total:   *report.Total,
+			Passed:  report.Passed,
+			Failed:  report.Failed,
+			Skipped: report.Skipped,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/robottest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RobotTestReconciler reconciles a RobotTest object
+type RobotTestReconciler struct {
+	Reconciler
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *RobotTestReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("RobotTest")
+}
+
+// +kubebuilder:rbac:groups=test.openstack.org,resources=robottests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=test.openstack.org,resources=robottests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=robottests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
+
+// Reconcile - RobotTest
+func (r *RobotTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &robotTestKind{r: r, instance: &testv1beta1.RobotTest{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RobotTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.RobotTest{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+// robotTestKind provides the parts of the reconcile flow of RobotTest that differ
+// between the test kinds (see testKind)
+type robotTestKind struct {
+	r        *RobotTestReconciler
+	instance *testv1beta1.RobotTest
+	stepSpec testv1beta1.RobotTestSpec
+}
+
+func (k *robotTestKind) Instance() testKindObject {
+	return k.instance
+}
+
+func (k *robotTestKind) ServiceName() string {
+	return robottest.ServiceName
+}
+
+func (k *robotTestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
+
+func (k *robotTestKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
+
+func (k *robotTestKind) SelectStep(_ NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	spec := *k.instance.Spec.DeepCopy()
+	if mergeSpecOverride && step < len(spec.Workflow) {
+		var err error
+		spec, err = MergeSpecOverride(spec, spec.Workflow[step].SpecOverride)
+		if err != nil {
+			return testKindStep{}, err
+		}
+	}
+
+	k.stepSpec = getRobotTestStepSpec(spec, step)
+
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        k.stepSpec.CommonOptions,
+		NetworkAttachments: k.stepSpec.NetworkAttachments,
+	}
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
+	}
+
+	return kindStep, nil
+}
+
+func (k *robotTestKind) EnvVars(podName string) (map[string]env.Setter, error) {
+	return k.r.PrepareRobotTestEnvVars(k.stepSpec, podName)
+}
+
+func (k *robotTestKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	return robottest.Pod(
+		k.instance,
+		k.stepSpec,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		logsPVCName,
+		mountCerts,
+		envVars,
+		containerImage,
+		k.stepSpec.Privileged,
+	)
+}
+
+func (k *robotTestKind) UpdateResults(ctx context.Context, stepNames []string) error {
+	return k.r.UpdateRobotResults(ctx, k.instance, &k.instance.Status, stepNames)
+}
+
+// This function prepares env variables for a single workflow step.
+func (r *RobotTestReconciler) PrepareRobotTestEnvVars(
+	stepSpec testv1beta1.RobotTestSpec,
+	podName string,
+) (map[string]env.Setter, error) {
+	envVars := make(map[string]env.Setter)
+	envVars["USE_EXTERNAL_FILES"] = env.SetValue("True")
+	envVars["ROBOT_LOGS_DIR_NAME"] = env.SetValue(podName)
+	envVars["ROBOT_SUITES_PATH"] = env.SetValue(stepSpec.SuitesPath)
+	envVars["ROBOT_DEBUG_MODE"] = env.SetValue(r.GetDefaultBool(stepSpec.Debug))
+
+	if len(stepSpec.SuitesGitRepo) > 0 {
+		envVars["ROBOT_SUITES_GIT_REPO"] = env.SetValue(BracketIPv6URL(stepSpec.SuitesGitRepo))
+		envVars["ROBOT_SUITES_GIT_REF"] = env.SetValue(stepSpec.SuitesGitRef)
+	} else {
+		envVars["ROBOT_SUITES_DIR"] = env.SetValue(robottest.SuitesDir)
+	}
+
+	if len(stepSpec.SuitesGitAuthSecretName) > 0 {
+		for name, value := range robottest.GetGitAuthEnvVars() {
+			envVars[name] = env.SetValue(value)
+		}
+	}
+
+	variables, err := robottest.GetVariables(stepSpec.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(variables) > 0 {
+		envVars["ROBOT_VARIABLES"] = env.SetValue(variables)
+	}
+
+	if len(stepSpec.VariablesFromSecrets) > 0 {
+		envVars["ROBOT_VARIABLE_DIRS"] = env.SetValue(robottest.GetVariableDirs(stepSpec.VariablesFromSecrets))
+	}
+
+	if len(stepSpec.IncludeTags) > 0 {
+		envVars["ROBOT_INCLUDE_TAGS"] = env.SetValue(strings.Join(stepSpec.IncludeTags, " "))
+	}
+
+	if len(stepSpec.ExcludeTags) > 0 {
+		envVars["ROBOT_EXCLUDE_TAGS"] = env.SetValue(strings.Join(stepSpec.ExcludeTags, " "))
+	}
+
+	return envVars, nil
+}
+
+// getRobotTestStepSpec returns the spec that applies to a single workflow
+// step, i.e. the spec of the instance with the values overridden by the
+// workflow step. The workflow of the returned spec is not set.
+func getRobotTestStepSpec(spec testv1beta1.RobotTestSpec, step int) testv1beta1.RobotTestSpec {
+	stepSpec := *spec.DeepCopy()
+	stepSpec.Workflow = nil
+
+	workflowStep := testv1beta1.RobotTestWorkflowSpec{}
+	if step < len(spec.Workflow) {
+		workflowStep = spec.Workflow[step]
+	}
+
+	stepSpec.Privileged = mergeWithWorkflow(spec.Privileged, workflowStep.Privileged)
+	stepSpec.ContainerImage = mergeNonZeroWithWorkflow(spec.ContainerImage, workflowStep.ContainerImage)
+
+	// The suites of a workflow step come from a single source, so the git
+	// repo and the config map of the spec are overridden together.
+	if len(workflowStep.SuitesGitRepo) > 0 || len(workflowStep.SuitesConfigMap) > 0 {
+		stepSpec.SuitesGitRepo = workflowStep.SuitesGitRepo
+		stepSpec.SuitesGitRef = workflowStep.SuitesGitRef
+		stepSpec.SuitesConfigMap = workflowStep.SuitesConfigMap
+	}
+
+	stepSpec.SuitesGitAuthSecretName = mergeNonZeroWithWorkflow(spec.SuitesGitAuthSecretName, workflowStep.SuitesGitAuthSecretName)
+	stepSpec.SuitesPath = mergeNonZeroWithWorkflow(spec.SuitesPath, workflowStep.SuitesPath)
+	if len(workflowStep.Variables) > 0 {
+		stepSpec.Variables = map[string]string{}
+		for name, value := range spec.Variables {
+			stepSpec.Variables[name] = value
+		}
+		for name, value := range workflowStep.Variables {
+			stepSpec.Variables[name] = value
+		}
+	}
+	if len(workflowStep.VariablesFromSecrets) > 0 {
+		stepSpec.VariablesFromSecrets = workflowStep.VariablesFromSecrets
+	}
+	if len(workflowStep.IncludeTags) > 0 {
+		stepSpec.IncludeTags = workflowStep.IncludeTags
+	}
+	if len(workflowStep.ExcludeTags) > 0 {
+		stepSpec.ExcludeTags = workflowStep.ExcludeTags
+	}
+	if len(workflowStep.NetworkAttachments) > 0 {
+		stepSpec.NetworkAttachments = workflowStep.NetworkAttachments
+	}
+
+	return stepSpec
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// requiredSCCAnnotation makes the SCC admission of OpenShift admit the
+	// pod using the given SCC only instead of picking the most privileged
+	// SCC the service account is allowed to use
+	requiredSCCAnnotation = "openshift.io/required-scc"
+)
+
+// GetSecurityContextConstraint returns the name of the SCC the test pod of
+// the workflow step is required to use. The SCC of the workflow step takes
+// precedence over the SCC from the spec. When neither is set, the SCC is
+// derived from the privileged mode of the test pod.
+func GetSecurityContextConstraint(
+	options v1beta1.CommonOptions,
+	workflowStep *v1beta1.WorkflowCommonParameters,
+	privileged bool,
+) string {
+	if workflowStep != nil && workflowStep.SecurityContextConstraint != nil {
+		return *workflowStep.SecurityContextConstraint
+	}
+
+	if len(options.SecurityContextConstraint) > 0 {
+		return options.SecurityContextConstraint
+	}
+
+	if privileged {
+		return v1beta1.SecurityContextConstraintPrivileged
+	}
+
+	return v1beta1.SecurityContextConstraintNonrootV2
+}
+
+// EnsureServiceAccount ensures that the service account of the test pods of
+// the instance exists and that it is allowed to use the given SCC only. The
+// role is updated before each workflow step so that a privileged SCC is not
+// available to the test pods that do not require it.
+func (r *Reconciler) EnsureServiceAccount(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance common_rbac.Reconciler,
+	securityContextConstraint string,
+) (ctrl.Result, error) {
+	rules := GetCommonRbacRules(securityContextConstraint)
+	return common_rbac.ReconcileRbac(ctx, helper, instance, rules)
+}
+
+// ApplySecurityContextConstraint runs the test pod using the service account
+// created by EnsureServiceAccount and requires the pod to be admitted using
+// the given SCC
+func ApplySecurityContextConstraint(
+	pod *corev1.Pod,
+	serviceAccountName string,
+	securityContextConstraint string,
+) {
+	pod.Spec.ServiceAccountName = serviceAccountName
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+
+	pod.Annotations[requiredSCCAnnotation] = securityContextConstraint
+}
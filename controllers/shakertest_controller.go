@@ -0,0 +1,225 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/shakertest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ShakerTestReconciler reconciles a ShakerTest object
+type ShakerTestReconciler struct {
+	Reconciler
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *ShakerTestReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("ShakerTest")
+}
+
+// +kubebuilder:rbac:groups=test.openstack.org,resources=shakertests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=test.openstack.org,resources=shakertests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=shakertests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
+// +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
+
+// Reconcile - ShakerTest
+func (r *ShakerTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &shakerTestKind{r: r, instance: &testv1beta1.ShakerTest{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ShakerTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.ShakerTest{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+// shakerTestKind provides the parts of the reconcile flow of ShakerTest that differ
+// between the test kinds (see testKind)
+type shakerTestKind struct {
+	r        *ShakerTestReconciler
+	instance *testv1beta1.ShakerTest
+	stepSpec testv1beta1.ShakerTestSpec
+}
+
+func (k *shakerTestKind) Instance() testKindObject {
+	return k.instance
+}
+
+func (k *shakerTestKind) ServiceName() string {
+	return shakertest.ServiceName
+}
+
+func (k *shakerTestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
+
+func (k *shakerTestKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
+
+func (k *shakerTestKind) SelectStep(_ NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	spec := *k.instance.Spec.DeepCopy()
+	if mergeSpecOverride && step < len(spec.Workflow) {
+		var err error
+		spec, err = MergeSpecOverride(spec, spec.Workflow[step].SpecOverride)
+		if err != nil {
+			return testKindStep{}, err
+		}
+	}
+
+	k.stepSpec = getShakerTestStepSpec(spec, step)
+
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        k.stepSpec.CommonOptions,
+		NetworkAttachments: k.stepSpec.NetworkAttachments,
+	}
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
+	}
+
+	return kindStep, nil
+}
+
+func (k *shakerTestKind) EnvVars(podName string) (map[string]env.Setter, error) {
+	return k.r.PrepareShakerTestEnvVars(k.stepSpec, podName)
+}
+
+func (k *shakerTestKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	return shakertest.Pod(
+		k.instance,
+		k.stepSpec,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		logsPVCName,
+		mountCerts,
+		envVars,
+		containerImage,
+		k.stepSpec.Privileged,
+	)
+}
+
+// This function prepares env variables for a single workflow step.
+func (r *ShakerTestReconciler) PrepareShakerTestEnvVars(
+	stepSpec testv1beta1.ShakerTestSpec,
+	podName string,
+) (map[string]env.Setter, error) {
+	envVars := make(map[string]env.Setter)
+	envVars["USE_EXTERNAL_FILES"] = env.SetValue("True")
+	envVars["SHAKER_LOGS_DIR_NAME"] = env.SetValue(podName)
+	envVars["SHAKER_OS_CLOUD"] = env.SetValue(stepSpec.Cloud)
+	envVars["SHAKER_SCENARIOS"] = env.SetValue(strings.Join(stepSpec.Scenarios, ","))
+	envVars["SHAKER_SERVER_PORT"] = env.SetValue(strconv.Itoa(int(stepSpec.ServerEndpointPort)))
+	envVars["SHAKER_IMAGE_NAME"] = env.SetValue(stepSpec.ImageName)
+	envVars["SHAKER_FLAVOR_NAME"] = env.SetValue(stepSpec.FlavorName)
+	envVars["SHAKER_REPORT_FILE"] = env.SetValue(shakertest.ReportFileName)
+	envVars["SHAKER_OUTPUT_FILE"] = env.SetValue(shakertest.OutputFileName)
+	envVars["SHAKER_DEBUG_MODE"] = env.SetValue(r.GetDefaultBool(stepSpec.Debug))
+
+	if len(stepSpec.ScenarioConfigMap) > 0 {
+		envVars["SHAKER_CUSTOM_SCENARIO_DIR"] = env.SetValue(shakertest.CustomScenarioDir)
+	}
+
+	if len(stepSpec.ExternalNetwork) > 0 {
+		envVars["SHAKER_EXTERNAL_NETWORK"] = env.SetValue(stepSpec.ExternalNetwork)
+	}
+
+	accommodation, err := shakertest.GetAccommodation(stepSpec.Accommodation)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accommodation) > 0 {
+		envVars["SHAKER_ACCOMMODATION"] = env.SetValue(accommodation)
+	}
+
+	return envVars, nil
+}
+
+// getShakerTestStepSpec returns the spec that applies to a single workflow
+// step, i.e. the spec of the instance with the values overridden by the
+// workflow step. The workflow of the returned spec is not set.
+func getShakerTestStepSpec(spec testv1beta1.ShakerTestSpec, step int) testv1beta1.ShakerTestSpec {
+	stepSpec := *spec.DeepCopy()
+	stepSpec.Workflow = nil
+
+	workflowStep := testv1beta1.ShakerTestWorkflowSpec{}
+	if step < len(spec.Workflow) {
+		workflowStep = spec.Workflow[step]
+	}
+
+	stepSpec.Privileged = mergeWithWorkflow(spec.Privileged, workflowStep.Privileged)
+	stepSpec.ContainerImage = mergeNonZeroWithWorkflow(spec.ContainerImage, workflowStep.ContainerImage)
+	stepSpec.Cloud = mergeNonZeroWithWorkflow(spec.Cloud, workflowStep.Cloud)
+	stepSpec.ScenarioConfigMap = mergeNonZeroWithWorkflow(spec.ScenarioConfigMap, workflowStep.ScenarioConfigMap)
+	stepSpec.ExternalNetwork = mergeNonZeroWithWorkflow(spec.ExternalNetwork, workflowStep.ExternalNetwork)
+	if len(workflowStep.Scenarios) > 0 {
+		stepSpec.Scenarios = workflowStep.Scenarios
+	}
+	if workflowStep.Accommodation != nil {
+		stepSpec.Accommodation = workflowStep.Accommodation.DeepCopy()
+	}
+	if len(workflowStep.NetworkAttachments) > 0 {
+		stepSpec.NetworkAttachments = workflowStep.NetworkAttachments
+	}
+
+	return stepSpec
+}
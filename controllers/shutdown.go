@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// OperatorRestartingAnnotation is set on instances that have test pods in
+	// progress while the operator is shutting down. It is removed once the
+	// instance is reconciled by the restarted operator.
+	OperatorRestartingAnnotation = "test.openstack.org/operator-restarting"
+)
+
+const (
+	InfoOperatorShuttingDown = "Operator is shutting down. Annotating instances with running test pods."
+	InfoOperatorRestarted    = "Resuming reconciliation of the instance after the operator restart."
+)
+
+// operatorShuttingDown is set once the manager starts shutting down. From
+// that point on no new test-operator-lock acquisitions are allowed.
+var operatorShuttingDown atomic.Bool
+
+// ShutdownHook is a manager runnable that prepares running test instances for
+// an operator restart once the manager is asked to stop.
+type ShutdownHook struct {
+	Client client.Client
+	Log    logr.Logger
+}
+
+var _ manager.Runnable = &ShutdownHook{}
+
+// Start blocks until the manager stops. Then it stops new test-operator-lock
+// acquisitions and annotates every instance with a test pod in progress with
+// the OperatorRestartingAnnotation.
+func (s *ShutdownHook) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	operatorShuttingDown.Store(true)
+	s.Log.Info(InfoOperatorShuttingDown)
+
+	// The manager context is already cancelled at this point. Use a fresh
+	// context, the manager bounds the shutdown by GracefulShutdownTimeout.
+	shutdownCtx := context.Background()
+
+	podList := &corev1.PodList{}
+	labelsListOpt := client.MatchingLabels{operatorNameLabel: "test-operator"}
+	if err := s.Client.List(shutdownCtx, podList, labelsListOpt); err != nil {
+		s.Log.Error(err, "unable to list test pods")
+		return nil
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		ownerRef := metav1.GetControllerOf(&pod)
+		if ownerRef == nil {
+			continue
+		}
+
+		owner := &metav1.PartialObjectMetadata{}
+		owner.APIVersion = ownerRef.APIVersion
+		owner.Kind = ownerRef.Kind
+		owner.Name = ownerRef.Name
+		owner.Namespace = pod.Namespace
+
+		patch := []byte(`{"metadata":{"annotations":{"` + OperatorRestartingAnnotation + `":"true"}}}`)
+		err := s.Client.Patch(shutdownCtx, owner, client.RawPatch(types.MergePatchType, patch))
+		if err != nil {
+			s.Log.Error(err, "unable to annotate instance", "kind", owner.Kind, "name", owner.Name)
+		}
+	}
+
+	return nil
+}
+
+// ClearOperatorRestarting removes the OperatorRestartingAnnotation from the
+// instance. The change is persisted together with the rest of the instance at
+// the end of the reconciliation.
+func (r *Reconciler) ClearOperatorRestarting(instance client.Object, Log logr.Logger) {
+	annotations := instance.GetAnnotations()
+	if _, ok := annotations[OperatorRestartingAnnotation]; !ok {
+		return
+	}
+
+	Log.Info(InfoOperatorRestarted)
+	delete(annotations, OperatorRestartingAnnotation)
+	instance.SetAnnotations(annotations)
+}
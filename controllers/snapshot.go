@@ -0,0 +1,279 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	failureSnapshotLabel  = "failureSnapshot"
+	failureSnapshotSuffix = "-failure-snapshot"
+
+	// Maximum number of events and the maximum length of a single event that
+	// are stored in the status of the instance. The full list of events is
+	// available in the ConfigMap with the snapshot.
+	failureSnapshotMaxEvents        = 5
+	failureSnapshotMaxMessageLength = 200
+
+	// Maximum number of the most recent events stored in the ConfigMap. It
+	// keeps the ConfigMap well below the size limit in busy namespaces.
+	failureSnapshotMaxStoredEvents = 1000
+)
+
+const (
+	InfoCapturingFailureSnapshot = "Test pod %s failed. Capturing a snapshot of the events and of the node conditions."
+)
+
+// CaptureFailureSnapshots captures a snapshot of the recent events and of the
+// conditions of the node for each failed test pod of the instance that does
+// not have one yet. The full snapshot is stored in a ConfigMap owned by the
+// instance and an excerpt is stored in status.FailureSnapshots.
+func (r *Reconciler) CaptureFailureSnapshots(
+	ctx context.Context,
+	instance client.Object,
+	h *helper.Helper,
+	status *v1beta1.CommonTestStatus,
+	Log logr.Logger,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodFailed || hasFailureSnapshot(status, pod.Name) {
+			continue
+		}
+
+		Log.Info(fmt.Sprintf(InfoCapturingFailureSnapshot, pod.Name))
+		snapshot, data := r.getFailureSnapshot(ctx, &pod, Log)
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      snapshot.ConfigMapName,
+				Namespace: instance.GetNamespace(),
+				Labels: map[string]string{
+					failureSnapshotLabel: instance.GetName(),
+					operatorNameLabel:    "test-operator",
+				},
+			},
+			Data: data,
+		}
+
+		err := controllerutil.SetControllerReference(h.GetBeforeObject(), configMap, r.GetScheme())
+		if err != nil {
+			return err
+		}
+
+		err = r.Client.Create(ctx, configMap)
+		if err != nil && !k8s_errors.IsAlreadyExists(err) {
+			return err
+		}
+
+		status.FailureSnapshots = append(status.FailureSnapshots, snapshot)
+	}
+
+	return nil
+}
+
+// getFailureSnapshot collects the events from the namespace of the pod and
+// the events and the conditions of the node the pod was scheduled on. Errors
+// are recorded in the snapshot rather than returned so that a missing piece
+// of information does not block the instance.
+func (r *Reconciler) getFailureSnapshot(
+	ctx context.Context,
+	pod *corev1.Pod,
+	Log logr.Logger,
+) (v1beta1.FailureSnapshot, map[string]string) {
+	snapshot := v1beta1.FailureSnapshot{
+		PodName:       pod.Name,
+		NodeName:      pod.Spec.NodeName,
+		CaptureTime:   metav1.Now(),
+		ConfigMapName: pod.Name + failureSnapshotSuffix,
+	}
+	data := map[string]string{}
+
+	// Events that happened before the pod was created are not relevant
+	since := pod.CreationTimestamp.Time
+	events := []corev1.Event{}
+
+	namespaceEvents, err := r.Kclient.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		Log.Error(err, "unable to list events", "namespace", pod.Namespace)
+		data["errors"] += fmt.Sprintf("unable to list events in namespace %s: %s\n", pod.Namespace, err)
+	} else {
+		events = append(events, namespaceEvents.Items...)
+	}
+
+	if pod.Spec.NodeName != "" {
+		nodeSelector := fields.Set{
+			"involvedObject.kind": "Node",
+			"involvedObject.name": pod.Spec.NodeName,
+		}.AsSelector().String()
+
+		nodeEvents, err := r.Kclient.CoreV1().Events(metav1.NamespaceAll).List(
+			ctx, metav1.ListOptions{FieldSelector: nodeSelector})
+		if err != nil {
+			Log.Error(err, "unable to list events", "node", pod.Spec.NodeName)
+			data["errors"] += fmt.Sprintf("unable to list events of node %s: %s\n", pod.Spec.NodeName, err)
+		} else {
+			events = append(events, nodeEvents.Items...)
+		}
+
+		node, err := r.Kclient.CoreV1().Nodes().Get(ctx, pod.Spec.NodeName, metav1.GetOptions{})
+		if err != nil {
+			Log.Error(err, "unable to get node", "node", pod.Spec.NodeName)
+			data["errors"] += fmt.Sprintf("unable to get node %s: %s\n", pod.Spec.NodeName, err)
+		} else {
+			data["node-conditions"] = formatNodeConditions(node.Status.Conditions)
+			snapshot.NodeConditions = getProblemNodeConditions(node.Status.Conditions)
+		}
+	}
+
+	events = filterEventsSince(events, since)
+	if len(events) > failureSnapshotMaxStoredEvents {
+		events = events[len(events)-failureSnapshotMaxStoredEvents:]
+	}
+
+	data["events"] = formatEvents(events)
+	snapshot.Events = getWarningEventsExcerpt(events)
+
+	return snapshot, data
+}
+
+func hasFailureSnapshot(status *v1beta1.CommonTestStatus, podName string) bool {
+	for _, snapshot := range status.FailureSnapshots {
+		if snapshot.PodName == podName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getEventTime returns the time of the last occurrence of the event
+func getEventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// filterEventsSince returns the events that occurred after the given time
+// sorted from the oldest to the most recent one. Events that were listed
+// more than once (e.g. node events in the namespace of the instance) are
+// returned only once.
+func filterEventsSince(events []corev1.Event, since time.Time) []corev1.Event {
+	filteredEvents := []corev1.Event{}
+	seenEvents := map[string]bool{}
+	for _, event := range events {
+		eventKey := event.Namespace + "/" + event.Name
+		if seenEvents[eventKey] || getEventTime(event).Before(since) {
+			continue
+		}
+
+		seenEvents[eventKey] = true
+		filteredEvents = append(filteredEvents, event)
+	}
+
+	sort.SliceStable(filteredEvents, func(i, j int) bool {
+		return getEventTime(filteredEvents[i]).Before(getEventTime(filteredEvents[j]))
+	})
+
+	return filteredEvents
+}
+
+func formatEvent(event corev1.Event) string {
+	return fmt.Sprintf("%s %s %s/%s %s: %s",
+		getEventTime(event).UTC().Format(time.RFC3339),
+		event.Type,
+		event.InvolvedObject.Kind,
+		event.InvolvedObject.Name,
+		event.Reason,
+		strings.TrimSpace(event.Message))
+}
+
+func formatEvents(events []corev1.Event) string {
+	var formattedEvents strings.Builder
+	for _, event := range events {
+		formattedEvents.WriteString(formatEvent(event) + "\n")
+	}
+
+	return formattedEvents.String()
+}
+
+// getWarningEventsExcerpt returns the most recent warning events formatted
+// for the status of the instance
+func getWarningEventsExcerpt(events []corev1.Event) []string {
+	excerpt := []string{}
+	for i := len(events) - 1; i >= 0 && len(excerpt) < failureSnapshotMaxEvents; i-- {
+		if events[i].Type != corev1.EventTypeWarning {
+			continue
+		}
+
+		formattedEvent := formatEvent(events[i])
+		if len(formattedEvent) > failureSnapshotMaxMessageLength {
+			formattedEvent = formattedEvent[:failureSnapshotMaxMessageLength]
+		}
+
+		excerpt = append(excerpt, formattedEvent)
+	}
+
+	return excerpt
+}
+
+func formatNodeConditions(conditions []corev1.NodeCondition) string {
+	var formattedConditions strings.Builder
+	for _, nodeCondition := range conditions {
+		formattedConditions.WriteString(fmt.Sprintf("%s=%s %s: %s\n",
+			nodeCondition.Type,
+			nodeCondition.Status,
+			nodeCondition.Reason,
+			nodeCondition.Message))
+	}
+
+	return formattedConditions.String()
+}
+
+// getProblemNodeConditions returns the node conditions that indicate a
+// problem, i.e. Ready that is not True and any other condition that is True
+// (e.g. DiskPressure)
+func getProblemNodeConditions(conditions []corev1.NodeCondition) []string {
+	problemConditions := []string{}
+	for _, nodeCondition := range conditions {
+		isReady := nodeCondition.Type == corev1.NodeReady
+		isTrue := nodeCondition.Status == corev1.ConditionTrue
+		if isReady == isTrue {
+			continue
+		}
+
+		problemConditions = append(problemConditions, fmt.Sprintf("%s=%s %s",
+			nodeCondition.Type,
+			nodeCondition.Status,
+			nodeCondition.Reason))
+	}
+
+	return problemConditions
+}
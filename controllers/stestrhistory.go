@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	stestrHistoryLabel = "stestrHistory"
+)
+
+const (
+	InfoCreatingStestrHistoryPVC = "Creating PVC %s that stores the stestr history."
+)
+
+// EnsureStestrHistoryPVCExists creates the PVC that stores the stestr history
+// when it does not exist yet. Unlike the logs PVC, the PVC is not owned by the
+// instance so that it outlives the instance and it can be used by the next
+// execution of the tests.
+func (r *Reconciler) EnsureStestrHistoryPVCExists(
+	ctx context.Context,
+	instance client.Object,
+	claimName string,
+	storageClassName string,
+	Log logr.Logger,
+) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	objectKey := client.ObjectKey{Namespace: instance.GetNamespace(), Name: claimName}
+	err := r.Client.Get(ctx, objectKey, pvc)
+	if err == nil || !k8s_errors.IsNotFound(err) {
+		return err
+	}
+
+	Log.Info(fmt.Sprintf(InfoCreatingStestrHistoryPVC, claimName))
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: instance.GetNamespace(),
+			Labels: map[string]string{
+				stestrHistoryLabel: instance.GetName(),
+				operatorNameLabel:  "test-operator",
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: k8sresource.MustParse("1Gi"),
+				},
+			},
+			StorageClassName: &storageClassName,
+		},
+	}
+
+	err = r.Client.Create(ctx, pvc)
+	if k8s_errors.IsAlreadyExists(err) {
+		return nil
+	}
+
+	return err
+}
+
+// UpdateStestrComparisons stores the comparison of the tempest run of each
+// finished test pod with the previous run in status.StestrComparisons. The
+// comparison is reported by the test container via its termination message.
+// Comparisons of the test pods that were already deleted are kept.
+func (r *Reconciler) UpdateStestrComparisons(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	stepNames []string,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	comparisons := []v1beta1.StestrComparison{}
+	for _, comparison := range status.StestrComparisons {
+		if !containsPod(podList.Items, comparison.PodName) {
+			comparisons = append(comparisons, comparison)
+		}
+	}
+
+	for _, pod := range podList.Items {
+		workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel])
+		if err != nil || isRerunPod(pod) {
+			continue
+		}
+
+		comparison := getStestrComparison(pod)
+		if comparison == nil {
+			continue
+		}
+
+		comparison.WorkflowStep = workflowStep
+		comparison.PodName = pod.Name
+		if workflowStep < len(stepNames) {
+			comparison.StepName = stepNames[workflowStep]
+		}
+
+		comparisons = append(comparisons, *comparison)
+	}
+
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		return comparisons[i].WorkflowStep < comparisons[j].WorkflowStep
+	})
+
+	status.StestrComparisons = nil
+	if len(comparisons) > 0 {
+		status.StestrComparisons = comparisons
+	}
+
+	return nil
+}
+
+// getStestrComparison returns the comparison reported by the test container
+// of a finished test pod. Nil is returned when the test container did not
+// report any comparison.
+func getStestrComparison(pod corev1.Pod) *v1beta1.StestrComparison {
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != pod.Spec.Containers[0].Name || terminated == nil {
+			continue
+		}
+
+		comparison := &v1beta1.StestrComparison{}
+		if err := json.Unmarshal([]byte(terminated.Message), comparison); err != nil || len(comparison.RunID) == 0 {
+			return nil
+		}
+
+		return comparison
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"encoding/json"
+	"time"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	logsVolumeName = "test-operator-logs"
+)
+
+// UpdateTestSummary stores the TestSummary of the instance in the
+// TestSummaryAnnotation. The summary is computed from status.Steps so that it
+// does not change when finished test pods are deleted. The change is
+// persisted together with the rest of the instance at the end of the
+// reconciliation.
+func (r *Reconciler) UpdateTestSummary(
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	workflowLength int,
+) error {
+	summary := getTestSummary(status.Steps, workflowLength)
+	encodedSummary, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	annotations := instance.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[v1beta1.TestSummaryAnnotation] = string(encodedSummary)
+	instance.SetAnnotations(annotations)
+
+	return nil
+}
+
+// getTestSummary computes the TestSummary from the state of the test pods of
+// an instance. Only finished pods contribute to the duration so that the
+// summary does not change while a pod is running.
+func getTestSummary(steps []v1beta1.TestStepStatus, workflowLength int) v1beta1.TestSummary {
+	summary := v1beta1.TestSummary{
+		Version: v1beta1.TestSummaryVersion,
+		Verdict: v1beta1.TestSummaryVerdictPending,
+		Total:   max(workflowLength, 1),
+	}
+
+	var startTime, finishTime time.Time
+	lastFinishedStep := -1
+	rerunSteps := getRerunSteps(steps)
+
+	for _, step := range steps {
+		if isSupersededStep(step, rerunSteps) {
+			// The start time of the workflow step is the start time of the
+			// test pod that ran first
+			if step.StartTime != nil && (startTime.IsZero() || step.StartTime.Time.Before(startTime)) {
+				startTime = step.StartTime.Time
+			}
+
+			continue
+		}
+
+		if step.StartTime != nil {
+			if startTime.IsZero() || step.StartTime.Time.Before(startTime) {
+				startTime = step.StartTime.Time
+			}
+		}
+
+		switch step.Phase {
+		case corev1.PodSucceeded:
+			summary.Succeeded++
+		case corev1.PodFailed:
+			summary.Failed++
+		default:
+			continue
+		}
+
+		if step.FinishTime != nil && step.FinishTime.Time.After(finishTime) {
+			finishTime = step.FinishTime.Time
+		}
+
+		if step.WorkflowStep < lastFinishedStep {
+			continue
+		}
+
+		lastFinishedStep = step.WorkflowStep
+		if step.ArtifactURL != "" {
+			summary.ArtifactURL = step.ArtifactURL
+		}
+	}
+
+	if !startTime.IsZero() && finishTime.After(startTime) {
+		summary.DurationSeconds = int64(finishTime.Sub(startTime).Seconds())
+	}
+
+	switch {
+	case len(steps) == 0:
+		summary.Verdict = v1beta1.TestSummaryVerdictPending
+	case summary.Succeeded+summary.Failed < summary.Total:
+		summary.Verdict = v1beta1.TestSummaryVerdictRunning
+	case summary.Failed > 0:
+		summary.Verdict = v1beta1.TestSummaryVerdictFailed
+	default:
+		summary.Verdict = v1beta1.TestSummaryVerdictPassed
+	}
+
+	return summary
+}
+
+// getPodArtifactURL returns the location of the logs of a test pod in the
+// form of pvc://<namespace>/<persistent volume claim name>
+func getPodArtifactURL(pod corev1.Pod) string {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == logsVolumeName && volume.PersistentVolumeClaim != nil {
+			return "pvc://" + pod.Namespace + "/" + volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+
+	return ""
+}
@@ -18,29 +18,37 @@ package controllers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/go-logr/logr"
-	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
-	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
-	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/test-operator/pkg/tempest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const (
+	ErrTempestListNotFound = "key %s of the %s config map referenced by %s was not found"
+)
+
+const (
+	InfoTestListNotFound = "Log of the test pod %s does not contain the list of the tests."
+)
+
 type TempestReconciler struct {
 	Reconciler
 }
@@ -53,336 +61,509 @@ func (r *TempestReconciler) GetLogger(ctx context.Context) logr.Logger {
 // +kubebuilder:rbac:groups=test.openstack.org,resources=tempests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=test.openstack.org,resources=tempests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=test.openstack.org,resources=tempests/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
-// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
 // +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
 
 // Reconcile - Tempest
-func (r *TempestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
-	Log := r.GetLogger(ctx)
+func (r *TempestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &tempestKind{r: r, instance: &testv1beta1.Tempest{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
 
-	// Fetch the Tempest instance
-	instance := &testv1beta1.Tempest{}
-	err := r.Client.Get(ctx, req.NamespacedName, instance)
-	if err != nil {
-		if k8s_errors.IsNotFound(err) {
-			return ctrl.Result{}, nil
-		}
-		return ctrl.Result{}, err
+// tempestKind provides the parts of the reconcile flow of Tempest that differ
+// between the test kinds (see testKind). Besides the test pods of the workflow
+// steps it creates the shards of a workflow step, the test pod that merges the
+// results of the shards and the test pod that reruns the failed tests.
+type tempestKind struct {
+	r            *TempestReconciler
+	instance     *testv1beta1.Tempest
+	stepInstance *testv1beta1.Tempest
+	nextAction   NextAction
+	step         int
+
+	// shards of the selected step, the shards are created instead of the
+	// test pod of the workflow step when createShards is set
+	shards       int
+	createShards bool
+
+	addStestrHistory bool
+	mountSSHKey      bool
+}
+
+func (k *tempestKind) Instance() testKindObject {
+	return k.instance
+}
+
+func (k *tempestKind) Conditions() []*condition.Condition {
+	return []*condition.Condition{
+		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
 	}
+}
 
-	// Create a helper
-	helper, err := helper.NewHelper(
-		instance,
-		r.Client,
-		r.Kclient,
-		r.Scheme,
-		r.Log,
-	)
-	if err != nil {
-		return ctrl.Result{}, err
+func (k *tempestKind) ReconcileDelete(ctx context.Context, helper *helper.Helper) (ctrl.Result, error) {
+	return k.r.reconcileDelete(ctx, k.instance, helper)
+}
+
+func (k *tempestKind) ServiceName() string {
+	return tempest.ServiceName
+}
+
+func (k *tempestKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
+
+func (k *tempestKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
+
+// UpdateResults stores the lists of the tests in the list-only mode and
+// compares the results of the test pods with the stestr history
+func (k *tempestKind) UpdateResults(ctx context.Context, stepNames []string) error {
+	if k.instance.Spec.ListTestsOnly {
+		if err := k.r.updateTestLists(ctx, k.instance, stepNames, k.r.GetLogger(ctx)); err != nil {
+			return err
+		}
 	}
 
-	// initialize status
-	isNewInstance := instance.Status.Conditions == nil
-	if isNewInstance {
-		instance.Status.Conditions = condition.Conditions{}
+	if k.instance.Spec.StestrHistory != nil {
+		return k.r.UpdateStestrComparisons(ctx, k.instance, &k.instance.Status, stepNames)
 	}
 
-	// Save a copy of the condtions so that we can restore the LastTransitionTime
-	// when a condition's state doesn't change.
-	savedConditions := instance.Status.Conditions.DeepCopy()
+	return nil
+}
 
-	// Always patch the instance status when exiting this function so we
-	// can persist any changes.
-	defer func() {
-		// update the overall status condition if service is ready
-		if instance.Status.Conditions.AllSubConditionIsTrue() {
-			instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
-		}
-		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
-		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
-			instance.Status.Conditions.Set(
-				instance.Status.Conditions.Mirror(condition.ReadyCondition))
-		}
-		err := helper.PatchInstance(ctx, instance)
+// NextAction creates the shards, the merge pod and the rerun pod of the last
+// workflow step before the test pod of the next workflow step
+func (k *tempestKind) NextAction(ctx context.Context, nextAction NextAction, step int) (NextAction, int, error) {
+	if nextAction == CreateNextPod || nextAction == EndTesting {
+		shardsAction, shardsStep, sharded, err := k.r.getShardsAction(ctx, k.instance)
 		if err != nil {
-			_err = err
-			return
+			return nextAction, step, err
+		} else if sharded {
+			nextAction = shardsAction
+			step = shardsStep
 		}
-	}()
-
-	if isNewInstance {
-		// Initialize conditions used later as Status=Unknown
-		cl := condition.CreateList(
-			condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
-			condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
-			condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
-			condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
-		)
-		instance.Status.Conditions.Init(&cl)
+	}
 
-		// Register overall status immediately to have an early feedback
-		// e.g. in the cli
-		return ctrl.Result{}, nil
+	if nextAction == CreateNextPod || nextAction == EndTesting {
+		rerunStep, rerun, err := k.r.getRerunWorkflowStep(ctx, k.instance)
+		if err != nil {
+			return nextAction, step, err
+		} else if rerun {
+			nextAction = CreateRerunPod
+			step = rerunStep
+		}
 	}
 
-	// If we're not deleting this and the service object doesn't have our
-	// finalizer, add it.
-	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
-		return ctrl.Result{}, nil
+	return nextAction, step, nil
+}
+
+func (k *tempestKind) ResultsParser() TestResultsParser {
+	return tempest.ParseResults
+}
+
+func (k *tempestKind) SelectStep(nextAction NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	var err error
+	k.nextAction = nextAction
+	k.step = step
+	k.stepInstance, err = getStepInstance(k.instance, mergeSpecOverride, step)
+	if err != nil {
+		return testKindStep{}, err
 	}
 
-	if instance.Status.NetworkAttachments == nil {
-		instance.Status.NetworkAttachments = map[string][]string{}
+	// The shards are created instead of the test pod of the workflow step.
+	// The test pod of the workflow step merges the results of the shards
+	// once they finish.
+	k.shards = getShards(k.stepInstance)
+	k.createShards = k.shards > 1 && (nextAction == CreateFirstPod || nextAction == CreateNextPod)
+
+	// The rerun of the failed tests and the shards are not stored in the
+	// stestr history as they would be compared with the full tempest run of
+	// the workflow step
+	k.addStestrHistory = k.instance.Spec.StestrHistory != nil && nextAction != CreateRerunPod &&
+		!k.createShards && !k.instance.Spec.ListTestsOnly
+
+	spec := k.stepInstance.Spec
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        spec.CommonOptions,
+		NetworkAttachments: spec.NetworkAttachments,
+	}
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
 	}
 
-	// Handle service delete
-	if !instance.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, instance, helper)
+	return kindStep, nil
+}
+
+// EnsureStepResources creates the stestr history PVC and the config maps read
+// by the test pod
+func (k *tempestKind) EnsureStepResources(
+	ctx context.Context,
+	helper *helper.Helper,
+	_ map[string]string,
+	_ string,
+	_ string,
+) (ctrl.Result, error) {
+	if k.addStestrHistory {
+		err := k.r.EnsureStestrHistoryPVCExists(
+			ctx,
+			k.instance,
+			tempest.GetStestrHistoryClaimName(k.instance),
+			k.stepInstance.Spec.StorageClass,
+			k.r.GetLogger(ctx),
+		)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	workflowLength := len(instance.Spec.Workflow)
-	nextAction, nextWorkflowStep, err := r.NextAction(ctx, instance, workflowLength)
+	k.mountSSHKey = false
+	if k.stepInstance.Spec.SSHKeySecretName != "" {
+		k.mountSSHKey = k.r.CheckSecretExists(ctx, k.instance, k.stepInstance.Spec.SSHKeySecretName)
+	}
 
-	switch nextAction {
-	case Failure:
+	// Generate ConfigMaps
+	err := k.r.generateServiceConfigMaps(ctx, helper, k.stepInstance, k.step)
+	if err != nil {
+		k.instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
 		return ctrl.Result{}, err
+	}
+	k.instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
+	// Generate ConfigMaps - end
 
-	case Wait:
-		Log.Info(InfoWaitingOnPod)
-		return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+	return ctrl.Result{}, nil
+}
 
-	case EndTesting:
-		// All pods created by the instance were completed. Release the lock
-		// so that other instances can spawn their pods.
-		if lockReleased, err := r.ReleaseLock(ctx, instance); !lockReleased {
-			Log.Info(fmt.Sprintf(InfoCanNotReleaseLock, testOperatorLockName))
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
+// EnvVars returns no env variables, the env variables of the test pod are
+// read from the env-vars config map
+func (k *tempestKind) EnvVars(_ string) (map[string]env.Setter, error) {
+	return map[string]env.Setter{}, nil
+}
 
-		instance.Status.Conditions.MarkTrue(
-			condition.DeploymentReadyCondition,
-			condition.DeploymentReadyMessage)
+func (k *tempestKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	_ map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	workflowStepPodName := podName
+	if k.nextAction == CreateRerunPod {
+		podName += tempest.RerunPodSuffix
+	}
 
-		Log.Info(InfoTestingCompleted)
-		return ctrl.Result{}, nil
+	podDef := tempest.Pod(
+		k.stepInstance,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		GetEnvVarsConfigMapName(k.instance, k.step),
+		GetCustomDataConfigMapName(k.instance, k.step),
+		logsPVCName,
+		mountCerts,
+		k.mountSSHKey,
+		containerImage,
+	)
 
-	case CreateFirstPod:
-		lockAcquired, err := r.AcquireLock(ctx, instance, helper, instance.Spec.Parallel)
-		if !lockAcquired {
-			Log.Info(fmt.Sprintf(InfoCanNotAcquireLock, testOperatorLockName))
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
+	if k.nextAction == CreateRerunPod {
+		tempest.AddRerunFailed(podDef, workflowStepPodName)
+	}
+
+	if k.nextAction == CreateMergePod {
+		shardArtifactDirectories := []string{}
+		for shard := 0; shard < k.shards; shard++ {
+			shardArtifactDirectories = append(shardArtifactDirectories, tempest.GetShardPodName(podName, shard))
 		}
 
-		Log.Info(fmt.Sprintf(InfoCreatingFirstPod, nextWorkflowStep))
+		tempest.AddShardMerge(podDef, shardArtifactDirectories)
+	}
+
+	tempest.AddPlugins(podDef, k.stepInstance.Spec.Plugins)
 
-	case CreateNextPod:
-		// Confirm that we still hold the lock. This is useful to check if for
-		// example somebody / something deleted the lock and it got claimed by
-		// another instance. This is considered to be an error state.
-		lockAcquired, err := r.AcquireLock(ctx, instance, helper, instance.Spec.Parallel)
-		if !lockAcquired {
-			Log.Error(err, ErrConfirmLockOwnership, testOperatorLockName)
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
+	if k.addStestrHistory {
+		stepName := ""
+		if k.step < len(k.instance.Spec.Workflow) {
+			stepName = k.instance.Spec.Workflow[k.step].StepName
 		}
 
-		Log.Info(fmt.Sprintf(InfoCreatingNextPod, nextWorkflowStep))
+		tempest.AddStestrHistory(
+			podDef,
+			tempest.GetStestrHistoryClaimName(k.instance),
+			tempest.GetStestrHistoryDirName(k.step, stepName),
+			k.instance.Spec.StestrHistory.TimingRegressionThreshold,
+		)
+	}
 
-	default:
-		return ctrl.Result{}, errors.New(ErrReceivedUnexpectedAction)
+	return podDef
+}
+
+// Pods returns the shards of the test pod when the tests of the workflow step
+// are sharded
+func (k *tempestKind) Pods(podDef *corev1.Pod) []*corev1.Pod {
+	if !k.createShards {
+		return []*corev1.Pod{podDef}
 	}
 
-	serviceLabels := map[string]string{
-		common.AppSelector: tempest.ServiceName,
-		workflowStepLabel:  strconv.Itoa(nextWorkflowStep),
-		instanceNameLabel:  instance.Name,
-		operatorNameLabel:  "test-operator",
+	return getShardPods(podDef, k.shards)
+}
+
+// getStepInstance returns a copy of the instance with the network attachments
+// of the workflow step and, when mergeSpecOverride is set, the spec override of
+// the workflow step merged over the spec
+func getStepInstance(
+	instance *testv1beta1.Tempest,
+	mergeSpecOverride bool,
+	workflowStep int,
+) (*testv1beta1.Tempest, error) {
+	var err error
+	stepInstance := instance.DeepCopy()
+	if mergeSpecOverride && workflowStep < len(instance.Spec.Workflow) {
+		stepInstance.Spec, err = MergeSpecOverride(
+			instance.Spec,
+			instance.Spec.Workflow[workflowStep].SpecOverride,
+		)
 	}
 
-	workflowStepNum := 0
-	// Create multiple PVCs for parallel execution
-	if instance.Spec.Parallel && nextWorkflowStep < len(instance.Spec.Workflow) {
-		workflowStepNum = nextWorkflowStep
+	if workflowStep < len(instance.Spec.Workflow) {
+		stepInstance.Spec.NetworkAttachments = mergeWithWorkflow(
+			stepInstance.Spec.NetworkAttachments,
+			instance.Spec.Workflow[workflowStep].NetworkAttachments,
+		)
 	}
 
-	// Create PersistentVolumeClaim
-	ctrlResult, err := r.EnsureLogsPVCExists(
-		ctx,
-		instance,
-		helper,
-		serviceLabels,
-		instance.Spec.StorageClass,
-		workflowStepNum,
-	)
+	return stepInstance, err
+}
+
+// getShards returns the number of the test pods that execute the tests of
+// the workflow step concurrently. The tests are not sharded in the list-only
+// mode.
+func getShards(stepInstance *testv1beta1.Tempest) int {
+	if stepInstance.Spec.ListTestsOnly {
+		return 1
+	}
+
+	return max(stepInstance.Spec.Shards, 1)
+}
 
+// getShardPods returns the definitions of the shards of the test pod of a
+// workflow step
+func getShardPods(podDef *corev1.Pod, shards int) []*corev1.Pod {
+	firstShardLabels := map[string]string{
+		instanceNameLabel: podDef.Labels[instanceNameLabel],
+		workflowStepLabel: podDef.Labels[workflowStepLabel],
+		runIDLabel:        podDef.Labels[runIDLabel],
+		shardLabel:        "0",
+	}
+
+	shardPods := []*corev1.Pod{}
+	for shard := 0; shard < shards; shard++ {
+		shardPod := podDef.DeepCopy()
+		shardPod.Labels[shardLabel] = strconv.Itoa(shard)
+		tempest.AddShard(shardPod, shard, shards, firstShardLabels)
+		shardPods = append(shardPods, shardPod)
+	}
+
+	return shardPods
+}
+
+// getShardsAction returns the action for the last workflow step when its
+// test pods are shards. Wait is returned while some of the shards are still
+// running and CreateMergePod once all the shards finished. CreateNextPod is
+// returned when some of the shards were not created. The returned bool is
+// false when the last test pod is not a shard, i.e. the last workflow step
+// is not sharded or its shards were already merged.
+func (r *TempestReconciler) getShardsAction(
+	ctx context.Context,
+	instance *testv1beta1.Tempest,
+) (NextAction, int, bool, error) {
+	lastPod, err := r.GetLastPod(ctx, instance)
+	if err != nil || lastPod == nil || !isShardPod(*lastPod) {
+		return Wait, 0, false, err
+	}
+
+	workflowStep, err := strconv.Atoi(lastPod.Labels[workflowStepLabel])
 	if err != nil {
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
+		return Wait, 0, false, err
 	}
-	// Create PersistentVolumeClaim - end
 
-	mountSSHKey := false
-	if instance.Spec.SSHKeySecretName != "" {
-		mountSSHKey = r.CheckSecretExists(ctx, instance, instance.Spec.SSHKeySecretName)
+	specOverrideEnabled := instance.Status.FeatureGates[testv1beta1.FeatureGateWorkflowSpecOverride]
+	stepInstance, err := getStepInstance(instance, specOverrideEnabled, workflowStep)
+	if err != nil {
+		return Wait, 0, false, err
 	}
 
-	// Generate ConfigMaps
-	err = r.generateServiceConfigMaps(ctx, helper, instance, nextWorkflowStep)
+	labels := map[string]string{
+		instanceNameLabel: instance.Name,
+		workflowStepLabel: lastPod.Labels[workflowStepLabel],
+	}
+
+	podList := &corev1.PodList{}
+	err = r.Client.List(ctx, podList, client.InNamespace(instance.Namespace),
+		client.MatchingLabels(labels), client.HasLabels{shardLabel})
 	if err != nil {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.ServiceConfigReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			condition.ServiceConfigReadyErrorMessage,
-			err.Error()))
-		return ctrl.Result{}, err
+		return Wait, 0, false, err
 	}
-	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
-	// Generate ConfigMaps - end
 
-	nadList := []networkv1.NetworkAttachmentDefinition{}
-	for _, netAtt := range instance.Spec.NetworkAttachments {
-		nad, err := nad.GetNADWithName(ctx, helper, netAtt, instance.Namespace)
-		if err != nil {
-			if k8s_errors.IsNotFound(err) {
-				Log.Info(fmt.Sprintf("network-attachment-definition %s not found", netAtt))
-				instance.Status.Conditions.Set(condition.FalseCondition(
-					condition.NetworkAttachmentsReadyCondition,
-					condition.RequestedReason,
-					condition.SeverityInfo,
-					condition.NetworkAttachmentsReadyWaitingMessage,
-					netAtt))
-				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
-			}
-			instance.Status.Conditions.Set(condition.FalseCondition(
-				condition.NetworkAttachmentsReadyCondition,
-				condition.ErrorReason,
-				condition.SeverityWarning,
-				condition.NetworkAttachmentsReadyErrorMessage,
-				err.Error()))
-			return ctrl.Result{}, err
-		}
+	if len(podList.Items) < getShards(stepInstance) {
+		return CreateNextPod, workflowStep, true, nil
+	}
 
-		if nad != nil {
-			nadList = append(nadList, *nad)
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodFailed && pod.Status.Phase != corev1.PodSucceeded {
+			return Wait, workflowStep, true, nil
 		}
 	}
 
-	serviceAnnotations, err := nad.EnsureNetworksAnnotation(nadList)
+	return CreateMergePod, workflowStep, true, nil
+}
+
+// getRerunWorkflowStep returns the workflow step whose failed tests should be
+// executed once more. It is the workflow step of the last test pod when the
+// test pod failed, it did not rerun the failed tests itself and the workflow
+// step has rerunFailed enabled.
+func (r *TempestReconciler) getRerunWorkflowStep(
+	ctx context.Context,
+	instance *testv1beta1.Tempest,
+) (int, bool, error) {
+	lastPod, err := r.GetLastPod(ctx, instance)
+	if err != nil || lastPod == nil {
+		return 0, false, err
+	}
+
+	if lastPod.Status.Phase != corev1.PodFailed || isRerunPod(*lastPod) || instance.Spec.ListTestsOnly {
+		return 0, false, nil
+	}
+
+	workflowStep, err := strconv.Atoi(lastPod.Labels[workflowStepLabel])
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
-			instance.Spec.NetworkAttachments, err)
+		return 0, false, err
 	}
 
-	// NetworkAttachments
-	if r.PodExists(ctx, instance, nextWorkflowStep) {
-		networkReady, networkAttachmentStatus, err := nad.VerifyNetworkStatusFromAnnotation(
-			ctx,
-			helper,
-			instance.Spec.NetworkAttachments,
-			serviceLabels,
-			1,
-		)
+	wtRun := testv1beta1.WorkflowTempestRunSpec{}
+	if workflowStep < len(instance.Spec.Workflow) {
+		wtRun = instance.Spec.Workflow[workflowStep].TempestRun
+	}
+
+	return workflowStep, mergeWithWorkflow(instance.Spec.TempestRun.RerunFailed, wtRun.RerunFailed), nil
+}
+
+// updateTestLists stores the list of the tests of each test pod that finished
+// in the list-only mode in a ConfigMap owned by the instance and refers to the
+// ConfigMap from status.TestLists. The list is read from the log of the test
+// pod (see tempest.ParseTestList).
+func (r *TempestReconciler) updateTestLists(
+	ctx context.Context,
+	instance *testv1beta1.Tempest,
+	stepNames []string,
+	Log logr.Logger,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodSucceeded || len(pod.Spec.Containers) == 0 ||
+			hasTestList(instance.Status, pod.Name) {
+			continue
+		}
+
+		workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel])
 		if err != nil {
-			return ctrl.Result{}, err
+			continue
 		}
 
-		instance.Status.NetworkAttachments = networkAttachmentStatus
-
-		if networkReady {
-			instance.Status.Conditions.MarkTrue(
-				condition.NetworkAttachmentsReadyCondition,
-				condition.NetworkAttachmentsReadyMessage)
-		} else {
-			err := fmt.Errorf(ErrNetworkAttachments, instance.Spec.NetworkAttachments)
-			instance.Status.Conditions.Set(condition.FalseCondition(
-				condition.NetworkAttachmentsReadyCondition,
-				condition.ErrorReason,
-				condition.SeverityWarning,
-				condition.NetworkAttachmentsReadyErrorMessage,
-				err.Error()))
+		logOptions := &corev1.PodLogOptions{Container: pod.Spec.Containers[0].Name}
+		podLog, err := r.Kclient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).DoRaw(ctx)
+		if err != nil {
+			return err
+		}
 
-			return ctrl.Result{}, err
+		tests := tempest.ParseTestList(string(podLog))
+		if tests == nil {
+			Log.Info(fmt.Sprintf(InfoTestListNotFound, pod.Name))
 		}
-	}
-	// NetworkAttachments - end
 
-	// Create a new pod
-	mountCerts := r.CheckSecretExists(ctx, instance, "combined-ca-bundle")
-	customDataConfigMapName := GetCustomDataConfigMapName(instance, nextWorkflowStep)
-	EnvVarsConfigMapName := GetEnvVarsConfigMapName(instance, nextWorkflowStep)
-	podName := r.GetPodName(instance, nextWorkflowStep)
-	logsPVCName := r.GetPVCLogsName(instance, workflowStepNum)
-	containerImage, err := r.GetContainerImage(ctx, instance.Spec.ContainerImage, instance)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
+		testList := testv1beta1.TestList{
+			WorkflowStep:  workflowStep,
+			PodName:       pod.Name,
+			Count:         len(tests),
+			ConfigMapName: pod.Name + tempest.TestListConfigMapSuffix,
+		}
 
-	// Note(lpiwowar): Remove all the workflow merge code to webhook once it is done.
-	//                 It will simplify the logic and duplicite code (Tempest vs Tobiko)
-	if nextWorkflowStep < len(instance.Spec.Workflow) {
-		if instance.Spec.Workflow[nextWorkflowStep].NodeSelector != nil {
-			instance.Spec.NodeSelector = *instance.Spec.Workflow[nextWorkflowStep].NodeSelector
+		if workflowStep < len(stepNames) {
+			testList.StepName = stepNames[workflowStep]
 		}
 
-		if instance.Spec.Workflow[nextWorkflowStep].Tolerations != nil {
-			instance.Spec.Tolerations = *instance.Spec.Workflow[nextWorkflowStep].Tolerations
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testList.ConfigMapName,
+				Namespace: instance.Namespace,
+				Labels: map[string]string{
+					instanceNameLabel: instance.Name,
+					operatorNameLabel: "test-operator",
+				},
+			},
+			Data: map[string]string{
+				tempest.TestListConfigMapKey: strings.Join(tests, "\n"),
+			},
 		}
 
-		if instance.Spec.Workflow[nextWorkflowStep].SELinuxLevel != nil {
-			instance.Spec.SELinuxLevel = *instance.Spec.Workflow[nextWorkflowStep].SELinuxLevel
+		err = controllerutil.SetControllerReference(instance, configMap, r.GetScheme())
+		if err != nil {
+			return err
 		}
 
-		if instance.Spec.Workflow[nextWorkflowStep].Resources != nil {
-			instance.Spec.Resources = *instance.Spec.Workflow[nextWorkflowStep].Resources
+		err = r.Client.Create(ctx, configMap)
+		if err != nil && !k8s_errors.IsAlreadyExists(err) {
+			return err
 		}
+
+		instance.Status.TestLists = append(instance.Status.TestLists, testList)
 	}
 
-	podDef := tempest.Pod(
-		instance,
-		serviceLabels,
-		serviceAnnotations,
-		podName,
-		EnvVarsConfigMapName,
-		customDataConfigMapName,
-		logsPVCName,
-		mountCerts,
-		mountSSHKey,
-		containerImage,
-	)
+	return nil
+}
 
-	ctrlResult, err = r.CreatePod(ctx, *helper, podDef)
-	if err != nil {
-		// Creation of the tempest pod was not successfull.
-		// Release the lock and allow other controllers to spawn
-		// a pod.
-		if lockReleased, lockErr := r.ReleaseLock(ctx, instance); lockReleased {
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, lockErr
+func hasTestList(status testv1beta1.CommonTestStatus, podName string) bool {
+	for _, testList := range status.TestLists {
+		if testList.PodName == podName {
+			return true
 		}
-
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			condition.DeploymentReadyErrorMessage,
-			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
 	}
-	// Create a new pod - end
 
-	return ctrl.Result{}, nil
+	return false
 }
 
 func (r *TempestReconciler) reconcileDelete(
@@ -454,9 +635,12 @@ func (r *TempestReconciler) setTempestConfigVars(envVars map[string]string,
 	}
 
 	// Bool
+	// tempest run does not accept --serial together with --parallel, which
+	// is enabled by default, so serial takes precedence
+	serial := mergeWithWorkflow(tRun.Serial, wtRun.Serial)
 	tempestBoolEnvVars := map[string]bool{
-		"TEMPEST_SERIAL":     mergeWithWorkflow(tRun.Serial, wtRun.Serial),
-		"TEMPEST_PARALLEL":   mergeWithWorkflow(tRun.Parallel, wtRun.Parallel),
+		"TEMPEST_SERIAL":     serial,
+		"TEMPEST_PARALLEL":   mergeWithWorkflow(tRun.Parallel, wtRun.Parallel) && !serial,
 		"TEMPEST_SMOKE":      mergeWithWorkflow(tRun.Smoke, wtRun.Smoke),
 		"USE_EXTERNAL_FILES": true,
 	}
@@ -472,7 +656,7 @@ func (r *TempestReconciler) setTempestConfigVars(envVars map[string]string,
 	// Dictionary
 	dictValue := mergeWithWorkflow(tRun.ExternalPlugin, wtRun.ExternalPlugin)
 	for _, externalPluginDictionary := range dictValue {
-		envVars["TEMPEST_EXTERNAL_PLUGIN_GIT_URL"] += externalPluginDictionary.Repository + ","
+		envVars["TEMPEST_EXTERNAL_PLUGIN_GIT_URL"] += BracketIPv6URL(externalPluginDictionary.Repository) + ","
 
 		if len(externalPluginDictionary.ChangeRepository) == 0 || len(externalPluginDictionary.ChangeRefspec) == 0 {
 			envVars["TEMPEST_EXTERNAL_PLUGIN_CHANGE_URL"] += "-,"
@@ -480,7 +664,7 @@ func (r *TempestReconciler) setTempestConfigVars(envVars map[string]string,
 			continue
 		}
 
-		envVars["TEMPEST_EXTERNAL_PLUGIN_CHANGE_URL"] += externalPluginDictionary.ChangeRepository + ","
+		envVars["TEMPEST_EXTERNAL_PLUGIN_CHANGE_URL"] += BracketIPv6URL(externalPluginDictionary.ChangeRepository) + ","
 		envVars["TEMPEST_EXTERNAL_PLUGIN_REFSPEC"] += externalPluginDictionary.ChangeRefspec + ","
 	}
 
@@ -488,7 +672,7 @@ func (r *TempestReconciler) setTempestConfigVars(envVars map[string]string,
 
 	extraImages := mergeWithWorkflow(tRun.ExtraImages, wtRun.ExtraImages)
 	for _, extraImageDict := range extraImages {
-		envVars["TEMPEST_EXTRA_IMAGES_URL"] += extraImageDict.URL + ","
+		envVars["TEMPEST_EXTRA_IMAGES_URL"] += BracketIPv6URL(extraImageDict.URL) + ","
 		envVars["TEMPEST_EXTRA_IMAGES_OS_CLOUD"] += extraImageDict.OsCloud + ","
 		envVars["TEMPEST_EXTRA_IMAGES_CONTAINER_FORMAT"] += extraImageDict.ContainerFormat + ","
 		envVars["TEMPEST_EXTRA_IMAGES_ID"] += extraImageDict.ID + ","
@@ -510,12 +694,87 @@ func (r *TempestReconciler) setTempestConfigVars(envVars map[string]string,
 	}
 }
 
-func mergeWithWorkflow[T any](value T, workflowValue *T) T {
-	if workflowValue == nil {
-		return value
+// setTempestListsFromConfigMaps replaces the include and exclude lists with
+// the content of the config maps referenced by includeListConfigMap and
+// excludeListConfigMap. An optional config map that does not exist is
+// ignored.
+func (r *TempestReconciler) setTempestListsFromConfigMaps(
+	ctx context.Context,
+	envVars map[string]string,
+	customData map[string]string,
+	instance *testv1beta1.Tempest,
+	workflowStepNum int,
+) error {
+	tRun := instance.Spec.TempestRun
+	wtRun := testv1beta1.WorkflowTempestRunSpec{}
+	if workflowStepNum < len(instance.Spec.Workflow) {
+		wtRun = instance.Spec.Workflow[workflowStepNum].TempestRun
 	}
 
-	return *workflowValue
+	testOperatorDir := "/etc/test_operator/"
+	lists := []struct {
+		field     string
+		file      string
+		envVar    string
+		configMap *corev1.ConfigMapKeySelector
+	}{
+		{
+			field:     "includeListConfigMap",
+			file:      "include.txt",
+			envVar:    "TEMPEST_INCLUDE_LIST",
+			configMap: getTempestListConfigMap(tRun.IncludeListConfigMap, wtRun.IncludeList, wtRun.IncludeListConfigMap),
+		},
+		{
+			field:     "excludeListConfigMap",
+			file:      "exclude.txt",
+			envVar:    "TEMPEST_EXCLUDE_LIST",
+			configMap: getTempestListConfigMap(tRun.ExcludeListConfigMap, wtRun.ExcludeList, wtRun.ExcludeListConfigMap),
+		},
+	}
+
+	for _, list := range lists {
+		if list.configMap == nil {
+			continue
+		}
+
+		optional := list.configMap.Optional != nil && *list.configMap.Optional
+		cm := &corev1.ConfigMap{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: list.configMap.Name}, cm)
+		if err != nil && (!k8s_errors.IsNotFound(err) || !optional) {
+			return err
+		} else if err != nil {
+			continue
+		}
+
+		value, ok := cm.Data[list.configMap.Key]
+		if !ok && optional {
+			continue
+		} else if !ok {
+			return fmt.Errorf(ErrTempestListNotFound, list.configMap.Key, list.configMap.Name, list.field)
+		}
+
+		customData[list.file] = value
+		envVars[list.envVar] = testOperatorDir + list.file
+	}
+
+	return nil
+}
+
+// getTempestListConfigMap returns the config map of the include or exclude
+// list of the workflow step. A workflow step that sets only the list itself
+// overrides the config map of the spec.
+func getTempestListConfigMap(
+	configMap *corev1.ConfigMapKeySelector,
+	workflowList *string,
+	workflowConfigMap *corev1.ConfigMapKeySelector,
+) *corev1.ConfigMapKeySelector {
+	if workflowConfigMap != nil {
+		return workflowConfigMap
+	} else if workflowList != nil {
+		return nil
+	}
+
+	return configMap
 }
 
 func (r *TempestReconciler) setTempestconfConfigVars(
@@ -524,6 +783,13 @@ func (r *TempestReconciler) setTempestconfConfigVars(
 	instance *testv1beta1.Tempest,
 	workflowStepNum int,
 ) {
+	// The tempest.conf provided in the secret is used verbatim and
+	// discover-tempest-config is not executed
+	if len(instance.Spec.TempestconfSecretName) > 0 {
+		envVars["TEMPEST_CONF_FILE"] = tempest.TempestconfSecretMountPath
+		return
+	}
+
 	tcRun := instance.Spec.TempestconfRun
 	wtcRun := testv1beta1.WorkflowTempestconfRunSpec{}
 	if workflowStepNum < len(instance.Spec.Workflow) {
@@ -545,6 +811,10 @@ func (r *TempestReconciler) setTempestconfConfigVars(
 		envVars["TEMPESTCONF_TEST_ACCOUNTS"] = testOperatorDir + accountsFile
 	}
 
+	if len(instance.Spec.AccountsSecretName) > 0 {
+		envVars["TEMPESTCONF_TEST_ACCOUNTS"] = tempest.AccountsSecretMountPath
+	}
+
 	value = mergeWithWorkflow(tcRun.Profile, wtcRun.Profile)
 	if len(value) != 0 {
 		profileFile := "profile.yaml"
@@ -605,6 +875,16 @@ func (r *TempestReconciler) setTempestconfConfigVars(
 	envVars["TEMPESTCONF_REMOVE"] = mValue
 
 	mValue = mergeWithWorkflow(tcRun.Overrides, wtcRun.Overrides)
+	if len(instance.Spec.AccountsSecretName) > 0 {
+		// Make sure that tempest does not try to create the credentials
+		// dynamically which requires admin credentials
+		mValue = strings.TrimSpace(mValue + " auth.use_dynamic_credentials false" +
+			" auth.test_accounts_file " + tempest.AccountsSecretMountPath)
+	}
+
+	if instance.Spec.Whitebox != nil {
+		mValue = strings.TrimSpace(mValue + " " + tempest.GetWhiteboxOverrides(instance.Spec.Whitebox))
+	}
 	envVars["TEMPESTCONF_OVERRIDES"] = mValue
 }
 
@@ -637,11 +917,20 @@ func (r *TempestReconciler) generateServiceConfigMaps(
 	envVars := make(map[string]string)
 
 	r.setTempestConfigVars(envVars, customData, instance, workflowStepNum)
+	if err := r.setTempestListsFromConfigMaps(ctx, envVars, customData, instance, workflowStepNum); err != nil {
+		return err
+	}
+
 	r.setTempestconfConfigVars(envVars, customData, instance, workflowStepNum)
 	r.setConfigOverwrite(customData, instance.Spec.ConfigOverwrite)
 
 	envVars["TEMPEST_DEBUG_MODE"] = r.GetDefaultBool(instance.Spec.Debug)
-	envVars["TEMPEST_CLEANUP"] = r.GetDefaultBool(instance.Spec.Cleanup)
+	cleanup := instance.Spec.Cleanup
+	if workflowStepNum < len(instance.Spec.Workflow) {
+		cleanup = mergeWithWorkflow(cleanup, instance.Spec.Workflow[workflowStepNum].Cleanup)
+	}
+	envVars["TEMPEST_CLEANUP"] = r.GetDefaultBool(cleanup)
+	envVars["TEMPEST_LIST_TESTS_ONLY"] = r.GetDefaultBool(instance.Spec.ListTestsOnly)
 
 	cms := []util.Template{
 		// ConfigMap
@@ -0,0 +1,826 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// testKindObject is an instance of a test kind reconciled by
+// reconcileTestKind
+type testKindObject interface {
+	testObject
+	common_rbac.Reconciler
+}
+
+// testKind is implemented by every test kind. The kinds share the reconcile
+// flow of reconcileTestKind and only provide the parts that differ between
+// them, i.e. the spec of a workflow step, the env variables and the test pod.
+// The optional testKind* interfaces below extend the flow for the kinds that
+// need more than that.
+type testKind interface {
+	// Instance returns the reconciled instance
+	Instance() testKindObject
+
+	// ServiceName returns the value of the app label of the test pods
+	ServiceName() string
+
+	// Options returns the common options of the spec of the instance
+	Options() v1beta1.CommonOptions
+
+	// Parallel tells whether the test pods can run in parallel with the test
+	// pods of other instances
+	Parallel() bool
+
+	// SelectStep selects the workflow step the next test pod is created for
+	// by nextAction. The spec override of the workflow step is merged into
+	// the spec when mergeSpecOverride is set.
+	SelectStep(nextAction NextAction, step int, mergeSpecOverride bool) (testKindStep, error)
+
+	// EnvVars returns the env variables of the test pod of the selected step
+	EnvVars(podName string) (map[string]env.Setter, error)
+
+	// Pod returns the test pod of the selected step
+	Pod(
+		effectiveSpec operatorutil.EffectiveSpec,
+		labels map[string]string,
+		annotations map[string]string,
+		podName string,
+		logsPVCName string,
+		mountCerts bool,
+		envVars map[string]env.Setter,
+		containerImage string,
+	) *corev1.Pod
+}
+
+// testKindConditions is implemented by the test kinds that report more
+// conditions than ReadyCondition, DeploymentReadyCondition and
+// NetworkAttachmentsReadyCondition (e.g. ServiceConfigReadyCondition of
+// Tempest)
+type testKindConditions interface {
+	Conditions() []*condition.Condition
+}
+
+// testKindFinalizer is implemented by the test kinds that add a finalizer to
+// their instances. ReconcileDelete removes the finalizer once the instance is
+// being deleted.
+type testKindFinalizer interface {
+	ReconcileDelete(ctx context.Context, helper *helper.Helper) (ctrl.Result, error)
+}
+
+// testKindResults is implemented by the test kinds that report the results of
+// the finished test pods in the status (e.g. the SLA results of RallyTest)
+type testKindResults interface {
+	UpdateResults(ctx context.Context, stepNames []string) error
+}
+
+// testKindNextAction is implemented by the test kinds that create other test
+// pods than the test pod of the next workflow step (e.g. the shards and the
+// rerun pods of Tempest). NextAction replaces the action and the workflow step
+// selected by Reconciler.NextAction.
+type testKindNextAction interface {
+	NextAction(ctx context.Context, nextAction NextAction, step int) (NextAction, int, error)
+}
+
+// testKindCreateCheck is implemented by the test kinds that check whether the
+// next test pod can be created before the test-operator-lock is acquired
+// (e.g. the disruption window of Tobiko). The test pod is not created when
+// CheckCreate returns an error or a non-empty result.
+type testKindCreateCheck interface {
+	CheckCreate(ctx context.Context, nextAction NextAction, mergeSpecOverride bool) (ctrl.Result, error)
+}
+
+// testKindResultsParser is implemented by the test kinds whose test results
+// can be read from the logs of the test pods (see EnsureTestResults)
+type testKindResultsParser interface {
+	ResultsParser() TestResultsParser
+}
+
+// testKindCleanup is implemented by the test kinds that delete the resources
+// created for the test pods once all the test pods finished (e.g. the node set
+// inventories of AnsibleTest)
+type testKindCleanup interface {
+	CleanupResources(ctx context.Context) error
+}
+
+// testKindParallelSteps is implemented by the test kinds whose workflow steps
+// can run at the same time (e.g. the browsers of HorizonTest). The test pods of
+// all the remaining workflow steps are created at once when ParallelSteps
+// returns true.
+type testKindParallelSteps interface {
+	ParallelSteps() bool
+}
+
+// testKindStepResources is implemented by the test kinds that create
+// additional resources for the test pod of the selected step (e.g. the target
+// PVC of FioTest)
+type testKindStepResources interface {
+	EnsureStepResources(
+		ctx context.Context,
+		helper *helper.Helper,
+		labels map[string]string,
+		podName string,
+		logsPVCName string,
+	) (ctrl.Result, error)
+}
+
+// testKindPods is implemented by the test kinds that split the test pod of the
+// selected step into several test pods (e.g. the shards of Tempest)
+type testKindPods interface {
+	Pods(podDef *corev1.Pod) []*corev1.Pod
+}
+
+// testKindStep is the spec of the workflow step the next test pod is created
+// for
+type testKindStep struct {
+	// Options and Resources of the spec with the spec override of the
+	// workflow step merged in
+	Options   v1beta1.CommonOptions
+	Resources corev1.ResourceRequirements
+
+	// WorkflowStep and WorkflowStepResources are nil when the instance has
+	// no workflow
+	WorkflowStep          *v1beta1.WorkflowCommonParameters
+	WorkflowStepResources *corev1.ResourceRequirements
+
+	// StepOptions and NetworkAttachments of the spec with the values
+	// overridden by the workflow step
+	StepOptions        v1beta1.CommonOptions
+	NetworkAttachments []string
+}
+
+// hasParallelSteps tells whether the test pods of all the workflow steps of
+// the kind are created at once
+func hasParallelSteps(kind testKind) bool {
+	kindParallelSteps, ok := kind.(testKindParallelSteps)
+	return ok && kindParallelSteps.ParallelSteps()
+}
+
+// reconcileTestKind reconciles an instance of a test kind. It creates the
+// test pod of every workflow step while holding the test-operator-lock and
+// reports the progress in the status of the instance.
+func (r *Reconciler) reconcileTestKind(
+	ctx context.Context,
+	req ctrl.Request,
+	kind testKind,
+	Log logr.Logger,
+) (result ctrl.Result, _err error) {
+	instance := kind.Instance()
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			r.DeleteLockWaitMetrics(instance, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	status := instance.GetCommonStatus()
+	options := kind.Options()
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		r.Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// initialize status
+	isNewInstance := status.Conditions == nil
+	if isNewInstance {
+		status.Conditions = condition.Conditions{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := status.Conditions.DeepCopy()
+
+	// Always patch the instance status when exiting this function so we
+	// can persist any changes.
+	defer func() {
+		// update the overall status condition if service is ready
+		if status.Conditions.AllSubConditionIsTrue() {
+			status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+		}
+		condition.RestoreLastTransitionTimes(&status.Conditions, savedConditions)
+		if status.Conditions.IsUnknown(condition.ReadyCondition) {
+			status.Conditions.Set(
+				status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	if isNewInstance {
+		// Initialize conditions used later as Status=Unknown
+		cl := condition.CreateList(
+			condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+			condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
+			condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
+		)
+		if kindConditions, ok := kind.(testKindConditions); ok {
+			for _, kindCondition := range kindConditions.Conditions() {
+				cl = append(cl, *kindCondition)
+			}
+		}
+		status.Conditions.Init(&cl)
+
+		// Register overall status immediately to have an early feedback
+		// e.g. in the cli
+		return ctrl.Result{}, nil
+
+	}
+
+	// If we're not deleting this and the service object doesn't have our
+	// finalizer, add it.
+	kindFinalizer, hasFinalizer := kind.(testKindFinalizer)
+	if hasFinalizer && instance.GetDeletionTimestamp().IsZero() &&
+		controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		return ctrl.Result{}, nil
+	}
+
+	if status.NetworkAttachments == nil {
+		status.NetworkAttachments = map[string][]string{}
+	}
+
+	// Handle service delete
+	if hasFinalizer && !instance.GetDeletionTimestamp().IsZero() {
+		return kindFinalizer.ReconcileDelete(ctx, helper)
+	}
+
+	r.ClearOperatorRestarting(instance, Log)
+
+	featureGates, err := r.GetFeatureGates(instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status.FeatureGates = featureGates
+
+	EnsureRunID(instance)
+	if featureGates[v1beta1.FeatureGateResourceAdoption] {
+		if err := r.AdoptResources(ctx, instance, Log); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	rerunPending, err := r.TriggerRerun(ctx, instance, status, options, Log)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if rerunPending {
+		Log.Info(InfoDeletingPreviousRunPods)
+		return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+	}
+
+	stepNames := instance.GetStepNames()
+	workflowLength := len(stepNames)
+	if options.PodRetentionPolicy == v1beta1.PodRetentionPolicyDeleteFinished {
+		if err := r.DeleteFinishedPods(ctx, instance, status, Log); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.UpdateTestStatus(ctx, instance, status, stepNames); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.StreamTestPodLogs(ctx, instance, options); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.RecordPrunedLogs(ctx, instance, status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.UploadToReportPortal(ctx, instance, helper, status, options)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.EnsureLogsServer(ctx, helper, instance, options, status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if kindResults, ok := kind.(testKindResults); ok {
+		if err := kindResults.UpdateResults(ctx, stepNames); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if featureGates[v1beta1.FeatureGateTestSummary] {
+		if err := r.UpdateTestSummary(instance, status, workflowLength); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if featureGates[v1beta1.FeatureGateFailureSnapshots] {
+		err := r.CaptureFailureSnapshots(ctx, instance, helper, status, Log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if featureGates[v1beta1.FeatureGateTimeline] {
+		if err := r.UpdateTimeline(ctx, instance, status); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.ReleaseDebugHolds(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	parallelSteps := hasParallelSteps(kind)
+	specOverrideEnabled := featureGates[v1beta1.FeatureGateWorkflowSpecOverride]
+	nextAction, nextWorkflowStep, err := r.NextAction(ctx, instance, workflowLength)
+	if kindNextAction, ok := kind.(testKindNextAction); ok && nextAction != Failure {
+		nextAction, nextWorkflowStep, err = kindNextAction.NextAction(ctx, nextAction, nextWorkflowStep)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if nextAction == EndTesting && parallelSteps {
+		// The test pod of the last workflow step does not have to be the
+		// last one to finish
+		finished, err := r.AllTestPodsFinished(ctx, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if !finished {
+			nextAction = Wait
+		}
+	}
+
+	if kindCreateCheck, ok := kind.(testKindCreateCheck); ok &&
+		(nextAction == CreateFirstPod || nextAction == CreateNextPod) {
+		ctrlResult, err := kindCreateCheck.CheckCreate(ctx, nextAction, specOverrideEnabled)
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
+	}
+
+	switch nextAction {
+	case Failure:
+		return ctrl.Result{}, err
+
+	case Wait:
+		Log.Info(InfoWaitingOnPod)
+		return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+
+	case EndTesting:
+		if featureGates[v1beta1.FeatureGateTestResults] {
+			var parseLog TestResultsParser
+			if kindResultsParser, ok := kind.(testKindResultsParser); ok {
+				parseLog = kindResultsParser.ResultsParser()
+			}
+
+			err := r.EnsureTestResults(ctx, instance, status, stepNames, workflowLength, parseLog)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		err := r.RecordTestRun(ctx, instance, status, options, workflowLength)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		reportGenerated, err := r.GenerateReport(ctx, instance, helper, status, options)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if !reportGenerated {
+			Log.Info(InfoGeneratingReport)
+			return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+		}
+
+		polarionExported, err := r.ExportToPolarion(ctx, instance, helper, status, options)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if !polarionExported {
+			Log.Info(InfoExportingToPolarion)
+			return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+		}
+
+		// All pods created by the instance were completed. Verify that the
+		// tests did not leave any resources behind while still holding the lock.
+		cleanupVerified, err := r.VerifyCleanup(
+			ctx,
+			instance,
+			helper,
+			status,
+			options.CleanupAssertions,
+			options.ContainerImage,
+			options.ImagePullSecrets,
+			options.ImagePullPolicy,
+			r.GetMountedCABundle(ctx, instance, options),
+		)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if !cleanupVerified {
+			Log.Info(InfoVerifyingCleanup)
+			return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+		}
+
+		if kindCleanup, ok := kind.(testKindCleanup); ok {
+			if err := kindCleanup.CleanupResources(ctx); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Release the lock so that other instances can spawn their pods.
+		if lockReleased, err := r.ReleaseLock(ctx, instance); !lockReleased {
+			Log.Info(fmt.Sprintf(InfoCanNotReleaseLock, testOperatorLockName))
+			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
+		}
+
+		status.Conditions.MarkTrue(
+			condition.DeploymentReadyCondition,
+			condition.DeploymentReadyMessage)
+
+		Log.Info(InfoTestingCompleted)
+		return ctrl.Result{}, nil
+
+	case CreateFirstPod:
+		lockAcquired, err := r.AcquireLock(ctx, instance, helper, kind.Parallel())
+		r.UpdateLockWaitStatus(instance, status, lockAcquired)
+		if !lockAcquired {
+			Log.Info(fmt.Sprintf(InfoCanNotAcquireLock, testOperatorLockName))
+			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
+		}
+
+		preflightDone, err := r.RunEndpointPreflight(
+			ctx,
+			instance,
+			helper,
+			status,
+			options.EndpointPreflight,
+			options.ContainerImage,
+			options.ImagePullSecrets,
+			options.ImagePullPolicy,
+			r.GetMountedCABundle(ctx, instance, options),
+		)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if !preflightDone {
+			Log.Info(InfoRunningEndpointPreflight)
+			return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+		}
+
+		Log.Info(fmt.Sprintf(InfoCreatingFirstPod, nextWorkflowStep))
+
+	case CreateNextPod, CreateRerunPod, CreateMergePod:
+		// Confirm that we still hold the lock. This needs to be checked in order
+		// to prevent situation when somebody / something deleted the lock and it
+		// got claimedy by another instance.
+		lockAcquired, err := r.AcquireLock(ctx, instance, helper, kind.Parallel())
+		if !lockAcquired {
+			Log.Error(err, ErrConfirmLockOwnership, testOperatorLockName)
+			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
+		}
+
+		if nextAction == CreateRerunPod {
+			Log.Info(fmt.Sprintf(InfoCreatingRerunPod, nextWorkflowStep))
+		} else if nextAction == CreateMergePod {
+			Log.Info(fmt.Sprintf(InfoCreatingMergePod, nextWorkflowStep))
+		} else {
+			Log.Info(fmt.Sprintf(InfoCreatingNextPod, nextWorkflowStep))
+		}
+
+	default:
+		return ctrl.Result{}, errors.New(ErrReceivedUnexpectedAction)
+	}
+
+	// The test pods of all the remaining workflow steps are created at once
+	// when the workflow steps run in parallel
+	workflowSteps := []int{nextWorkflowStep}
+	if parallelSteps {
+		workflowSteps = []int{}
+		for step := nextWorkflowStep; step < max(workflowLength, 1); step++ {
+			workflowSteps = append(workflowSteps, step)
+		}
+	}
+
+	for _, workflowStep := range workflowSteps {
+		ctrlResult, err := r.reconcileTestKindPod(
+			ctx, helper, kind, featureGates, nextAction, workflowStep, workflowLength, Log)
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
+	}
+
+	Log.Info("Reconciled Service successfully")
+	return ctrl.Result{}, nil
+}
+
+// reconcileTestKindPod creates the test pod of the workflow step selected by
+// nextAction together with the resources the test pod needs
+func (r *Reconciler) reconcileTestKindPod(
+	ctx context.Context,
+	helper *helper.Helper,
+	kind testKind,
+	featureGates map[string]bool,
+	nextAction NextAction,
+	nextWorkflowStep int,
+	workflowLength int,
+	Log logr.Logger,
+) (ctrl.Result, error) {
+	instance := kind.Instance()
+	status := instance.GetCommonStatus()
+	options := kind.Options()
+
+	// Merge the spec override of the workflow step over the spec. The
+	// resources of the workflow step are generated from the merged spec
+	// while the status is reported via instance.
+	specOverrideEnabled := featureGates[v1beta1.FeatureGateWorkflowSpecOverride]
+	step, err := kind.SelectStep(nextAction, nextWorkflowStep, specOverrideEnabled)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	serviceLabels := map[string]string{
+		common.AppSelector: kind.ServiceName(),
+		workflowStepLabel:  strconv.Itoa(nextWorkflowStep),
+		instanceNameLabel:  instance.GetName(),
+		runIDLabel:         GetRunID(instance),
+		operatorNameLabel:  "test-operator",
+	}
+
+	if nextAction == CreateRerunPod {
+		serviceLabels[rerunLabel] = "true"
+	}
+
+	workflowStepNum := 0
+
+	// Create multiple PVCs for parallel execution or when requested
+	logsPVCPerStep := kind.Parallel() || hasParallelSteps(kind) || options.LogsPVCPerStep
+	if logsPVCPerStep && nextWorkflowStep < workflowLength {
+		workflowStepNum = nextWorkflowStep
+	}
+
+	// Create PersistentVolumeClaim
+	ctrlResult, err := r.EnsureLogsPVCExists(
+		ctx,
+		instance,
+		helper,
+		serviceLabels,
+		step.Options.StorageClass,
+		step.Options.Persistence,
+		workflowStepNum,
+	)
+	if err != nil {
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+	// Create PersistentVolumeClaim - end
+
+	networkSelections, err := GetNetworkSelections(step.Options, step.WorkflowStep, instance.GetNamespace())
+	if err != nil {
+		status.Conditions.Set(condition.FalseCondition(
+			condition.NetworkAttachmentsReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.NetworkAttachmentsReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	networkAttachments := GetNetworkAttachmentNames(step.NetworkAttachments, networkSelections)
+	nadList := []networkv1.NetworkAttachmentDefinition{}
+	for _, netAtt := range networkAttachments {
+		nad, err := nad.GetNADWithName(ctx, helper, netAtt, instance.GetNamespace())
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				Log.Info(fmt.Sprintf("network-attachment-definition %s not found", netAtt))
+				status.Conditions.Set(condition.FalseCondition(
+					condition.NetworkAttachmentsReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					condition.NetworkAttachmentsReadyWaitingMessage,
+					netAtt))
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		if nad != nil {
+			nadList = append(nadList, *nad)
+		}
+	}
+
+	if err := VerifyNetworkAttachmentIPFamilies(nadList, step.StepOptions.NetworkAttachmentIPFamilies); err != nil {
+		status.Conditions.Set(condition.FalseCondition(
+			condition.NetworkAttachmentsReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.NetworkAttachmentsReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	serviceAnnotations, err := nad.EnsureNetworksAnnotation(nadList)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
+			networkAttachments, err)
+	}
+
+	serviceAnnotations, err = MergeNetworkSelections(serviceAnnotations, networkSelections)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// NetworkAttachments
+	if r.PodExists(ctx, instance, nextWorkflowStep) {
+		networkReady, networkAttachmentStatus, err := nad.VerifyNetworkStatusFromAnnotation(
+			ctx,
+			helper,
+			networkAttachments,
+			serviceLabels,
+			1,
+		)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		networkAttachmentStatus, ipFamiliesReady := SelectNetworkAttachmentIPFamilies(
+			networkAttachmentStatus,
+			instance.GetNamespace(),
+			step.StepOptions.NetworkAttachmentIPFamilies,
+		)
+		status.NetworkAttachments = networkAttachmentStatus
+
+		if networkReady && ipFamiliesReady {
+			status.Conditions.MarkTrue(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.NetworkAttachmentsReadyMessage)
+		} else {
+			err := fmt.Errorf(ErrNetworkAttachments, networkAttachments)
+			status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+
+			return ctrl.Result{}, err
+		}
+	}
+	// NetworkAttachments - end
+
+	podName := r.GetPodName(instance, nextWorkflowStep)
+	logsPVCName := r.GetPVCLogsName(instance, workflowStepNum)
+
+	if kindStepResources, ok := kind.(testKindStepResources); ok {
+		ctrlResult, err := kindStepResources.EnsureStepResources(ctx, helper, serviceLabels, podName, logsPVCName)
+		if err != nil {
+			return ctrlResult, err
+		} else if (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, nil
+		}
+	}
+
+	// Create a new pod
+	mountCerts := len(r.GetMountedCABundle(ctx, instance, step.StepOptions)) > 0
+	envVars, err := kind.EnvVars(podName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	containerImage, err := r.GetContainerImage(ctx, step.StepOptions.ContainerImage, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	containerImage, err = r.PinContainerImage(
+		ctx, instance, status, step.StepOptions, containerImage, Log)
+	if err != nil {
+		status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+
+		// Do not block other instances while the image can not be pinned
+		if _, lockErr := r.ReleaseLock(ctx, instance); lockErr != nil {
+			return ctrl.Result{}, lockErr
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	effectiveSpec := GetEffectiveSpec(
+		step.Options,
+		step.Resources,
+		step.WorkflowStep,
+		step.WorkflowStepResources,
+	)
+
+	securityContextConstraint := GetSecurityContextConstraint(step.Options, step.WorkflowStep, step.StepOptions.Privileged)
+	ctrlResult, err = r.EnsureServiceAccount(ctx, helper, instance, securityContextConstraint)
+	if err != nil {
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+
+	proxy, err := r.GetProxy(ctx, step.StepOptions)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	podDef := kind.Pod(
+		effectiveSpec,
+		serviceLabels,
+		serviceAnnotations,
+		podName,
+		logsPVCName,
+		mountCerts,
+		envVars,
+		containerImage,
+	)
+
+	ApplyCABundleSources(podDef, step.StepOptions.CABundleSources)
+	ApplyIOLimits(podDef, GetIOLimits(step.Options, step.WorkflowStep), status)
+	ApplyNetworkResources(podDef, nadList)
+	ApplyLogRetention(podDef, step.Options.LogRetention, status, logsPVCName)
+	ApplyLogsRotation(podDef, step.Options.LogsRotation, status, logsPVCName)
+	err = r.ApplyLogForwarding(ctx, helper, instance, podDef, step.Options, logsPVCName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ApplyLogsPersistence(podDef, step.Options.Persistence, step.Options.StorageClass, logsPVCName)
+	ApplyProxy(podDef, proxy)
+	ApplySecurityContextConstraint(podDef, instance.RbacResourceName(), securityContextConstraint)
+	AddPodMetadata(podDef, step.StepOptions)
+	AddDebugHold(podDef, step.StepOptions)
+
+	podDefs := []*corev1.Pod{podDef}
+	if kindPods, ok := kind.(testKindPods); ok {
+		podDefs = kindPods.Pods(podDef)
+	}
+
+	for _, podDef := range podDefs {
+		ctrlResult, err = r.CreateTestPod(
+			ctx, *helper, podDef, getPodTemplateOverrides(step.StepOptions.PodTemplateOverrides, featureGates))
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			break
+		}
+	}
+
+	if err != nil {
+		// Creation of the test pod was not successful. Release the lock and
+		// allow other instances to spawn their pods.
+		if _, lockErr := r.ReleaseLock(ctx, instance); lockErr != nil {
+			return ctrl.Result{}, lockErr
+		}
+
+		status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.DeploymentReadyRunningMessage))
+		return ctrlResult, nil
+	}
+	// Create a new pod - end
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	testResultsConfigMapInfix = "-results-"
+)
+
+// TestResultsParser returns the numbers of the tests and the names of the
+// failed tests printed in the log of a test pod
+type TestResultsParser func(log string) (*v1beta1.TestCounts, []string)
+
+// GetTestResultsConfigMapName returns the name of the ConfigMap that contains
+// the TestResults of the run of the instance
+func GetTestResultsConfigMapName(instance client.Object) string {
+	return instance.GetName() + testResultsConfigMapInfix + GetRunID(instance)
+}
+
+// EnsureTestResults stores the TestResults of the finished run of the
+// instance in a ConfigMap owned by the instance and refers to the ConfigMap
+// from status.ResultsConfigMap. The numbers of the tests are taken from the
+// results reported in the status (RobotTest, CustomTest) or from the log of
+// the test pods using parseLog. The ConfigMap is created only once per run.
+func (r *Reconciler) EnsureTestResults(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	stepNames []string,
+	workflowLength int,
+	parseLog TestResultsParser,
+) error {
+	configMapName := GetTestResultsConfigMapName(instance)
+	if status.ResultsConfigMap == configMapName {
+		return nil
+	}
+
+	instanceGVK, err := apiutil.GVKForObject(instance, r.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	results := v1beta1.TestResults{
+		Version:   v1beta1.TestResultsVersion,
+		Kind:      instanceGVK.Kind,
+		Name:      instance.GetName(),
+		Namespace: instance.GetNamespace(),
+		RunID:     GetRunID(instance),
+		Summary:   getTestSummary(status.Steps, workflowLength),
+		Steps:     []v1beta1.TestStepResults{},
+	}
+
+	for _, step := range status.Steps {
+		stepResults := v1beta1.TestStepResults{
+			WorkflowStep: step.WorkflowStep,
+			StepName:     step.StepName,
+			PodName:      step.PodName,
+			Phase:        step.Phase,
+			Rerun:        step.Rerun,
+			Shard:        step.Shard,
+			StartTime:    step.StartTime,
+			FinishTime:   step.FinishTime,
+			ExitCode:     step.ExitCode,
+			ArtifactURL:  step.ArtifactURL,
+			Counts:       getReportedTestCounts(status, step.PodName),
+		}
+
+		if stepResults.StepName == "" && step.WorkflowStep < len(stepNames) {
+			stepResults.StepName = stepNames[step.WorkflowStep]
+		}
+
+		if step.StartTime != nil && step.FinishTime != nil {
+			stepResults.DurationSeconds = int64(step.FinishTime.Sub(step.StartTime.Time).Seconds())
+		}
+
+		if parseLog != nil && step.FinishTime != nil {
+			counts, failedTests, err := r.parseTestPodLog(ctx, instance, step.PodName, parseLog)
+			if err != nil {
+				return err
+			}
+
+			if counts != nil {
+				stepResults.Counts = counts
+			}
+
+			if len(failedTests) > v1beta1.MaxTestResultsFailedTests {
+				failedTests = failedTests[:v1beta1.MaxTestResultsFailedTests]
+				stepResults.FailedTestsTruncated = true
+			}
+
+			if len(failedTests) > 0 {
+				stepResults.FailedTests = failedTests
+			}
+		}
+
+		results.Steps = append(results.Steps, stepResults)
+	}
+
+	results.Counts = getTotalTestCounts(results.Steps)
+
+	encodedResults, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName,
+			Namespace: instance.GetNamespace(),
+			Labels: map[string]string{
+				instanceNameLabel: instance.GetName(),
+				runIDLabel:        GetRunID(instance),
+				operatorNameLabel: "test-operator",
+			},
+		},
+		Data: map[string]string{
+			v1beta1.TestResultsConfigMapKey: string(encodedResults),
+		},
+	}
+
+	err = controllerutil.SetControllerReference(instance, configMap, r.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	err = r.Client.Create(ctx, configMap)
+	if err != nil && !k8s_errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	status.ResultsConfigMap = configMapName
+	return nil
+}
+
+// parseTestPodLog parses the log of the test container of a test pod using
+// parseLog. Nothing is returned when the test pod no longer exists.
+func (r *Reconciler) parseTestPodLog(
+	ctx context.Context,
+	instance client.Object,
+	podName string,
+	parseLog TestResultsParser,
+) (*v1beta1.TestCounts, []string, error) {
+	pod := &corev1.Pod{}
+	objectKey := client.ObjectKey{Namespace: instance.GetNamespace(), Name: podName}
+	err := r.Client.Get(ctx, objectKey, pod)
+	if k8s_errors.IsNotFound(err) || (err == nil && len(pod.Spec.Containers) == 0) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	logOptions := &corev1.PodLogOptions{Container: pod.Spec.Containers[0].Name}
+	podLog, err := r.Kclient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).DoRaw(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts, failedTests := parseLog(string(podLog))
+	return counts, failedTests, nil
+}
+
+// getReportedTestCounts returns the numbers of the tests reported in the
+// status for the test pod or nil when the test pod did not report any
+func getReportedTestCounts(status *v1beta1.CommonTestStatus, podName string) *v1beta1.TestCounts {
+	for _, result := range status.RobotResults {
+		if result.PodName == podName {
+			return &v1beta1.TestCounts{
+				Total:   result.Total,
+				Passed:  result.Passed,
+				Failed:  result.Failed,
+				Skipped: result.Skipped,
+			}
+		}
+	}
+
+	for _, result := range status.CustomTestResults {
+		if result.PodName == podName {
+			return &v1beta1.TestCounts{
+				Total:   result.Total,
+				Passed:  result.Passed,
+				Failed:  result.Failed,
+				Skipped: result.Skipped,
+			}
+		}
+	}
+
+	return nil
+}
+
+// getTotalTestCounts sums the numbers of the tests of the test pods whose
+// results are final (see isSupersededStep). The numbers of a rerun pod are
+// combined with the numbers of the test pod whose failed tests it executed
+// once more. Nil is returned when no such test pod reported the numbers.
+func getTotalTestCounts(steps []v1beta1.TestStepResults) *v1beta1.TestCounts {
+	rerunSteps := map[int]bool{}
+	originalCounts := map[int]*v1beta1.TestCounts{}
+	for _, step := range steps {
+		if step.Rerun {
+			rerunSteps[step.WorkflowStep] = true
+		} else if step.Shard == nil {
+			originalCounts[step.WorkflowStep] = step.Counts
+		}
+	}
+
+	var total *v1beta1.TestCounts
+	for _, step := range steps {
+		if step.Counts == nil || step.Shard != nil || (!step.Rerun && rerunSteps[step.WorkflowStep]) {
+			continue
+		}
+
+		counts := *step.Counts
+		if original := originalCounts[step.WorkflowStep]; step.Rerun && original != nil {
+			// The rerun pod executed only the failed tests of the original
+			// test pod
+			counts = v1beta1.TestCounts{
+				Total:   original.Total,
+				Passed:  original.Passed + step.Counts.Passed,
+				Failed:  step.Counts.Failed,
+				Skipped: original.Skipped + step.Counts.Skipped,
+			}
+		}
+
+		if total == nil {
+			total = &v1beta1.TestCounts{}
+		}
+
+		total.Total += counts.Total
+		total.Passed += counts.Passed
+		total.Failed += counts.Failed
+		total.Skipped += counts.Skipped
+	}
+
+	return total
+}
@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	testKindLabel = "testKind"
+)
+
+// GetTestRunName returns the name of the TestRun that records the run of the
+// instance
+func GetTestRunName(instance client.Object, kind string) string {
+	return instance.GetName() + "-" + strings.ToLower(kind) + "-" + GetRunID(instance)
+}
+
+// RecordTestRun creates the TestRun that records the finished run of the
+// instance and deletes the oldest TestRuns of the instance that exceed
+// spec.historyLimit. Like the stestr history PVC, the TestRun is not owned by
+// the instance so that the history of the runs outlives the instance. The
+// TestRun is created only once per run.
+func (r *Reconciler) RecordTestRun(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	options v1beta1.CommonOptions,
+	workflowLength int,
+) error {
+	historyLimit := int32(v1beta1.DefaultTestRunHistoryLimit)
+	if options.HistoryLimit != nil {
+		historyLimit = *options.HistoryLimit
+	}
+
+	instanceGVK, err := apiutil.GVKForObject(instance, r.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	if historyLimit > 0 {
+		err = r.createTestRun(ctx, instance, instanceGVK.Kind, status, workflowLength)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.pruneTestRuns(ctx, instance, instanceGVK.Kind, int(historyLimit))
+}
+
+// createTestRun creates the TestRun of the run of the instance unless it
+// already exists
+func (r *Reconciler) createTestRun(
+	ctx context.Context,
+	instance client.Object,
+	kind string,
+	status *v1beta1.CommonTestStatus,
+	workflowLength int,
+) error {
+	testRun := &v1beta1.TestRun{}
+	testRunName := GetTestRunName(instance, kind)
+	objectKey := client.ObjectKey{Namespace: instance.GetNamespace(), Name: testRunName}
+	err := r.Client.Get(ctx, objectKey, testRun)
+	if err == nil || !k8s_errors.IsNotFound(err) {
+		return err
+	}
+
+	testSpec, err := getTestSpec(instance)
+	if err != nil {
+		return err
+	}
+
+	images, err := r.getTestPodImages(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	testRun = &v1beta1.TestRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testRunName,
+			Namespace: instance.GetNamespace(),
+			Labels: map[string]string{
+				instanceNameLabel: instance.GetName(),
+				testKindLabel:     kind,
+				runIDLabel:        GetRunID(instance),
+				operatorNameLabel: "test-operator",
+			},
+		},
+		Spec: v1beta1.TestRunSpec{
+			TestKind:         kind,
+			TestName:         instance.GetName(),
+			TestUID:          string(instance.GetUID()),
+			RunID:            GetRunID(instance),
+			TestSpec:         testSpec,
+			Images:           images,
+			ImageDigests:     status.ImageDigests,
+			Summary:          getTestSummary(status.Steps, workflowLength),
+			Steps:            status.Steps,
+			ResultsConfigMap: status.ResultsConfigMap,
+		},
+	}
+
+	for _, step := range status.Steps {
+		if step.StartTime != nil && (testRun.Spec.StartTime == nil || step.StartTime.Before(testRun.Spec.StartTime)) {
+			testRun.Spec.StartTime = step.StartTime.DeepCopy()
+		}
+
+		if step.FinishTime != nil && (testRun.Spec.FinishTime == nil || testRun.Spec.FinishTime.Before(step.FinishTime)) {
+			testRun.Spec.FinishTime = step.FinishTime.DeepCopy()
+		}
+	}
+
+	err = r.Client.Create(ctx, testRun)
+	if k8s_errors.IsAlreadyExists(err) {
+		return nil
+	}
+
+	return err
+}
+
+// pruneTestRuns deletes the oldest TestRuns of the instances of the kind with
+// the name of the instance so that at most historyLimit TestRuns are kept
+func (r *Reconciler) pruneTestRuns(
+	ctx context.Context,
+	instance client.Object,
+	kind string,
+	historyLimit int,
+) error {
+	labels := map[string]string{
+		instanceNameLabel: instance.GetName(),
+		testKindLabel:     kind,
+	}
+
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	testRunList := &v1beta1.TestRunList{}
+	if err := r.Client.List(ctx, testRunList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	if len(testRunList.Items) <= historyLimit {
+		return nil
+	}
+
+	testRuns := testRunList.Items
+	sort.SliceStable(testRuns, func(i, j int) bool {
+		return testRuns[i].CreationTimestamp.Before(&testRuns[j].CreationTimestamp)
+	})
+
+	for i := range testRuns[:len(testRuns)-historyLimit] {
+		err := r.Client.Delete(ctx, &testRuns[i])
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getTestSpec returns the spec of the instance
+func getTestSpec(instance client.Object) (runtime.RawExtension, error) {
+	unstructuredInstance, err := runtime.DefaultUnstructuredConverter.ToUnstructured(instance)
+	if err != nil {
+		return runtime.RawExtension{}, err
+	}
+
+	testSpec, err := json.Marshal(unstructuredInstance["spec"])
+	if err != nil {
+		return runtime.RawExtension{}, err
+	}
+
+	return runtime.RawExtension{Raw: testSpec}, nil
+}
+
+// getTestPodImages returns the container images of the test pods of the
+// instance indexed by the name of the pod
+func (r *Reconciler) getTestPodImages(
+	ctx context.Context,
+	instance client.Object,
+) (map[string]string, error) {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return nil, err
+	}
+
+	images := map[string]string{}
+	for _, pod := range podList.Items {
+		if len(pod.Spec.Containers) > 0 {
+			images[pod.Name] = pod.Spec.Containers[0].Image
+		}
+	}
+
+	return images, nil
+}
@@ -0,0 +1,290 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/test-operator/pkg/ansibletest"
+	"github.com/openstack-k8s-operators/test-operator/pkg/debughold"
+	"github.com/openstack-k8s-operators/test-operator/pkg/horizontest"
+	"github.com/openstack-k8s-operators/test-operator/pkg/tempest"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpdateTestStatus computes status.Phase and status.Steps from the test pods
+// spawned by the instance. The stepNames contain the names of the workflow
+// steps of the instance and are empty when the instance has no workflow.
+func (r *Reconciler) UpdateTestStatus(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+	stepNames []string,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	// Keep the state of the test pods that were already deleted (see
+	// DeleteFinishedPods)
+	steps := []v1beta1.TestStepStatus{}
+	for _, step := range status.Steps {
+		if !containsPod(podList.Items, step.PodName) {
+			steps = append(steps, step)
+		}
+	}
+
+	for _, pod := range podList.Items {
+		workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel])
+		if err != nil {
+			continue
+		}
+
+		step := v1beta1.TestStepStatus{
+			WorkflowStep:      workflowStep,
+			PodName:           pod.Name,
+			Phase:             pod.Status.Phase,
+			StartTime:         pod.Status.StartTime,
+			FinishTime:        getPodFinishTime(pod),
+			ArtifactURL:       getPodArtifactURL(pod),
+			ArtifactDirectory: pod.Annotations[operatorutil.ArtifactDirectoryAnnotation],
+			GitCommit:         getPodGitCommit(pod),
+			AraURL:            pod.Annotations[ansibletest.AraURLAnnotation],
+			Rerun:             isRerunPod(pod),
+			Shard:             getPodShard(pod),
+			Plugins:           getPodPlugins(pod),
+			FailureCaptures:   getPodFailureCaptures(pod),
+		}
+
+		if terminated := getPodTerminatedState(pod); terminated != nil {
+			step.ExitCode = &terminated.ExitCode
+			step.Reason = terminated.Reason
+		}
+
+		if workflowStep < len(stepNames) {
+			step.StepName = stepNames[workflowStep]
+		}
+
+		steps = append(steps, step)
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		if steps[i].WorkflowStep != steps[j].WorkflowStep {
+			return steps[i].WorkflowStep < steps[j].WorkflowStep
+		}
+
+		if precedence := getStepPrecedence(steps[i]) - getStepPrecedence(steps[j]); precedence != 0 {
+			return precedence < 0
+		}
+
+		return steps[i].Shard != nil && steps[j].Shard != nil && *steps[i].Shard < *steps[j].Shard
+	})
+
+	status.Steps = steps
+	status.Phase = getTestPhase(status, steps, max(len(stepNames), 1))
+
+	return nil
+}
+
+// getRerunSteps returns the workflow steps whose failed tests were executed
+// once more by a rerun pod
+func getRerunSteps(steps []v1beta1.TestStepStatus) map[int]bool {
+	rerunSteps := map[int]bool{}
+	for _, step := range steps {
+		if step.Rerun {
+			rerunSteps[step.WorkflowStep] = true
+		}
+	}
+
+	return rerunSteps
+}
+
+// isSupersededStep returns true for the step of a test pod whose failed tests
+// were executed once more and for the shards of a workflow step. The result of
+// such workflow step is decided by the rerun pod or by the pod that merges the
+// results of the shards.
+func isSupersededStep(step v1beta1.TestStepStatus, rerunSteps map[int]bool) bool {
+	return step.Shard != nil || (!step.Rerun && rerunSteps[step.WorkflowStep])
+}
+
+// getStepPrecedence orders the steps of the same workflow step the same way
+// as getPodPrecedence orders the test pods
+func getStepPrecedence(step v1beta1.TestStepStatus) int {
+	switch {
+	case step.Rerun:
+		return 2
+	case step.Shard != nil:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// getPodShard returns the index of the shard executed by the test pod or nil
+// when the test pod is not a shard
+func getPodShard(pod corev1.Pod) *int {
+	shard, err := strconv.Atoi(pod.Labels[shardLabel])
+	if err != nil {
+		return nil
+	}
+
+	return &shard
+}
+
+// getTestPhase returns the phase of an instance that is expected to spawn
+// totalSteps test pods
+func getTestPhase(
+	status *v1beta1.CommonTestStatus,
+	steps []v1beta1.TestStepStatus,
+	totalSteps int,
+) v1beta1.TestPhase {
+	if len(steps) == 0 {
+		if status.LockWaitStartTime != nil {
+			return v1beta1.TestPhaseQueued
+		}
+
+		return v1beta1.TestPhasePending
+	}
+
+	rerunSteps := getRerunSteps(steps)
+	finishedSteps := 0
+	failed := false
+	for _, step := range steps {
+		if isSupersededStep(step, rerunSteps) {
+			continue
+		}
+
+		switch step.Phase {
+		case corev1.PodSucceeded:
+			finishedSteps++
+		case corev1.PodFailed:
+			finishedSteps++
+			failed = true
+		}
+	}
+
+	switch {
+	case finishedSteps < totalSteps:
+		return v1beta1.TestPhaseRunning
+	case failed:
+		return v1beta1.TestPhaseFailed
+	default:
+		return v1beta1.TestPhaseSucceeded
+	}
+}
+
+// getPodTerminatedState returns the state of the container of a finished pod
+// that terminated last
+func getPodTerminatedState(pod corev1.Pod) *corev1.ContainerStateTerminated {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return nil
+	}
+
+	var lastTerminated *corev1.ContainerStateTerminated
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		// The debug-hold container terminates once the hold is released
+		if containerStatus.Name == debughold.ContainerName {
+			continue
+		}
+
+		terminated := containerStatus.State.Terminated
+		if terminated != nil && (lastTerminated == nil || lastTerminated.FinishedAt.Before(&terminated.FinishedAt)) {
+			lastTerminated = terminated.DeepCopy()
+		}
+	}
+
+	return lastTerminated
+}
+
+// getPodGitCommit returns the commit SHA that the git checkout init container
+// of an AnsibleTest pod wrote to its termination message
+func getPodGitCommit(pod corev1.Pod) string {
+	for _, containerStatus := range pod.Status.InitContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != ansibletest.GitCheckoutContainerName || terminated == nil {
+			continue
+		}
+
+		if terminated.ExitCode == 0 {
+			return strings.TrimSpace(terminated.Message)
+		}
+	}
+
+	return ""
+}
+
+// getPodPlugins returns the packages installed together with the tempest
+// plugins as reported by the init container that installed them
+func getPodPlugins(pod corev1.Pod) []string {
+	for _, containerStatus := range pod.Status.InitContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != tempest.PluginsContainerName || terminated == nil {
+			continue
+		}
+
+		if terminated.ExitCode != 0 {
+			return nil
+		}
+
+		plugins := []string{}
+		for _, line := range strings.Split(terminated.Message, "\n") {
+			if line = strings.TrimSpace(line); len(line) > 0 {
+				plugins = append(plugins, line)
+			}
+		}
+
+		return plugins
+	}
+
+	return nil
+}
+
+// getPodFailureCaptures returns the files captured on test failures as
+// reported by the test container of a HorizonTest pod. The container writes
+// one file per line to its termination message.
+func getPodFailureCaptures(pod corev1.Pod) []string {
+	if pod.Labels[common.AppSelector] != horizontest.ServiceName || len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if containerStatus.Name != pod.Spec.Containers[0].Name || terminated == nil {
+			continue
+		}
+
+		captures := []string{}
+		for _, line := range strings.Split(terminated.Message, "\n") {
+			if line = strings.TrimSpace(line); len(line) > 0 {
+				captures = append(captures, line)
+			}
+		}
+
+		if len(captures) == 0 {
+			return nil
+		}
+
+		return captures
+	}
+
+	return nil
+}
+
+func containsPod(pods []corev1.Pod, podName string) bool {
+	for _, pod := range pods {
+		if pod.Name == podName {
+			return true
+		}
+	}
+
+	return false
+}
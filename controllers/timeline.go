@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpdateTimeline records the start and the finish times of the test pods of
+// the instance in status.Timeline. The entries of the waits for the
+// test-operator-lock are recorded by UpdateLockWaitStatus.
+func (r *Reconciler) UpdateTimeline(
+	ctx context.Context,
+	instance client.Object,
+	status *v1beta1.CommonTestStatus,
+) error {
+	labels := map[string]string{instanceNameLabel: instance.GetName()}
+	namespaceListOpt := client.InNamespace(instance.GetNamespace())
+	labelsListOpt := client.MatchingLabels(labels)
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, namespaceListOpt, labelsListOpt); err != nil {
+		return err
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.StartTime == nil {
+			continue
+		}
+
+		entry := v1beta1.TimelineEntry{
+			Type:       v1beta1.TimelineEntryTypeStep,
+			Name:       pod.Name,
+			StartTime:  *pod.Status.StartTime,
+			FinishTime: getPodFinishTime(pod),
+		}
+
+		if workflowStep, err := strconv.Atoi(pod.Labels[workflowStepLabel]); err == nil {
+			entry.WorkflowStep = &workflowStep
+		}
+
+		setTimelineEntry(status, entry)
+	}
+
+	return nil
+}
+
+// recordQueueWait records the wait of the instance for the
+// test-operator-lock in status.Timeline. The entry is left open (without the
+// finish time) until the lock is acquired.
+func recordQueueWait(status *v1beta1.CommonTestStatus, lockAcquired bool) {
+	if !status.FeatureGates[v1beta1.FeatureGateTimeline] || status.LockWaitStartTime == nil {
+		return
+	}
+
+	entry := v1beta1.TimelineEntry{
+		Type:      v1beta1.TimelineEntryTypeQueueWait,
+		Name:      testOperatorLockName,
+		StartTime: *status.LockWaitStartTime,
+	}
+
+	if lockAcquired {
+		now := metav1.Now()
+		entry.FinishTime = &now
+	}
+
+	setTimelineEntry(status, entry)
+}
+
+// setTimelineEntry adds the entry to status.Timeline or replaces the entry of
+// the same type and name that started at the same time
+func setTimelineEntry(status *v1beta1.CommonTestStatus, entry v1beta1.TimelineEntry) {
+	for i := range status.Timeline {
+		existing := &status.Timeline[i]
+		if existing.Type == entry.Type && existing.Name == entry.Name &&
+			existing.StartTime.Equal(&entry.StartTime) {
+			*existing = entry
+			return
+		}
+	}
+
+	status.Timeline = append(status.Timeline, entry)
+	sort.SliceStable(status.Timeline, func(i, j int) bool {
+		return status.Timeline[i].StartTime.Before(&status.Timeline[j].StartTime)
+	})
+}
+
+// getPodFinishTime returns the time when the last container of a finished
+// pod terminated
+func getPodFinishTime(pod corev1.Pod) *metav1.Time {
+	if terminated := getPodTerminatedState(pod); terminated != nil {
+		return &terminated.FinishedAt
+	}
+
+	return nil
+}
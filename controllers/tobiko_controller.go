@@ -18,24 +18,21 @@ package controllers
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
-	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
-	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
-	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/test-operator/pkg/tobiko"
+	operatorutil "github.com/openstack-k8s-operators/test-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
-	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -53,340 +50,188 @@ func (r *TobikoReconciler) GetLogger(ctx context.Context) logr.Logger {
 // +kubebuilder:rbac:groups=test.openstack.org,resources=tobikoes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=test.openstack.org,resources=tobikoes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=test.openstack.org,resources=tobikoes/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=test.openstack.org,resources=testruns,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
-// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged;nonroot;nonroot-v2;restricted-v2,resources=securitycontextconstraints,verbs=use
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
 // +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create;update;watch;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list;create;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
 
 // Reconcile - Tobiko
-func (r *TobikoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
-	Log := r.GetLogger(ctx)
-
-	instance := &testv1beta1.Tobiko{}
-	err := r.Client.Get(ctx, req.NamespacedName, instance)
-	if err != nil {
-		if k8s_errors.IsNotFound(err) {
-			return ctrl.Result{}, nil
-		}
-		return ctrl.Result{}, err
-	}
-
-	helper, err := helper.NewHelper(
-		instance,
-		r.Client,
-		r.Kclient,
-		r.Scheme,
-		r.Log,
-	)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// initialize status
-	isNewInstance := instance.Status.Conditions == nil
-	if isNewInstance {
-		instance.Status.Conditions = condition.Conditions{}
-	}
-
-	// Save a copy of the condtions so that we can restore the LastTransitionTime
-	// when a condition's state doesn't change.
-	savedConditions := instance.Status.Conditions.DeepCopy()
-
-	// Always patch the instance status when exiting this function so we
-	// can persist any changes.
-	defer func() {
-		// update the overall status condition if service is ready
-		if instance.Status.Conditions.AllSubConditionIsTrue() {
-			instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
-		}
-		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
-		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
-			instance.Status.Conditions.Set(
-				instance.Status.Conditions.Mirror(condition.ReadyCondition))
-		}
-		err := helper.PatchInstance(ctx, instance)
-		if err != nil {
-			_err = err
-			return
-		}
-	}()
-
-	if isNewInstance {
-		// Initialize conditions used later as Status=Unknown
-		cl := condition.CreateList(
-			condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
-			condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
-			condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
-		)
-		instance.Status.Conditions.Init(&cl)
-
-		// Register overall status immediately to have an early feedback
-		// e.g. in the cli
-		return ctrl.Result{}, nil
-
-	}
+func (r *TobikoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	kind := &tobikoKind{r: r, instance: &testv1beta1.Tobiko{}}
+	return r.reconcileTestKind(ctx, req, kind, r.GetLogger(ctx))
+}
 
-	if instance.Status.NetworkAttachments == nil {
-		instance.Status.NetworkAttachments = map[string][]string{}
-	}
+// SetupWithManager sets up the controller with the Manager.
+func (r *TobikoReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&testv1beta1.Tobiko{}).
+		Owns(&corev1.Pod{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
 
-	workflowLength := len(instance.Spec.Workflow)
-	nextAction, nextWorkflowStep, err := r.NextAction(ctx, instance, workflowLength)
+// tobikoKind provides the parts of the reconcile flow of Tobiko that differ
+// between the test kinds (see testKind)
+type tobikoKind struct {
+	r            *TobikoReconciler
+	instance     *testv1beta1.Tobiko
+	stepInstance *testv1beta1.Tobiko
+	step         int
+	privileged   bool
+}
 
-	switch nextAction {
-	case Failure:
-		return ctrl.Result{}, err
+func (k *tobikoKind) Instance() testKindObject {
+	return k.instance
+}
 
-	case Wait:
-		Log.Info(InfoWaitingOnPod)
-		return ctrl.Result{RequeueAfter: RequeueAfterValue}, nil
+func (k *tobikoKind) ServiceName() string {
+	return tobiko.ServiceName
+}
 
-	case EndTesting:
-		// All pods created by the instance were completed. Release the lock
-		// so that other instances can spawn their pods.
-		if lockReleased, err := r.ReleaseLock(ctx, instance); !lockReleased {
-			Log.Info(fmt.Sprintf(InfoCanNotReleaseLock, testOperatorLockName))
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
+func (k *tobikoKind) Options() testv1beta1.CommonOptions {
+	return k.instance.Spec.CommonOptions
+}
 
-		instance.Status.Conditions.MarkTrue(
-			condition.DeploymentReadyCondition,
-			condition.DeploymentReadyMessage)
+func (k *tobikoKind) Parallel() bool {
+	return k.instance.Spec.Parallel
+}
 
-		Log.Info(InfoTestingCompleted)
+// CheckCreate postpones the creation of the test pods until the disruption
+// window opens
+func (k *tobikoKind) CheckCreate(ctx context.Context, _ NextAction, _ bool) (ctrl.Result, error) {
+	wait, opensAt := getDisruptionWindowWait(k.instance.Spec.DisruptionWindow, time.Now())
+	if wait <= 0 {
 		return ctrl.Result{}, nil
-
-	case CreateFirstPod:
-		lockAcquired, err := r.AcquireLock(ctx, instance, helper, instance.Spec.Parallel)
-		if !lockAcquired {
-			Log.Info(fmt.Sprintf(InfoCanNotAcquireLock, testOperatorLockName))
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
-
-		Log.Info(fmt.Sprintf(InfoCreatingFirstPod, nextWorkflowStep))
-
-	case CreateNextPod:
-		// Confirm that we still hold the lock. This needs to be checked in order
-		// to prevent situation when somebody / something deleted the lock and it
-		// got claimedy by another instance.
-		lockAcquired, err := r.AcquireLock(ctx, instance, helper, instance.Spec.Parallel)
-		if !lockAcquired {
-			Log.Error(err, ErrConfirmLockOwnership, testOperatorLockName)
-			return ctrl.Result{RequeueAfter: RequeueAfterValue}, err
-		}
-
-		Log.Info(fmt.Sprintf(InfoCreatingNextPod, nextWorkflowStep))
-
-	default:
-		return ctrl.Result{}, errors.New(ErrReceivedUnexpectedAction)
-	}
-
-	serviceLabels := map[string]string{
-		common.AppSelector: tobiko.ServiceName,
-		workflowStepLabel:  strconv.Itoa(nextWorkflowStep),
-		instanceNameLabel:  instance.Name,
-		operatorNameLabel:  "test-operator",
-	}
-
-	yamlResult, err := EnsureCloudsConfigMapExists(ctx, instance, helper, serviceLabels)
-
-	if err != nil {
-		return yamlResult, err
 	}
 
-	workflowStepNum := 0
+	k.instance.Status.Conditions.Set(condition.FalseCondition(
+		condition.DeploymentReadyCondition,
+		testv1beta1.WaitingForWindowReason,
+		condition.SeverityInfo,
+		testv1beta1.DeploymentReadyWaitingForWindowMessage,
+		opensAt.Format(time.RFC3339)))
 
-	// Create multiple PVCs for parallel execution
-	if instance.Spec.Parallel && nextWorkflowStep < len(instance.Spec.Workflow) {
-		workflowStepNum = nextWorkflowStep
-	}
-
-	// Create PersistentVolumeClaim
-	ctrlResult, err := r.EnsureLogsPVCExists(
-		ctx,
-		instance,
-		helper,
-		serviceLabels,
-		instance.Spec.StorageClass,
-		workflowStepNum,
-	)
-	if err != nil {
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		return ctrlResult, nil
-	}
-	// Create PersistentVolumeClaim - end
+	k.r.GetLogger(ctx).Info(fmt.Sprintf(InfoWaitingForDisruptionWindow, opensAt.Format(time.RFC3339)))
+	return ctrl.Result{RequeueAfter: wait}, nil
+}
 
-	nadList := []networkv1.NetworkAttachmentDefinition{}
-	for _, netAtt := range instance.Spec.NetworkAttachments {
-		nad, err := nad.GetNADWithName(ctx, helper, netAtt, instance.Namespace)
+func (k *tobikoKind) SelectStep(_ NextAction, step int, mergeSpecOverride bool) (testKindStep, error) {
+	k.step = step
+	k.stepInstance = k.instance.DeepCopy()
+	if mergeSpecOverride && step < len(k.instance.Spec.Workflow) {
+		var err error
+		k.stepInstance.Spec, err = MergeSpecOverride(
+			k.instance.Spec,
+			k.instance.Spec.Workflow[step].SpecOverride,
+		)
 		if err != nil {
-			if k8s_errors.IsNotFound(err) {
-				Log.Info(fmt.Sprintf("network-attachment-definition %s not found", netAtt))
-				instance.Status.Conditions.Set(condition.FalseCondition(
-					condition.NetworkAttachmentsReadyCondition,
-					condition.RequestedReason,
-					condition.SeverityInfo,
-					condition.NetworkAttachmentsReadyWaitingMessage,
-					netAtt))
-				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
-			}
-			instance.Status.Conditions.Set(condition.FalseCondition(
-				condition.NetworkAttachmentsReadyCondition,
-				condition.ErrorReason,
-				condition.SeverityWarning,
-				condition.NetworkAttachmentsReadyErrorMessage,
-				err.Error()))
-			return ctrl.Result{}, err
-		}
-
-		if nad != nil {
-			nadList = append(nadList, *nad)
+			return testKindStep{}, err
 		}
 	}
 
-	serviceAnnotations, err := nad.EnsureNetworksAnnotation(nadList)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
-			instance.Spec.NetworkAttachments, err)
+	// The network attachments of the workflow step replace the network
+	// attachments of the spec
+	if step < len(k.instance.Spec.Workflow) {
+		if networkAttachments := k.instance.Spec.Workflow[step].NetworkAttachments; len(networkAttachments) > 0 {
+			k.stepInstance.Spec.NetworkAttachments = networkAttachments
+		}
 	}
 
-	// NetworkAttachments
-	if r.PodExists(ctx, instance, nextWorkflowStep) {
-		networkReady, networkAttachmentStatus, err := nad.VerifyNetworkStatusFromAnnotation(
-			ctx,
-			helper,
-			instance.Spec.NetworkAttachments,
-			serviceLabels,
-			1,
-		)
-		if err != nil {
-			return ctrl.Result{}, err
-		}
+	spec := k.stepInstance.Spec
+	k.privileged = k.r.OverwriteValueWithWorkflow(spec, "Privileged", "pbool", step).(bool)
 
-		instance.Status.NetworkAttachments = networkAttachmentStatus
-
-		if networkReady {
-			instance.Status.Conditions.MarkTrue(
-				condition.NetworkAttachmentsReadyCondition,
-				condition.NetworkAttachmentsReadyMessage)
-		} else {
-			err := fmt.Errorf(ErrNetworkAttachments, instance.Spec.NetworkAttachments)
-			instance.Status.Conditions.Set(condition.FalseCondition(
-				condition.NetworkAttachmentsReadyCondition,
-				condition.ErrorReason,
-				condition.SeverityWarning,
-				condition.NetworkAttachmentsReadyErrorMessage,
-				err.Error()))
-
-			return ctrl.Result{}, err
-		}
+	kindStep := testKindStep{
+		Options:            spec.CommonOptions,
+		Resources:          spec.Resources,
+		StepOptions:        spec.CommonOptions,
+		NetworkAttachments: spec.NetworkAttachments,
+	}
+	kindStep.StepOptions.Privileged = k.privileged
+	if step < len(spec.Workflow) {
+		kindStep.WorkflowStep = &spec.Workflow[step].WorkflowCommonParameters
+		kindStep.WorkflowStepResources = spec.Workflow[step].Resources
 	}
-	// NetworkAttachments - end
 
-	// Create Job
-	mountCerts := r.CheckSecretExists(ctx, instance, "combined-ca-bundle")
+	return kindStep, nil
+}
 
-	mountKeys := false
-	if (len(instance.Spec.PublicKey) == 0) || (len(instance.Spec.PrivateKey) == 0) {
-		Log.Info("Both values privateKey and publicKey need to be specified. Keys not mounted.")
-	} else {
-		mountKeys = true
+// EnsureStepResources creates the config maps with the clouds.yaml, the
+// tobiko.conf and the keys read by the test pod
+func (k *tobikoKind) EnsureStepResources(
+	ctx context.Context,
+	helper *helper.Helper,
+	labels map[string]string,
+	_ string,
+	_ string,
+) (ctrl.Result, error) {
+	ctrlResult, err := EnsureCloudsConfigMapExists(ctx, k.instance, helper, labels)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
 	}
 
-	mountKubeconfig := false
-	if len(instance.Spec.KubeconfigSecretName) != 0 {
-		mountKubeconfig = true
+	if !k.mountKeys() {
+		k.r.GetLogger(ctx).Info("Both values privateKey and publicKey need to be specified. Keys not mounted.")
 	}
 
-	// Prepare Tobiko env vars
-	envVars := r.PrepareTobikoEnvVars(ctx, serviceLabels, instance, helper, nextWorkflowStep)
-	podName := r.GetPodName(instance, nextWorkflowStep)
-	logsPVCName := r.GetPVCLogsName(instance, workflowStepNum)
-	containerImage, err := r.GetContainerImage(ctx, instance.Spec.ContainerImage, instance)
-	privileged := r.OverwriteValueWithWorkflow(instance.Spec, "Privileged", "pbool", nextWorkflowStep).(bool)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
+	return ctrl.Result{}, k.r.ensureTobikoConfigMaps(ctx, helper, labels, k.stepInstance, k.step)
+}
+
+func (k *tobikoKind) EnvVars(_ string) (map[string]env.Setter, error) {
+	return k.r.PrepareTobikoEnvVars(k.stepInstance, k.step), nil
+}
 
-	podDef := tobiko.Pod(
-		instance,
-		serviceLabels,
-		serviceAnnotations,
+func (k *tobikoKind) Pod(
+	effectiveSpec operatorutil.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+) *corev1.Pod {
+	return tobiko.Pod(
+		k.stepInstance,
+		effectiveSpec,
+		labels,
+		annotations,
 		podName,
 		logsPVCName,
 		mountCerts,
-		mountKeys,
-		mountKubeconfig,
+		k.mountKeys(),
+		len(k.stepInstance.Spec.KubeconfigSecretName) != 0,
 		envVars,
 		containerImage,
-		privileged,
+		k.privileged,
 	)
-
-	ctrlResult, err = r.CreatePod(ctx, *helper, podDef)
-	if err != nil {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			condition.DeploymentReadyErrorMessage,
-			err.Error()))
-		return ctrlResult, err
-	} else if (ctrlResult != ctrl.Result{}) {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.DeploymentReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.DeploymentReadyRunningMessage))
-		return ctrlResult, nil
-	}
-	// create Job - end
-	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *TobikoReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&testv1beta1.Tobiko{}).
-		Owns(&corev1.Pod{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.ConfigMap{}).
-		Complete(r)
+// mountKeys tells whether the keys are mounted to the test pod. Both of the
+// keys have to be specified.
+func (k *tobikoKind) mountKeys() bool {
+	return len(k.stepInstance.Spec.PublicKey) != 0 && len(k.stepInstance.Spec.PrivateKey) != 0
 }
 
 // This function prepares env variables for a single workflow step.
 func (r *TobikoReconciler) PrepareTobikoEnvVars(
-	ctx context.Context,
-	labels map[string]string,
 	instance *testv1beta1.Tobiko,
-	helper *helper.Helper,
 	step int,
 ) map[string]env.Setter {
 
-	// NOTE(lpiwowar): Move all the merge code to the webhook once it is completed.
-	//                 It will clean up the workflow code and remove the duplicit code
-	//                 (Tempest vs Tobiko)
-	if step < len(instance.Spec.Workflow) {
-		if instance.Spec.Workflow[step].NodeSelector != nil {
-			instance.Spec.NodeSelector = *instance.Spec.Workflow[step].NodeSelector
-		}
-
-		if instance.Spec.Workflow[step].Tolerations != nil {
-			instance.Spec.Tolerations = *instance.Spec.Workflow[step].Tolerations
-		}
-
-		if instance.Spec.Workflow[step].SELinuxLevel != nil {
-			instance.Spec.SELinuxLevel = *instance.Spec.Workflow[step].SELinuxLevel
-		}
-
-		if instance.Spec.Workflow[step].Resources != nil {
-			instance.Spec.Resources = *instance.Spec.Workflow[step].Resources
-		}
-	}
-
 	// Prepare env vars
 	envVars := make(map[string]env.Setter)
 	envVars["USE_EXTERNAL_FILES"] = env.SetValue("True")
@@ -399,7 +244,10 @@ func (r *TobikoReconciler) PrepareTobikoEnvVars(
 	envVars["TOBIKO_VERSION"] = env.SetValue(version)
 
 	pytestAddopts := r.OverwriteValueWithWorkflow(instance.Spec, "PytestAddopts", "string", step).(string)
-	envVars["TOBIKO_PYTEST_ADDOPTS"] = env.SetValue(pytestAddopts)
+	pytestMarkers := r.OverwriteValueWithWorkflow(instance.Spec, "PytestMarkers", "string", step).(string)
+	testPattern := r.OverwriteValueWithWorkflow(instance.Spec, "TestPattern", "string", step).(string)
+	selectionArgs := tobiko.GetPytestSelectionArgs(pytestMarkers, testPattern)
+	envVars["TOBIKO_PYTEST_ADDOPTS"] = env.SetValue(strings.TrimSpace(pytestAddopts + " " + selectionArgs))
 
 	preventCreate := r.OverwriteValueWithWorkflow(instance.Spec, "PreventCreate", "pbool", step).(bool)
 	if preventCreate {
@@ -413,9 +261,19 @@ func (r *TobikoReconciler) PrepareTobikoEnvVars(
 
 	envVars["TOBIKO_KEYS_FOLDER"] = env.SetValue("/etc/test_operator")
 	envVars["TOBIKO_DEBUG_MODE"] = env.SetValue(r.GetDefaultBool(instance.Spec.Debug))
-	// Prepare env vars - end
 
-	// Prepare custom data
+	return envVars
+}
+
+// ensureTobikoConfigMaps creates the config maps with the tobiko.conf and the
+// keys of a single workflow step
+func (r *TobikoReconciler) ensureTobikoConfigMaps(
+	ctx context.Context,
+	helper *helper.Helper,
+	labels map[string]string,
+	instance *testv1beta1.Tobiko,
+	step int,
+) error {
 	customData := make(map[string]string)
 	tobikoConf := r.OverwriteValueWithWorkflow(instance.Spec, "Config", "string", step).(string)
 	customData["tobiko.conf"] = tobikoConf
@@ -452,10 +310,5 @@ func (r *TobikoReconciler) PrepareTobikoEnvVars(
 		},
 	}
 
-	err := configmap.EnsureConfigMaps(ctx, helper, instance, cms, nil)
-	if err != nil {
-		return map[string]env.Setter{}
-	}
-
-	return envVars
+	return configmap.EnsureConfigMaps(ctx, helper, instance, cms, nil)
 }
@@ -9,3 +9,20 @@ oc delete validatingwebhookconfiguration/vhorizontest.kb.io --ignore-not-found
 oc delete mutatingwebhookconfiguration/mhorizontest.kb.io --ignore-not-found
 oc delete validatingwebhookconfiguration/vansibletest.kb.io --ignore-not-found
 oc delete mutatingwebhookconfiguration/mansibletest.kb.io --ignore-not-found
+oc delete validatingwebhookconfiguration/vrallytest.kb.io --ignore-not-found
+oc delete mutatingwebhookconfiguration/mrallytest.kb.io --ignore-not-found
+oc delete validatingwebhookconfiguration/vshakertest.kb.io --ignore-not-found
+oc delete mutatingwebhookconfiguration/mshakertest.kb.io --ignore-not-found
+oc delete validatingwebhookconfiguration/vfiotest.kb.io --ignore-not-found
+oc delete mutatingwebhookconfiguration/mfiotest.kb.io --ignore-not-found
+oc delete validatingwebhookconfiguration/vk6test.kb.io --ignore-not-found
+oc delete mutatingwebhookconfiguration/mk6test.kb.io --ignore-not-found
+oc delete validatingwebhookconfiguration/vrobottest.kb.io --ignore-not-found
+oc delete mutatingwebhookconfiguration/mrobottest.kb.io --ignore-not-found
+oc delete validatingwebhookconfiguration/vcustomtest.kb.io --ignore-not-found
+oc delete mutatingwebhookconfiguration/mcustomtest.kb.io --ignore-not-found
+
+for CRD_NAME in ansibletests horizontests tempests tobikoes ; do
+    oc patch crd/${CRD_NAME}.test.openstack.org --type json \
+        -p '[{"op": "replace", "path": "/spec/conversion", "value": {"strategy": "None"}}]' || true
+done
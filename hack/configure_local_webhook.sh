@@ -100,7 +100,16 @@ webhooks:
 EOF_CAT
 }
 
-RESOURCE_TYPES=(tempest tobiko ansibletest horizontest)
+configure_local_conversion_webhook() {
+    CRD_NAME=$1
+
+    # Point the conversion webhook of the CRD at the locally running operator
+    oc patch crd/${CRD_NAME} --type merge -p "{\"spec\":{\"conversion\":{\"strategy\":\"Webhook\",\"webhook\":{\"conversionReviewVersions\":[\"v1\"],\"clientConfig\":{\"caBundle\":\"${CA_BUNDLE}\",\"url\":\"https://${CRC_IP}:9443/convert\"}}}}}"
+}
+
+CONVERSION_CRDS=(ansibletests.test.openstack.org horizontests.test.openstack.org tempests.test.openstack.org tobikoes.test.openstack.org)
+
+RESOURCE_TYPES=(tempest tobiko ansibletest horizontest rallytest shakertest fiotest k6test robottest customtest)
 
 if [ $# -eq 0 ]; then
     for RESOURCE_TYPE in "${RESOURCE_TYPES[@]}" ; do
@@ -115,3 +124,7 @@ else
 fi
 
 oc apply -n openstack -f ${TMPDIR}/patch_webhook_configurations.yaml
+
+for CRD_NAME in "${CONVERSION_CRDS[@]}" ; do
+    configure_local_conversion_webhook $CRD_NAME
+done
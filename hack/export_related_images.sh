@@ -4,3 +4,11 @@ export RELATED_IMAGE_TEST_TEMPEST_IMAGE_URL_DEFAULT=quay.io/podified-antelope-ce
 export RELATED_IMAGE_TEST_TOBIKO_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-tobiko:current-podified
 export RELATED_IMAGE_TEST_ANSIBLETEST_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-ansible-tests:current-podified
 export RELATED_IMAGE_TEST_HORIZONTEST_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-horizontest:current-podified
+export RELATED_IMAGE_TEST_RALLYTEST_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-rally:current-podified
+export RELATED_IMAGE_TEST_SHAKERTEST_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-shaker:current-podified
+export RELATED_IMAGE_TEST_FIOTEST_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-fio:current-podified
+export RELATED_IMAGE_TEST_K6TEST_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-k6:current-podified
+export RELATED_IMAGE_TEST_ROBOTTEST_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-robot:current-podified
+export RELATED_IMAGE_TEST_LOG_FORWARDER_IMAGE_URL_DEFAULT=cr.fluentbit.io/fluent/fluent-bit:3.2
+export RELATED_IMAGE_TEST_LOGS_SERVER_IMAGE_URL_DEFAULT=docker.io/nginxinc/nginx-unprivileged:stable-alpine
+export RELATED_IMAGE_TEST_REPORT_IMAGE_URL_DEFAULT=quay.io/podified-antelope-centos9/openstack-tempest-all:current-podified
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"os"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -39,6 +40,7 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	testv1 "github.com/openstack-k8s-operators/test-operator/api/v1"
 	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/test-operator/controllers"
 	//+kubebuilder:scaffold:imports
@@ -52,6 +54,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(testv1beta1.AddToScheme(scheme))
+	utilruntime.Must(testv1.AddToScheme(scheme))
 	utilruntime.Must(networkv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -61,9 +64,18 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var enableHTTP2 bool
+	var lockStarvationThreshold time.Duration
+	var gracefulShutdownTimeout time.Duration
+	var featureGatesValue string
 	flag.BoolVar(&enableHTTP2, "enable-http2", enableHTTP2, "If HTTP/2 should be enabled for the metrics and webhook servers.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.DurationVar(&lockStarvationThreshold, "lock-starvation-threshold", controllers.DefaultLockStarvationThreshold,
+		"How long an instance can wait for the test-operator-lock before it is marked as starved.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", time.Minute*2,
+		"How long to wait for in-flight reconciles to finish when the operator is shutting down.")
+	flag.StringVar(&featureGatesValue, "feature-gates", "",
+		"A comma separated list of <name>=<true|false> pairs that enable or disable test-operator features.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -92,9 +104,10 @@ func main() {
 				Port:    9443,
 				TLSOpts: []func(config *tls.Config){disableHTTP2},
 			}),
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "6cce095b.openstack.org",
+		HealthProbeBindAddress:  probeAddr,
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        "6cce095b.openstack.org",
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -124,10 +137,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	featureGates, err := testv1beta1.ParseFeatureGates(featureGatesValue)
+	if err != nil {
+		setupLog.Error(err, "unable to parse feature gates")
+		os.Exit(1)
+	}
+
+	logStreamer := controllers.NewLogStreamer(kclient, ctrl.Log.WithName("testpods"))
+
 	tempestReconciler := &controllers.TempestReconciler{}
 	tempestReconciler.Client = mgr.GetClient()
 	tempestReconciler.Scheme = mgr.GetScheme()
 	tempestReconciler.Kclient = kclient
+	tempestReconciler.LockStarvationThreshold = lockStarvationThreshold
+	tempestReconciler.FeatureGates = featureGates
+	tempestReconciler.LogStreamer = logStreamer
+	tempestReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
 	if err = tempestReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Tempest")
 		os.Exit(1)
@@ -137,6 +162,10 @@ func main() {
 	tobikoReconciler.Client = mgr.GetClient()
 	tobikoReconciler.Scheme = mgr.GetScheme()
 	tobikoReconciler.Kclient = kclient
+	tobikoReconciler.LockStarvationThreshold = lockStarvationThreshold
+	tobikoReconciler.FeatureGates = featureGates
+	tobikoReconciler.LogStreamer = logStreamer
+	tobikoReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
 	if err = tobikoReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Tobiko")
 		os.Exit(1)
@@ -146,6 +175,10 @@ func main() {
 	ansibleReconciler.Client = mgr.GetClient()
 	ansibleReconciler.Scheme = mgr.GetScheme()
 	ansibleReconciler.Kclient = kclient
+	ansibleReconciler.LockStarvationThreshold = lockStarvationThreshold
+	ansibleReconciler.FeatureGates = featureGates
+	ansibleReconciler.LogStreamer = logStreamer
+	ansibleReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
 	if err = ansibleReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AnsibleTest")
 		os.Exit(1)
@@ -155,11 +188,102 @@ func main() {
 	horizontestReconciler.Client = mgr.GetClient()
 	horizontestReconciler.Scheme = mgr.GetScheme()
 	horizontestReconciler.Kclient = kclient
+	horizontestReconciler.LockStarvationThreshold = lockStarvationThreshold
+	horizontestReconciler.FeatureGates = featureGates
+	horizontestReconciler.LogStreamer = logStreamer
+	horizontestReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
 	if err = horizontestReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HorizonTest")
 		os.Exit(1)
 	}
 
+	rallytestReconciler := &controllers.RallyTestReconciler{}
+	rallytestReconciler.Client = mgr.GetClient()
+	rallytestReconciler.Scheme = mgr.GetScheme()
+	rallytestReconciler.Kclient = kclient
+	rallytestReconciler.LockStarvationThreshold = lockStarvationThreshold
+	rallytestReconciler.FeatureGates = featureGates
+	rallytestReconciler.LogStreamer = logStreamer
+	rallytestReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
+	if err = rallytestReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RallyTest")
+		os.Exit(1)
+	}
+
+	shakertestReconciler := &controllers.ShakerTestReconciler{}
+	shakertestReconciler.Client = mgr.GetClient()
+	shakertestReconciler.Scheme = mgr.GetScheme()
+	shakertestReconciler.Kclient = kclient
+	shakertestReconciler.LockStarvationThreshold = lockStarvationThreshold
+	shakertestReconciler.FeatureGates = featureGates
+	shakertestReconciler.LogStreamer = logStreamer
+	shakertestReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
+	if err = shakertestReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ShakerTest")
+		os.Exit(1)
+	}
+
+	fiotestReconciler := &controllers.FioTestReconciler{}
+	fiotestReconciler.Client = mgr.GetClient()
+	fiotestReconciler.Scheme = mgr.GetScheme()
+	fiotestReconciler.Kclient = kclient
+	fiotestReconciler.LockStarvationThreshold = lockStarvationThreshold
+	fiotestReconciler.FeatureGates = featureGates
+	fiotestReconciler.LogStreamer = logStreamer
+	fiotestReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
+	if err = fiotestReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FioTest")
+		os.Exit(1)
+	}
+
+	k6testReconciler := &controllers.K6TestReconciler{}
+	k6testReconciler.Client = mgr.GetClient()
+	k6testReconciler.Scheme = mgr.GetScheme()
+	k6testReconciler.Kclient = kclient
+	k6testReconciler.LockStarvationThreshold = lockStarvationThreshold
+	k6testReconciler.FeatureGates = featureGates
+	k6testReconciler.LogStreamer = logStreamer
+	k6testReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
+	if err = k6testReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "K6Test")
+		os.Exit(1)
+	}
+
+	robottestReconciler := &controllers.RobotTestReconciler{}
+	robottestReconciler.Client = mgr.GetClient()
+	robottestReconciler.Scheme = mgr.GetScheme()
+	robottestReconciler.Kclient = kclient
+	robottestReconciler.LockStarvationThreshold = lockStarvationThreshold
+	robottestReconciler.FeatureGates = featureGates
+	robottestReconciler.LogStreamer = logStreamer
+	robottestReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
+	if err = robottestReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RobotTest")
+		os.Exit(1)
+	}
+
+	customtestReconciler := &controllers.CustomTestReconciler{}
+	customtestReconciler.Client = mgr.GetClient()
+	customtestReconciler.Scheme = mgr.GetScheme()
+	customtestReconciler.Kclient = kclient
+	customtestReconciler.LockStarvationThreshold = lockStarvationThreshold
+	customtestReconciler.FeatureGates = featureGates
+	customtestReconciler.LogStreamer = logStreamer
+	customtestReconciler.Recorder = mgr.GetEventRecorderFor("test-operator")
+	if err = customtestReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CustomTest")
+		os.Exit(1)
+	}
+
+	shutdownHook := &controllers.ShutdownHook{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("shutdown"),
+	}
+	if err = mgr.Add(shutdownHook); err != nil {
+		setupLog.Error(err, "unable to set up shutdown hook")
+		os.Exit(1)
+	}
+
 	// Setup webhooks if requested
 	if strings.ToLower(os.Getenv("ENABLE_WEBHOOKS")) != "false" {
 		if err = (&testv1beta1.Tempest{}).SetupWebhookWithManager(mgr); err != nil {
@@ -178,6 +302,30 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "HorizonTest")
 			os.Exit(1)
 		}
+		if err = (&testv1beta1.RallyTest{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RallyTest")
+			os.Exit(1)
+		}
+		if err = (&testv1beta1.ShakerTest{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ShakerTest")
+			os.Exit(1)
+		}
+		if err = (&testv1beta1.FioTest{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FioTest")
+			os.Exit(1)
+		}
+		if err = (&testv1beta1.K6Test{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "K6Test")
+			os.Exit(1)
+		}
+		if err = (&testv1beta1.RobotTest{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RobotTest")
+			os.Exit(1)
+		}
+		if err = (&testv1beta1.CustomTest{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CustomTest")
+			os.Exit(1)
+		}
 	}
 
 	//+kubebuilder:scaffold:builder
@@ -0,0 +1,101 @@
+package ansibletest
+
+import (
+	"fmt"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GetAraSidecarURL returns the location of the ARA database that the ARA API
+// sidecar of the test pod stores in the logs PVC
+func GetAraSidecarURL(namespace string, logsPVCName string, artifactDirectory string) string {
+	return fmt.Sprintf("pvc://%s/%s/%s/%s/ansible.sqlite", namespace, logsPVCName, artifactDirectory, araDirName)
+}
+
+// GetAraServerURL returns the URL of the playbook runs of the test pod on an
+// external ARA API server. The playbooks are labeled with the name of the
+// test pod.
+func GetAraServerURL(serverURL string, podName string) string {
+	return fmt.Sprintf("%s/?label=%s", serverURL, podName)
+}
+
+// addAra configures the ARA callback plugin of the test container. The
+// records are sent to the external ARA API server from the ServerSecretName
+// or to an ARA API server that runs as a sidecar of the test pod.
+func addAra(pod *corev1.Pod, ara *testv1beta1.AraConfig, artifactDirectory string) {
+	if len(pod.Spec.Containers) == 0 || ara == nil {
+		return
+	}
+
+	testContainer := &pod.Spec.Containers[0]
+	testContainer.Env = append(testContainer.Env,
+		corev1.EnvVar{Name: "ANSIBLE_CALLBACK_PLUGINS", Value: ara.CallbackPluginsPath},
+		corev1.EnvVar{Name: "ARA_API_CLIENT", Value: "http"},
+		corev1.EnvVar{Name: "ARA_DEFAULT_LABELS", Value: pod.Name},
+	)
+
+	if ara.ServerSecretName != "" {
+		testContainer.Env = append(testContainer.Env,
+			getAraSecretEnvVar("ARA_API_SERVER", ara.ServerSecretName, "url", false),
+			getAraSecretEnvVar("ARA_API_USERNAME", ara.ServerSecretName, "username", true),
+			getAraSecretEnvVar("ARA_API_PASSWORD", ara.ServerSecretName, "password", true),
+		)
+
+		return
+	}
+
+	testContainer.Env = append(testContainer.Env, corev1.EnvVar{
+		Name:  "ARA_API_SERVER",
+		Value: fmt.Sprintf("http://127.0.0.1:%d", araPort),
+	})
+
+	// The sidecar is an init container that keeps running so that it is
+	// ready before the playbook starts and it does not keep the pod running
+	// once the test container finishes
+	restartPolicy := corev1.ContainerRestartPolicyAlways
+	sidecar := corev1.Container{
+		Name:            AraContainerName,
+		Image:           ara.Image,
+		ImagePullPolicy: testContainer.ImagePullPolicy,
+		RestartPolicy:   &restartPolicy,
+		SecurityContext: testContainer.SecurityContext.DeepCopy(),
+		Env: []corev1.EnvVar{
+			{Name: "ARA_BASE_DIR", Value: araBaseDir},
+			{Name: "ARA_ALLOWED_HOSTS", Value: "['127.0.0.1', 'localhost']"},
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: "ara", ContainerPort: araPort},
+		},
+		StartupProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(araPort)},
+			},
+			PeriodSeconds:    2,
+			FailureThreshold: 60,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      logsVolumeName,
+				MountPath: araBaseDir,
+				SubPath:   artifactDirectory + "/" + araDirName,
+			},
+		},
+	}
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, sidecar)
+}
+
+func getAraSecretEnvVar(name string, secretName string, key string, optional bool) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+				Optional:             &optional,
+			},
+		},
+	}
+}
@@ -3,4 +3,105 @@ package ansibletest
 const (
 	// ServiceName - ansibleTest service name
 	ServiceName = "ansibleTest"
+
+	// LogsMountPath - path to the artifacts directory of the workflow step
+	// on the logs PVC in the test pod
+	LogsMountPath = "/var/lib/AnsibleTests/external_files"
+
+	// JUnitOutputDir - directory the junit callback plugin writes its results
+	// to in the test pod
+	JUnitOutputDir = LogsMountPath + "/junit"
+
+	// DefaultOfflineBundlePath - path to the offline bundle in the test pod
+	// when spec.offlineBundle.path is not set
+	DefaultOfflineBundlePath = "/var/lib/ansible-offline-bundle"
+
+	offlineBundleVolumeName = "offline-bundle"
+
+	// OfflineInstallContainerName - name of the init container that installs
+	// the ansibleCollections from the offline bundle
+	OfflineInstallContainerName = "offline-install"
+
+	// OfflineCollectionsPath - path to the collections installed from the
+	// offline bundle in the test pod
+	OfflineCollectionsPath = "/var/lib/ansible-offline-collections"
+
+	offlineCollectionsVolumeName = "offline-collections"
+
+	// GitAuthPath - path to the directory with the keys of the
+	// ansibleGitAuthSecretName secret in the test pod
+	GitAuthPath = "/var/lib/ansible/.git-auth"
+
+	gitAuthVolumeName = "git-auth"
+
+	logsVolumeName = "test-operator-logs"
+
+	// AraContainerName - name of the ARA API server sidecar
+	AraContainerName = "ara-api"
+
+	// AraURLAnnotation - annotation of the test pod with the location of the
+	// ARA records of the playbook run
+	AraURLAnnotation = "test.openstack.org/ara-url"
+
+	araPort    = 8000
+	araBaseDir = "/var/lib/ara"
+	araDirName = "ara"
+
+	// GalaxyInstallContainerName - name of the init container that installs
+	// the ansibleGalaxyRequirements
+	GalaxyInstallContainerName = "galaxy-install"
+
+	// GalaxyPath - path to the roles and collections installed from the
+	// ansibleGalaxyRequirements in the test pod
+	GalaxyPath = "/var/lib/ansible-galaxy"
+
+	// DefaultGalaxyRequirementsKey - key of the config map with the
+	// requirements.yml when ansibleGalaxyRequirements.key is not set
+	DefaultGalaxyRequirementsKey = "requirements.yml"
+
+	galaxyVolumeName             = "galaxy"
+	galaxyRequirementsVolumeName = "galaxy-requirements"
+	galaxyRequirementsFile       = "/var/lib/ansible-galaxy-requirements/requirements.yml"
+
+	// VaultPasswordFile - path to the ansible vault password of the
+	// ansibleVaultPasswordSecretName secret in the test pod
+	VaultPasswordFile = vaultPasswordPath + "/password"
+
+	vaultPasswordPath       = "/var/lib/ansible/.vault"
+	vaultPasswordVolumeName = "vault-password"
+
+	// VarFilesPath - path to the directory with the ansibleVarFilesFrom in
+	// the test pod
+	VarFilesPath = "/var/lib/ansible/var-files"
+
+	varFileVolumePrefix = "var-file-"
+
+	// GitCheckoutContainerName - name of the init container that checks out
+	// ansibleGitRef. Its termination message contains the commit SHA.
+	GitCheckoutContainerName = "git-checkout"
+
+	// GitCheckoutPath - path to the checked out git repo in the test pod
+	GitCheckoutPath = "/var/lib/ansible-git/repo"
+
+	// GitCachePath - path to the directory with the git repos cached by
+	// ansibleGitCache in the test pod. The directory is stored on the logs
+	// PVC (gitCacheSubPath) so that it is shared by the workflow steps.
+	GitCachePath = "/var/lib/ansible-git-cache"
+
+	gitCacheSubPath = ".git-cache"
+
+	gitCheckoutVolumeName = "git-checkout"
+	gitCheckoutMountPath  = "/var/lib/ansible-git"
+
+	// WrapperContainerName - name of the init container that installs the
+	// ansible-playbook wrapper passing the ansibleExtraArgs and running the
+	// ansiblePlaybooks
+	WrapperContainerName = "ansible-playbook-wrapper"
+
+	// WrapperPath - path to the directory with the ansible-playbook wrapper
+	// in the test pod. The directory is placed in front of the PATH of the
+	// image by the BASH_ENV script of the wrapper.
+	WrapperPath = "/var/lib/ansible-playbook-wrapper"
+
+	wrapperVolumeName = "ansible-playbook-wrapper"
 )
@@ -0,0 +1,40 @@
+package ansibletest
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// RenderVarFile returns the content of the var file passed to the test pod
+// in POD_ANSIBLE_FILE_EXTRA_VARS. The extra vars are appended to the var file
+// of ansibleVarFiles as YAML. Keys and values are rendered as quoted strings
+// so that they do not have to be escaped. When a key is defined in both, the
+// value of the extra vars wins as ansible uses the last occurrence of a
+// duplicate key.
+func RenderVarFile(varFiles string, extraVars map[string]string) string {
+	if len(extraVars) == 0 {
+		return varFiles
+	}
+
+	keys := make([]string, 0, len(extraVars))
+	for key := range extraVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var varFile strings.Builder
+	varFile.WriteString(varFiles)
+	if varFiles != "" && !strings.HasSuffix(varFiles, "\n") {
+		varFile.WriteString("\n")
+	}
+
+	for _, key := range keys {
+		// JSON strings are valid YAML double-quoted scalars
+		quotedKey, _ := json.Marshal(key)
+		quotedValue, _ := json.Marshal(extraVars[key])
+		varFile.WriteString(string(quotedKey) + ": " + string(quotedValue) + "\n")
+	}
+
+	return varFile.String()
+}
@@ -0,0 +1,93 @@
+package ansibletest
+
+import (
+	"fmt"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getGalaxyInstallScript returns a script that installs the roles and the
+// collections of the requirements.yml to GalaxyPath. The collections are
+// installed with --offline when offlineMode is enabled.
+func getGalaxyInstallScript(offline bool) string {
+	collectionOptions := ""
+	if offline {
+		collectionOptions = " --offline"
+	}
+
+	return fmt.Sprintf(
+		"set -e; ansible-galaxy role install -r %[1]s -p %[2]s/roles; "+
+			"ansible-galaxy collection install -r %[1]s -p %[2]s/collections%[3]s",
+		galaxyRequirementsFile, GalaxyPath, collectionOptions)
+}
+
+// addGalaxyInstall adds an init container to the pod that installs the
+// ansibleGalaxyRequirements with ansible-galaxy. The test container finds the
+// installed roles and collections through ANSIBLE_ROLES_PATH and
+// ANSIBLE_COLLECTIONS_PATH.
+func addGalaxyInstall(pod *corev1.Pod, requirements *testv1beta1.GalaxyRequirements, offline bool) {
+	if len(pod.Spec.Containers) == 0 || requirements == nil {
+		return
+	}
+
+	key := requirements.Key
+	if key == "" {
+		key = DefaultGalaxyRequirementsKey
+	}
+
+	galaxyMount := corev1.VolumeMount{
+		Name:      galaxyVolumeName,
+		MountPath: GalaxyPath,
+	}
+
+	testContainer := &pod.Spec.Containers[0]
+	initContainer := testContainer.DeepCopy()
+	initContainer.Name = GalaxyInstallContainerName
+	initContainer.Command = []string{"/bin/sh", "-c", getGalaxyInstallScript(offline)}
+	initContainer.Args = nil
+	initContainer.VolumeMounts = append(initContainer.VolumeMounts, galaxyMount, corev1.VolumeMount{
+		Name:      galaxyRequirementsVolumeName,
+		MountPath: galaxyRequirementsFile,
+		SubPath:   key,
+		ReadOnly:  true,
+	})
+
+	if requirements.Server != "" {
+		initContainer.Env = append(initContainer.Env, corev1.EnvVar{
+			Name:  "ANSIBLE_GALAXY_SERVER",
+			Value: requirements.Server,
+		})
+	}
+
+	testContainer.VolumeMounts = append(testContainer.VolumeMounts, galaxyMount)
+	testContainer.Env = append(testContainer.Env,
+		corev1.EnvVar{
+			Name:  "ANSIBLE_ROLES_PATH",
+			Value: GalaxyPath + "/roles:~/.ansible/roles:/usr/share/ansible/roles:/etc/ansible/roles",
+		},
+		corev1.EnvVar{
+			Name:  "ANSIBLE_COLLECTIONS_PATH",
+			Value: GalaxyPath + "/collections:~/.ansible/collections:/usr/share/ansible/collections",
+		},
+	)
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes,
+		corev1.Volume{
+			Name: galaxyVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		corev1.Volume{
+			Name: galaxyRequirementsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: requirements.ConfigMap},
+				},
+			},
+		},
+	)
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
+}
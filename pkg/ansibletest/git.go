@@ -0,0 +1,91 @@
+package ansibletest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetGitAuthEnvVars returns the environment variables that make git use the
+// credentials of the ansibleGitAuthSecretName secret mounted to GitAuthPath
+func GetGitAuthEnvVars() map[string]string {
+	return util.GetGitAuthEnvVars(GitAuthPath)
+}
+
+// getGitCheckoutScript returns a script that clones the git repo to
+// checkoutPath (unless it was already cloned there), checks out the requested
+// branch, tag or commit and writes the SHA of the checked out commit to the
+// termination message of the container. Refs that are not reachable from the
+// cloned branches (e.g. a commit of a pull request) are fetched explicitly.
+func getGitCheckoutScript(checkoutPath string) string {
+	return fmt.Sprintf(
+		"set -e; if [ ! -d %[1]s/.git ]; then rm -rf %[1]s %[1]s.tmp; mkdir -p %[1]s.tmp; "+
+			"git clone \"$POD_ANSIBLE_GIT_REPO\" %[1]s.tmp; mv %[1]s.tmp %[1]s; fi; cd %[1]s; "+
+			"if [ -n \"$POD_ANSIBLE_GIT_REF\" ]; then "+
+			"git checkout --detach \"$POD_ANSIBLE_GIT_REF\" 2>/dev/null || "+
+			"git checkout --detach \"origin/$POD_ANSIBLE_GIT_REF\" 2>/dev/null || "+
+			"{ git fetch origin \"$POD_ANSIBLE_GIT_REF\" && git checkout --detach FETCH_HEAD; }; fi; "+
+			"git rev-parse HEAD | tee /dev/termination-log",
+		checkoutPath)
+}
+
+// GetGitCachePath returns the path in the test pod where the checkout of the
+// given git repo and ref is cached. The workflow steps that use the same repo
+// and ref share the checkout.
+func GetGitCachePath(gitRepo string, gitRef string) string {
+	hash := sha256.Sum256([]byte(gitRepo + "\n" + gitRef))
+	return fmt.Sprintf("%s/%s", GitCachePath, hex.EncodeToString(hash[:])[:16])
+}
+
+// addGitCheckout adds an init container to the pod that clones the git repo
+// to checkoutPath and checks out the ansibleGitRef (the default branch when
+// it is not set). The test container then clones the checked out repo from
+// checkoutPath instead of the remote git repo, so the commit recorded in the
+// status does not depend on the test image. When checkoutPath is empty the repo is cloned to a volume of the pod
+// (GitCheckoutPath), otherwise it is expected to be in GitCachePath.
+func addGitCheckout(pod *corev1.Pod, checkoutPath string) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	testContainer := &pod.Spec.Containers[0]
+	if checkoutPath != "" {
+		testContainer.VolumeMounts = append(testContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      logsVolumeName,
+			MountPath: GitCachePath,
+			SubPath:   gitCacheSubPath,
+		})
+	} else {
+		checkoutPath = GitCheckoutPath
+		testContainer.VolumeMounts = append(testContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      gitCheckoutVolumeName,
+			MountPath: gitCheckoutMountPath,
+		})
+
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: gitCheckoutVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
+	initContainer := testContainer.DeepCopy()
+	initContainer.Name = GitCheckoutContainerName
+	initContainer.Command = []string{"/bin/sh", "-c", getGitCheckoutScript(checkoutPath)}
+	initContainer.Args = nil
+	// The SHA is the last line of the log when the termination message
+	// could not be written
+	initContainer.TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+
+	for i := range testContainer.Env {
+		if testContainer.Env[i].Name == "POD_ANSIBLE_GIT_REPO" {
+			testContainer.Env[i].Value = checkoutPath
+		}
+	}
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
+}
@@ -0,0 +1,101 @@
+package ansibletest
+
+import (
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// nodeSetInventorySecretPrefix is the prefix of the secrets in which the
+	// dataplane operator stores the inventories of the
+	// OpenStackDataPlaneNodeSets
+	nodeSetInventorySecretPrefix = "dataplanenodeset-"
+
+	// InventoryKey is the key of the inventory in the secrets of the node
+	// sets and in the secret rendered for the test pod
+	InventoryKey = "inventory"
+)
+
+// GetNodeSetInventorySecretName returns the name of the secret with the
+// inventory of the OpenStackDataPlaneNodeSet
+func GetNodeSetInventorySecretName(nodeSet string) string {
+	return nodeSetInventorySecretPrefix + nodeSet
+}
+
+// GetInventorySecretName returns the name of the secret with the inventory
+// rendered from the node sets for the test pod
+func GetInventorySecretName(podName string) string {
+	return podName + "-inventory"
+}
+
+// GetInventoryEnvVar returns the value of POD_ANSIBLE_INVENTORY. The
+// inventory is read from the config map or from the secret rendered from the
+// node sets when the workflow step uses one of them.
+func GetInventoryEnvVar(stepSpec testv1beta1.AnsibleTestSpec, podName string) env.Setter {
+	var source *corev1.EnvVarSource
+	if stepSpec.AnsibleInventoryConfigMap != nil {
+		source = &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: stepSpec.AnsibleInventoryConfigMap.Name,
+				},
+				Key: stepSpec.AnsibleInventoryConfigMap.Key,
+			},
+		}
+	} else if len(stepSpec.AnsibleInventoryFromNodeSets) > 0 {
+		source = &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: GetInventorySecretName(podName),
+				},
+				Key: InventoryKey,
+			},
+		}
+	} else {
+		return env.SetValue(stepSpec.AnsibleInventory)
+	}
+
+	return func(envVar *corev1.EnvVar) {
+		envVar.Value = ""
+		envVar.ValueFrom = source
+	}
+}
+
+// RenderNodeSetInventory merges the inventories of the node sets into a
+// single inventory. Groups defined by several node sets are merged, for
+// conflicting host and group variables the node set listed last wins.
+func RenderNodeSetInventory(inventories map[string][]byte, nodeSets []string) (string, error) {
+	merged := map[string]interface{}{}
+	for _, nodeSet := range nodeSets {
+		inventory := map[string]interface{}{}
+		if err := yaml.Unmarshal(inventories[nodeSet], &inventory); err != nil {
+			return "", fmt.Errorf("failed to parse the inventory of the %s node set: %w", nodeSet, err)
+		}
+
+		mergeInventory(merged, inventory)
+	}
+
+	rendered, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+
+	return string(rendered), nil
+}
+
+func mergeInventory(dst map[string]interface{}, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeInventory(dstMap, srcMap)
+			continue
+		}
+
+		dst[key] = value
+	}
+}
@@ -0,0 +1,103 @@
+package ansibletest
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getOfflineInstallScript returns a script that installs the collections of
+// POD_INSTALL_COLLECTIONS from the tarballs in the collections directory of
+// the offline bundle (the output of ansible-galaxy collection download). A
+// collection that is not in the bundle fails the container with a message
+// naming the collection instead of falling back to the network.
+func getOfflineInstallScript(bundlePath string) string {
+	return fmt.Sprintf(
+		"set -e; for c in $POD_INSTALL_COLLECTIONS; do name=${c%%%%:*}; "+
+			"set -- \"%[1]s\"/collections/$(echo \"$name\" | tr . -)-*.tar.gz; "+
+			"if [ ! -e \"$1\" ]; then "+
+			"echo \"collection $name is not in the offline bundle %[1]s\" | tee /dev/termination-log; exit 1; fi; "+
+			"ansible-galaxy collection install --offline -p %[2]s \"$1\"; done",
+		bundlePath, OfflineCollectionsPath)
+}
+
+// addOfflineBundle makes the test pod use the offline bundle instead of the
+// network. pip only installs the packages of the pip directory of the bundle
+// (PIP_NO_INDEX and PIP_FIND_LINKS) and the ansibleCollections are installed
+// from the bundle by an init container so that the test container does not
+// install them from Ansible Galaxy.
+func addOfflineBundle(pod *corev1.Pod, bundlePath string) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	testContainer := &pod.Spec.Containers[0]
+	testContainer.Env = append(testContainer.Env,
+		corev1.EnvVar{
+			Name:  "PIP_NO_INDEX",
+			Value: "1",
+		},
+		corev1.EnvVar{
+			Name:  "PIP_FIND_LINKS",
+			Value: bundlePath + "/pip",
+		},
+	)
+
+	collections := ""
+	for i := range testContainer.Env {
+		if testContainer.Env[i].Name == "POD_INSTALL_COLLECTIONS" {
+			collections = testContainer.Env[i].Value
+			testContainer.Env[i].Value = ""
+		}
+	}
+
+	if collections == "" {
+		return
+	}
+
+	offlineCollectionsMount := corev1.VolumeMount{
+		Name:      offlineCollectionsVolumeName,
+		MountPath: OfflineCollectionsPath,
+	}
+
+	initContainer := testContainer.DeepCopy()
+	initContainer.Name = OfflineInstallContainerName
+	initContainer.Command = []string{"/bin/sh", "-c", getOfflineInstallScript(bundlePath)}
+	initContainer.Args = nil
+	initContainer.VolumeMounts = append(initContainer.VolumeMounts, offlineCollectionsMount)
+	for i := range initContainer.Env {
+		if initContainer.Env[i].Name == "POD_INSTALL_COLLECTIONS" {
+			initContainer.Env[i].Value = collections
+		}
+	}
+
+	testContainer.VolumeMounts = append(testContainer.VolumeMounts, offlineCollectionsMount)
+	prependEnvPath(testContainer, "ANSIBLE_COLLECTIONS_PATH", OfflineCollectionsPath,
+		"~/.ansible/collections:/usr/share/ansible/collections")
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: offlineCollectionsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
+}
+
+// prependEnvPath prepends dir to the colon separated list of the env var of
+// the container. When the container does not set the env var yet, dir is
+// prepended to defaultValue.
+func prependEnvPath(container *corev1.Container, name string, dir string, defaultValue string) {
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i].Value = dir + ":" + container.Env[i].Value
+			return
+		}
+	}
+
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  name,
+		Value: dir + ":" + defaultValue,
+	})
+}
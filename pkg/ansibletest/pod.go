@@ -12,7 +12,9 @@ import (
 // Pod - prepare pod to run AnsibleTests tests
 func Pod(
 	instance *testv1beta1.AnsibleTest,
+	effectiveSpec util.EffectiveSpec,
 	labels map[string]string,
+	annotations map[string]string,
 	podName string,
 	logsPVCName string,
 	mountCerts bool,
@@ -31,15 +33,23 @@ func Pod(
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      podName,
-			Namespace: instance.Namespace,
-			Labels:    labels,
+			Annotations: annotations,
+			Name:        podName,
+			Namespace:   instance.Namespace,
+			Labels:      labels,
 		},
 		Spec: corev1.PodSpec{
 			AutomountServiceAccountToken: &instance.Spec.Privileged,
 			RestartPolicy:                corev1.RestartPolicyNever,
-			Tolerations:                  instance.Spec.Tolerations,
-			NodeSelector:                 instance.Spec.NodeSelector,
+			Tolerations:                  effectiveSpec.Tolerations,
+			NodeSelector:                 effectiveSpec.NodeSelector,
+			Affinity:                     effectiveSpec.Affinity,
+			TopologySpreadConstraints:    effectiveSpec.TopologySpreadConstraints,
+			RuntimeClassName:             effectiveSpec.RuntimeClassName,
+			HostNetwork:                  effectiveSpec.HostNetwork,
+			DNSPolicy:                    effectiveSpec.DNSPolicy,
+			DNSConfig:                    effectiveSpec.DNSConfig,
+			ImagePullSecrets:             effectiveSpec.ImagePullSecrets,
 			SecurityContext: &corev1.PodSecurityContext{
 				RunAsUser:  &runAsUser,
 				RunAsGroup: &runAsGroup,
@@ -49,11 +59,13 @@ func Pod(
 				{
 					Name:            instance.Name,
 					Image:           containerImage,
+					ImagePullPolicy: effectiveSpec.ImagePullPolicy,
 					Args:            []string{},
 					Env:             env.MergeEnvs([]corev1.EnvVar{}, envVars),
-					VolumeMounts:    GetVolumeMounts(mountCerts, instance, externalWorkflowCounter),
+					EnvFrom:         effectiveSpec.EnvFrom,
+					VolumeMounts:    GetVolumeMounts(mountCerts, instance, workflowOverrideParams, externalWorkflowCounter, podName),
 					SecurityContext: &securityContext,
-					Resources:       instance.Spec.Resources,
+					Resources:       effectiveSpec.Resources,
 				},
 			},
 			Volumes: GetVolumes(
@@ -66,11 +78,39 @@ func Pod(
 		},
 	}
 
-	if len(instance.Spec.SELinuxLevel) > 0 {
+	if len(effectiveSpec.SELinuxLevel) > 0 {
 		pod.Spec.SecurityContext.SELinuxOptions = &corev1.SELinuxOptions{
-			Level: instance.Spec.SELinuxLevel,
+			Level: effectiveSpec.SELinuxLevel,
 		}
 	}
 
+	util.ApplySecurityContext(pod, effectiveSpec.SecurityContext)
+	util.SetArtifactDirectory(pod, podName)
+
+	if instance.Spec.AnsibleAra != nil {
+		addAra(pod, instance.Spec.AnsibleAra, podName)
+	}
+
+	if instance.Spec.AnsibleGalaxyRequirements != nil {
+		addGalaxyInstall(pod, instance.Spec.AnsibleGalaxyRequirements, instance.Spec.OfflineMode)
+	}
+
+	if instance.Spec.OfflineMode {
+		addOfflineBundle(pod, GetOfflineBundlePath(instance.Spec.OfflineBundle))
+	}
+
+	if instance.Spec.AnsibleGitCache {
+		addGitCheckout(pod, GetGitCachePath(workflowOverrideParams["AnsibleGitRepo"], workflowOverrideParams["AnsibleGitRef"]))
+	} else if workflowOverrideParams["AnsibleGitRepo"] != "" {
+		addGitCheckout(pod, "")
+	}
+
+	addWrapper(
+		pod,
+		workflowOverrideParams["AnsibleExtraArgs"],
+		workflowOverrideParams["AnsiblePlaybooks"],
+		workflowOverrideParams["AnsiblePlaybookFailurePolicy"] == string(testv1beta1.PlaybookFailurePolicyContinue),
+	)
+
 	return pod
 }
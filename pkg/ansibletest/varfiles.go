@@ -0,0 +1,84 @@
+package ansibletest
+
+import (
+	"fmt"
+	"strings"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getVarFilesFrom returns the ansibleVarFilesFrom of the workflow step when
+// they are set and the ansibleVarFilesFrom of the spec otherwise
+func getVarFilesFrom(instance *testv1beta1.AnsibleTest, externalWorkflowCounter int) []testv1beta1.AnsibleVarFileSource {
+	if externalWorkflowCounter < len(instance.Spec.Workflow) {
+		if varFiles := instance.Spec.Workflow[externalWorkflowCounter].AnsibleVarFilesFrom; varFiles != nil {
+			return *varFiles
+		}
+	}
+
+	return instance.Spec.AnsibleVarFilesFrom
+}
+
+// GetVarFilesExtraVars returns the -e @<file> arguments of the ansible
+// command for the var files
+func GetVarFilesExtraVars(varFiles []testv1beta1.AnsibleVarFileSource) string {
+	extraVars := []string{}
+	for idx, varFile := range varFiles {
+		extraVars = append(extraVars, "-e @"+getVarFilePath(idx, varFile))
+	}
+
+	return strings.Join(extraVars, " ")
+}
+
+func getVarFileVolumeName(idx int) string {
+	return fmt.Sprintf("%s%d", varFileVolumePrefix, idx)
+}
+
+func getVarFilePath(idx int, varFile testv1beta1.AnsibleVarFileSource) string {
+	return fmt.Sprintf("%s/%d-%s", VarFilesPath, idx, varFile.Key)
+}
+
+func getVarFileVolumes(varFiles []testv1beta1.AnsibleVarFileSource) []corev1.Volume {
+	var varFileMode int32 = 0440
+
+	volumes := []corev1.Volume{}
+	for idx, varFile := range varFiles {
+		volume := corev1.Volume{Name: getVarFileVolumeName(idx)}
+		if varFile.SecretRef != nil {
+			volume.VolumeSource.Secret = &corev1.SecretVolumeSource{
+				SecretName:  varFile.SecretRef.Name,
+				DefaultMode: &varFileMode,
+			}
+		} else if varFile.ConfigMapRef != nil {
+			volume.VolumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: *varFile.ConfigMapRef,
+				DefaultMode:          &varFileMode,
+			}
+		} else {
+			continue
+		}
+
+		volumes = append(volumes, volume)
+	}
+
+	return volumes
+}
+
+func getVarFileVolumeMounts(varFiles []testv1beta1.AnsibleVarFileSource) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+	for idx, varFile := range varFiles {
+		if varFile.SecretRef == nil && varFile.ConfigMapRef == nil {
+			continue
+		}
+
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      getVarFileVolumeName(idx),
+			MountPath: getVarFilePath(idx, varFile),
+			SubPath:   varFile.Key,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumeMounts
+}
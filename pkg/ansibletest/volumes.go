@@ -43,7 +43,7 @@ func GetVolumes(
 			},
 		},
 		{
-			Name: "test-operator-logs",
+			Name: logsVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
 					ClaimName: logsPVCName,
@@ -71,7 +71,7 @@ func GetVolumes(
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
 					DefaultMode: &scriptsVolumeConfidentialMode,
-					SecretName:  "combined-ca-bundle",
+					SecretName:  util.GetCABundleSecretName(instance.Spec.CommonOptions),
 				},
 			},
 		}
@@ -103,6 +103,34 @@ func GetVolumes(
 
 	volumes = append(volumes, keysVolume)
 
+	if workflowOverrideParams["AnsibleGitAuthSecretName"] != "" {
+		gitAuthVolume := corev1.Volume{
+			Name: gitAuthVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  workflowOverrideParams["AnsibleGitAuthSecretName"],
+					DefaultMode: &privateKeyMode,
+				},
+			},
+		}
+
+		volumes = append(volumes, gitAuthVolume)
+	}
+
+	if workflowOverrideParams["AnsibleVaultPasswordSecretName"] != "" {
+		vaultPasswordVolume := corev1.Volume{
+			Name: vaultPasswordVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  workflowOverrideParams["AnsibleVaultPasswordSecretName"],
+					DefaultMode: &privateKeyMode,
+				},
+			},
+		}
+
+		volumes = append(volumes, vaultPasswordVolume)
+	}
+
 	for _, vol := range instance.Spec.ExtraConfigmapsMounts {
 		extraVol := corev1.Volume{
 			Name: vol.Name,
@@ -136,11 +164,36 @@ func GetVolumes(
 			volumes = append(volumes, extraWorkflowVol)
 		}
 	}
+
+	volumes = append(volumes, util.GetExtraMountVolumes(getExtraMounts(instance, externalWorkflowCounter))...)
+	volumes = append(volumes, getVarFileVolumes(getVarFilesFrom(instance, externalWorkflowCounter))...)
+
+	if instance.Spec.OfflineMode && instance.Spec.OfflineBundle != nil &&
+		instance.Spec.OfflineBundle.PersistentVolumeClaim != "" {
+		offlineBundleVolume := corev1.Volume{
+			Name: offlineBundleVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: instance.Spec.OfflineBundle.PersistentVolumeClaim,
+					ReadOnly:  true,
+				},
+			},
+		}
+
+		volumes = append(volumes, offlineBundleVolume)
+	}
+
 	return volumes
 }
 
 // GetVolumeMounts -
-func GetVolumeMounts(mountCerts bool, instance *testv1beta1.AnsibleTest, externalWorkflowCounter int) []corev1.VolumeMount {
+func GetVolumeMounts(
+	mountCerts bool,
+	instance *testv1beta1.AnsibleTest,
+	workflowOverrideParams map[string]string,
+	externalWorkflowCounter int,
+	artifactDirectory string,
+) []corev1.VolumeMount {
 	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      util.TestOperatorEphemeralVolumeNameWorkdir,
@@ -153,8 +206,9 @@ func GetVolumeMounts(mountCerts bool, instance *testv1beta1.AnsibleTest, externa
 			ReadOnly:  false,
 		},
 		{
-			Name:      "test-operator-logs",
-			MountPath: "/var/lib/AnsibleTests/external_files",
+			Name:      logsVolumeName,
+			MountPath: LogsMountPath,
+			SubPath:   artifactDirectory,
 			ReadOnly:  false,
 		},
 		{
@@ -215,6 +269,26 @@ func GetVolumeMounts(mountCerts bool, instance *testv1beta1.AnsibleTest, externa
 
 	volumeMounts = append(volumeMounts, computeSSHKeyMount)
 
+	if workflowOverrideParams["AnsibleGitAuthSecretName"] != "" {
+		gitAuthMount := corev1.VolumeMount{
+			Name:      gitAuthVolumeName,
+			MountPath: GitAuthPath,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, gitAuthMount)
+	}
+
+	if workflowOverrideParams["AnsibleVaultPasswordSecretName"] != "" {
+		vaultPasswordMount := corev1.VolumeMount{
+			Name:      vaultPasswordVolumeName,
+			MountPath: vaultPasswordPath,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, vaultPasswordMount)
+	}
+
 	for _, vol := range instance.Spec.ExtraConfigmapsMounts {
 
 		extraConfigmapsMounts := corev1.VolumeMount{
@@ -241,5 +315,40 @@ func GetVolumeMounts(mountCerts bool, instance *testv1beta1.AnsibleTest, externa
 		}
 	}
 
+	volumeMounts = append(volumeMounts, util.GetExtraMountVolumeMounts(getExtraMounts(instance, externalWorkflowCounter))...)
+	volumeMounts = append(volumeMounts, getVarFileVolumeMounts(getVarFilesFrom(instance, externalWorkflowCounter))...)
+
+	if instance.Spec.OfflineMode && instance.Spec.OfflineBundle != nil &&
+		instance.Spec.OfflineBundle.PersistentVolumeClaim != "" {
+		offlineBundleMount := corev1.VolumeMount{
+			Name:      offlineBundleVolumeName,
+			MountPath: GetOfflineBundlePath(instance.Spec.OfflineBundle),
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, offlineBundleMount)
+	}
+
 	return volumeMounts
 }
+
+// getExtraMounts returns the extraMounts of the workflow step when they are
+// set and the extraMounts of the spec otherwise
+func getExtraMounts(instance *testv1beta1.AnsibleTest, externalWorkflowCounter int) []testv1beta1.ExtraMount {
+	if externalWorkflowCounter < len(instance.Spec.Workflow) {
+		if extraMounts := instance.Spec.Workflow[externalWorkflowCounter].ExtraMounts; extraMounts != nil {
+			return *extraMounts
+		}
+	}
+
+	return instance.Spec.ExtraMounts
+}
+
+// GetOfflineBundlePath returns the path to the offline bundle in the test pod
+func GetOfflineBundlePath(offlineBundle *testv1beta1.OfflineBundle) string {
+	if offlineBundle == nil || offlineBundle.Path == "" {
+		return DefaultOfflineBundlePath
+	}
+
+	return offlineBundle.Path
+}
@@ -0,0 +1,133 @@
+package ansibletest
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getWrapperEnvScript returns the script the shell of the test container
+// sources through BASH_ENV. It places the directory of the ansible-playbook
+// wrapper in front of the PATH of the image, so the PATH of the image does not
+// have to be known when the pod is created.
+func getWrapperEnvScript() string {
+	return "case \":$PATH:\" in\n" +
+		"*\":" + WrapperPath + ":\"*) ;;\n" +
+		"*) PATH=" + WrapperPath + ":$PATH; export PATH ;;\n" +
+		"esac\n"
+}
+
+// getWrapperScript returns the ansible-playbook wrapper that is placed in
+// front of PATH in the test container. It removes itself from PATH (and
+// BASH_ENV from the environment) so that ansible-playbook and the commands
+// it runs are not wrapped again.
+//
+// The wrapper appends the lines of POD_ANSIBLE_EXTRA_ARGS to the arguments of
+// ansible-playbook, each of them as a separate argument, so that neither the
+// whitespace nor the shell metacharacters of the arguments are interpreted by
+// a shell.
+//
+// When POD_ANSIBLE_PLAYBOOKS is set, the invocation of the playbook of
+// POD_ANSIBLE_PLAYBOOK (the first of the playbooks) is repeated for each line
+// of POD_ANSIBLE_PLAYBOOKS. The wrapper stops at the first failed playbook
+// unless POD_ANSIBLE_CONTINUE_ON_FAILURE is true and exits with the exit code
+// of the first failed playbook.
+func getWrapperScript() string {
+	return "#!/bin/sh\n" +
+		"PATH=${PATH#" + WrapperPath + ":}\n" +
+		"unset BASH_ENV\n" +
+		"set -f\n" +
+		"IFS='\n'\n" +
+		"is_test_run() {\n" +
+		"    for arg do [ \"$arg\" = \"$POD_ANSIBLE_PLAYBOOK\" ] && return 0; done\n" +
+		"    return 1\n" +
+		"}\n" +
+		"run_playbook() {\n" +
+		"    playbook=$1\n" +
+		"    shift\n" +
+		"    for arg do\n" +
+		"        shift\n" +
+		"        [ \"$arg\" = \"$POD_ANSIBLE_PLAYBOOK\" ] && arg=$playbook\n" +
+		"        set -- \"$@\" \"$arg\"\n" +
+		"    done\n" +
+		"    ansible-playbook \"$@\" $POD_ANSIBLE_EXTRA_ARGS\n" +
+		"}\n" +
+		"if [ -z \"$POD_ANSIBLE_PLAYBOOKS\" ] || ! is_test_run \"$@\"; then\n" +
+		"    exec ansible-playbook \"$@\" $POD_ANSIBLE_EXTRA_ARGS\n" +
+		"fi\n" +
+		"rc=0\n" +
+		"for playbook in $POD_ANSIBLE_PLAYBOOKS; do\n" +
+		"    run_playbook \"$playbook\" \"$@\" && continue\n" +
+		"    status=$?\n" +
+		"    [ $rc -eq 0 ] && rc=$status\n" +
+		"    [ \"$POD_ANSIBLE_CONTINUE_ON_FAILURE\" = true ] || exit $rc\n" +
+		"done\n" +
+		"exit $rc\n"
+}
+
+// addWrapper adds an init container to the pod that installs the
+// ansible-playbook wrapper that passes the ansibleExtraArgs to ansible
+// playbook and runs the ansiblePlaybooks one after another. The extraArgs and
+// the playbooks are separated by line breaks. The wrapper is not installed
+// when there are neither extraArgs nor several playbooks.
+//
+// The PATH of the test container is not set in the pod, the bash entrypoint
+// of the test image prepends WrapperPath to the PATH of the image when it
+// sources the BASH_ENV script.
+func addWrapper(pod *corev1.Pod, extraArgs string, playbooks string, continueOnFailure bool) {
+	if len(pod.Spec.Containers) == 0 || (extraArgs == "" && playbooks == "") {
+		return
+	}
+
+	wrapperMount := corev1.VolumeMount{
+		Name:      wrapperVolumeName,
+		MountPath: WrapperPath,
+	}
+
+	wrapperPath := WrapperPath + "/ansible-playbook"
+	wrapperEnvPath := WrapperPath + "/env.sh"
+	testContainer := &pod.Spec.Containers[0]
+	initContainer := testContainer.DeepCopy()
+	initContainer.Name = WrapperContainerName
+	initContainer.Command = []string{
+		"/bin/sh",
+		"-c",
+		"printf '%s' \"$1\" > " + wrapperPath + " && chmod 0755 " + wrapperPath +
+			" && printf '%s' \"$2\" > " + wrapperEnvPath,
+		WrapperContainerName,
+		getWrapperScript(),
+		getWrapperEnvScript(),
+	}
+	initContainer.Args = nil
+	initContainer.VolumeMounts = append(initContainer.VolumeMounts, wrapperMount)
+
+	testContainer.VolumeMounts = append(testContainer.VolumeMounts, wrapperMount)
+	testContainer.Env = append(testContainer.Env,
+		corev1.EnvVar{
+			Name:  "POD_ANSIBLE_EXTRA_ARGS",
+			Value: extraArgs,
+		},
+		corev1.EnvVar{
+			Name:  "POD_ANSIBLE_PLAYBOOKS",
+			Value: playbooks,
+		},
+		corev1.EnvVar{
+			Name:  "BASH_ENV",
+			Value: wrapperEnvPath,
+		},
+	)
+
+	if continueOnFailure {
+		testContainer.Env = append(testContainer.Env, corev1.EnvVar{
+			Name:  "POD_ANSIBLE_CONTINUE_ON_FAILURE",
+			Value: "true",
+		})
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: wrapperVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
+}
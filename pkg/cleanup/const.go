@@ -0,0 +1,25 @@
+package cleanup
+
+const (
+	// ServiceName - cleanup verification service name
+	ServiceName = "cleanup-verification"
+
+	// PodNameSuffix - suffix of the cleanup verification pod name
+	PodNameSuffix = "-cleanup-verification"
+)
+
+// resourceCommands maps a resource type used in CleanupAssertion to the
+// openstack client command and the options needed to list the resources
+// owned by a specific project.
+var resourceCommands = map[string]struct {
+	command        string
+	projectOptions string
+}{
+	"server":        {"server", "--all-projects --project"},
+	"port":          {"port", "--project"},
+	"network":       {"network", "--project"},
+	"subnet":        {"subnet", "--project"},
+	"router":        {"router", "--project"},
+	"volume":        {"volume", "--all-projects --project"},
+	"securitygroup": {"security group", "--project"},
+}
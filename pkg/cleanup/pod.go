@@ -0,0 +1,102 @@
+package cleanup
+
+import (
+	"fmt"
+	"strings"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Pod - prepare pod that verifies that no leftover resources remained in the
+// cloud after the tests finished
+func Pod(
+	namespace string,
+	labels map[string]string,
+	podName string,
+	containerImage string,
+	caBundleSecretName string,
+	assertions []testv1beta1.CleanupAssertion,
+) *corev1.Pod {
+	runAsUser := int64(42480)
+	runAsGroup := int64(42480)
+
+	securityContext := util.GetSecurityContext(runAsUser, []corev1.Capability{}, false)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &runAsUser,
+				RunAsGroup: &runAsGroup,
+				FSGroup:    &runAsGroup,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:    ServiceName,
+					Image:   containerImage,
+					Command: []string{"/bin/sh", "-c", GetScript(assertions)},
+					Env: []corev1.EnvVar{
+						{Name: "OS_CLOUD", Value: "default"},
+						{Name: "HOME", Value: "/tmp"},
+					},
+					VolumeMounts:    util.GetOpenStackClientVolumeMounts(len(caBundleSecretName) > 0),
+					SecurityContext: &securityContext,
+				},
+			},
+			Volumes: util.GetOpenStackClientVolumes(caBundleSecretName),
+		},
+	}
+
+	return pod
+}
+
+// GetScript returns a shell script that lists resources matching each of the
+// assertions and fails when any of them is found.
+func GetScript(assertions []testv1beta1.CleanupAssertion) string {
+	script := []string{"failed=0"}
+
+	for _, assertion := range assertions {
+		resourceCommand := resourceCommands[assertion.Resource]
+		listCommand := "openstack " + resourceCommand.command + " list -f value -c Name"
+		if len(assertion.Project) > 0 {
+			listCommand += " " + resourceCommand.projectOptions + " " + shellQuote(assertion.Project)
+		}
+
+		description := fmt.Sprintf("%s resources", assertion.Resource)
+		if len(assertion.NamePrefix) > 0 {
+			description += fmt.Sprintf(" with prefix %s", assertion.NamePrefix)
+		}
+		if len(assertion.Project) > 0 {
+			description += fmt.Sprintf(" in project %s", assertion.Project)
+		}
+
+		script = append(script,
+			"if ! leftovers=$("+listCommand+"); then",
+			"  echo "+shellQuote("Unable to list "+description),
+			"  failed=1",
+			"elif leftovers=$(echo \"$leftovers\" | awk -v prefix="+shellQuote(assertion.NamePrefix)+
+				" '(prefix == \"\" || index($0, prefix) == 1) && NF'); [ -n \"$leftovers\" ]; then",
+			"  echo "+shellQuote("Leftover "+description+":"),
+			"  echo \"$leftovers\"",
+			"  failed=1",
+			"fi",
+		)
+	}
+
+	script = append(script, "exit $failed")
+
+	return strings.Join(script, "\n")
+}
+
+// shellQuote quotes the value so that it can be safely used in a shell script
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
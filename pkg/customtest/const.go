@@ -0,0 +1,10 @@
+package customtest
+
+const (
+	// ServiceName - customtest service name
+	ServiceName = "customtest"
+
+	// HomeDir - home directory of the test container. The container image
+	// is not known, so a writable directory is provided for it.
+	HomeDir = "/var/lib/test-operator/home"
+)
@@ -0,0 +1,53 @@
+package customtest
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Pod - prepare pod to run the command of a CustomTest
+func Pod(
+	instance *testv1beta1.CustomTest,
+	stepSpec testv1beta1.CustomTestSpec,
+	effectiveSpec util.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+	privileged bool,
+) *corev1.Pod {
+	// The user of the container image is not known. It can be changed via
+	// spec.securityContext.
+	runAsUser := int64(1000)
+
+	pod := util.GetTestPod(
+		instance.Name,
+		instance.Namespace,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		env.MergeEnvs(append([]corev1.EnvVar{}, stepSpec.Env...), envVars),
+		containerImage,
+		privileged,
+		runAsUser,
+		GetVolumes(stepSpec, logsPVCName, mountCerts),
+		GetVolumeMounts(mountCerts, stepSpec),
+	)
+
+	container := &pod.Spec.Containers[0]
+	container.Command = stepSpec.Command
+	container.Args = stepSpec.Args
+	container.WorkingDir = stepSpec.WorkingDir
+	if len(stepSpec.ResultFile) > 0 {
+		container.TerminationMessagePath = stepSpec.ResultFile
+	}
+
+	return pod
+}
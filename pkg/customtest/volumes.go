@@ -0,0 +1,32 @@
+package customtest
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetVolumes -
+func GetVolumes(
+	stepSpec testv1beta1.CustomTestSpec,
+	logsPVCName string,
+	mountCerts bool,
+) []corev1.Volume {
+	return util.GetTestVolumes(
+		stepSpec.CommonOptions,
+		stepSpec.CommonOpenstackConfig,
+		logsPVCName,
+		mountCerts,
+	)
+}
+
+// GetVolumeMounts -
+func GetVolumeMounts(mountCerts bool, stepSpec testv1beta1.CustomTestSpec) []corev1.VolumeMount {
+	return util.GetTestVolumeMounts(
+		stepSpec.CommonOptions,
+		HomeDir,
+		stepSpec.LogsMountPath,
+		mountCerts,
+	)
+}
@@ -0,0 +1,46 @@
+package debughold
+
+const (
+	// ContainerName - name of the container that keeps a failed test pod
+	// alive
+	ContainerName = "debug-hold"
+
+	// PauseContainerName - name of the init container that pauses the test
+	// pod before the test container starts
+	PauseContainerName = "debug-pause"
+
+	// ReleaseAnnotation - the debug-hold container exits once the annotation
+	// of the test pod is set to "true"
+	ReleaseAnnotation = "test.openstack.org/debug-hold-release"
+
+	// PauseAnnotation - the debug-pause init container exits once the
+	// annotation of the test pod is removed or set to anything else than
+	// "true"
+	PauseAnnotation = "test.openstack.org/debug-pause"
+
+	// DurationAnnotation - how long the test pod is kept once its test
+	// container fails. The pod is kept until it is released when the
+	// annotation is not set.
+	DurationAnnotation = "test.openstack.org/debug-hold-duration"
+
+	// StartTimeAnnotation - time when the test container failed and the hold
+	// of the test pod started
+	StartTimeAnnotation = "test.openstack.org/debug-hold-start-time"
+
+	// ContinueFile - the debug-pause init container exits once the file is
+	// created
+	ContinueFile = pauseMountPath + "/continue"
+
+	// annotationsVolumeName - name of the volume that exposes
+	// ReleaseAnnotation and PauseAnnotation to the debug containers
+	annotationsVolumeName = "debug-hold"
+
+	// annotationsMountPath - path the annotations volume is mounted to
+	annotationsMountPath = "/var/lib/debug-hold"
+
+	// pauseVolumeName - name of the volume ContinueFile is created in
+	pauseVolumeName = "debug-pause"
+
+	// pauseMountPath - path the pause volume is mounted to
+	pauseMountPath = "/var/lib/debug-pause"
+)
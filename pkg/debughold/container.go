@@ -0,0 +1,62 @@
+package debughold
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Script keeps the debug-hold container running until ReleaseAnnotation of
+// the test pod is set to "true". The annotation is exposed via the downward
+// API volume.
+var Script = `trap 'exit 0' TERM INT
+while [ "$(cat ` + annotationsMountPath + `/release 2>/dev/null)" != "true" ]; do
+    sleep 5 &
+    wait $!
+done`
+
+// PauseScript keeps the debug-pause init container running while
+// PauseAnnotation of the test pod is set to "true" and ContinueFile does not
+// exist
+var PauseScript = `trap 'exit 0' TERM INT
+echo "The test pod is paused. Remove the ` + PauseAnnotation + ` annotation of the pod or create ` + ContinueFile + ` to start the tests."
+while [ "$(cat ` + annotationsMountPath + `/pause 2>/dev/null)" = "true" ] && [ ! -e ` + ContinueFile + ` ]; do
+    sleep 5 &
+    wait $!
+done`
+
+// Container - prepare the container that keeps the test pod alive once the
+// test container fails. The container shares the image, the environment and
+// the volumes of the test container so that `oc rsh -c debug-hold` opens a
+// shell in an environment equivalent to the one of the test container.
+func Container(testContainer corev1.Container) corev1.Container {
+	return getDebugContainer(ContainerName, Script, testContainer, false)
+}
+
+// PauseContainer - prepare the init container that pauses the test pod
+// before the test container starts. Like the debug-hold container, it shares
+// the image, the environment and the volumes of the test container so that
+// files can be staged in the volumes using `oc rsh -c debug-pause`.
+func PauseContainer(testContainer corev1.Container) corev1.Container {
+	return getDebugContainer(PauseContainerName, PauseScript, testContainer, true)
+}
+
+func getDebugContainer(
+	name string,
+	script string,
+	testContainer corev1.Container,
+	pauseBeforeRun bool,
+) corev1.Container {
+	volumeMounts := append([]corev1.VolumeMount{}, testContainer.VolumeMounts...)
+	volumeMounts = append(volumeMounts, GetVolumeMounts(pauseBeforeRun)...)
+
+	return corev1.Container{
+		Name:            name,
+		Image:           testContainer.Image,
+		ImagePullPolicy: testContainer.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", script},
+		WorkingDir:      testContainer.WorkingDir,
+		Env:             testContainer.Env,
+		EnvFrom:         testContainer.EnvFrom,
+		VolumeMounts:    volumeMounts,
+		SecurityContext: testContainer.SecurityContext,
+	}
+}
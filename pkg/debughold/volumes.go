@@ -0,0 +1,63 @@
+package debughold
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetVolumes -
+func GetVolumes(pauseBeforeRun bool) []corev1.Volume {
+	volumes := []corev1.Volume{
+		{
+			Name: annotationsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				DownwardAPI: &corev1.DownwardAPIVolumeSource{
+					Items: []corev1.DownwardAPIVolumeFile{
+						{
+							Path: "release",
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: "metadata.annotations['" + ReleaseAnnotation + "']",
+							},
+						},
+						{
+							Path: "pause",
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: "metadata.annotations['" + PauseAnnotation + "']",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if pauseBeforeRun {
+		volumes = append(volumes, corev1.Volume{
+			Name: pauseVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
+	return volumes
+}
+
+// GetVolumeMounts -
+func GetVolumeMounts(pauseBeforeRun bool) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      annotationsVolumeName,
+			MountPath: annotationsMountPath,
+			ReadOnly:  true,
+		},
+	}
+
+	if pauseBeforeRun {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      pauseVolumeName,
+			MountPath: pauseMountPath,
+		})
+	}
+
+	return volumeMounts
+}
@@ -0,0 +1,15 @@
+package fiotest
+
+const (
+	// ServiceName - fiotest service name
+	ServiceName = "fiotest"
+
+	// HomeDir - home directory of the test container
+	HomeDir = "/var/lib/fio"
+
+	// TargetDir - directory the target PVC is mounted to
+	TargetDir = "/var/lib/fio/target"
+
+	// TargetPVCSuffix - suffix of the name of the target PVC of a test pod
+	TargetPVCSuffix = "-target"
+)
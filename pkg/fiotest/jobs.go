@@ -0,0 +1,42 @@
+package fiotest
+
+import (
+	"fmt"
+	"strings"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+// GetJobFile returns the fio job file that executes the profiles one after
+// another. The directory the jobs perform I/O in is not part of the job file,
+// it is set by the test container depending on the target.
+func GetJobFile(profiles []testv1beta1.FioProfile) string {
+	var jobFile strings.Builder
+	jobFile.WriteString("[global]\n")
+	jobFile.WriteString("ioengine=libaio\n")
+	jobFile.WriteString("time_based=1\n")
+	jobFile.WriteString("group_reporting=1\n")
+
+	for _, profile := range profiles {
+		direct := 0
+		if profile.Direct {
+			direct = 1
+		}
+
+		jobFile.WriteString(fmt.Sprintf("\n[%s]\n", profile.Name))
+		jobFile.WriteString("stonewall\n")
+		jobFile.WriteString(fmt.Sprintf("rw=%s\n", profile.ReadWrite))
+		jobFile.WriteString(fmt.Sprintf("bs=%s\n", profile.BlockSize))
+		jobFile.WriteString(fmt.Sprintf("iodepth=%d\n", profile.IODepth))
+		jobFile.WriteString(fmt.Sprintf("numjobs=%d\n", profile.NumJobs))
+		jobFile.WriteString(fmt.Sprintf("size=%s\n", profile.Size))
+		jobFile.WriteString(fmt.Sprintf("runtime=%d\n", int64(profile.Runtime.Seconds())))
+		jobFile.WriteString(fmt.Sprintf("direct=%d\n", direct))
+
+		if profile.RWMixRead != nil {
+			jobFile.WriteString(fmt.Sprintf("rwmixread=%d\n", *profile.RWMixRead))
+		}
+	}
+
+	return jobFile.String()
+}
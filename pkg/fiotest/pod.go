@@ -0,0 +1,44 @@
+package fiotest
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Pod - prepare pod to run fio profiles
+func Pod(
+	instance *testv1beta1.FioTest,
+	stepSpec testv1beta1.FioTestSpec,
+	effectiveSpec util.EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	logsPVCName string,
+	targetPVCName string,
+	mountCerts bool,
+	envVars map[string]env.Setter,
+	containerImage string,
+	privileged bool,
+) *corev1.Pod {
+	runAsUser := int64(42480)
+
+	pod := util.GetTestPod(
+		instance.Name,
+		instance.Namespace,
+		effectiveSpec,
+		labels,
+		annotations,
+		podName,
+		env.MergeEnvs([]corev1.EnvVar{}, envVars),
+		containerImage,
+		privileged,
+		runAsUser,
+		GetVolumes(stepSpec, logsPVCName, targetPVCName, mountCerts),
+		GetVolumeMounts(mountCerts, targetPVCName, stepSpec),
+	)
+
+	return pod
+}
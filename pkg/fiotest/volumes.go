@@ -0,0 +1,64 @@
+package fiotest
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetVolumes -
+func GetVolumes(
+	stepSpec testv1beta1.FioTestSpec,
+	logsPVCName string,
+	targetPVCName string,
+	mountCerts bool,
+) []corev1.Volume {
+
+	volumes := []corev1.Volume{}
+
+	if len(targetPVCName) > 0 {
+		targetVolume := corev1.Volume{
+			Name: "fio-target",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: targetPVCName,
+					ReadOnly:  false,
+				},
+			},
+		}
+
+		volumes = append(volumes, targetVolume)
+	}
+
+	return util.GetTestVolumes(
+		stepSpec.CommonOptions,
+		stepSpec.CommonOpenstackConfig,
+		logsPVCName,
+		mountCerts,
+		volumes...,
+	)
+}
+
+// GetVolumeMounts -
+func GetVolumeMounts(mountCerts bool, targetPVCName string, stepSpec testv1beta1.FioTestSpec) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+
+	if len(targetPVCName) > 0 {
+		targetMount := corev1.VolumeMount{
+			Name:      "fio-target",
+			MountPath: TargetDir,
+			ReadOnly:  false,
+		}
+
+		volumeMounts = append(volumeMounts, targetMount)
+	}
+
+	return util.GetTestVolumeMounts(
+		stepSpec.CommonOptions,
+		HomeDir,
+		HomeDir+"/external_files",
+		mountCerts,
+		volumeMounts...,
+	)
+}
@@ -0,0 +1,25 @@
+package horizontest
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+// GetBrowser returns the browser tested by the test pod of the workflow step
+// or an empty string when the instance does not list any browsers
+func GetBrowser(instance *testv1beta1.HorizonTest, workflowStep int) testv1beta1.HorizonTestBrowser {
+	if workflowStep < 0 || workflowStep >= len(instance.Spec.Browsers) {
+		return ""
+	}
+
+	return instance.Spec.Browsers[workflowStep]
+}
+
+// GetLogsDirName returns the directory of the logs PVC the test pod of the
+// browser stores its logs in
+func GetLogsDirName(browser testv1beta1.HorizonTestBrowser) string {
+	if len(browser) == 0 {
+		return LogsDirName
+	}
+
+	return LogsDirName + "-" + string(browser)
+}
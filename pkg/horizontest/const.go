@@ -2,4 +2,24 @@ package horizontest
 
 const (
 	ServiceName = "horizontest"
+
+	// LogsDirName - directory of the logs PVC the test pod stores its logs in.
+	// The test pod of a browser uses LogsDirName-<browser>.
+	LogsDirName = "horizon"
+
+	// VideoDirName - directory of the logs directory of the test pod the
+	// recordings of the browser sessions are stored in
+	VideoDirName = "videos"
+
+	// ScreenshotsDirName - directory of the logs directory of the test pod
+	// the screenshots and the page sources of the failed tests are stored in
+	ScreenshotsDirName = "screenshots"
+
+	// SeleniumGridUsernameKey - key of the username in the secret with the
+	// credentials of the Selenium Grid
+	SeleniumGridUsernameKey = "username"
+
+	// SeleniumGridPasswordKey - key of the password in the secret with the
+	// credentials of the Selenium Grid
+	SeleniumGridPasswordKey = "password"
 )
@@ -14,6 +14,7 @@ func Pod(
 	instance *testv1beta1.HorizonTest,
 	labels map[string]string,
 	podName string,
+	logsDirName string,
 	logsPVCName string,
 	mountCerts bool,
 	mountKeys bool,
@@ -27,6 +28,7 @@ func Pod(
 
 	capabilities := []corev1.Capability{"NET_ADMIN", "NET_RAW"}
 	securityContext := util.GetSecurityContext(runAsUser, capabilities, instance.Spec.Privileged)
+	hostNetwork := instance.Spec.Privileged && instance.Spec.HostNetwork
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -39,6 +41,13 @@ func Pod(
 			RestartPolicy:                corev1.RestartPolicyNever,
 			Tolerations:                  instance.Spec.Tolerations,
 			NodeSelector:                 instance.Spec.NodeSelector,
+			Affinity:                     instance.Spec.Affinity,
+			TopologySpreadConstraints:    instance.Spec.TopologySpreadConstraints,
+			RuntimeClassName:             instance.Spec.RuntimeClassName,
+			HostNetwork:                  hostNetwork,
+			DNSPolicy:                    util.GetDNSPolicy(hostNetwork, instance.Spec.DNSPolicy),
+			DNSConfig:                    instance.Spec.DNSConfig,
+			ImagePullSecrets:             util.GetImagePullSecrets(instance.Spec.ImagePullSecrets),
 			SecurityContext: &corev1.PodSecurityContext{
 				RunAsUser:  &runAsUser,
 				RunAsGroup: &runAsGroup,
@@ -48,8 +57,10 @@ func Pod(
 				{
 					Name:            instance.Name,
 					Image:           containerImage,
+					ImagePullPolicy: instance.Spec.ImagePullPolicy,
 					Args:            []string{},
 					Env:             env.MergeEnvs([]corev1.EnvVar{}, envVars),
+					EnvFrom:         util.GetEnvFromSources(instance.Spec.EnvFromSecrets, instance.Spec.EnvFromConfigMaps),
 					VolumeMounts:    GetVolumeMounts(mountCerts, mountKeys, mountKubeconfig, instance),
 					SecurityContext: &securityContext,
 					Resources:       instance.Spec.Resources,
@@ -70,5 +81,8 @@ func Pod(
 		}
 	}
 
+	util.ApplySecurityContext(pod, instance.Spec.SecurityContext)
+	util.SetArtifactDirectory(pod, logsDirName)
+
 	return pod
 }
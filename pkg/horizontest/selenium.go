@@ -0,0 +1,21 @@
+package horizontest
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetSecretKeyEnvVar returns an env var that is read from the key of the
+// secret (e.g. the credentials of the Selenium Grid) so that the value is not
+// stored in the pod definition
+func GetSecretKeyEnvVar(secretName string, key string) env.Setter {
+	return func(envVar *corev1.EnvVar) {
+		envVar.Value = ""
+		envVar.ValueFrom = &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		}
+	}
+}
@@ -81,7 +81,7 @@ func GetVolumes(
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
 					DefaultMode: &scriptsVolumeConfidentialMode,
-					SecretName:  "combined-ca-bundle",
+					SecretName:  util.GetCABundleSecretName(instance.Spec.CommonOptions),
 				},
 			},
 		}
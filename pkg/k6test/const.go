@@ -0,0 +1,15 @@
+package k6test
+
+const (
+	// ServiceName - k6test service name
+	ServiceName = "k6test"
+
+	// HomeDir - home directory of the test container
+	HomeDir = "/var/lib/k6"
+
+	// ScriptDir - directory the k6 script is mounted to
+	ScriptDir = "/var/lib/k6/script"
+
+	// ScriptFileName - name of the k6 script in ScriptDir
+	ScriptFileName = "script.js"
+)
@@ -0,0 +1,51 @@
+package k6test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+// GetStages returns the stages in the format of the K6_STAGES environment
+// variable of k6, e.g. 30s:10,1m0s:20
+func GetStages(stages []testv1beta1.K6Stage) string {
+	formatted := []string{}
+	for _, stage := range stages {
+		formatted = append(formatted, fmt.Sprintf("%s:%d", stage.Duration.Duration, stage.Target))
+	}
+
+	return strings.Join(formatted, ",")
+}
+
+// GetThresholds returns the thresholds in the format of the thresholds
+// option of k6 encoded as JSON. An empty string is returned when no threshold
+// is set.
+func GetThresholds(thresholds []testv1beta1.K6Threshold) (string, error) {
+	if len(thresholds) == 0 {
+		return "", nil
+	}
+
+	options := map[string][]interface{}{}
+	for _, threshold := range thresholds {
+		for _, condition := range threshold.Conditions {
+			if threshold.AbortOnFail {
+				options[threshold.Metric] = append(options[threshold.Metric], map[string]interface{}{
+					"threshold":   condition,
+					"abortOnFail": true,
+				})
+				continue
+			}
+
+			options[threshold.Metric] = append(options[threshold.Metric], condition)
+		}
+	}
+
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,73 @@
+package k6test
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetVolumes -
+func GetVolumes(
+	stepSpec testv1beta1.K6TestSpec,
+	logsPVCName string,
+	mountCerts bool,
+) []corev1.Volume {
+
+	var publicInfoMode int32 = 0744
+
+	volumes := []corev1.Volume{}
+
+	if stepSpec.ScriptConfigMap != nil {
+		scriptVolume := corev1.Volume{
+			Name: "k6-script",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &publicInfoMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: stepSpec.ScriptConfigMap.Name,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  stepSpec.ScriptConfigMap.Key,
+							Path: ScriptFileName,
+						},
+					},
+				},
+			},
+		}
+
+		volumes = append(volumes, scriptVolume)
+	}
+
+	return util.GetTestVolumes(
+		stepSpec.CommonOptions,
+		stepSpec.CommonOpenstackConfig,
+		logsPVCName,
+		mountCerts,
+		volumes...,
+	)
+}
+
+// GetVolumeMounts -
+func GetVolumeMounts(mountCerts bool, stepSpec testv1beta1.K6TestSpec) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+
+	if stepSpec.ScriptConfigMap != nil {
+		scriptMount := corev1.VolumeMount{
+			Name:      "k6-script",
+			MountPath: ScriptDir,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, scriptMount)
+	}
+
+	return util.GetTestVolumeMounts(
+		stepSpec.CommonOptions,
+		HomeDir,
+		HomeDir+"/external_files",
+		mountCerts,
+		volumeMounts...,
+	)
+}
@@ -0,0 +1,22 @@
+package polarion
+
+const (
+	// ServiceName - Polarion export service name
+	ServiceName = "polarion-export"
+
+	// PodNameSuffix - suffix of the Polarion export pod name
+	PodNameSuffix = "-polarion-export"
+
+	// LogsMountPath - path where the logs PVCs are mounted in the export pod.
+	// Each logs PVC is mounted to a directory named after the PVC.
+	LogsMountPath = "/var/lib/test-operator/polarion"
+
+	// UsernameSecretKey and PasswordSecretKey - keys of the credentials in
+	// the secret referred by credentialsSecretName
+	UsernameSecretKey = "username"
+	PasswordSecretKey = "password"
+
+	// DefaultTestCaseIDProperty - name of the testcase property that holds
+	// the ID of the test case in Polarion
+	DefaultTestCaseIDProperty = "polarion-testcase-id"
+)
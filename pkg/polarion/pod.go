@@ -0,0 +1,166 @@
+package polarion
+
+import (
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Script merges the testcases of the JUnit XML files and the subunit streams
+// (converted using subunit2junitxml) found in POLARION_DIRECTORIES into a
+// single xUnit file for the Polarion xUnit importer. The directories are
+// ordered from the oldest test pod so that the result of a rerun replaces
+// the original result of the testcase. The ID of the test case is matched by
+// POLARION_TESTCASE_ID_PATTERN and stored in the
+// POLARION_TESTCASE_ID_PROPERTY property of each testcase. Testcases without
+// an ID are skipped. The ID of the import job (or the error) is reported via
+// the termination message.
+var Script = `import json
+import os
+import re
+import subprocess
+import sys
+import xml.etree.ElementTree as ET
+
+
+def fail(message):
+    with open("/dev/termination-log", "w") as termination_log:
+        termination_log.write(message)
+    sys.exit(1)
+
+
+def read_results(path):
+    if path.endswith(".subunit"):
+        with open(path, "rb") as stream:
+            result = subprocess.run(["subunit2junitxml"], stdin=stream, capture_output=True)
+        return result.stdout if result.returncode == 0 else None
+    if path.endswith(".xml"):
+        with open(path, "rb") as stream:
+            return stream.read()
+    return None
+
+
+pattern = re.compile(os.environ.get("POLARION_TESTCASE_ID_PATTERN") or "^.*$")
+id_property = os.environ["POLARION_TESTCASE_ID_PROPERTY"]
+testcases = {}
+for directory in os.environ["POLARION_DIRECTORIES"].split():
+    for root, _, names in os.walk(directory):
+        for name in sorted(names):
+            try:
+                content = read_results(os.path.join(root, name))
+                tree = ET.fromstring(content) if content else None
+            except (OSError, ET.ParseError):
+                tree = None
+            if tree is None:
+                continue
+            for testcase in tree.iter("testcase"):
+                full_name = ".".join(filter(None, [testcase.get("classname"), testcase.get("name")]))
+                match = pattern.search(full_name)
+                if not match:
+                    continue
+                testcase_id = match.group(1) if pattern.groups else match.group(0)
+                for properties in testcase.findall("properties"):
+                    testcase.remove(properties)
+                properties = ET.SubElement(testcase, "properties")
+                ET.SubElement(properties, "property", name=id_property, value=testcase_id)
+                testcases[full_name] = testcase
+
+if not testcases:
+    fail("No testcases with the ID of the test case were found in the results")
+
+testsuites = ET.Element("testsuites")
+properties = ET.SubElement(testsuites, "properties")
+for name, value in json.loads(os.environ["POLARION_PROPERTIES"]).items():
+    ET.SubElement(properties, "property", name=name, value=value)
+testsuite = ET.SubElement(testsuites, "testsuite", name=os.environ["POLARION_TESTSUITE_NAME"])
+counts = {"tests": 0, "failures": 0, "errors": 0, "skipped": 0}
+for testcase in testcases.values():
+    testsuite.append(testcase)
+    counts["tests"] += 1
+    for kind, count in (("failure", "failures"), ("error", "errors"), ("skipped", "skipped")):
+        if testcase.find(kind) is not None:
+            counts[count] += 1
+for count, value in counts.items():
+    testsuite.set(count, str(value))
+ET.ElementTree(testsuites).write("/tmp/xunit.xml", encoding="utf-8", xml_declaration=True)
+
+command = ["curl", "-sSf", "-u", os.environ["POLARION_USERNAME"] + ":" + os.environ["POLARION_PASSWORD"],
+           "-F", "file=@/tmp/xunit.xml", os.environ["POLARION_URL"]]
+if os.environ.get("POLARION_TLS_INSECURE_SKIP_VERIFY") == "true":
+    command.insert(1, "--insecure")
+result = subprocess.run(command, capture_output=True, text=True)
+if result.returncode != 0:
+    fail("Unable to import the results: " + result.stderr.strip())
+try:
+    job_ids = [str(job_id) for file in json.loads(result.stdout)["files"].values() for job_id in file["job-ids"]]
+except (ValueError, KeyError, TypeError, AttributeError):
+    fail("Unexpected response: " + result.stdout.strip())
+with open("/dev/termination-log", "w") as termination_log:
+    termination_log.write(" ".join(job_ids))
+`
+
+// Pod - prepare pod that exports the test results stored in the logs PVCs to
+// the Polarion xUnit importer
+func Pod(
+	namespace string,
+	labels map[string]string,
+	podName string,
+	containerImage string,
+	envVars []corev1.EnvVar,
+	claimNames []string,
+	credentialsSecretName string,
+) *corev1.Pod {
+	runAsUser := int64(42480)
+	runAsGroup := int64(42480)
+
+	securityContext := util.GetSecurityContext(runAsUser, []corev1.Capability{}, false)
+
+	for _, credential := range []struct {
+		name string
+		key  string
+	}{
+		{"POLARION_USERNAME", UsernameSecretKey},
+		{"POLARION_PASSWORD", PasswordSecretKey},
+	} {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: credential.name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
+					Key:                  credential.key,
+				},
+			},
+		})
+	}
+
+	envVars = append(envVars, corev1.EnvVar{Name: "HOME", Value: "/tmp"})
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &runAsUser,
+				RunAsGroup: &runAsGroup,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:                     ServiceName,
+					Image:                    containerImage,
+					Command:                  []string{"python3", "-c", Script},
+					Env:                      envVars,
+					VolumeMounts:             GetVolumeMounts(claimNames),
+					SecurityContext:          &securityContext,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+			},
+			Volumes: GetVolumes(claimNames),
+		},
+	}
+
+	return pod
+}
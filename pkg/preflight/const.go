@@ -0,0 +1,16 @@
+package preflight
+
+const (
+	// ServiceName - endpoint preflight service name
+	ServiceName = "endpoint-preflight"
+
+	// PodNameSuffix - suffix of the endpoint preflight pod name
+	PodNameSuffix = "-endpoint-preflight"
+
+	// EndpointInterface - interface of the catalog endpoints that are checked
+	EndpointInterface = "public"
+
+	// ProbeTimeout - number of seconds after which an endpoint that did not
+	// respond is considered unreachable
+	ProbeTimeout = 10
+)
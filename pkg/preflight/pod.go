@@ -0,0 +1,175 @@
+package preflight
+
+import (
+	"fmt"
+
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// probeScript lists the endpoints from the service catalog and checks
+// whether each of them responds to an HTTP request. The results are written
+// as a JSON list of EndpointChecks to the termination log of the container so
+// that the controller can store them in the status of the instance. The
+// script exits with a non-zero code only when the catalog can not be listed.
+const probeScript = `
+import json
+import socket
+import subprocess
+import sys
+import time
+import urllib.error
+import urllib.parse
+import urllib.request
+
+INTERFACE = sys.argv[1]
+TIMEOUT = int(sys.argv[2])
+TERMINATION_LOG = "/dev/termination-log"
+MAX_MESSAGE_SIZE = 4096
+MAX_ERROR_SIZE = 100
+
+
+def report(checks):
+    for check in checks:
+        latency = "-"
+        if "latencyMilliseconds" in check:
+            latency = "%dms" % check["latencyMilliseconds"]
+        print("%-12s %-8s %-6s %8s %s %s" % (
+            check["service"], check["interface"],
+            "OK" if check["reachable"] else "FAILED", latency,
+            check.get("url", ""), check.get("error", "")))
+
+    # The termination message has to stay a valid JSON list. The URLs and
+    # the errors are dropped first, then the reachable endpoints and finally
+    # the unreachable endpoints except the first one so that the controller
+    # still reports the endpoints as unreachable.
+    checks = [dict(check) for check in checks]
+    message = encode(checks)
+    for key in ("url", "error"):
+        if len(message) <= MAX_MESSAGE_SIZE:
+            break
+        for check in checks:
+            check.pop(key, None)
+        message = encode(checks)
+
+    checks.sort(key=lambda check: check["reachable"])
+    while len(message) > MAX_MESSAGE_SIZE and len(checks) > 1:
+        checks.pop()
+        message = encode(checks)
+
+    with open(TERMINATION_LOG, "w") as f:
+        f.write(message)
+
+
+def encode(checks):
+    return json.dumps(checks, separators=(",", ":"))
+
+
+def probe(service, url):
+    check = {"service": service, "interface": INTERFACE, "url": url,
+             "reachable": False}
+
+    host = urllib.parse.urlsplit(url).hostname
+    try:
+        socket.getaddrinfo(host, None)
+    except (socket.gaierror, UnicodeError) as e:
+        check["error"] = ("DNS resolution of %s failed: %s" % (host, e))[:MAX_ERROR_SIZE]
+        return check
+
+    start = time.monotonic()
+    try:
+        urllib.request.urlopen(url, timeout=TIMEOUT).close()
+    except urllib.error.HTTPError:
+        # Any HTTP response means that the endpoint is reachable
+        pass
+    except Exception as e:
+        check["error"] = str(getattr(e, "reason", e))[:MAX_ERROR_SIZE]
+        return check
+
+    check["reachable"] = True
+    check["latencyMilliseconds"] = int((time.monotonic() - start) * 1000)
+    return check
+
+
+def endpoints(entry):
+    # Older clients return the endpoints as a formatted string
+    if isinstance(entry["Endpoints"], str):
+        for line in entry["Endpoints"].splitlines():
+            interface, _, url = line.strip().partition(": ")
+            yield {"interface": interface, "url": url}
+    else:
+        yield from entry["Endpoints"]
+
+
+try:
+    catalog = json.loads(subprocess.run(
+        ["openstack", "catalog", "list", "-f", "json"],
+        check=True, capture_output=True, text=True).stdout)
+except Exception as e:
+    error = getattr(e, "stderr", None) or str(e)
+    report([{"service": "keystone", "interface": INTERFACE, "reachable": False,
+             "error": error.strip()[:MAX_ERROR_SIZE]}])
+    sys.exit(1)
+
+checks = []
+for entry in catalog:
+    for endpoint in endpoints(entry):
+        if endpoint["interface"] == INTERFACE:
+            checks.append(probe(entry["Name"], endpoint["url"]))
+
+report(checks)
+`
+
+// Pod - prepare pod that checks the reachability of the OpenStack endpoints
+// before the tests are started
+func Pod(
+	namespace string,
+	labels map[string]string,
+	podName string,
+	containerImage string,
+	caBundleSecretName string,
+) *corev1.Pod {
+	runAsUser := int64(42480)
+	runAsGroup := int64(42480)
+
+	securityContext := util.GetSecurityContext(runAsUser, []corev1.Capability{}, false)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &runAsUser,
+				RunAsGroup: &runAsGroup,
+				FSGroup:    &runAsGroup,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  ServiceName,
+					Image: containerImage,
+					Command: []string{
+						"python3",
+						"-c",
+						probeScript,
+						EndpointInterface,
+						fmt.Sprint(ProbeTimeout),
+					},
+					Env: []corev1.EnvVar{
+						{Name: "OS_CLOUD", Value: "default"},
+						{Name: "HOME", Value: "/tmp"},
+					},
+					VolumeMounts:    util.GetOpenStackClientVolumeMounts(len(caBundleSecretName) > 0),
+					SecurityContext: &securityContext,
+				},
+			},
+			Volumes: util.GetOpenStackClientVolumes(caBundleSecretName),
+		},
+	}
+
+	return pod
+}
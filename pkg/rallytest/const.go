@@ -0,0 +1,15 @@
+package rallytest
+
+const (
+	// ServiceName - rallytest service name
+	ServiceName = "rallytest"
+
+	// HomeDir - home directory of the test container
+	HomeDir = "/var/lib/rally"
+
+	// TaskDir - directory the rally task file is mounted to
+	TaskDir = "/var/lib/rally/task"
+
+	// TaskFileName - name of the rally task file in TaskDir
+	TaskFileName = "task.yaml"
+)
@@ -0,0 +1,40 @@
+package rallytest
+
+import (
+	"encoding/json"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+// GetSLA returns the SLA thresholds in the format of the sla section of a
+// rally workload encoded as JSON. An empty string is returned when no
+// threshold is set.
+func GetSLA(sla *testv1beta1.RallySLA) (string, error) {
+	if sla == nil {
+		return "", nil
+	}
+
+	criteria := map[string]interface{}{}
+	if sla.MaxFailureRate != nil {
+		criteria["failure_rate"] = map[string]interface{}{"max": *sla.MaxFailureRate}
+	}
+
+	if sla.MaxAvgDuration != nil {
+		criteria["max_avg_duration"] = sla.MaxAvgDuration.Seconds()
+	}
+
+	if sla.MaxIterationDuration != nil {
+		criteria["max_seconds_per_iteration"] = sla.MaxIterationDuration.Seconds()
+	}
+
+	if len(criteria) == 0 {
+		return "", nil
+	}
+
+	encoded, err := json.Marshal(criteria)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
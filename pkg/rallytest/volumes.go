@@ -0,0 +1,73 @@
+package rallytest
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetVolumes -
+func GetVolumes(
+	stepSpec testv1beta1.RallyTestSpec,
+	logsPVCName string,
+	mountCerts bool,
+) []corev1.Volume {
+
+	var publicInfoMode int32 = 0744
+
+	volumes := []corev1.Volume{}
+
+	if stepSpec.TaskConfigMap != nil {
+		taskVolume := corev1.Volume{
+			Name: "rally-task",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &publicInfoMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: stepSpec.TaskConfigMap.Name,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  stepSpec.TaskConfigMap.Key,
+							Path: TaskFileName,
+						},
+					},
+				},
+			},
+		}
+
+		volumes = append(volumes, taskVolume)
+	}
+
+	return util.GetTestVolumes(
+		stepSpec.CommonOptions,
+		stepSpec.CommonOpenstackConfig,
+		logsPVCName,
+		mountCerts,
+		volumes...,
+	)
+}
+
+// GetVolumeMounts -
+func GetVolumeMounts(mountCerts bool, stepSpec testv1beta1.RallyTestSpec) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+
+	if stepSpec.TaskConfigMap != nil {
+		taskMount := corev1.VolumeMount{
+			Name:      "rally-task",
+			MountPath: TaskDir,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, taskMount)
+	}
+
+	return util.GetTestVolumeMounts(
+		stepSpec.CommonOptions,
+		HomeDir,
+		HomeDir+"/external_files",
+		mountCerts,
+		volumeMounts...,
+	)
+}
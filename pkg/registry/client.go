@@ -0,0 +1,274 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout of a single request to a registry
+	DefaultTimeout = 30 * time.Second
+
+	// Maximum size of a manifest or a signature payload read from a registry
+	maxResponseSize = 4 * 1024 * 1024
+)
+
+const (
+	ErrUnexpectedStatus = "registry %s responded with %s to %s"
+	ErrNoDigest         = "registry %s did not return the digest of %s"
+	ErrInvalidToken     = "registry %s returned an invalid token: %s"
+)
+
+// manifestMediaTypes are the media types of the manifests the client accepts
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Credentials used to authenticate to a registry
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Client talks to registries that implement the OCI distribution API
+type Client struct {
+	HTTPClient *http.Client
+
+	// Credentials indexed by the registry host. Registries without
+	// credentials are accessed anonymously.
+	Credentials map[string]Credentials
+}
+
+// NewClient returns a client that uses the given credentials
+func NewClient(credentials map[string]Credentials) *Client {
+	return &Client{
+		HTTPClient:  &http.Client{Timeout: DefaultTimeout},
+		Credentials: credentials,
+	}
+}
+
+// ResolveDigest returns the digest of the manifest the reference points to.
+// References that are already pinned to a digest are returned as they are.
+func (c *Client) ResolveDigest(ctx context.Context, ref Reference) (string, error) {
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+
+	resp, err := c.get(ctx, ref, http.MethodHead, "manifests/"+ref.Tag, manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// Some registries return the digest for GET requests only
+	body, err := c.fetch(ctx, ref, "manifests/"+ref.Tag, manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+
+	if len(body) == 0 {
+		return "", fmt.Errorf(ErrNoDigest, ref.Registry, ref)
+	}
+
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// fetch returns the body of a GET request to the API of the repository of
+// the reference
+func (c *Client) fetch(ctx context.Context, ref Reference, path string, accept []string) ([]byte, error) {
+	resp, err := c.get(ctx, ref, http.MethodGet, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+}
+
+// get sends a request to the API of the repository of the reference. When
+// the registry asks for authentication, the request is repeated with the
+// credentials of the registry (Basic) or with a token obtained using them
+// (Bearer).
+func (c *Client) get(
+	ctx context.Context,
+	ref Reference,
+	method string,
+	path string,
+	accept []string,
+) (*http.Response, error) {
+	requestURL := "https://" + ref.apiHost() + "/v2/" + ref.Repository + "/" + path
+	resp, err := c.do(ctx, method, requestURL, accept, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		authorization, err := c.authorize(ctx, ref, challenge)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err = c.do(ctx, method, requestURL, accept, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf(ErrUnexpectedStatus, ref.Registry, resp.Status, path)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	requestURL string,
+	accept []string,
+	authorization string,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	return c.HTTPClient.Do(req)
+}
+
+// authorize returns the value of the Authorization header that answers the
+// challenge of the registry
+func (c *Client) authorize(ctx context.Context, ref Reference, challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	credentials, hasCredentials := c.Credentials[ref.Registry]
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCredentials {
+			return "", fmt.Errorf(ErrUnexpectedStatus, ref.Registry, "401 Unauthorized", ref)
+		}
+
+		return "Basic " + basicAuth(credentials), nil
+
+	case "bearer":
+		tokenURL, err := url.Parse(params["realm"])
+		if err != nil || tokenURL.Scheme == "" {
+			return "", fmt.Errorf(ErrInvalidToken, ref.Registry, "invalid realm "+params["realm"])
+		}
+
+		query := tokenURL.Query()
+		if service := params["service"]; service != "" {
+			query.Set("service", service)
+		}
+
+		scope := params["scope"]
+		if scope == "" {
+			scope = "repository:" + ref.Repository + ":pull"
+		}
+		query.Set("scope", scope)
+		tokenURL.RawQuery = query.Encode()
+
+		authorization := ""
+		if hasCredentials {
+			authorization = "Basic " + basicAuth(credentials)
+		}
+
+		resp, err := c.do(ctx, http.MethodGet, tokenURL.String(), nil, authorization)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf(ErrUnexpectedStatus, ref.Registry, resp.Status, "token request")
+		}
+
+		token := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+
+		err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&token)
+		if err != nil {
+			return "", fmt.Errorf(ErrInvalidToken, ref.Registry, err)
+		}
+
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+
+		if token.Token == "" {
+			return "", fmt.Errorf(ErrInvalidToken, ref.Registry, "empty token")
+		}
+
+		return "Bearer " + token.Token, nil
+
+	default:
+		return "", errors.New("unsupported authentication challenge: " + challenge)
+	}
+}
+
+// parseChallenge parses the value of the WWW-Authenticate header, e.g.
+// Bearer realm="https://auth.example.com/token",service="registry"
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+
+	for rest != "" {
+		var pair string
+		rest = strings.TrimLeft(rest, " ,")
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				break
+			}
+
+			pair, rest = value[1:end+1], value[end+2:]
+		} else {
+			pair, rest, _ = strings.Cut(value, ",")
+		}
+
+		params[strings.ToLower(strings.TrimSpace(key))] = pair
+	}
+
+	return scheme, params
+}
+
+func basicAuth(credentials Credentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + credentials.Password))
+}
@@ -0,0 +1,286 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	testRepository = "test/image"
+	testToken      = "secret-token"
+	testService    = "fake-registry"
+)
+
+// fakeRegistry is a registry that serves the manifests and the blobs of
+// testRepository. The requests to the API are authenticated according to
+// auth ("", "basic" or "bearer").
+type fakeRegistry struct {
+	auth     string
+	username string
+	password string
+
+	// tokenKey is the key of the token in the response of the token
+	// endpoint (token or access_token). No token is returned when empty.
+	tokenKey string
+
+	// noHeadDigest disables the Docker-Content-Digest header of the HEAD
+	// responses
+	noHeadDigest bool
+
+	// content indexed by the path relative to /v2/<testRepository>/
+	content map[string][]byte
+
+	server *httptest.Server
+}
+
+func (f *fakeRegistry) start(t *testing.T) Reference {
+	t.Helper()
+
+	f.server = httptest.NewTLSServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+
+	return Reference{
+		Registry:   strings.TrimPrefix(f.server.URL, "https://"),
+		Repository: testRepository,
+		Tag:        "latest",
+	}
+}
+
+func (f *fakeRegistry) client(credentials map[string]Credentials) *Client {
+	client := NewClient(credentials)
+	client.HTTPClient = f.server.Client()
+	return client
+}
+
+func (f *fakeRegistry) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		f.serveToken(w, r)
+		return
+	}
+
+	if !f.authorized(r) {
+		switch f.auth {
+		case "basic":
+			w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+		case "bearer":
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+f.server.URL+`/token",service="`+testService+`"`)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, found := f.content[strings.TrimPrefix(r.URL.Path, "/v2/"+testRepository+"/")]
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodHead {
+		if !f.noHeadDigest {
+			w.Header().Set("Docker-Content-Digest", sha256Digest(body))
+		}
+		return
+	}
+
+	_, _ = w.Write(body)
+}
+
+func (f *fakeRegistry) serveToken(w http.ResponseWriter, r *http.Request) {
+	username, password, _ := r.BasicAuth()
+	if username != f.username || password != f.password ||
+		r.URL.Query().Get("service") != testService ||
+		r.URL.Query().Get("scope") != "repository:"+testRepository+":pull" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	token := map[string]string{}
+	if f.tokenKey != "" {
+		token[f.tokenKey] = testToken
+	}
+
+	_ = json.NewEncoder(w).Encode(token)
+}
+
+func (f *fakeRegistry) authorized(r *http.Request) bool {
+	switch f.auth {
+	case "basic":
+		username, password, ok := r.BasicAuth()
+		return ok && username == f.username && password == f.password
+	case "bearer":
+		return r.Header.Get("Authorization") == "Bearer "+testToken
+	}
+
+	return true
+}
+
+func sha256Digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestResolveDigest(t *testing.T) {
+	manifest := []byte(`{"schemaVersion":2}`)
+	credentials := Credentials{Username: "user", Password: "pass"}
+
+	tests := []struct {
+		name        string
+		registry    fakeRegistry
+		credentials *Credentials
+		digest      string
+		wantDigest  string
+		wantErr     string
+	}{
+		{
+			name:       "anonymous",
+			registry:   fakeRegistry{},
+			wantDigest: sha256Digest(manifest),
+		},
+		{
+			name:       "digest of the GET response",
+			registry:   fakeRegistry{noHeadDigest: true},
+			wantDigest: sha256Digest(manifest),
+		},
+		{
+			name:       "pinned reference",
+			registry:   fakeRegistry{auth: "basic"},
+			digest:     "sha256:pinned",
+			wantDigest: "sha256:pinned",
+		},
+		{
+			name:        "basic challenge",
+			registry:    fakeRegistry{auth: "basic", username: "user", password: "pass"},
+			credentials: &credentials,
+			wantDigest:  sha256Digest(manifest),
+		},
+		{
+			name:     "basic challenge without credentials",
+			registry: fakeRegistry{auth: "basic", username: "user", password: "pass"},
+			wantErr:  "401 Unauthorized",
+		},
+		{
+			name:       "anonymous bearer token",
+			registry:   fakeRegistry{auth: "bearer", tokenKey: "token"},
+			wantDigest: sha256Digest(manifest),
+		},
+		{
+			name:        "bearer token obtained with credentials",
+			registry:    fakeRegistry{auth: "bearer", username: "user", password: "pass", tokenKey: "token"},
+			credentials: &credentials,
+			wantDigest:  sha256Digest(manifest),
+		},
+		{
+			name:       "bearer access_token",
+			registry:   fakeRegistry{auth: "bearer", tokenKey: "access_token"},
+			wantDigest: sha256Digest(manifest),
+		},
+		{
+			name:        "bearer token with wrong credentials",
+			registry:    fakeRegistry{auth: "bearer", username: "user", password: "other", tokenKey: "token"},
+			credentials: &credentials,
+			wantErr:     "token request",
+		},
+		{
+			name:     "empty bearer token",
+			registry: fakeRegistry{auth: "bearer"},
+			wantErr:  "empty token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := tt.registry
+			registry.content = map[string][]byte{"manifests/latest": manifest}
+			ref := registry.start(t)
+			ref.Digest = tt.digest
+
+			credentialsMap := map[string]Credentials{}
+			if tt.credentials != nil {
+				credentialsMap[ref.Registry] = *tt.credentials
+			}
+
+			digest, err := registry.client(credentialsMap).ResolveDigest(context.Background(), ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveDigest() error = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResolveDigest() error = %v", err)
+			}
+
+			if digest != tt.wantDigest {
+				t.Errorf("ResolveDigest() = %s, want %s", digest, tt.wantDigest)
+			}
+		})
+	}
+}
+
+func TestResolveDigestNotFound(t *testing.T) {
+	registry := fakeRegistry{}
+	ref := registry.start(t)
+	ref.Tag = "missing"
+
+	_, err := registry.client(nil).ResolveDigest(context.Background(), ref)
+	if err == nil || !strings.Contains(err.Error(), "404 Not Found") {
+		t.Errorf("ResolveDigest() error = %v, want a 404 Not Found error", err)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	tests := []struct {
+		challenge  string
+		wantScheme string
+		wantParams map[string]string
+	}{
+		{
+			challenge:  `Basic realm="registry"`,
+			wantScheme: "Basic",
+			wantParams: map[string]string{"realm": "registry"},
+		},
+		{
+			challenge:  `Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull,push"`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{
+				"realm":   "https://auth.example.com/token",
+				"service": "registry.example.com",
+				"scope":   "repository:a/b:pull,push",
+			},
+		},
+		{
+			challenge:  `Bearer Realm=https://auth.example.com/token, service=registry`,
+			wantScheme: "Bearer",
+			wantParams: map[string]string{
+				"realm":   "https://auth.example.com/token",
+				"service": "registry",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		scheme, params := parseChallenge(tt.challenge)
+		if scheme != tt.wantScheme {
+			t.Errorf("parseChallenge(%q) scheme = %s, want %s", tt.challenge, scheme, tt.wantScheme)
+		}
+
+		if len(params) != len(tt.wantParams) {
+			t.Errorf("parseChallenge(%q) params = %v, want %v", tt.challenge, params, tt.wantParams)
+			continue
+		}
+
+		for key, value := range tt.wantParams {
+			if params[key] != value {
+				t.Errorf("parseChallenge(%q) params[%s] = %q, want %q", tt.challenge, key, params[key], value)
+			}
+		}
+	}
+}
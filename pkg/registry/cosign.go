@@ -0,0 +1,151 @@
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// cosignSignatureAnnotation is the annotation of a layer of a cosign
+	// signature manifest that contains the base64 encoded signature of the
+	// layer (the simple signing payload)
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+)
+
+const (
+	ErrInvalidPublicKey    = "invalid cosign public key: %s"
+	ErrNoValidSignature    = "no valid cosign signature of %s signed by the public key was found"
+	ErrSignatureNotFound   = "cosign signature of %s was not found: %w"
+	ErrUnsupportedKeyType  = "unsupported cosign public key type %T"
+	ErrInvalidSignedDigest = "signed digest %s does not match %s"
+)
+
+type signatureManifest struct {
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// VerifyCosignSignature verifies that the image with the given digest has a
+// cosign signature made by the owner of the PEM encoded public key. The
+// signatures are looked up using the cosign tag convention
+// (<repository>:sha256-<hex>.sig).
+func (c *Client) VerifyCosignSignature(
+	ctx context.Context,
+	ref Reference,
+	digest string,
+	publicKeyPEM []byte,
+) error {
+	publicKey, err := parsePublicKey(publicKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	signatureTag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	body, err := c.fetch(ctx, ref, "manifests/"+signatureTag, []string{ociManifestMediaType})
+	if err != nil {
+		return fmt.Errorf(ErrSignatureNotFound, ref.WithDigest(digest), err)
+	}
+
+	manifest := signatureManifest{}
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return fmt.Errorf(ErrSignatureNotFound, ref.WithDigest(digest), err)
+	}
+
+	var verifyErr error
+	for _, layer := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+
+		payload, err := c.fetch(ctx, ref, "blobs/"+layer.Digest, nil)
+		if err != nil {
+			verifyErr = err
+			continue
+		}
+
+		if err := verifyPayload(publicKey, payload, signature, digest); err != nil {
+			verifyErr = err
+			continue
+		}
+
+		return nil
+	}
+
+	if verifyErr != nil {
+		return fmt.Errorf("%s: %w", fmt.Sprintf(ErrNoValidSignature, ref.WithDigest(digest)), verifyErr)
+	}
+
+	return fmt.Errorf(ErrNoValidSignature, ref.WithDigest(digest))
+}
+
+// verifyPayload verifies the signature of the simple signing payload and
+// checks that the payload refers to the digest
+func verifyPayload(publicKey crypto.PublicKey, payload []byte, signature []byte, digest string) error {
+	hash := sha256.Sum256(payload)
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hash[:], signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature); err != nil {
+			return err
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, signature) {
+			return errors.New("invalid Ed25519 signature")
+		}
+	default:
+		return fmt.Errorf(ErrUnsupportedKeyType, publicKey)
+	}
+
+	signed := simpleSigningPayload{}
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return err
+	}
+
+	if signed.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf(ErrInvalidSignedDigest, signed.Critical.Image.DockerManifestDigest, digest)
+	}
+
+	return nil
+}
+
+func parsePublicKey(publicKeyPEM []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return nil, fmt.Errorf(ErrInvalidPublicKey, "no PEM block found")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf(ErrInvalidPublicKey, err)
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,241 @@
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+const testImageDigest = "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+
+// testSigner signs cosign payloads with a private key of one of the
+// supported key types
+type testSigner struct {
+	publicKeyPEM []byte
+	sign         func(payload []byte) []byte
+}
+
+func newTestSigner(t *testing.T, keyType string) testSigner {
+	t.Helper()
+
+	var publicKey crypto.PublicKey
+	var sign func(payload []byte) []byte
+
+	switch keyType {
+	case "ecdsa":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKey = &key.PublicKey
+		sign = func(payload []byte) []byte {
+			hash := sha256.Sum256(payload)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return signature
+		}
+	case "rsa":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKey = &key.PublicKey
+		sign = func(payload []byte) []byte {
+			hash := sha256.Sum256(payload)
+			signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return signature
+		}
+	case "ed25519":
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKey = public
+		sign = func(payload []byte) []byte {
+			return ed25519.Sign(private, payload)
+		}
+	default:
+		t.Fatalf("unknown key type %s", keyType)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testSigner{
+		publicKeyPEM: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		sign:         sign,
+	}
+}
+
+// signatureContent returns the cosign signature manifest and the payload
+// blob of a signature of signedDigest, stored the way cosign stores the
+// signature of imageDigest
+func signatureContent(t *testing.T, signer testSigner, imageDigest string, signedDigest string) map[string][]byte {
+	t.Helper()
+
+	payload := simpleSigningPayload{}
+	payload.Critical.Image.DockerManifestDigest = signedDigest
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payloadDigest := sha256Digest(payloadJSON)
+	manifest, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     ociManifestMediaType,
+		"layers": []map[string]interface{}{
+			{
+				"mediaType": "application/vnd.dev.cosign.simplesigning.v1+json",
+				"digest":    payloadDigest,
+				"annotations": map[string]string{
+					cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signer.sign(payloadJSON)),
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string][]byte{
+		"manifests/" + strings.Replace(imageDigest, ":", "-", 1) + ".sig": manifest,
+		"blobs/" + payloadDigest: payloadJSON,
+	}
+}
+
+func TestVerifyCosignSignature(t *testing.T) {
+	tests := []struct {
+		name string
+		auth string
+
+		// keyType of the key that signed the image
+		keyType string
+
+		// verifyKeyType is the type of the key the signature is verified
+		// with. A different key of the type is generated when it is set.
+		verifyKeyType string
+
+		signedDigest string
+		noSignature  bool
+		wantErr      string
+	}{
+		{
+			name:    "ECDSA",
+			keyType: "ecdsa",
+		},
+		{
+			name:    "RSA",
+			keyType: "rsa",
+		},
+		{
+			name:    "Ed25519",
+			keyType: "ed25519",
+		},
+		{
+			name:    "signature behind a bearer token",
+			auth:    "bearer",
+			keyType: "ecdsa",
+		},
+		{
+			name:         "signed digest does not match",
+			keyType:      "ecdsa",
+			signedDigest: "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			wantErr:      "does not match " + testImageDigest,
+		},
+		{
+			name:          "ECDSA signature of another key",
+			keyType:       "ecdsa",
+			verifyKeyType: "ecdsa",
+			wantErr:       "invalid ECDSA signature",
+		},
+		{
+			name:          "RSA signature of another key",
+			keyType:       "rsa",
+			verifyKeyType: "rsa",
+			wantErr:       "verification error",
+		},
+		{
+			name:          "Ed25519 signature of another key",
+			keyType:       "ed25519",
+			verifyKeyType: "ed25519",
+			wantErr:       "invalid Ed25519 signature",
+		},
+		{
+			name:          "signature of another key type",
+			keyType:       "ecdsa",
+			verifyKeyType: "ed25519",
+			wantErr:       "invalid Ed25519 signature",
+		},
+		{
+			name:        "missing .sig tag",
+			keyType:     "ecdsa",
+			noSignature: true,
+			wantErr:     "cosign signature of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := newTestSigner(t, tt.keyType)
+			publicKeyPEM := signer.publicKeyPEM
+			if tt.verifyKeyType != "" {
+				publicKeyPEM = newTestSigner(t, tt.verifyKeyType).publicKeyPEM
+			}
+
+			signedDigest := tt.signedDigest
+			if signedDigest == "" {
+				signedDigest = testImageDigest
+			}
+
+			registry := fakeRegistry{auth: tt.auth, tokenKey: "token", content: map[string][]byte{}}
+			if !tt.noSignature {
+				registry.content = signatureContent(t, signer, testImageDigest, signedDigest)
+			}
+
+			ref := registry.start(t)
+			err := registry.client(nil).VerifyCosignSignature(context.Background(), ref, testImageDigest, publicKeyPEM)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("VerifyCosignSignature() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("VerifyCosignSignature() error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyCosignSignatureInvalidPublicKey(t *testing.T) {
+	registry := fakeRegistry{}
+	ref := registry.start(t)
+
+	err := registry.client(nil).VerifyCosignSignature(context.Background(), ref, testImageDigest, []byte("not a key"))
+	if err == nil || !strings.Contains(err.Error(), "invalid cosign public key") {
+		t.Errorf("VerifyCosignSignature() error = %v, want an invalid public key error", err)
+	}
+}
@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+// ParseDockerConfig returns the credentials stored in the content of a
+// kubernetes.io/dockerconfigjson secret indexed by the registry host
+func ParseDockerConfig(data []byte) (map[string]Credentials, error) {
+	config := dockerConfig{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	credentials := map[string]Credentials{}
+	for server, auth := range config.Auths {
+		entry := Credentials{Username: auth.Username, Password: auth.Password}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, err
+			}
+
+			entry.Username, entry.Password, _ = strings.Cut(string(decoded), ":")
+		}
+
+		credentials[registryHost(server)] = entry
+	}
+
+	return credentials, nil
+}
+
+// registryHost returns the registry host of a server entry of a docker
+// config (e.g. https://index.docker.io/v1/ -> docker.io)
+func registryHost(server string) string {
+	host := server
+	if _, after, found := strings.Cut(host, "://"); found {
+		host = after
+	}
+
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "index.docker.io", dockerHubRegistry:
+		return DefaultRegistry
+	}
+
+	return host
+}
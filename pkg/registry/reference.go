@@ -0,0 +1,121 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultRegistry is the registry of the image references that do not
+	// contain a registry host (e.g. quay.io)
+	DefaultRegistry = "docker.io"
+
+	// dockerHubRegistry is the host that serves the API of DefaultRegistry
+	dockerHubRegistry = "registry-1.docker.io"
+
+	defaultTag = "latest"
+)
+
+const (
+	ErrInvalidReference = "invalid image reference %q"
+)
+
+// Reference is a parsed container image reference
+type Reference struct {
+	// Registry host (e.g. quay.io or localhost:5000)
+	Registry string
+
+	// Repository in the registry (e.g. podified-antelope-centos9/openstack-tempest)
+	Repository string
+
+	// Tag of the image. It is empty when the reference contains a digest
+	// only.
+	Tag string
+
+	// Digest of the image (e.g. sha256:...). It is empty unless the reference
+	// is pinned to a digest.
+	Digest string
+}
+
+// ParseReference parses an image reference in the format used by the
+// container runtimes: [registry/]repository[:tag][@digest]
+func ParseReference(image string) (Reference, error) {
+	ref := Reference{}
+	name := image
+
+	if before, digest, found := strings.Cut(name, "@"); found {
+		name = before
+		ref.Digest = digest
+		if !strings.Contains(digest, ":") {
+			return ref, fmt.Errorf(ErrInvalidReference, image)
+		}
+	}
+
+	lastSlash := strings.LastIndex(name, "/")
+	if lastColon := strings.LastIndex(name, ":"); lastColon > lastSlash {
+		ref.Tag = name[lastColon+1:]
+		name = name[:lastColon]
+		if ref.Tag == "" {
+			return ref, fmt.Errorf(ErrInvalidReference, image)
+		}
+	}
+
+	registry, repository, found := strings.Cut(name, "/")
+	if !found || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		registry = DefaultRegistry
+		repository = name
+	}
+
+	if registry == DefaultRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	if repository == "" {
+		return ref, fmt.Errorf(ErrInvalidReference, image)
+	}
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+
+	ref.Registry = registry
+	ref.Repository = repository
+
+	return ref, nil
+}
+
+// Name returns the reference without the tag and the digest
+func (r Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// String returns the reference in the [registry/]repository[:tag][@digest]
+// format
+func (r Reference) String() string {
+	reference := r.Name()
+	if r.Tag != "" {
+		reference += ":" + r.Tag
+	}
+
+	if r.Digest != "" {
+		reference += "@" + r.Digest
+	}
+
+	return reference
+}
+
+// WithDigest returns the reference pinned to the digest. The tag is kept for
+// readability only, the container runtimes pull the image by the digest.
+func (r Reference) WithDigest(digest string) Reference {
+	r.Digest = digest
+	return r
+}
+
+// apiHost returns the host that serves the registry API
+func (r Reference) apiHost() string {
+	if r.Registry == DefaultRegistry {
+		return dockerHubRegistry
+	}
+
+	return r.Registry
+}
@@ -0,0 +1,17 @@
+package report
+
+const (
+	// ServiceName - report generation service name
+	ServiceName = "report"
+
+	// PodNameSuffix - suffix of the report generation pod name
+	PodNameSuffix = "-report"
+
+	// LogsMountPath - path where the logs PVCs are mounted in the report
+	// generation pod. Each logs PVC is mounted to a directory named after the
+	// PVC.
+	LogsMountPath = "/var/lib/test-operator/report"
+
+	// IndexFileName - name of the file written to the root of each logs PVC
+	IndexFileName = "index.html"
+)
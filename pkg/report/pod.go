@@ -0,0 +1,121 @@
+package report
+
+import (
+	"strings"
+
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Script converts the subunit streams and the JUnit XML files found in the
+// artifact directories of the test pods to HTML (when subunit2html and
+// junit2html are available in the image) and writes IndexFileName with the
+// links to the reports to the root of each directory listed in
+// REPORT_DIRECTORIES. The test pods are listed in REPORT_STEPS, one
+// <artifact directory>|<step name>|<phase> entry per line. Existing reports
+// are not regenerated.
+var Script = `escape() { printf '%s' "$1" | sed 's/&/\&amp;/g; s/</\&lt;/g; s/>/\&gt;/g; s/"/\&quot;/g'; }
+convert() {
+    find "$1" -type f -name "*.$2" | while read -r file; do
+        html="${file%.*}.html"
+        [ -e "$html" ] && continue
+        if [ "$2" = xml ]; then
+            grep -q '<testsuite' "$file" || continue
+        fi
+        "$3" "$file" "$html" || rm -f "$html"
+    done
+}
+for root in $REPORT_DIRECTORIES; do
+    cd "$root" || exit 1
+    echo "$REPORT_STEPS" | while IFS='|' read -r dir step phase; do
+        [ -n "$dir" ] && [ -d "$dir" ] || continue
+        command -v subunit2html >/dev/null && convert "$dir" subunit subunit2html
+        command -v junit2html >/dev/null && convert "$dir" xml junit2html
+    done
+    {
+        echo '<!DOCTYPE html>'
+        echo "<html><head><meta charset=\"utf-8\"><title>$(escape "$REPORT_TITLE")</title></head><body>"
+        echo "<h1>$(escape "$REPORT_TITLE")</h1>"
+        echo '<table border="1"><tr><th>Step</th><th>Phase</th><th>Logs</th><th>Reports</th></tr>'
+        echo "$REPORT_STEPS" | while IFS='|' read -r dir step phase; do
+            [ -n "$dir" ] || continue
+            if [ -d "$dir" ]; then
+                logs="<a href=\"$(escape "$dir")/\">$(escape "$dir")</a>"
+            elif [ -e "$dir.tar.gz" ]; then
+                logs="<a href=\"$(escape "$dir").tar.gz\">$(escape "$dir").tar.gz</a>"
+            else
+                continue
+            fi
+            echo "<tr><td>$(escape "$step")</td><td>$(escape "$phase")</td><td>$logs</td><td>"
+            [ -d "$dir" ] && find "$dir" -type f -name '*.html' | sort | while read -r html; do
+                echo "<a href=\"$(escape "$html")\">$(escape "${html#"$dir"/}")</a><br>"
+            done
+            echo '</td></tr>'
+        done
+        echo '</table></body></html>'
+    } > ` + IndexFileName + `.tmp && mv ` + IndexFileName + `.tmp ` + IndexFileName + `
+done`
+
+// Pod - prepare pod that generates the HTML report of the test results stored
+// in the logs PVCs
+func Pod(
+	namespace string,
+	labels map[string]string,
+	podName string,
+	containerImage string,
+	command []string,
+	envVars []corev1.EnvVar,
+	claimNames []string,
+) *corev1.Pod {
+	runAsUser := int64(42480)
+	runAsGroup := int64(42480)
+
+	securityContext := util.GetSecurityContext(runAsUser, []corev1.Capability{}, false)
+
+	if len(command) == 0 {
+		command = []string{"/bin/sh", "-c", Script}
+	}
+
+	envVars = append(envVars, corev1.EnvVar{Name: "HOME", Value: "/tmp"})
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &runAsUser,
+				RunAsGroup: &runAsGroup,
+				FSGroup:    &runAsGroup,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            ServiceName,
+					Image:           containerImage,
+					Command:         command,
+					Env:             envVars,
+					VolumeMounts:    GetVolumeMounts(claimNames),
+					SecurityContext: &securityContext,
+				},
+			},
+			Volumes: GetVolumes(claimNames),
+		},
+	}
+
+	return pod
+}
+
+// GetDirectories returns the directories where the logs PVCs are mounted
+// separated by spaces (REPORT_DIRECTORIES)
+func GetDirectories(claimNames []string) string {
+	directories := []string{}
+	for _, claimName := range claimNames {
+		directories = append(directories, GetLogsDirectory(claimName))
+	}
+
+	return strings.Join(directories, " ")
+}
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetVolumes -
+func GetVolumes(claimNames []string) []corev1.Volume {
+	volumes := []corev1.Volume{
+		{
+			Name: util.TestOperatorEphemeralVolumeNameTmp,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	for idx, claimName := range claimNames {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("logs-%d", idx),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		})
+	}
+
+	return volumes
+}
+
+// GetVolumeMounts -
+func GetVolumeMounts(claimNames []string) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      util.TestOperatorEphemeralVolumeNameTmp,
+			MountPath: "/tmp",
+			ReadOnly:  false,
+		},
+	}
+
+	for idx, claimName := range claimNames {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      fmt.Sprintf("logs-%d", idx),
+			MountPath: GetLogsDirectory(claimName),
+			ReadOnly:  false,
+		})
+	}
+
+	return volumeMounts
+}
+
+// GetLogsDirectory returns the directory where the logs PVC is mounted in the
+// report generation pod
+func GetLogsDirectory(claimName string) string {
+	return LogsMountPath + "/" + claimName
+}
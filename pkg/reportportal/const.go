@@ -0,0 +1,17 @@
+package reportportal
+
+const (
+	// ServiceName - ReportPortal upload service name
+	ServiceName = "reportportal-upload"
+
+	// PodNameSuffix - suffix of the name of the pod that uploads the results
+	// of a test pod
+	PodNameSuffix = "-reportportal"
+
+	// LogsMountPath - path where the logs PVC is mounted in the upload pod
+	LogsMountPath = "/var/lib/test-operator/reportportal"
+
+	// TokenSecretKey - key of the API key in the secret referred by
+	// tokenSecretName
+	TokenSecretKey = "token"
+)
@@ -0,0 +1,97 @@
+package reportportal
+
+import (
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Script collects the JUnit XML files and the subunit streams (converted
+// using subunit2junitxml) from REPORTPORTAL_ARTIFACT_DIRECTORY, imports them
+// to ReportPortal as a single launch described by REPORTPORTAL_LAUNCH and
+// reports the URL of the launch via the termination message. Errors are
+// reported via the termination message as well.
+var Script = `fail() { echo "$1" > /dev/termination-log; exit 1; }
+api() {
+    curl -sSf $REPORTPORTAL_CURL_OPTIONS -H "Authorization: Bearer $REPORTPORTAL_TOKEN" "$@"
+}
+json() { python3 -c "import json, sys; print(json.load(sys.stdin)$1)"; }
+cd "` + LogsMountPath + `/$REPORTPORTAL_ARTIFACT_DIRECTORY" || fail "The logs of the test pod were not found"
+results=/tmp/reportportal-results
+mkdir -p "$results"
+if command -v subunit2junitxml >/dev/null; then
+    find . -type f -name '*.subunit' | while read -r file; do
+        subunit2junitxml < "$file" > "$results/$(echo "${file#./}" | tr / _).xml" || true
+    done
+fi
+find . -type f -name '*.xml' | while read -r file; do
+    grep -q '<testsuite' "$file" && cp "$file" "$results/$(echo "${file#./}" | tr / _)"
+done
+ls "$results"/*.xml >/dev/null 2>&1 || fail "No JUnit XML or subunit results were found"
+cd "$results" && python3 -m zipfile -c /tmp/results.zip *.xml || fail "Unable to archive the results"
+printf '%s' "$REPORTPORTAL_LAUNCH" > /tmp/launch.json
+response=$(api -F "file=@/tmp/results.zip" -F "launchImportRq=</tmp/launch.json;type=application/json" \
+    "$REPORTPORTAL_ENDPOINT/api/v1/plugin/$REPORTPORTAL_PROJECT/junit/import" 2>&1) ||
+    fail "Unable to import the results: $response"
+uuid=$(echo "$response" | json '["data"]["id"]') || fail "Unexpected response: $response"
+launch=$(api "$REPORTPORTAL_ENDPOINT/api/v1/$REPORTPORTAL_PROJECT/launch/uuid/$uuid" | json '["id"]') ||
+    fail "Unable to get the launch $uuid"
+echo "$REPORTPORTAL_ENDPOINT/ui/#$REPORTPORTAL_PROJECT/launches/all/$launch" > /dev/termination-log`
+
+// Pod - prepare pod that uploads the results of a test pod to ReportPortal
+func Pod(
+	namespace string,
+	labels map[string]string,
+	podName string,
+	containerImage string,
+	envVars []corev1.EnvVar,
+	claimName string,
+	tokenSecretName string,
+) *corev1.Pod {
+	runAsUser := int64(42480)
+	runAsGroup := int64(42480)
+
+	securityContext := util.GetSecurityContext(runAsUser, []corev1.Capability{}, false)
+
+	envVars = append(envVars,
+		corev1.EnvVar{Name: "HOME", Value: "/tmp"},
+		corev1.EnvVar{
+			Name: "REPORTPORTAL_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: tokenSecretName},
+					Key:                  TokenSecretKey,
+				},
+			},
+		},
+	)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &runAsUser,
+				RunAsGroup: &runAsGroup,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:                     ServiceName,
+					Image:                    containerImage,
+					Command:                  []string{"/bin/sh", "-c", Script},
+					Env:                      envVars,
+					VolumeMounts:             GetVolumeMounts(),
+					SecurityContext:          &securityContext,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				},
+			},
+			Volumes: GetVolumes(claimName),
+		},
+	}
+
+	return pod
+}
@@ -0,0 +1,20 @@
+package robottest
+
+const (
+	// ServiceName - robottest service name
+	ServiceName = "robottest"
+
+	// HomeDir - home directory of the test container
+	HomeDir = "/var/lib/robot"
+
+	// SuitesDir - directory the suitesConfigMap is mounted to
+	SuitesDir = "/var/lib/robot/suites"
+
+	// GitAuthPath - path to the directory with the keys of the
+	// suitesGitAuthSecretName secret in the test pod
+	GitAuthPath = "/var/lib/robot/.git-auth"
+
+	// VariablesDir - directory the variablesFromSecrets secrets are mounted
+	// to. Each secret is mounted to a subdirectory named after the secret.
+	VariablesDir = "/var/lib/robot/variables"
+)
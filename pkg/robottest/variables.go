@@ -0,0 +1,49 @@
+package robottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+)
+
+// GetVariables returns the variables passed to the suites encoded as JSON.
+// An empty string is returned when no variables are set.
+func GetVariables(variables map[string]string) (string, error) {
+	if len(variables) == 0 {
+		return "", nil
+	}
+
+	// json.Marshal sorts the keys of the map, so the value is stable
+	variablesJSON, err := json.Marshal(variables)
+	if err != nil {
+		return "", err
+	}
+
+	return string(variablesJSON), nil
+}
+
+// GetVariableDirs returns the space separated list of the directories the
+// variablesFromSecrets secrets are mounted to
+func GetVariableDirs(secretNames []string) string {
+	dirs := []string{}
+	for _, secretName := range secretNames {
+		dirs = append(dirs, VariablesDir+"/"+secretName)
+	}
+
+	return strings.Join(dirs, " ")
+}
+
+// GetGitAuthEnvVars returns the environment variables that make git use the
+// credentials of the suitesGitAuthSecretName secret mounted to GitAuthPath
+func GetGitAuthEnvVars() map[string]string {
+	return util.GetGitAuthEnvVars(GitAuthPath)
+}
+
+// getVariablesVolumeName returns the name of the volume with the idx-th
+// variablesFromSecrets secret. The index is used as secret names are not
+// necessarily valid volume names.
+func getVariablesVolumeName(idx int) string {
+	return fmt.Sprintf("robot-variables-%d", idx)
+}
@@ -0,0 +1,116 @@
+package robottest
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetVolumes -
+func GetVolumes(
+	stepSpec testv1beta1.RobotTestSpec,
+	logsPVCName string,
+	mountCerts bool,
+) []corev1.Volume {
+
+	var scriptsVolumeConfidentialMode int32 = 0420
+	var publicInfoMode int32 = 0744
+
+	volumes := []corev1.Volume{}
+
+	if len(stepSpec.SuitesConfigMap) > 0 {
+		suitesVolume := corev1.Volume{
+			Name: "robot-suites",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &publicInfoMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: stepSpec.SuitesConfigMap,
+					},
+				},
+			},
+		}
+
+		volumes = append(volumes, suitesVolume)
+	}
+
+	if len(stepSpec.SuitesGitAuthSecretName) > 0 {
+		gitAuthVolume := corev1.Volume{
+			Name: "robot-git-auth",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &scriptsVolumeConfidentialMode,
+					SecretName:  stepSpec.SuitesGitAuthSecretName,
+				},
+			},
+		}
+
+		volumes = append(volumes, gitAuthVolume)
+	}
+
+	for idx, secretName := range stepSpec.VariablesFromSecrets {
+		variablesVolume := corev1.Volume{
+			Name: getVariablesVolumeName(idx),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &scriptsVolumeConfidentialMode,
+					SecretName:  secretName,
+				},
+			},
+		}
+
+		volumes = append(volumes, variablesVolume)
+	}
+
+	return util.GetTestVolumes(
+		stepSpec.CommonOptions,
+		stepSpec.CommonOpenstackConfig,
+		logsPVCName,
+		mountCerts,
+		volumes...,
+	)
+}
+
+// GetVolumeMounts -
+func GetVolumeMounts(mountCerts bool, stepSpec testv1beta1.RobotTestSpec) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+
+	if len(stepSpec.SuitesConfigMap) > 0 {
+		suitesMount := corev1.VolumeMount{
+			Name:      "robot-suites",
+			MountPath: SuitesDir,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, suitesMount)
+	}
+
+	if len(stepSpec.SuitesGitAuthSecretName) > 0 {
+		gitAuthMount := corev1.VolumeMount{
+			Name:      "robot-git-auth",
+			MountPath: GitAuthPath,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, gitAuthMount)
+	}
+
+	for idx, secretName := range stepSpec.VariablesFromSecrets {
+		variablesMount := corev1.VolumeMount{
+			Name:      getVariablesVolumeName(idx),
+			MountPath: VariablesDir + "/" + secretName,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, variablesMount)
+	}
+
+	return util.GetTestVolumeMounts(
+		stepSpec.CommonOptions,
+		HomeDir,
+		HomeDir+"/external_files",
+		mountCerts,
+		volumeMounts...,
+	)
+}
@@ -0,0 +1,44 @@
+package shakertest
+
+import (
+	"encoding/json"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+// GetAccommodation returns the placement of the agents in the format of the
+// accommodation section of a shaker scenario encoded as JSON. An empty string
+// is returned when the accommodation is not set.
+func GetAccommodation(accommodation *testv1beta1.ShakerAccommodation) (string, error) {
+	if accommodation == nil {
+		return "", nil
+	}
+
+	items := []interface{}{}
+	for _, placement := range accommodation.Placement {
+		items = append(items, string(placement))
+	}
+
+	if accommodation.Density != nil {
+		items = append(items, map[string]interface{}{"density": *accommodation.Density})
+	}
+
+	if accommodation.ComputeNodes != nil {
+		items = append(items, map[string]interface{}{"compute_nodes": *accommodation.ComputeNodes})
+	}
+
+	if len(accommodation.Zones) > 0 {
+		items = append(items, map[string]interface{}{"zones": accommodation.Zones})
+	}
+
+	if len(items) == 0 {
+		return "", nil
+	}
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,18 @@
+package shakertest
+
+const (
+	// ServiceName - shakertest service name
+	ServiceName = "shakertest"
+
+	// HomeDir - home directory of the test container
+	HomeDir = "/var/lib/shaker"
+
+	// CustomScenarioDir - directory the custom scenario files are mounted to
+	CustomScenarioDir = "/var/lib/shaker/scenarios/custom"
+
+	// ReportFileName - name of the HTML report stored in the logs directory
+	ReportFileName = "shaker-report.html"
+
+	// OutputFileName - name of the JSON output stored in the logs directory
+	OutputFileName = "shaker-report.json"
+)
@@ -0,0 +1,67 @@
+package shakertest
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetVolumes -
+func GetVolumes(
+	stepSpec testv1beta1.ShakerTestSpec,
+	logsPVCName string,
+	mountCerts bool,
+) []corev1.Volume {
+
+	var publicInfoMode int32 = 0744
+
+	volumes := []corev1.Volume{}
+
+	if len(stepSpec.ScenarioConfigMap) > 0 {
+		scenarioVolume := corev1.Volume{
+			Name: "shaker-scenarios",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &publicInfoMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: stepSpec.ScenarioConfigMap,
+					},
+				},
+			},
+		}
+
+		volumes = append(volumes, scenarioVolume)
+	}
+
+	return util.GetTestVolumes(
+		stepSpec.CommonOptions,
+		stepSpec.CommonOpenstackConfig,
+		logsPVCName,
+		mountCerts,
+		volumes...,
+	)
+}
+
+// GetVolumeMounts -
+func GetVolumeMounts(mountCerts bool, stepSpec testv1beta1.ShakerTestSpec) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+
+	if len(stepSpec.ScenarioConfigMap) > 0 {
+		scenarioMount := corev1.VolumeMount{
+			Name:      "shaker-scenarios",
+			MountPath: CustomScenarioDir,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, scenarioMount)
+	}
+
+	return util.GetTestVolumeMounts(
+		stepSpec.CommonOptions,
+		HomeDir,
+		HomeDir+"/external_files",
+		mountCerts,
+		volumeMounts...,
+	)
+}
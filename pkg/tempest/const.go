@@ -3,4 +3,59 @@ package tempest
 const (
 	// ServiceName - tempest service name
 	ServiceName = "tempest"
+
+	// RerunPodSuffix - suffix of the name of the test pod that executes the
+	// failed tests of a workflow step once more
+	RerunPodSuffix = "-rerun"
+
+	// ShardPodInfix - infix of the names of the test pods that execute the
+	// shards of the tests of a workflow step
+	ShardPodInfix = "-shard-"
+
+	// TempestconfSecretKey - key of the tempest.conf in the secret referred
+	// by tempestconfSecretName
+	TempestconfSecretKey = "tempest.conf"
+
+	// TempestconfSecretMountPath - path where the tempest.conf from the
+	// secret referred by tempestconfSecretName is mounted in the test pod
+	TempestconfSecretMountPath = "/etc/tempest/tempest.conf"
+
+	// AccountsSecretKey - key of the accounts.yaml in the secret referred by
+	// accountsSecretName
+	AccountsSecretKey = "accounts.yaml"
+
+	// AccountsSecretMountPath - path where the accounts.yaml from the secret
+	// referred by accountsSecretName is mounted in the test pod
+	AccountsSecretMountPath = "/etc/tempest/accounts.yaml"
+
+	// TestListStartMarker and TestListEndMarker - lines that enclose the list
+	// of the tests in the log of the test pod in the list-only mode
+	TestListStartMarker = "=== TEST LIST START ==="
+	TestListEndMarker   = "=== TEST LIST END ==="
+
+	// TestListConfigMapSuffix - suffix of the name of the ConfigMap with the
+	// list of the tests of a test pod
+	TestListConfigMapSuffix = "-test-list"
+
+	// TestListConfigMapKey - key of the list of the tests in the ConfigMap
+	TestListConfigMapKey = "tests.txt"
+
+	// WhiteboxSSHKeyMountPath - path where the private SSH key used by the
+	// whitebox tempest plugin is mounted in the test pod
+	WhiteboxSSHKeyMountPath = "/var/lib/tempest/whitebox_ssh_key"
+
+	// PluginsContainerName - name of the init container that installs the
+	// tempest plugins
+	PluginsContainerName = "install-plugins"
+
+	// PluginsPath - path where the virtual environment with the tempest
+	// plugins is created in the test pod
+	PluginsPath = "/var/lib/tempest-plugins"
+
+	// StestrHistoryMountPath - path where the PVC with the stestr history is
+	// mounted in the test pod
+	StestrHistoryMountPath = "/var/lib/tempest/stestr_history"
+
+	// StestrHistoryVolumeName - name of the volume with the stestr history
+	StestrHistoryVolumeName = "stestr-history"
 )
@@ -0,0 +1,89 @@
+package tempest
+
+import (
+	"fmt"
+	"strings"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	pluginsVolumeName = "tempest-plugins"
+)
+
+// getPluginsInstallScript returns a script that installs the requirements
+// passed in TEMPEST_PLUGINS into a virtual environment that has access to the
+// packages of the container image, links its site-packages to a stable path
+// and writes the installed packages to the termination message of the
+// container.
+func getPluginsInstallScript() string {
+	return fmt.Sprintf(
+		"set -e; python3 -m venv --system-site-packages %[1]s/venv; "+
+			"printf '%%s\\n' \"$TEMPEST_PLUGINS\" > %[1]s/requirements.txt; "+
+			"%[1]s/venv/bin/pip install --no-cache-dir -r %[1]s/requirements.txt; "+
+			"ln -sfn \"$(%[1]s/venv/bin/python -c "+
+			"\"import sysconfig; print(sysconfig.get_paths()['purelib'])\")\" %[1]s/site-packages; "+
+			"%[1]s/venv/bin/pip freeze --local | tee /dev/termination-log",
+		PluginsPath)
+}
+
+// GetPluginRequirement returns the pip requirement of the tempest plugin
+func GetPluginRequirement(plugin testv1beta1.TempestPlugin) string {
+	if len(plugin.GitURL) > 0 {
+		if len(plugin.Ref) > 0 {
+			return "git+" + plugin.GitURL + "@" + plugin.Ref
+		}
+
+		return "git+" + plugin.GitURL
+	}
+
+	if len(plugin.Ref) > 0 {
+		return plugin.Name + "==" + plugin.Ref
+	}
+
+	return plugin.Name
+}
+
+// AddPlugins adds an init container to the pod that installs the tempest
+// plugins into a virtual environment on a volume shared with the test
+// container. The test container finds the plugins via PYTHONPATH.
+func AddPlugins(pod *corev1.Pod, plugins []testv1beta1.TempestPlugin) {
+	if len(plugins) == 0 || len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	requirements := []string{}
+	for _, plugin := range plugins {
+		requirements = append(requirements, GetPluginRequirement(plugin))
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: pluginsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	testContainer := &pod.Spec.Containers[0]
+	testContainer.VolumeMounts = append(testContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      pluginsVolumeName,
+		MountPath: PluginsPath,
+	})
+
+	initContainer := testContainer.DeepCopy()
+	initContainer.Name = PluginsContainerName
+	initContainer.Command = []string{"/bin/sh", "-c", getPluginsInstallScript()}
+	initContainer.Args = nil
+	initContainer.Env = append(initContainer.Env, corev1.EnvVar{
+		Name:  "TEMPEST_PLUGINS",
+		Value: strings.Join(requirements, "\n"),
+	})
+
+	testContainer.Env = append(testContainer.Env, corev1.EnvVar{
+		Name:  "PYTHONPATH",
+		Value: PluginsPath + "/site-packages",
+	})
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
+}
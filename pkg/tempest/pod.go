@@ -12,6 +12,7 @@ import (
 // Pod - prepare pod to run Tempest tests
 func Pod(
 	instance *testv1beta1.Tempest,
+	effectiveSpec util.EffectiveSpec,
 	labels map[string]string,
 	annotations map[string]string,
 	podName string,
@@ -38,8 +39,15 @@ func Pod(
 		Spec: corev1.PodSpec{
 			AutomountServiceAccountToken: &instance.Spec.Privileged,
 			RestartPolicy:                corev1.RestartPolicyNever,
-			Tolerations:                  instance.Spec.Tolerations,
-			NodeSelector:                 instance.Spec.NodeSelector,
+			Tolerations:                  effectiveSpec.Tolerations,
+			NodeSelector:                 effectiveSpec.NodeSelector,
+			Affinity:                     effectiveSpec.Affinity,
+			TopologySpreadConstraints:    effectiveSpec.TopologySpreadConstraints,
+			RuntimeClassName:             effectiveSpec.RuntimeClassName,
+			HostNetwork:                  effectiveSpec.HostNetwork,
+			DNSPolicy:                    effectiveSpec.DNSPolicy,
+			DNSConfig:                    effectiveSpec.DNSConfig,
+			ImagePullSecrets:             effectiveSpec.ImagePullSecrets,
 			SecurityContext: &corev1.PodSecurityContext{
 				RunAsUser:  &runAsUser,
 				RunAsGroup: &runAsGroup,
@@ -49,12 +57,13 @@ func Pod(
 				{
 					Name:            instance.Name + "-tests-runner",
 					Image:           containerImage,
+					ImagePullPolicy: effectiveSpec.ImagePullPolicy,
 					Args:            []string{},
 					Env:             env.MergeEnvs([]corev1.EnvVar{}, envVars),
 					VolumeMounts:    GetVolumeMounts(mountCerts, mountSSHKey, instance),
 					SecurityContext: &securityContext,
-					Resources:       instance.Spec.Resources,
-					EnvFrom: []corev1.EnvFromSource{
+					Resources:       effectiveSpec.Resources,
+					EnvFrom: append(effectiveSpec.EnvFrom, []corev1.EnvFromSource{
 						{
 							ConfigMapRef: &corev1.ConfigMapEnvSource{
 								LocalObjectReference: corev1.LocalObjectReference{
@@ -69,7 +78,7 @@ func Pod(
 								},
 							},
 						},
-					},
+					}...),
 				},
 			},
 			Volumes: GetVolumes(
@@ -82,11 +91,14 @@ func Pod(
 		},
 	}
 
-	if len(instance.Spec.SELinuxLevel) > 0 {
+	if len(effectiveSpec.SELinuxLevel) > 0 {
 		pod.Spec.SecurityContext.SELinuxOptions = &corev1.SELinuxOptions{
-			Level: instance.Spec.SELinuxLevel,
+			Level: effectiveSpec.SELinuxLevel,
 		}
 	}
 
+	util.ApplySecurityContext(pod, effectiveSpec.SecurityContext)
+	util.SetArtifactDirectory(pod, podName)
+
 	return pod
 }
@@ -0,0 +1,23 @@
+package tempest
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AddRerunFailed configures the test pod to execute only the tests that
+// failed in the test pod whose logs are stored in failedArtifactDirectory.
+// The failed tests are read from the stestr state of the failed test pod and
+// the merged results are stored in the artifact directory of the test pod.
+func AddRerunFailed(pod *corev1.Pod, failedArtifactDirectory string) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	// The env vars of the container take precedence over the env vars config
+	// map that is shared with the failed test pod
+	testContainer := &pod.Spec.Containers[0]
+	testContainer.Env = append(testContainer.Env,
+		corev1.EnvVar{Name: "TEMPEST_RERUN_FAILED_DIR_NAME", Value: failedArtifactDirectory},
+		corev1.EnvVar{Name: "TEMPEST_WORKFLOW_STEP_DIR_NAME", Value: pod.Name},
+	)
+}
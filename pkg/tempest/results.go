@@ -0,0 +1,65 @@
+package tempest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	v1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+var (
+	// failedTestRegexp matches the lines printed by subunit-trace for the
+	// failed tests, e.g.
+	// {0} tempest.api.compute.test_x.TestX.test_y [1.234567s] ... FAILED
+	failedTestRegexp = regexp.MustCompile(`^\{\d+\} (.+?)(?: \[[\d.]+s\])? \.\.\. FAILED$`)
+
+	// totalsRegexp matches the lines of the totals printed by subunit-trace
+	// at the end of the tempest run, e.g. " - Passed: 10"
+	totalsRegexp = regexp.MustCompile(`^(?:Ran: (\d+) tests?|- (Passed|Failed|Skipped): (\d+))`)
+)
+
+// ParseResults returns the numbers of the tests and the names of the failed
+// tests printed by subunit-trace in the log of a test pod. Nil counts are
+// returned when the log does not contain the totals (e.g. the tempest run
+// did not finish).
+func ParseResults(log string) (*v1beta1.TestCounts, []string) {
+	var counts *v1beta1.TestCounts
+	failedTests := []string{}
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimSpace(line)
+		if match := failedTestRegexp.FindStringSubmatch(line); match != nil {
+			failedTests = append(failedTests, match[1])
+			continue
+		}
+
+		match := totalsRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		if match[1] != "" {
+			// The totals of a later tempest run (e.g. the rerun of the
+			// failed tests) replace the earlier ones
+			total, _ := strconv.Atoi(match[1])
+			counts = &v1beta1.TestCounts{Total: total}
+			continue
+		}
+
+		if counts == nil {
+			continue
+		}
+
+		value, _ := strconv.Atoi(match[3])
+		switch match[2] {
+		case "Passed":
+			counts.Passed = value
+		case "Failed":
+			counts.Failed = value
+		case "Skipped":
+			counts.Skipped = value
+		}
+	}
+
+	return counts, failedTests
+}
@@ -0,0 +1,94 @@
+package tempest
+
+import (
+	"strconv"
+	"strings"
+
+	util "github.com/openstack-k8s-operators/test-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetShardPodName returns the name of the test pod that executes the shard of
+// the tests of the workflow step whose test pod is named podName
+func GetShardPodName(podName string, shard int) string {
+	return podName + ShardPodInfix + strconv.Itoa(shard)
+}
+
+// AddShard turns the definition of the test pod of a workflow step into the
+// test pod that executes the shard of the tests. The shards of a workflow step
+// share the logs PVC so the shards are scheduled to the node of the first
+// shard (selected by firstShardLabels).
+func AddShard(pod *corev1.Pod, shard int, shards int, firstShardLabels map[string]string) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	pod.Name = GetShardPodName(pod.Name, shard)
+	util.SetArtifactDirectory(pod, pod.Name)
+
+	// The env vars of the container take precedence over the env vars config
+	// map that is shared by the shards
+	testContainer := &pod.Spec.Containers[0]
+	testContainer.Env = append(testContainer.Env,
+		corev1.EnvVar{Name: "TEMPEST_SHARD_INDEX", Value: strconv.Itoa(shard)},
+		corev1.EnvVar{Name: "TEMPEST_SHARD_COUNT", Value: strconv.Itoa(shards)},
+		corev1.EnvVar{Name: "TEMPEST_WORKFLOW_STEP_DIR_NAME", Value: pod.Name},
+	)
+
+	if shard == 0 {
+		return
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+
+	if pod.Spec.Affinity.PodAffinity == nil {
+		pod.Spec.Affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+
+	podAffinity := pod.Spec.Affinity.PodAffinity
+	podAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+		podAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: firstShardLabels},
+			TopologyKey:   corev1.LabelHostname,
+		},
+	)
+}
+
+// AddShardMerge configures the test pod of a workflow step to combine the
+// subunit streams stored by the shards in the shardArtifactDirectories
+// instead of executing the tests. The merged results are stored in the
+// artifact directory of the test pod.
+func AddShardMerge(pod *corev1.Pod, shardArtifactDirectories []string) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	testContainer := &pod.Spec.Containers[0]
+	testContainer.Env = append(testContainer.Env, corev1.EnvVar{
+		Name:  "TEMPEST_MERGE_SHARDS_DIR_NAMES",
+		Value: strings.Join(shardArtifactDirectories, ","),
+	})
+}
+
+// GetReadArtifactDirectories returns the artifact directories of the other
+// test pods that the test pod reads (the directory of the failed test pod
+// of a rerun pod and the directories of the shards of a merge pod)
+func GetReadArtifactDirectories(pod *corev1.Pod) []string {
+	directories := []string{}
+	for _, container := range pod.Spec.Containers {
+		for _, envVar := range container.Env {
+			switch envVar.Name {
+			case "TEMPEST_RERUN_FAILED_DIR_NAME":
+				directories = append(directories, envVar.Value)
+			case "TEMPEST_MERGE_SHARDS_DIR_NAMES":
+				directories = append(directories, strings.Split(envVar.Value, ",")...)
+			}
+		}
+	}
+
+	return directories
+}
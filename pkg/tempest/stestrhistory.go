@@ -0,0 +1,71 @@
+package tempest
+
+import (
+	"fmt"
+	"strconv"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetStestrHistoryClaimName returns the name of the PVC that stores the
+// stestr history of the instance
+func GetStestrHistoryClaimName(instance *testv1beta1.Tempest) string {
+	if instance.Spec.StestrHistory != nil && len(instance.Spec.StestrHistory.ClaimName) > 0 {
+		return instance.Spec.StestrHistory.ClaimName
+	}
+
+	return instance.Name + "-stestr-history"
+}
+
+// GetStestrHistoryDirName returns the name of the directory in the stestr
+// history PVC that stores the stestr repository of the workflow step. Named
+// workflow steps keep their history when the workflow is reordered.
+func GetStestrHistoryDirName(workflowStep int, stepName string) string {
+	if len(stepName) > 0 {
+		return stepName
+	}
+
+	return fmt.Sprintf("workflow-step-%d", workflowStep)
+}
+
+// AddStestrHistory mounts the stestr history PVC to the test pod. The stestr
+// repository of the workflow step is restored from historyDirName before the
+// tempest run and stored back once the tests finish. The comparison with the
+// previous run is reported by the test container via its termination message.
+func AddStestrHistory(
+	pod *corev1.Pod,
+	claimName string,
+	historyDirName string,
+	timingRegressionThreshold int64,
+) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: StestrHistoryVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	})
+
+	testContainer := &pod.Spec.Containers[0]
+	testContainer.VolumeMounts = append(testContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      StestrHistoryVolumeName,
+		MountPath: StestrHistoryMountPath,
+	})
+
+	testContainer.Env = append(testContainer.Env,
+		corev1.EnvVar{
+			Name:  "TEMPEST_STESTR_HISTORY_DIR",
+			Value: StestrHistoryMountPath + "/" + historyDirName,
+		},
+		corev1.EnvVar{
+			Name:  "TEMPEST_STESTR_TIMING_REGRESSION_THRESHOLD",
+			Value: strconv.FormatInt(timingRegressionThreshold, 10),
+		},
+	)
+}
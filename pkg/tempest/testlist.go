@@ -0,0 +1,28 @@
+package tempest
+
+import (
+	"strings"
+)
+
+// ParseTestList returns the tests listed in the log of a test pod executed in
+// the list-only mode. The tests are enclosed by TestListStartMarker and
+// TestListEndMarker. Nil is returned when the log does not contain a
+// complete list.
+func ParseTestList(log string) []string {
+	var tests []string
+	inList := false
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == TestListStartMarker:
+			inList = true
+			tests = []string{}
+		case line == TestListEndMarker && inList:
+			return tests
+		case inList && len(line) > 0:
+			tests = append(tests, line)
+		}
+	}
+
+	return nil
+}
@@ -82,7 +82,7 @@ func GetVolumes(
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
 					DefaultMode: &scriptsVolumeConfidentialMode,
-					SecretName:  "combined-ca-bundle",
+					SecretName:  util.GetCABundleSecretName(instance.Spec.CommonOptions),
 				},
 			},
 		}
@@ -110,6 +110,66 @@ func GetVolumes(
 		volumes = append(volumes, sshKeyVolume)
 	}
 
+	if len(instance.Spec.TempestconfSecretName) > 0 {
+		tempestconfVolume := corev1.Volume{
+			Name: "tempestconf-secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  instance.Spec.TempestconfSecretName,
+					DefaultMode: &scriptsVolumeConfidentialMode,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  TempestconfSecretKey,
+							Path: TempestconfSecretKey,
+						},
+					},
+				},
+			},
+		}
+
+		volumes = append(volumes, tempestconfVolume)
+	}
+
+	if len(instance.Spec.AccountsSecretName) > 0 {
+		accountsVolume := corev1.Volume{
+			Name: "accounts-secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  instance.Spec.AccountsSecretName,
+					DefaultMode: &scriptsVolumeConfidentialMode,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  AccountsSecretKey,
+							Path: AccountsSecretKey,
+						},
+					},
+				},
+			},
+		}
+
+		volumes = append(volumes, accountsVolume)
+	}
+
+	if instance.Spec.Whitebox != nil {
+		whiteboxSSHKeyVolume := corev1.Volume{
+			Name: "whitebox-ssh-key",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  instance.Spec.Whitebox.SSHKeySecretName,
+					DefaultMode: &privateKeyMode,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  "ssh-privatekey",
+							Path: "ssh_key",
+						},
+					},
+				},
+			},
+		}
+
+		volumes = append(volumes, whiteboxSSHKeyVolume)
+	}
+
 	for _, vol := range instance.Spec.ExtraConfigmapsMounts {
 		extraVol := corev1.Volume{
 			Name: vol.Name,
@@ -193,6 +253,39 @@ func GetVolumeMounts(mountCerts bool, mountSSHKey bool, instance *testv1beta1.Te
 		volumeMounts = append(volumeMounts, sshKeyMount)
 	}
 
+	if len(instance.Spec.TempestconfSecretName) > 0 {
+		tempestconfMount := corev1.VolumeMount{
+			Name:      "tempestconf-secret",
+			MountPath: TempestconfSecretMountPath,
+			SubPath:   TempestconfSecretKey,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, tempestconfMount)
+	}
+
+	if len(instance.Spec.AccountsSecretName) > 0 {
+		accountsMount := corev1.VolumeMount{
+			Name:      "accounts-secret",
+			MountPath: AccountsSecretMountPath,
+			SubPath:   AccountsSecretKey,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, accountsMount)
+	}
+
+	if instance.Spec.Whitebox != nil {
+		whiteboxSSHKeyMount := corev1.VolumeMount{
+			Name:      "whitebox-ssh-key",
+			MountPath: WhiteboxSSHKeyMountPath,
+			SubPath:   "ssh_key",
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, whiteboxSSHKeyMount)
+	}
+
 	for _, vol := range instance.Spec.ExtraConfigmapsMounts {
 
 		extraMounts := corev1.VolumeMount{
@@ -0,0 +1,40 @@
+package tempest
+
+import (
+	"strings"
+
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+)
+
+// GetWhiteboxOverrides returns the options of the whitebox section of
+// tempest.conf in the format of the overrides of discover-tempest-config
+// (section.option value)
+func GetWhiteboxOverrides(whitebox *testv1beta1.WhiteboxSpec) string {
+	if whitebox == nil {
+		return ""
+	}
+
+	overrides := []string{
+		"whitebox.ctlplane_ssh_username " + whitebox.SSHUser,
+		"whitebox.ctlplane_ssh_private_key_path " + WhiteboxSSHKeyMountPath,
+	}
+
+	hypervisors := []string{}
+	for _, node := range whitebox.Nodes {
+		hypervisors = append(hypervisors, node.Name+":"+node.Address)
+	}
+
+	if len(hypervisors) > 0 {
+		overrides = append(overrides, "whitebox.hypervisors "+strings.Join(hypervisors, ","))
+	}
+
+	if whitebox.ContainerRuntime == testv1beta1.WhiteboxContainerRuntimeNone {
+		overrides = append(overrides, "whitebox.containers false")
+	} else if len(whitebox.ContainerRuntime) > 0 {
+		overrides = append(overrides,
+			"whitebox.containers true",
+			"whitebox.container_runtime "+string(whitebox.ContainerRuntime))
+	}
+
+	return strings.Join(overrides, " ")
+}
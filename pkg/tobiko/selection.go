@@ -0,0 +1,27 @@
+package tobiko
+
+import (
+	"strings"
+)
+
+// GetPytestSelectionArgs returns the pytest arguments that select the tobiko
+// tests matching the marker expression (-m) and the test name expression
+// (-k). The arguments are quoted the way pytest splits PYTEST_ADDOPTS.
+func GetPytestSelectionArgs(markers string, testPattern string) string {
+	args := []string{}
+	if len(markers) > 0 {
+		args = append(args, "-m", quoteArg(markers))
+	}
+
+	if len(testPattern) > 0 {
+		args = append(args, "-k", quoteArg(testPattern))
+	}
+
+	return strings.Join(args, " ")
+}
+
+// quoteArg wraps the argument into single quotes so that the expression is
+// not split on whitespace
+func quoteArg(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+}
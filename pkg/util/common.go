@@ -1,6 +1,7 @@
 package util
 
 import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -15,8 +16,101 @@ const (
 
 	// TestOperatorEphemeralVolumeNameTmp
 	TestOperatorEphemeralVolumeNameTmp = "test-operator-ephemeral-temporary"
+
+	// DefaultCABundleSecretName is the name of the secret with the CA bundle
+	// that is mounted to the test pods unless a different secret is specified
+	DefaultCABundleSecretName = "combined-ca-bundle"
+
+	// ArtifactDirectoryAnnotation is the annotation of the test pods with the
+	// directory of the logs PVC the test pod stores its logs in
+	ArtifactDirectoryAnnotation = "test.openstack.org/artifact-directory"
 )
 
+// EffectiveSpec - pod related values that apply to a single workflow step. The
+// values are taken from the spec of the instance unless they are overridden
+// by the workflow step. Unlike writing the overrides into the spec, computing
+// them per step leaves the spec of the instance untouched.
+type EffectiveSpec struct {
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+	SELinuxLevel string
+	Resources    corev1.ResourceRequirements
+	EnvFrom      []corev1.EnvFromSource
+
+	Affinity                  *corev1.Affinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+	RuntimeClassName          *string
+	HostNetwork               bool
+	DNSPolicy                 corev1.DNSPolicy
+	DNSConfig                 *corev1.PodDNSConfig
+	ImagePullSecrets          []corev1.LocalObjectReference
+	ImagePullPolicy           corev1.PullPolicy
+	SecurityContext           *testv1beta1.TestSecurityContext
+}
+
+// GetCABundleSecretName returns the name of the secret with the CA bundle
+// that is mounted to the test pods or an empty string when the CA bundle
+// should not be mounted
+func GetCABundleSecretName(options testv1beta1.CommonOptions) string {
+	if options.MountCABundle != nil && !*options.MountCABundle {
+		return ""
+	}
+
+	if len(options.CABundleSecretName) == 0 {
+		return DefaultCABundleSecretName
+	}
+
+	return options.CABundleSecretName
+}
+
+// GetImagePullSecrets returns the references to the image pull secrets with
+// the given names
+func GetImagePullSecrets(secretNames []string) []corev1.LocalObjectReference {
+	imagePullSecrets := []corev1.LocalObjectReference{}
+	for _, secretName := range secretNames {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: secretName})
+	}
+
+	return imagePullSecrets
+}
+
+// GetDNSPolicy returns the DNS policy of a test pod. Pods in the host network
+// keep resolving the cluster services unless a different policy is set.
+func GetDNSPolicy(hostNetwork bool, dnsPolicy corev1.DNSPolicy) corev1.DNSPolicy {
+	if hostNetwork && dnsPolicy == "" {
+		return corev1.DNSClusterFirstWithHostNet
+	}
+
+	return dnsPolicy
+}
+
+// GetEnvFromSources returns the envFrom entries that expose the keys of the
+// given secrets and config maps as environment variables
+func GetEnvFromSources(secretNames []string, configMapNames []string) []corev1.EnvFromSource {
+	envFrom := []corev1.EnvFromSource{}
+	for _, configMapName := range configMapNames {
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configMapName,
+				},
+			},
+		})
+	}
+
+	for _, secretName := range secretNames {
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+			},
+		})
+	}
+
+	return envFrom
+}
+
 func GetSecurityContext(
 	runAsUser int64,
 	addCapabilities []corev1.Capability,
@@ -60,3 +154,66 @@ func GetSecurityContext(
 
 	return securityContext
 }
+
+// ApplySecurityContext replaces the security settings of the test pod with
+// the ones specified in the CR. Settings that are not specified keep the
+// values set by the test-operator.
+func ApplySecurityContext(pod *corev1.Pod, securityContext *testv1beta1.TestSecurityContext) {
+	if securityContext == nil {
+		return
+	}
+
+	if pod.Spec.SecurityContext == nil {
+		pod.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+
+	podSecurityContext := pod.Spec.SecurityContext
+	if securityContext.RunAsUser != nil {
+		podSecurityContext.RunAsUser = securityContext.RunAsUser
+	}
+
+	if securityContext.RunAsGroup != nil {
+		podSecurityContext.RunAsGroup = securityContext.RunAsGroup
+	}
+
+	if securityContext.FSGroup != nil {
+		podSecurityContext.FSGroup = securityContext.FSGroup
+	}
+
+	if securityContext.SeccompProfile != nil {
+		podSecurityContext.SeccompProfile = securityContext.SeccompProfile.DeepCopy()
+	}
+
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if container.SecurityContext == nil {
+			container.SecurityContext = &corev1.SecurityContext{}
+		}
+
+		if securityContext.RunAsUser != nil {
+			container.SecurityContext.RunAsUser = securityContext.RunAsUser
+		}
+
+		if securityContext.RunAsGroup != nil {
+			container.SecurityContext.RunAsGroup = securityContext.RunAsGroup
+		}
+
+		if securityContext.SeccompProfile != nil {
+			container.SecurityContext.SeccompProfile = securityContext.SeccompProfile.DeepCopy()
+		}
+
+		if securityContext.Capabilities != nil {
+			container.SecurityContext.Capabilities = securityContext.Capabilities.DeepCopy()
+		}
+	}
+}
+
+// SetArtifactDirectory records the directory of the logs PVC the test pod
+// stores its logs in. The directory is reported in status.steps.
+func SetArtifactDirectory(pod *corev1.Pod, directory string) {
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+
+	pod.Annotations[ArtifactDirectoryAnnotation] = directory
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ExtraMountVolumePrefix is prepended to the name of the volumes created
+	// for extraMounts so that they do not collide with the volumes of the
+	// test-operator
+	ExtraMountVolumePrefix = "extra-mount-"
+)
+
+// GetExtraMountVolumes returns the volumes for the given extraMounts
+func GetExtraMountVolumes(extraMounts []testv1beta1.ExtraMount) []corev1.Volume {
+	volumes := []corev1.Volume{}
+	for _, extraMount := range extraMounts {
+		volume := corev1.Volume{
+			Name: ExtraMountVolumePrefix + extraMount.Name,
+			VolumeSource: corev1.VolumeSource{
+				Secret:                extraMount.Secret.DeepCopy(),
+				ConfigMap:             extraMount.ConfigMap.DeepCopy(),
+				PersistentVolumeClaim: extraMount.PersistentVolumeClaim.DeepCopy(),
+			},
+		}
+
+		volumes = append(volumes, volume)
+	}
+
+	return volumes
+}
+
+// GetExtraMountVolumeMounts returns the volume mounts for the given extraMounts
+func GetExtraMountVolumeMounts(extraMounts []testv1beta1.ExtraMount) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{}
+	for _, extraMount := range extraMounts {
+		volumeMount := corev1.VolumeMount{
+			Name:      ExtraMountVolumePrefix + extraMount.Name,
+			MountPath: extraMount.MountPath,
+			SubPath:   extraMount.SubPath,
+			ReadOnly:  extraMount.ReadOnly,
+		}
+
+		volumeMounts = append(volumeMounts, volumeMount)
+	}
+
+	return volumeMounts
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"fmt"
+)
+
+// GetGitAuthEnvVars returns the environment variables that make git use the
+// credentials mounted to gitAuthPath. SSH uses the private key and the
+// known_hosts of the secret (new hosts are accepted when the secret contains
+// no known_hosts). HTTPS uses a credential helper that reads the username and
+// the password from the secret so that they are not exposed in the pod
+// definition.
+func GetGitAuthEnvVars(gitAuthPath string) map[string]string {
+	sshCommand := fmt.Sprintf(
+		"ssh -i %[1]s/ssh-privatekey -o IdentitiesOnly=yes "+
+			"-o UserKnownHostsFile=%[1]s/known_hosts -o StrictHostKeyChecking=accept-new",
+		gitAuthPath)
+
+	credentialHelper := fmt.Sprintf(
+		"!f() { test \"$1\" = get && test -f %[1]s/password || return 0; "+
+			"echo \"username=$(cat %[1]s/username 2>/dev/null || echo git)\"; "+
+			"echo \"password=$(cat %[1]s/password)\"; }; f",
+		gitAuthPath)
+
+	return map[string]string{
+		"GIT_SSH_COMMAND":    sshCommand,
+		"GIT_CONFIG_COUNT":   "1",
+		"GIT_CONFIG_KEY_0":   "credential.helper",
+		"GIT_CONFIG_VALUE_0": credentialHelper,
+	}
+}
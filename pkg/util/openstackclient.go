@@ -0,0 +1,103 @@
+package util
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetOpenStackClientVolumes - volumes of the helper pods (e.g. the cleanup
+// verification and the endpoint preflight pods) that run the openstack client
+func GetOpenStackClientVolumes(caBundleSecretName string) []corev1.Volume {
+	var scriptsVolumeConfidentialMode int32 = 0420
+	var tlsCertificateMode int32 = 0444
+
+	volumes := []corev1.Volume{
+		{
+			Name: "openstack-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &scriptsVolumeConfidentialMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "openstack-config",
+					},
+				},
+			},
+		},
+		{
+			Name: "openstack-config-secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &tlsCertificateMode,
+					SecretName:  "openstack-config-secret",
+				},
+			},
+		},
+		{
+			Name: TestOperatorEphemeralVolumeNameTmp,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	if len(caBundleSecretName) > 0 {
+		caCertsVolume := corev1.Volume{
+			Name: "ca-certs",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &scriptsVolumeConfidentialMode,
+					SecretName:  caBundleSecretName,
+				},
+			},
+		}
+
+		volumes = append(volumes, caCertsVolume)
+	}
+
+	return volumes
+}
+
+// GetOpenStackClientVolumeMounts - volume mounts of the helper pods that run
+// the openstack client
+func GetOpenStackClientVolumeMounts(mountCerts bool) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      TestOperatorEphemeralVolumeNameTmp,
+			MountPath: "/tmp",
+			ReadOnly:  false,
+		},
+		{
+			Name:      "openstack-config",
+			MountPath: "/etc/openstack/clouds.yaml",
+			SubPath:   "clouds.yaml",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "openstack-config-secret",
+			MountPath: "/etc/openstack/secure.yaml",
+			SubPath:   "secure.yaml",
+			ReadOnly:  true,
+		},
+	}
+
+	if mountCerts {
+		caCertVolumeMount := corev1.VolumeMount{
+			Name:      "ca-certs",
+			MountPath: "/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+			ReadOnly:  true,
+			SubPath:   "tls-ca-bundle.pem",
+		}
+
+		volumeMounts = append(volumeMounts, caCertVolumeMount)
+
+		caCertVolumeMount = corev1.VolumeMount{
+			Name:      "ca-certs",
+			MountPath: "/etc/pki/tls/certs/ca-bundle.trust.crt",
+			ReadOnly:  true,
+			SubPath:   "tls-ca-bundle.pem",
+		}
+
+		volumeMounts = append(volumeMounts, caCertVolumeMount)
+	}
+
+	return volumeMounts
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetTestPod - prepare the test pod of the kinds that run the tests in a
+// single container (e.g. RallyTest or FioTest). The container is named after
+// the instance. The kind specific settings of the container (e.g. the ports
+// or the command) are set by the caller. The pod runs as runAsUser, which is
+// also used as the group of the pod.
+func GetTestPod(
+	instanceName string,
+	namespace string,
+	effectiveSpec EffectiveSpec,
+	labels map[string]string,
+	annotations map[string]string,
+	podName string,
+	envVars []corev1.EnvVar,
+	containerImage string,
+	privileged bool,
+	runAsUser int64,
+	volumes []corev1.Volume,
+	volumeMounts []corev1.VolumeMount,
+) *corev1.Pod {
+
+	runAsGroup := runAsUser
+
+	capabilities := []corev1.Capability{"NET_ADMIN", "NET_RAW"}
+	securityContext := GetSecurityContext(runAsUser, capabilities, privileged)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: annotations,
+			Name:        podName,
+			Namespace:   namespace,
+			Labels:      labels,
+		},
+		Spec: corev1.PodSpec{
+			AutomountServiceAccountToken: &privileged,
+			RestartPolicy:                corev1.RestartPolicyNever,
+			Tolerations:                  effectiveSpec.Tolerations,
+			NodeSelector:                 effectiveSpec.NodeSelector,
+			Affinity:                     effectiveSpec.Affinity,
+			TopologySpreadConstraints:    effectiveSpec.TopologySpreadConstraints,
+			RuntimeClassName:             effectiveSpec.RuntimeClassName,
+			HostNetwork:                  effectiveSpec.HostNetwork,
+			DNSPolicy:                    effectiveSpec.DNSPolicy,
+			DNSConfig:                    effectiveSpec.DNSConfig,
+			ImagePullSecrets:             effectiveSpec.ImagePullSecrets,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &runAsUser,
+				RunAsGroup: &runAsGroup,
+				FSGroup:    &runAsGroup,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            instanceName,
+					Image:           containerImage,
+					ImagePullPolicy: effectiveSpec.ImagePullPolicy,
+					Args:            []string{},
+					Env:             envVars,
+					EnvFrom:         effectiveSpec.EnvFrom,
+					VolumeMounts:    volumeMounts,
+					SecurityContext: &securityContext,
+					Resources:       effectiveSpec.Resources,
+				},
+			},
+			Volumes: volumes,
+		},
+	}
+
+	if len(effectiveSpec.SELinuxLevel) > 0 {
+		pod.Spec.SecurityContext.SELinuxOptions = &corev1.SELinuxOptions{
+			Level: effectiveSpec.SELinuxLevel,
+		}
+	}
+
+	ApplySecurityContext(pod, effectiveSpec.SecurityContext)
+	SetArtifactDirectory(pod, podName)
+
+	return pod
+}
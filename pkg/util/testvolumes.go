@@ -0,0 +1,184 @@
+package util
+
+import (
+	testv1beta1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetTestVolumes - volumes of the test pods built by GetTestPod. The volumes
+// of the kind specific files (e.g. the rally task) are passed in kindVolumes.
+func GetTestVolumes(
+	options testv1beta1.CommonOptions,
+	openstackConfig testv1beta1.CommonOpenstackConfig,
+	logsPVCName string,
+	mountCerts bool,
+	kindVolumes ...corev1.Volume,
+) []corev1.Volume {
+
+	var scriptsVolumeConfidentialMode int32 = 0420
+	var tlsCertificateMode int32 = 0444
+	var publicInfoMode int32 = 0744
+
+	volumes := []corev1.Volume{
+		{
+			Name: "openstack-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &scriptsVolumeConfidentialMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: openstackConfig.OpenStackConfigMap,
+					},
+				},
+			},
+		},
+		{
+			Name: "openstack-config-secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &tlsCertificateMode,
+					SecretName:  openstackConfig.OpenStackConfigSecret,
+				},
+			},
+		},
+		{
+			Name: "test-operator-logs",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: logsPVCName,
+					ReadOnly:  false,
+				},
+			},
+		},
+		{
+			Name: TestOperatorEphemeralVolumeNameWorkdir,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: TestOperatorEphemeralVolumeNameTmp,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	volumes = append(volumes, kindVolumes...)
+
+	if mountCerts {
+		caCertsVolume := corev1.Volume{
+			Name: "ca-certs",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &scriptsVolumeConfidentialMode,
+					SecretName:  GetCABundleSecretName(options),
+				},
+			},
+		}
+
+		volumes = append(volumes, caCertsVolume)
+	}
+
+	for _, vol := range options.ExtraConfigmapsMounts {
+		extraVol := corev1.Volume{
+			Name: vol.Name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &publicInfoMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: vol.Name,
+					},
+				},
+			},
+		}
+
+		volumes = append(volumes, extraVol)
+	}
+
+	return volumes
+}
+
+// GetTestVolumeMounts - volume mounts of the volumes returned by
+// GetTestVolumes. The working directory is mounted to homeDir and the logs
+// PVC to logsDir. The mounts of the kind specific volumes are passed in
+// kindVolumeMounts.
+func GetTestVolumeMounts(
+	options testv1beta1.CommonOptions,
+	homeDir string,
+	logsDir string,
+	mountCerts bool,
+	kindVolumeMounts ...corev1.VolumeMount,
+) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      TestOperatorEphemeralVolumeNameWorkdir,
+			MountPath: homeDir,
+			ReadOnly:  false,
+		},
+		{
+			Name:      TestOperatorEphemeralVolumeNameTmp,
+			MountPath: "/tmp",
+			ReadOnly:  false,
+		},
+		{
+			Name:      "test-operator-logs",
+			MountPath: logsDir,
+			ReadOnly:  false,
+		},
+		{
+			Name:      "openstack-config",
+			MountPath: "/etc/openstack/clouds.yaml",
+			SubPath:   "clouds.yaml",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "openstack-config",
+			MountPath: homeDir + "/.config/openstack/clouds.yaml",
+			SubPath:   "clouds.yaml",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "openstack-config-secret",
+			MountPath: "/etc/openstack/secure.yaml",
+			ReadOnly:  false,
+			SubPath:   "secure.yaml",
+		},
+	}
+
+	volumeMounts = append(volumeMounts, kindVolumeMounts...)
+
+	if mountCerts {
+		caCertVolumeMount := corev1.VolumeMount{
+			Name:      "ca-certs",
+			MountPath: "/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+			ReadOnly:  true,
+			SubPath:   "tls-ca-bundle.pem",
+		}
+
+		volumeMounts = append(volumeMounts, caCertVolumeMount)
+
+		caCertVolumeMount = corev1.VolumeMount{
+			Name:      "ca-certs",
+			MountPath: "/etc/pki/tls/certs/ca-bundle.trust.crt",
+			ReadOnly:  true,
+			SubPath:   "tls-ca-bundle.pem",
+		}
+
+		volumeMounts = append(volumeMounts, caCertVolumeMount)
+	}
+
+	for _, vol := range options.ExtraConfigmapsMounts {
+
+		extraMounts := corev1.VolumeMount{
+			Name:      vol.Name,
+			MountPath: vol.MountPath,
+			SubPath:   vol.SubPath,
+			ReadOnly:  true,
+		}
+
+		volumeMounts = append(volumeMounts, extraMounts)
+	}
+
+	return volumeMounts
+}
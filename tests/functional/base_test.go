@@ -0,0 +1,123 @@
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	testv1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// testContainerImage is set on the test CRs as there is no
+	// test-operator-config config map with the default images in the
+	// namespaces of the tests
+	testContainerImage = "quay.io/podified-antelope-centos9/openstack-test:current-podified"
+
+	// instanceNameLabel is the label of the test pods with the name of
+	// the test CR
+	instanceNameLabel = "instanceName"
+)
+
+// testInstance is an instance of one of the test kinds
+type testInstance interface {
+	client.Object
+	GetCommonStatus() *testv1.CommonTestStatus
+}
+
+// GetTestInstanceRaw returns a test CR of the kind with the spec
+func GetTestInstanceRaw(kind string, name types.NamespacedName, spec map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "test.openstack.org/v1beta1",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+}
+
+// CreateTestInstance creates a test CR of the kind with the spec and deletes
+// it at the end of the test
+func CreateTestInstance(kind string, name types.NamespacedName, spec map[string]interface{}) client.Object {
+	instance := th.CreateUnstructured(GetTestInstanceRaw(kind, name, spec))
+	DeferCleanup(th.DeleteInstance, instance)
+
+	return instance
+}
+
+// GetTestPods returns the test pods spawned for the test CR
+func GetTestPods(instance testInstance) []corev1.Pod {
+	podList := &corev1.PodList{}
+	Expect(k8sClient.List(
+		ctx,
+		podList,
+		client.InNamespace(instance.GetNamespace()),
+		client.MatchingLabels{instanceNameLabel: instance.GetName()},
+	)).Should(Succeed())
+
+	pods := []corev1.Pod{}
+	for _, pod := range podList.Items {
+		if metav1.IsControlledBy(&pod, instance) {
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods
+}
+
+// ExpectTestPodSpawned waits until the test pod of the first workflow step of
+// the test CR is spawned and reported in the status of the test CR. It returns
+// the test pod.
+func ExpectTestPodSpawned(name types.NamespacedName, instance testInstance) corev1.Pod {
+	var testPod corev1.Pod
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+		pods := GetTestPods(instance)
+		g.Expect(pods).To(HaveLen(1))
+		testPod = pods[0]
+
+		status := instance.GetCommonStatus()
+		g.Expect(status.Steps).To(HaveLen(1))
+		g.Expect(status.Steps[0].PodName).To(Equal(testPod.Name))
+		g.Expect(status.Steps[0].WorkflowStep).To(Equal(0))
+		g.Expect(status.Phase).To(Equal(testv1.TestPhaseRunning))
+		g.Expect(status.Conditions.IsTrue(condition.DeploymentReadyCondition)).To(BeFalse())
+	}, timeout, interval).Should(Succeed())
+
+	Expect(testPod.Spec.Containers).NotTo(BeEmpty())
+	Expect(testPod.Spec.Containers[0].Image).To(Equal(testContainerImage))
+
+	return testPod
+}
+
+// ExpectTestPodRunning simulates that the test pod is running and waits until
+// the phase is reported in the status of the test CR
+func ExpectTestPodRunning(name types.NamespacedName, instance testInstance, testPod corev1.Pod) {
+	th.SimulatePodPhaseRunning(types.NamespacedName{Namespace: testPod.Namespace, Name: testPod.Name})
+
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+		status := instance.GetCommonStatus()
+		g.Expect(status.Steps).To(HaveLen(1))
+		g.Expect(status.Steps[0].Phase).To(Equal(corev1.PodRunning))
+		g.Expect(status.Phase).To(Equal(testv1.TestPhaseRunning))
+	}, timeout, interval).Should(Succeed())
+}
+
+// ExpectRejected checks that the creation of a test CR of the kind with the
+// spec is rejected by the validating webhook with an error that contains
+// message
+func ExpectRejected(kind string, name types.NamespacedName, spec map[string]interface{}, message string) {
+	instance := &unstructured.Unstructured{Object: GetTestInstanceRaw(kind, name, spec)}
+	err := k8sClient.Create(ctx, instance)
+	Expect(err).Should(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("admission webhook"))
+	Expect(err.Error()).To(ContainSubstring(message))
+}
@@ -0,0 +1,48 @@
+package functional_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+
+	testv1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDefaultCustomTestSpec returns the spec of a CustomTest with a single test pod
+func GetDefaultCustomTestSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"containerImage": testContainerImage,
+		"command":        []interface{}{"/bin/sh", "-c", "echo test"},
+	}
+}
+
+var _ = Describe("CustomTest controller", func() {
+	var customTestName types.NamespacedName
+
+	BeforeEach(func() {
+		customTestName = types.NamespacedName{
+			Name:      "customtest",
+			Namespace: namespace,
+		}
+	})
+
+	When("A CustomTest is created", func() {
+		BeforeEach(func() {
+			CreateTestInstance("CustomTest", customTestName, GetDefaultCustomTestSpec())
+		})
+
+		It("spawns the test pod and reports it in the status", func() {
+			testPod := ExpectTestPodSpawned(customTestName, &testv1.CustomTest{})
+			ExpectTestPodRunning(customTestName, &testv1.CustomTest{}, testPod)
+		})
+	})
+
+	When("A CustomTest with the host network without the privileged mode is created", func() {
+		It("is rejected by the webhook", func() {
+			spec := GetDefaultCustomTestSpec()
+			spec["hostNetwork"] = true
+			ExpectRejected("CustomTest", customTestName, spec, fmt.Sprintf(testv1.ErrHostNetworkPrivileged, "CustomTest", "CustomTest"))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+
+	testv1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDefaultFioTestSpec returns the spec of a FioTest with a single test pod
+func GetDefaultFioTestSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"containerImage": testContainerImage,
+		"profiles": []interface{}{
+			map[string]interface{}{
+				"name": "randrw",
+			},
+		},
+	}
+}
+
+var _ = Describe("FioTest controller", func() {
+	var fioTestName types.NamespacedName
+
+	BeforeEach(func() {
+		fioTestName = types.NamespacedName{
+			Name:      "fiotest",
+			Namespace: namespace,
+		}
+	})
+
+	When("A FioTest is created", func() {
+		BeforeEach(func() {
+			CreateTestInstance("FioTest", fioTestName, GetDefaultFioTestSpec())
+		})
+
+		It("spawns the test pod and reports it in the status", func() {
+			testPod := ExpectTestPodSpawned(fioTestName, &testv1.FioTest{})
+			ExpectTestPodRunning(fioTestName, &testv1.FioTest{}, testPod)
+		})
+	})
+
+	When("A FioTest with the cinder target without a cinder volume is created", func() {
+		It("is rejected by the webhook", func() {
+			spec := GetDefaultFioTestSpec()
+			spec["target"] = "cinder"
+			ExpectRejected("FioTest", fioTestName, spec, testv1.ErrFioCinderRequired)
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+
+	testv1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDefaultK6TestSpec returns the spec of a K6Test with a single test pod
+func GetDefaultK6TestSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"containerImage": testContainerImage,
+		"scriptConfigMap": map[string]interface{}{
+			"name": "k6-script",
+		},
+	}
+}
+
+var _ = Describe("K6Test controller", func() {
+	var k6TestName types.NamespacedName
+
+	BeforeEach(func() {
+		k6TestName = types.NamespacedName{
+			Name:      "k6test",
+			Namespace: namespace,
+		}
+	})
+
+	When("A K6Test is created", func() {
+		BeforeEach(func() {
+			CreateTestInstance("K6Test", k6TestName, GetDefaultK6TestSpec())
+		})
+
+		It("spawns the test pod and reports it in the status", func() {
+			testPod := ExpectTestPodSpawned(k6TestName, &testv1.K6Test{})
+			ExpectTestPodRunning(k6TestName, &testv1.K6Test{}, testPod)
+		})
+	})
+
+	When("A K6Test without a script is created", func() {
+		It("is rejected by the webhook", func() {
+			spec := GetDefaultK6TestSpec()
+			delete(spec, "scriptConfigMap")
+			ExpectRejected("K6Test", k6TestName, spec, testv1.ErrK6ScriptRequired)
+		})
+	})
+})
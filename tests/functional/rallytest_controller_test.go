@@ -0,0 +1,48 @@
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+
+	testv1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDefaultRallyTestSpec returns the spec of a RallyTest with a single test pod
+func GetDefaultRallyTestSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"containerImage": testContainerImage,
+		"taskConfigMap": map[string]interface{}{
+			"name": "rally-task",
+		},
+	}
+}
+
+var _ = Describe("RallyTest controller", func() {
+	var rallyTestName types.NamespacedName
+
+	BeforeEach(func() {
+		rallyTestName = types.NamespacedName{
+			Name:      "rallytest",
+			Namespace: namespace,
+		}
+	})
+
+	When("A RallyTest is created", func() {
+		BeforeEach(func() {
+			CreateTestInstance("RallyTest", rallyTestName, GetDefaultRallyTestSpec())
+		})
+
+		It("spawns the test pod and reports it in the status", func() {
+			testPod := ExpectTestPodSpawned(rallyTestName, &testv1.RallyTest{})
+			ExpectTestPodRunning(rallyTestName, &testv1.RallyTest{}, testPod)
+		})
+	})
+
+	When("A RallyTest without a task is created", func() {
+		It("is rejected by the webhook", func() {
+			spec := GetDefaultRallyTestSpec()
+			delete(spec, "taskConfigMap")
+			ExpectRejected("RallyTest", rallyTestName, spec, testv1.ErrRallyTaskRequired)
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+
+	testv1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDefaultRobotTestSpec returns the spec of a RobotTest with a single test pod
+func GetDefaultRobotTestSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"containerImage":  testContainerImage,
+		"suitesConfigMap": "robot-suites",
+	}
+}
+
+var _ = Describe("RobotTest controller", func() {
+	var robotTestName types.NamespacedName
+
+	BeforeEach(func() {
+		robotTestName = types.NamespacedName{
+			Name:      "robottest",
+			Namespace: namespace,
+		}
+	})
+
+	When("A RobotTest is created", func() {
+		BeforeEach(func() {
+			CreateTestInstance("RobotTest", robotTestName, GetDefaultRobotTestSpec())
+		})
+
+		It("spawns the test pod and reports it in the status", func() {
+			testPod := ExpectTestPodSpawned(robotTestName, &testv1.RobotTest{})
+			ExpectTestPodRunning(robotTestName, &testv1.RobotTest{}, testPod)
+		})
+	})
+
+	When("A RobotTest with both the suites git repo and the suites config map is created", func() {
+		It("is rejected by the webhook", func() {
+			spec := GetDefaultRobotTestSpec()
+			spec["suitesGitRepo"] = "https://github.com/openstack-k8s-operators/robot-suites"
+			ExpectRejected("RobotTest", robotTestName, spec, testv1.ErrRobotSuitesSource)
+		})
+	})
+})
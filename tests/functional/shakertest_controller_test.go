@@ -0,0 +1,48 @@
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+
+	testv1 "github.com/openstack-k8s-operators/test-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDefaultShakerTestSpec returns the spec of a ShakerTest with a single test pod
+func GetDefaultShakerTestSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"containerImage": testContainerImage,
+		"scenario":       "openstack/instance_metadata.yaml",
+	}
+}
+
+var _ = Describe("ShakerTest controller", func() {
+	var shakerTestName types.NamespacedName
+
+	BeforeEach(func() {
+		shakerTestName = types.NamespacedName{
+			Name:      "shakertest",
+			Namespace: namespace,
+		}
+	})
+
+	When("A ShakerTest is created", func() {
+		BeforeEach(func() {
+			CreateTestInstance("ShakerTest", shakerTestName, GetDefaultShakerTestSpec())
+		})
+
+		It("spawns the test pod and reports it in the status", func() {
+			testPod := ExpectTestPodSpawned(shakerTestName, &testv1.ShakerTest{})
+			ExpectTestPodRunning(shakerTestName, &testv1.ShakerTest{}, testPod)
+		})
+	})
+
+	When("A ShakerTest with the single_room and double_room placements is created", func() {
+		It("is rejected by the webhook", func() {
+			spec := GetDefaultShakerTestSpec()
+			spec["accommodation"] = map[string]interface{}{
+				"placement": []interface{}{"single_room", "double_room"},
+			}
+			ExpectRejected("ShakerTest", shakerTestName, spec, testv1.ErrShakerPlacement)
+		})
+	})
+})
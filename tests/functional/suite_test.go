@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -60,6 +61,10 @@ func TestAPIs(t *testing.T) {
 }
 
 var _ = BeforeSuite(func() {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		Skip("KUBEBUILDER_ASSETS is not set, the functional tests are run by make test")
+	}
+
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true), func(o *zap.Options) {
 		o.Development = true
 		o.TimeEncoder = zapcore.ISO8601TimeEncoder
@@ -123,6 +128,24 @@ var _ = BeforeSuite(func() {
 
 	err = (&testv1.Tempest{}).SetupWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.Tobiko{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.AnsibleTest{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.HorizonTest{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.RallyTest{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.ShakerTest{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.FioTest{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.K6Test{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.RobotTest{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&testv1.CustomTest{}).SetupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
 
 	err = (&controllers.AnsibleTestReconciler{
 		Reconciler: controllers.Reconciler{
@@ -144,6 +167,66 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&controllers.RallyTestReconciler{
+		Reconciler: controllers.Reconciler{
+			Client:  k8sManager.GetClient(),
+			Scheme:  k8sManager.GetScheme(),
+			Kclient: kclient,
+			Log:     logger,
+		},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.ShakerTestReconciler{
+		Reconciler: controllers.Reconciler{
+			Client:  k8sManager.GetClient(),
+			Scheme:  k8sManager.GetScheme(),
+			Kclient: kclient,
+			Log:     logger,
+		},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.FioTestReconciler{
+		Reconciler: controllers.Reconciler{
+			Client:  k8sManager.GetClient(),
+			Scheme:  k8sManager.GetScheme(),
+			Kclient: kclient,
+			Log:     logger,
+		},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.K6TestReconciler{
+		Reconciler: controllers.Reconciler{
+			Client:  k8sManager.GetClient(),
+			Scheme:  k8sManager.GetScheme(),
+			Kclient: kclient,
+			Log:     logger,
+		},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.RobotTestReconciler{
+		Reconciler: controllers.Reconciler{
+			Client:  k8sManager.GetClient(),
+			Scheme:  k8sManager.GetScheme(),
+			Kclient: kclient,
+			Log:     logger,
+		},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&controllers.CustomTestReconciler{
+		Reconciler: controllers.Reconciler{
+			Client:  k8sManager.GetClient(),
+			Scheme:  k8sManager.GetScheme(),
+			Kclient: kclient,
+			Log:     logger,
+		},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&controllers.TempestReconciler{
 		Reconciler: controllers.Reconciler{
 			Client:  k8sManager.GetClient(),
@@ -184,6 +267,10 @@ var _ = BeforeSuite(func() {
 })
 
 var _ = AfterSuite(func() {
+	if testEnv == nil {
+		return
+	}
+
 	By("tearing down the test environment")
 	cancel()
 	err := testEnv.Stop()